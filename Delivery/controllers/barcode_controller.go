@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"net/http"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BarcodeController struct {
+	barcodeUC Usecases.BarcodeUseCase
+}
+
+func NewBarcodeController(barcodeUC Usecases.BarcodeUseCase) *BarcodeController {
+	return &BarcodeController{barcodeUC: barcodeUC}
+}
+
+// GetProductByBarcode godoc
+// @Summary      Look up product by barcode
+// @Description  Find the product with a scanned barcode. A 12-digit UPC-A scan also matches its EAN-13 form
+// @Tags         barcodes
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        code        path  string  true  "Barcode"
+// @Success      200  {object}  Domain.Product
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/by-barcode/{code} [get]
+// @Security     BearerAuth
+func (c *BarcodeController) GetProductByBarcode(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	code := ctx.Param("code")
+	if code == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Barcode is required")
+		return
+	}
+
+	product, err := c.barcodeUC.GetProductByBarcode(businessID, code)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+// GenerateBarcode godoc
+// @Summary      Generate product barcode
+// @Description  Give a product without a barcode an internal EAN-13 (prefix 20) or Code 128 barcode
+// @Tags         barcodes
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                         true   "Business ID"
+// @Param        productId   path  string                         true   "Product ID"
+// @Param        request     body  Domain.GenerateBarcodeRequest  false  "Barcode format"
+// @Success      200  {object}  Domain.Product
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/barcode [post]
+// @Security     BearerAuth
+func (c *BarcodeController) GenerateBarcode(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	var req Domain.GenerateBarcodeRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+			return
+		}
+	}
+
+	product, err := c.barcodeUC.GenerateBarcode(productID, businessID, req.Format)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+// GenerateMissingBarcodes godoc
+// @Summary      Generate missing barcodes
+// @Description  Give every product without a barcode an internal one
+// @Tags         barcodes
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                         true   "Business ID"
+// @Param        request     body  Domain.GenerateBarcodeRequest  false  "Barcode format"
+// @Success      200  {object}  Domain.GenerateBarcodesResult
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/barcodes/generate [post]
+// @Security     BearerAuth
+func (c *BarcodeController) GenerateMissingBarcodes(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var req Domain.GenerateBarcodeRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+			return
+		}
+	}
+
+	result, err := c.barcodeUC.GenerateMissingBarcodes(businessID, req.Format)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// PrintLabels godoc
+// @Summary      Print barcode labels
+// @Description  Render a printable sheet of labels with name, price and barcode for the selected products, as an A4 PDF (24 labels per page) or a PNG
+// @Tags         barcodes
+// @Accept       json
+// @Produce      application/pdf
+// @Produce      image/png
+// @Param        businessId  path  string                    true  "Business ID"
+// @Param        request     body  Domain.LabelSheetRequest  true  "Products and copies"
+// @Success      200  {string}  string  "Label sheet"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/labels [post]
+// @Security     BearerAuth
+func (c *BarcodeController) PrintLabels(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var req Domain.LabelSheetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	data, filename, err := c.barcodeUC.RenderLabels(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	contentType := "application/pdf"
+	if req.Format == Domain.LabelFormatPNG {
+		contentType = "image/png"
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Data(http.StatusOK, contentType, data)
+}
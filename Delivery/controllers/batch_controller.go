@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BatchController struct {
+	batchUC Usecases.BatchUseCase
+}
+
+func NewBatchController(batchUC Usecases.BatchUseCase) *BatchController {
+	return &BatchController{batchUC: batchUC}
+}
+
+// ReceiveBatch godoc
+// @Summary      Receive a stock batch
+// @Description  Receive stock for a product as a lot with lot number, expiry and received dates
+// @Tags         inventory
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                      true  "Business ID"
+// @Param        productId   path  string                      true  "Product ID"
+// @Param        request     body  Domain.ReceiveBatchRequest  true  "Batch details"
+// @Success      201  {object}  Domain.StockBatch
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/batches [post]
+// @Security     BearerAuth
+func (c *BatchController) ReceiveBatch(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.ReceiveBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	batch, err := c.batchUC.ReceiveBatch(productID, businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, batch)
+}
+
+// GetBatches godoc
+// @Summary      List product batches
+// @Description  Get the stock batches held for a product
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId        path   string  true   "Business ID"
+// @Param        productId         path   string  true   "Product ID"
+// @Param        include_depleted  query  bool    false  "Include depleted and expired batches"
+// @Success      200  {array}   Domain.StockBatch
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/batches [get]
+// @Security     BearerAuth
+func (c *BatchController) GetBatches(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	includeDepleted := ctx.Query("include_depleted") == "true"
+
+	batches, err := c.batchUC.GetBatches(productID, businessID, includeDepleted)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, batches)
+}
+
+// GetNearExpiry godoc
+// @Summary      Get near-expiry report
+// @Description  List batches with stock that expire within the given number of days, including already expired ones
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        days        query  int     false  "Days ahead to look (default 30)"
+// @Success      200  {object}  Domain.NearExpiryReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/batches/near-expiry [get]
+// @Security     BearerAuth
+func (c *BatchController) GetNearExpiry(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	days := 30
+	if daysStr := ctx.Query("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 {
+			days = d
+		}
+	}
+
+	report, err := c.batchUC.GetNearExpiryReport(businessID, days)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// WriteOffExpired godoc
+// @Summary      Write off expired batches
+// @Description  Remove the remaining stock of every expired batch, recording a damage movement for each. This also runs automatically every hour for every business
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {object}  Domain.ExpiryWriteOffResult
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/batches/write-off-expired [post]
+// @Security     BearerAuth
+func (c *BatchController) WriteOffExpired(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	result, err := c.batchUC.WriteOffExpired(businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCatalogFileSize caps the size of an uploaded catalog file.
+const maxCatalogFileSize = 10 << 20
+
+type CatalogController struct {
+	catalogUC Usecases.CatalogUseCase
+}
+
+func NewCatalogController(catalogUC Usecases.CatalogUseCase) *CatalogController {
+	return &CatalogController{catalogUC: catalogUC}
+}
+
+// ImportCatalog godoc
+// @Summary      Import product catalog
+// @Description  Upload a CSV or XLSX catalog. Rows are upserted by SKU, then barcode, and opening stock is booked as stock movements. A dry run returns the validation report with row-level errors straight away; otherwise the import runs in the background and the returned job can be polled
+// @Tags         catalog
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        businessId  path      string  true   "Business ID"
+// @Param        file        formData  file    true   "Catalog file (.csv or .xlsx)"
+// @Param        mapping     formData  string  false  "JSON object mapping file headers to product fields, e.g. {\"Item\":\"name\",\"Price\":\"selling_price\"}"
+// @Param        dry_run     formData  bool    false  "Validate only, without importing"
+// @Success      200  {object}  Domain.CatalogImportJob  "Dry run report"
+// @Success      202  {object}  Domain.CatalogImportJob  "Import job started"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/catalog/import [post]
+// @Security     BearerAuth
+func (c *CatalogController) ImportCatalog(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Catalog file is required")
+		return
+	}
+	if fileHeader.Size > maxCatalogFileSize {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil,
+			fmt.Sprintf("Catalog file must be smaller than %d MB", maxCatalogFileSize>>20))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Failed to read catalog file")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Failed to read catalog file")
+		return
+	}
+
+	req := Domain.CatalogImportRequest{
+		FileName: fileHeader.Filename,
+		Data:     data,
+		DryRun:   ctx.PostForm("dry_run") == "true" || ctx.Query("dry_run") == "true",
+	}
+
+	if mapping := ctx.PostForm("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &req.Mapping); err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Mapping must be a JSON object of header to field")
+			return
+		}
+	}
+
+	job, err := c.catalogUC.ImportCatalog(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	if req.DryRun {
+		ctx.JSON(http.StatusOK, job)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, job)
+}
+
+// GetImportJobs godoc
+// @Summary      List catalog imports
+// @Description  Get the most recent catalog import jobs, newest first, without their row errors
+// @Tags         catalog
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {array}   Domain.CatalogImportJob
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/catalog/imports [get]
+// @Security     BearerAuth
+func (c *CatalogController) GetImportJobs(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	jobs, err := c.catalogUC.GetImportJobs(businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jobs)
+}
+
+// GetImportJob godoc
+// @Summary      Get catalog import
+// @Description  Poll the progress of a catalog import, with its row-level errors
+// @Tags         catalog
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        jobId       path  string  true  "Import job ID"
+// @Success      200  {object}  Domain.CatalogImportJob
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/catalog/imports/{jobId} [get]
+// @Security     BearerAuth
+func (c *CatalogController) GetImportJob(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	jobID := ctx.Param("jobId")
+	if jobID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Import job ID is required")
+		return
+	}
+
+	job, err := c.catalogUC.GetImportJob(jobID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
+
+// ExportCatalog godoc
+// @Summary      Export product catalog
+// @Description  Download all products in the import template, ready to edit and import again
+// @Tags         catalog
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        format      query  string  false  "Format: csv (default), xlsx"
+// @Success      200  {string}  string  "Catalog file"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/catalog/export [get]
+// @Security     BearerAuth
+func (c *CatalogController) ExportCatalog(ctx *gin.Context) {
+	c.exportCatalog(ctx, false)
+}
+
+// GetCatalogTemplate godoc
+// @Summary      Download catalog template
+// @Description  Download an empty catalog file with the template headers
+// @Tags         catalog
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        format      query  string  false  "Format: csv (default), xlsx"
+// @Success      200  {string}  string  "Template file"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/catalog/template [get]
+// @Security     BearerAuth
+func (c *CatalogController) GetCatalogTemplate(ctx *gin.Context) {
+	c.exportCatalog(ctx, true)
+}
+
+func (c *CatalogController) exportCatalog(ctx *gin.Context, templateOnly bool) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	format := Domain.CatalogFormat(ctx.DefaultQuery("format", "csv"))
+
+	data, filename, err := c.catalogUC.ExportCatalog(businessID, format, templateOnly)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	contentType := "text/csv"
+	if format == Domain.CatalogFormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Data(http.StatusOK, contentType, data)
+}
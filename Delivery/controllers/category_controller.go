@@ -0,0 +1,307 @@
+package controllers
+
+import (
+	"net/http"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryController struct {
+	categoryUC Usecases.CategoryUseCase
+}
+
+func NewCategoryController(categoryUC Usecases.CategoryUseCase) *CategoryController {
+	return &CategoryController{categoryUC: categoryUC}
+}
+
+// CreateCategory godoc
+// @Summary      Create category
+// @Description  Add a product category, at the top level or below a parent. Names are unique within the business regardless of case
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        request     body  Domain.CreateCategoryRequest  true  "Category"
+// @Success      201  {object}  Domain.Category
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/categories [post]
+// @Security     BearerAuth
+func (c *CategoryController) CreateCategory(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.CreateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	category, err := c.categoryUC.CreateCategory(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, category)
+}
+
+// GetCategories godoc
+// @Summary      Get category tree
+// @Description  Get the category tree of the business, each category with its subcategories in order
+// @Tags         categories
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {array}   Domain.Category
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/categories [get]
+// @Security     BearerAuth
+func (c *CategoryController) GetCategories(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	categories, err := c.categoryUC.GetCategories(businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, categories)
+}
+
+// GetCategory godoc
+// @Summary      Get category
+// @Description  Get a category with the subcategories below it
+// @Tags         categories
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        categoryId  path  string  true  "Category ID"
+// @Success      200  {object}  Domain.Category
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/categories/{categoryId} [get]
+// @Security     BearerAuth
+func (c *CategoryController) GetCategory(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	categoryID := ctx.Param("categoryId")
+	if categoryID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Category ID is required")
+		return
+	}
+
+	category, err := c.categoryUC.GetCategory(categoryID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+// UpdateCategory godoc
+// @Summary      Update category
+// @Description  Rename a category or move it, with everything below it, under another parent. An empty parent_id moves it to the top level
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        categoryId  path  string                        true  "Category ID"
+// @Param        request     body  Domain.UpdateCategoryRequest  true  "Changes"
+// @Success      200  {object}  Domain.Category
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/categories/{categoryId} [patch]
+// @Security     BearerAuth
+func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	categoryID := ctx.Param("categoryId")
+	if categoryID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Category ID is required")
+		return
+	}
+
+	var req Domain.UpdateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	category, err := c.categoryUC.UpdateCategory(categoryID, businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory godoc
+// @Summary      Delete category
+// @Description  Delete a category that has no products or subcategories; merge it into another category otherwise
+// @Tags         categories
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        categoryId  path  string  true  "Category ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/categories/{categoryId} [delete]
+// @Security     BearerAuth
+func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	categoryID := ctx.Param("categoryId")
+	if categoryID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Category ID is required")
+		return
+	}
+
+	if err := c.categoryUC.DeleteCategory(categoryID, businessID); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
+
+// ReorderCategories godoc
+// @Summary      Reorder categories
+// @Description  Set the order of the subcategories of a parent, or of the top-level categories when parent_id is empty
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                           true  "Business ID"
+// @Param        request     body  Domain.ReorderCategoriesRequest  true  "Category IDs in their new order"
+// @Success      200  {array}   Domain.Category
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/categories/reorder [post]
+// @Security     BearerAuth
+func (c *CategoryController) ReorderCategories(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var req Domain.ReorderCategoriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	categories, err := c.categoryUC.ReorderCategories(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, categories)
+}
+
+// MergeCategory godoc
+// @Summary      Merge category
+// @Description  Fold a category into another: its products and subcategories move to the target and it is deleted
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                       true  "Business ID"
+// @Param        categoryId  path  string                       true  "Category to merge away"
+// @Param        request     body  Domain.MergeCategoryRequest  true  "Target category"
+// @Success      200  {object}  Domain.Category
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/categories/{categoryId}/merge [post]
+// @Security     BearerAuth
+func (c *CategoryController) MergeCategory(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	categoryID := ctx.Param("categoryId")
+	if categoryID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Category ID is required")
+		return
+	}
+
+	var req Domain.MergeCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	category, err := c.categoryUC.MergeCategory(categoryID, businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+// MigrateCategories godoc
+// @Summary      Migrate free-text categories
+// @Description  Create categories from the category names of products that have no category ID yet, matching names regardless of case, and link the products to them
+// @Tags         categories
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {object}  Domain.CategoryMigrationResult
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/categories/migrate [post]
+// @Security     BearerAuth
+func (c *CategoryController) MigrateCategories(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	result, err := c.categoryUC.MigrateCategories(businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
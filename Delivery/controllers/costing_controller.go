@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CostingController struct {
+	costingUC Usecases.CostingUseCase
+}
+
+func NewCostingController(costingUC Usecases.CostingUseCase) *CostingController {
+	return &CostingController{costingUC: costingUC}
+}
+
+// GetCostLayers godoc
+// @Summary      List product cost layers
+// @Description  Get the open cost layers (quantities still held at each purchase cost) for a product, oldest first
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        productId   path  string  true  "Product ID"
+// @Success      200  {array}   Domain.CostLayer
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/cost-layers [get]
+// @Security     BearerAuth
+func (c *CostingController) GetCostLayers(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	layers, err := c.costingUC.GetCostLayers(productID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, layers)
+}
+
+// RebuildProductCosts godoc
+// @Summary      Rebuild product costs
+// @Description  Replay a product's stock history to rebuild its cost layers, average cost and the cost of goods of its sales
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        productId   path  string  true  "Product ID"
+// @Success      200  {object}  Domain.CostRebuildResult
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/costs/rebuild [post]
+// @Security     BearerAuth
+func (c *CostingController) RebuildProductCosts(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	result, err := c.costingUC.RebuildProductCosts(productID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// RebuildBusinessCosts godoc
+// @Summary      Rebuild business costs
+// @Description  Rebuild cost layers, average costs and cost of goods sold for every product, e.g. after changing the costing method
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {object}  Domain.CostRebuildResult
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/costs/rebuild [post]
+// @Security     BearerAuth
+func (c *CostingController) RebuildBusinessCosts(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	result, err := c.costingUC.RebuildBusinessCosts(businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetStockValuation godoc
+// @Summary      Stock valuation on a date
+// @Description  Value the stock held at the end of a date by replaying the stock movement ledger up to it, pricing each product with the business costing method and the costs in effect at the time. Defaults to now
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        as_of       query  string  false  "Valuation date (YYYY-MM-DD)"
+// @Success      200  {object}  Domain.StockValuation
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/inventory/valuation [get]
+// @Security     BearerAuth
+func (c *CostingController) GetStockValuation(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	asOf := time.Now()
+	if asOfStr := ctx.Query("as_of"); asOfStr != "" {
+		parsed, err := time.Parse("2006-01-02", asOfStr)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Valuation date must be YYYY-MM-DD")
+			return
+		}
+		// Value the stock at the close of the day
+		asOf = parsed.Add(24*time.Hour - time.Nanosecond)
+		if asOf.After(time.Now()) {
+			asOf = time.Now()
+		}
+	}
+
+	valuation, err := c.costingUC.GetStockValuation(businessID, asOf)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, valuation)
+}
+
+// CheckLedgerIntegrity godoc
+// @Summary      Check stock ledger integrity
+// @Description  Walk every product's stock movement history and flag products whose current stock disagrees with it, or whose movements do not chain from one to the next
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {object}  Domain.LedgerIntegrityReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/ledger/integrity [get]
+// @Security     BearerAuth
+func (c *CostingController) CheckLedgerIntegrity(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	report, err := c.costingUC.CheckLedgerIntegrity(businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -21,7 +21,7 @@ func NewInventoryController(inventoryUC Usecases.InventoryUseCase) *InventoryCon
 
 // CreateProduct godoc
 // @Summary      Add new product
-// @Description  Create a new product with stock information
+// @Description  Create a new product with stock information. A bundle (type "bundle") lists its component products instead; its stock and cost are derived from them
 // @Tags         inventory
 // @Accept       json
 // @Produce      json
@@ -66,7 +66,7 @@ func (c *InventoryController) CreateProduct(ctx *gin.Context) {
 // @Tags         inventory
 // @Produce      json
 // @Param        businessId  path    string  true   "Business ID"
-// @Param        category    query   string  false  "Product category"
+// @Param        category    query   string  false  "Category ID or name; includes its subcategories"
 // @Param        status      query   string  false  "Product status"
 // @Param        low_stock   query   bool    false  "Filter low stock items"
 // @Param        search      query   string  false  "Search in name, SKU, barcode"
@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"net/http"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationController struct {
+	locationUC Usecases.LocationUseCase
+}
+
+func NewLocationController(locationUC Usecases.LocationUseCase) *LocationController {
+	return &LocationController{locationUC: locationUC}
+}
+
+// CreateLocation godoc
+// @Summary      Create stock location
+// @Description  Add a location that holds stock, such as a back store or an outlet. The first location becomes the default and takes over all existing stock
+// @Tags         locations
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        request     body  Domain.CreateLocationRequest  true  "Location details"
+// @Success      201  {object}  Domain.Location
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/locations [post]
+// @Security     BearerAuth
+func (c *LocationController) CreateLocation(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var req Domain.CreateLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	location, err := c.locationUC.CreateLocation(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, location)
+}
+
+// GetLocations godoc
+// @Summary      List stock locations
+// @Description  Get all stock locations of a business, default first
+// @Tags         locations
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {array}   Domain.Location
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/locations [get]
+// @Security     BearerAuth
+func (c *LocationController) GetLocations(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	locations, err := c.locationUC.GetLocations(businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, locations)
+}
+
+// GetLocation godoc
+// @Summary      Get stock location
+// @Description  Get a stock location by ID
+// @Tags         locations
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        locationId  path  string  true  "Location ID"
+// @Success      200  {object}  Domain.Location
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/locations/{locationId} [get]
+// @Security     BearerAuth
+func (c *LocationController) GetLocation(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	locationID := ctx.Param("locationId")
+	if locationID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Location ID is required")
+		return
+	}
+
+	location, err := c.locationUC.GetLocation(locationID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, location)
+}
+
+// UpdateLocation godoc
+// @Summary      Update stock location
+// @Description  Update a location's details, make it the default or deactivate it
+// @Tags         locations
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        locationId  path  string                        true  "Location ID"
+// @Param        request     body  Domain.UpdateLocationRequest  true  "Location update details"
+// @Success      200  {object}  Domain.Location
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/locations/{locationId} [patch]
+// @Security     BearerAuth
+func (c *LocationController) UpdateLocation(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	locationID := ctx.Param("locationId")
+	if locationID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Location ID is required")
+		return
+	}
+
+	var req Domain.UpdateLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	location, err := c.locationUC.UpdateLocation(locationID, businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, location)
+}
+
+// GetLocationStock godoc
+// @Summary      Get stock at a location
+// @Description  Get the stock level of every product held at a location, with low stock items
+// @Tags         locations
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        locationId  path  string  true  "Location ID"
+// @Success      200  {object}  Domain.LocationStockReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/locations/{locationId}/stock [get]
+// @Security     BearerAuth
+func (c *LocationController) GetLocationStock(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	locationID := ctx.Param("locationId")
+	if locationID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Location ID is required")
+		return
+	}
+
+	report, err := c.locationUC.GetLocationStock(locationID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// SetMinStock godoc
+// @Summary      Set location minimum stock
+// @Description  Set the low stock threshold of a product at one location, overriding the product minimum there
+// @Tags         locations
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                             true  "Business ID"
+// @Param        locationId  path  string                             true  "Location ID"
+// @Param        productId   path  string                             true  "Product ID"
+// @Param        request     body  Domain.SetLocationMinStockRequest  true  "Minimum stock"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/locations/{locationId}/products/{productId}/min-stock [put]
+// @Security     BearerAuth
+func (c *LocationController) SetMinStock(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	locationID := ctx.Param("locationId")
+	if locationID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Location ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	var req Domain.SetLocationMinStockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	if err := c.locationUC.SetMinStock(locationID, productID, businessID, req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Minimum stock updated successfully"})
+}
+
+// GetProductStock godoc
+// @Summary      Get product stock by location
+// @Description  Get how much of a product each location holds and how much is in transit
+// @Tags         locations
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        productId   path  string  true  "Product ID"
+// @Success      200  {object}  Domain.ProductLocationStock
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/locations [get]
+// @Security     BearerAuth
+func (c *LocationController) GetProductStock(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	stock, err := c.locationUC.GetProductStock(productID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stock)
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NegativeStockController struct {
+	negativeStockUC Usecases.NegativeStockUseCase
+}
+
+func NewNegativeStockController(negativeStockUC Usecases.NegativeStockUseCase) *NegativeStockController {
+	return &NegativeStockController{negativeStockUC: negativeStockUC}
+}
+
+// GetNegativeStockReport godoc
+// @Summary      Reconcile negative stock
+// @Description  List the sales that took products below zero under a warn or allow negative stock policy, with the later receipts that covered each shortfall
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        status      query  string  false  "Filter by status (open, covered)"
+// @Param        product_id  query  string  false  "Filter by product"
+// @Param        start_date  query  string  false  "Went negative on or after (YYYY-MM-DD)"
+// @Param        end_date    query  string  false  "Went negative on or before (YYYY-MM-DD)"
+// @Success      200  {object}  Domain.NegativeStockReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/negative-stock [get]
+// @Security     BearerAuth
+func (c *NegativeStockController) GetNegativeStockReport(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var filters Domain.NegativeStockFilters
+
+	if status := ctx.Query("status"); status != "" {
+		s := Domain.NegativeStockStatus(status)
+		filters.Status = &s
+	}
+
+	if productID := ctx.Query("product_id"); productID != "" {
+		filters.ProductID = &productID
+	}
+
+	if startDateStr := ctx.Query("start_date"); startDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Start date must be YYYY-MM-DD")
+			return
+		}
+		filters.StartDate = &parsed
+	}
+
+	if endDateStr := ctx.Query("end_date"); endDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "End date must be YYYY-MM-DD")
+			return
+		}
+		// Include the whole end day
+		endDate := parsed.Add(24*time.Hour - time.Nanosecond)
+		filters.EndDate = &endDate
+	}
+
+	report, err := c.negativeStockUC.GetNegativeStockReport(businessID, filters)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PricingController struct {
+	pricingUC Usecases.PricingUseCase
+}
+
+func NewPricingController(pricingUC Usecases.PricingUseCase) *PricingController {
+	return &PricingController{pricingUC: pricingUC}
+}
+
+// SchedulePriceChanges godoc
+// @Summary      Schedule price changes
+// @Description  Schedule new prices for the listed products or for every active product of a category. Give new cost/selling prices or a percentage change of the selling price (e.g. 10 or -5). The changes are applied automatically at effective_at
+// @Tags         pricing
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                               true  "Business ID"
+// @Param        request     body  Domain.SchedulePriceChangeRequest  true  "Scheduled change"
+// @Success      201  {array}   Domain.ScheduledPriceChange
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/price-changes/schedule [post]
+// @Security     BearerAuth
+func (c *PricingController) SchedulePriceChanges(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.SchedulePriceChangeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	changes, err := c.pricingUC.SchedulePriceChanges(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, changes)
+}
+
+// GetScheduledPriceChanges godoc
+// @Summary      List scheduled price changes
+// @Description  Get the scheduled price changes of the business, soonest first
+// @Tags         pricing
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        status      query  string  false  "Status: pending, applied, cancelled, failed"
+// @Success      200  {array}   Domain.ScheduledPriceChange
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/price-changes/scheduled [get]
+// @Security     BearerAuth
+func (c *PricingController) GetScheduledPriceChanges(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var status *Domain.ScheduledPriceStatus
+	if statusStr := ctx.Query("status"); statusStr != "" {
+		s := Domain.ScheduledPriceStatus(statusStr)
+		status = &s
+	}
+
+	changes, err := c.pricingUC.GetScheduledPriceChanges(businessID, status)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, changes)
+}
+
+// CancelScheduledPriceChange godoc
+// @Summary      Cancel scheduled price change
+// @Description  Cancel a scheduled price change that has not been applied yet
+// @Tags         pricing
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        scheduleId  path  string  true  "Scheduled price change ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/price-changes/scheduled/{scheduleId}/cancel [post]
+// @Security     BearerAuth
+func (c *PricingController) CancelScheduledPriceChange(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	scheduleID := ctx.Param("scheduleId")
+	if scheduleID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Scheduled price change ID is required")
+		return
+	}
+
+	if err := c.pricingUC.CancelScheduledPriceChange(scheduleID, businessID); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Scheduled price change cancelled successfully"})
+}
+
+// GetPriceChanges godoc
+// @Summary      List price changes
+// @Description  Get every price change made between two dates, newest first. Defaults to the last 30 days
+// @Tags         pricing
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        start_date  query  string  false  "Start date (YYYY-MM-DD)"
+// @Param        end_date    query  string  false  "End date (YYYY-MM-DD)"
+// @Success      200  {array}   Domain.PriceChange
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/price-changes [get]
+// @Security     BearerAuth
+func (c *PricingController) GetPriceChanges(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	// Left zero, the range defaults to the last 30 days
+	var startDate, endDate time.Time
+
+	if startDateStr := ctx.Query("start_date"); startDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Start date must be YYYY-MM-DD")
+			return
+		}
+		startDate = parsed
+	}
+
+	if endDateStr := ctx.Query("end_date"); endDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "End date must be YYYY-MM-DD")
+			return
+		}
+		// Include the whole end day
+		endDate = parsed.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	changes, err := c.pricingUC.GetPriceChanges(businessID, startDate, endDate)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, changes)
+}
+
+// GetPriceHistory godoc
+// @Summary      Get product price history
+// @Description  Get the price changes of a product, newest first, with who made them and the old and new prices
+// @Tags         pricing
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        productId   path   string  true   "Product ID"
+// @Param        limit       query  int     false  "Limit results (default 50)"
+// @Success      200  {array}   Domain.PriceChange
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/price-history [get]
+// @Security     BearerAuth
+func (c *PricingController) GetPriceHistory(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	limit := 50
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	changes, err := c.pricingUC.GetPriceHistory(productID, businessID, limit)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, changes)
+}
+
+// GetPricesAt godoc
+// @Summary      Get prices on a date
+// @Description  Get the cost and selling price of every product in effect at the end of a given date, for reports that need historical prices
+// @Tags         pricing
+// @Produce      json
+// @Param        businessId  path   string  true  "Business ID"
+// @Param        date        query  string  true  "Date (YYYY-MM-DD)"
+// @Success      200  {array}   Domain.ProductPrice
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/prices [get]
+// @Security     BearerAuth
+func (c *PricingController) GetPricesAt(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	dateStr := ctx.Query("date")
+	if dateStr == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Date is required")
+		return
+	}
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Date must be YYYY-MM-DD")
+		return
+	}
+
+	prices, err := c.pricingUC.GetPricesAt(businessID, date.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prices)
+}
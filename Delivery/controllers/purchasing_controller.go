@@ -0,0 +1,309 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PurchasingController struct {
+	purchasingUC Usecases.PurchasingUseCase
+}
+
+func NewPurchasingController(purchasingUC Usecases.PurchasingUseCase) *PurchasingController {
+	return &PurchasingController{purchasingUC: purchasingUC}
+}
+
+// GetReorderSuggestions godoc
+// @Summary      Get reorder suggestions
+// @Description  Suggest what to reorder from recent sales velocity, lead times and maximum stock, grouped by supplier
+// @Tags         purchasing
+// @Produce      json
+// @Param        businessId     path   string  true   "Business ID"
+// @Param        lookback_days  query  int     false  "Days of sales history used for demand (default 30)"
+// @Param        safety_days    query  int     false  "Extra days of cover held against demand spikes (default 7)"
+// @Param        cover_days     query  int     false  "Days of demand ordered beyond the reorder point when a product has no maximum stock (default 30)"
+// @Param        supplier_id    query  string  false  "Only products of this supplier"
+// @Success      200  {object}  Domain.ReorderSuggestionsReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/reorder-suggestions [get]
+// @Security     BearerAuth
+func (c *PurchasingController) GetReorderSuggestions(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	opts := Domain.ReorderOptions{SupplierID: ctx.Query("supplier_id")}
+	if daysStr := ctx.Query("lookback_days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 {
+			opts.LookbackDays = d
+		}
+	}
+	if daysStr := ctx.Query("safety_days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 {
+			opts.SafetyDays = d
+		}
+	}
+	if daysStr := ctx.Query("cover_days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 {
+			opts.CoverDays = d
+		}
+	}
+
+	report, err := c.purchasingUC.GetReorderSuggestions(businessID, opts)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// CreateDraftOrders godoc
+// @Summary      Draft purchase orders from suggestions
+// @Description  Turn the current reorder suggestions into one draft purchase order per supplier
+// @Tags         purchasing
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                           true  "Business ID"
+// @Param        request     body  Domain.CreateDraftOrdersRequest  true  "Which suggestions to order"
+// @Success      201  {array}   Domain.PurchaseOrder
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/purchase-orders/from-suggestions [post]
+// @Security     BearerAuth
+func (c *PurchasingController) CreateDraftOrders(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.CreateDraftOrdersRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	orders, err := c.purchasingUC.CreateDraftOrders(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, orders)
+}
+
+// GetPurchaseOrders godoc
+// @Summary      List purchase orders
+// @Description  Get the purchase orders of a business, newest first
+// @Tags         purchasing
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        status      query  string  false  "Status: draft, ordered, received, cancelled"
+// @Success      200  {array}   Domain.PurchaseOrder
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/purchase-orders [get]
+// @Security     BearerAuth
+func (c *PurchasingController) GetPurchaseOrders(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var status *Domain.PurchaseOrderStatus
+	if statusStr := ctx.Query("status"); statusStr != "" {
+		s := Domain.PurchaseOrderStatus(statusStr)
+		status = &s
+	}
+
+	orders, err := c.purchasingUC.GetPurchaseOrders(businessID, status)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, orders)
+}
+
+// GetPurchaseOrder godoc
+// @Summary      Get purchase order
+// @Description  Get a purchase order by ID
+// @Tags         purchasing
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        orderId     path  string  true  "Purchase order ID"
+// @Success      200  {object}  Domain.PurchaseOrder
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/purchase-orders/{orderId} [get]
+// @Security     BearerAuth
+func (c *PurchasingController) GetPurchaseOrder(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	orderID := ctx.Param("orderId")
+	if orderID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Purchase order ID is required")
+		return
+	}
+
+	order, err := c.purchasingUC.GetPurchaseOrder(orderID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+// MarkOrdered godoc
+// @Summary      Mark purchase order as ordered
+// @Description  Record that a draft purchase order has been sent to the supplier
+// @Tags         purchasing
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        orderId     path  string  true  "Purchase order ID"
+// @Success      200  {object}  Domain.PurchaseOrder
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/purchase-orders/{orderId}/order [post]
+// @Security     BearerAuth
+func (c *PurchasingController) MarkOrdered(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	orderID := ctx.Param("orderId")
+	if orderID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Purchase order ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	order, err := c.purchasingUC.MarkOrdered(orderID, businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary      Receive purchase order
+// @Description  Book the goods of an open purchase order into stock at their order cost
+// @Tags         purchasing
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                              true   "Business ID"
+// @Param        orderId     path  string                              true   "Purchase order ID"
+// @Param        request     body  Domain.ReceivePurchaseOrderRequest  false  "Receiving location"
+// @Success      200  {object}  Domain.PurchaseOrder
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/purchase-orders/{orderId}/receive [post]
+// @Security     BearerAuth
+func (c *PurchasingController) ReceivePurchaseOrder(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	orderID := ctx.Param("orderId")
+	if orderID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Purchase order ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	// The body is optional; receive at the default location without one
+	var req Domain.ReceivePurchaseOrderRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+			return
+		}
+	}
+
+	order, err := c.purchasingUC.ReceivePurchaseOrder(orderID, businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+// CancelPurchaseOrder godoc
+// @Summary      Cancel purchase order
+// @Description  Cancel a draft or ordered purchase order
+// @Tags         purchasing
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        orderId     path  string  true  "Purchase order ID"
+// @Success      200  {object}  Domain.PurchaseOrder
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/purchase-orders/{orderId}/cancel [post]
+// @Security     BearerAuth
+func (c *PurchasingController) CancelPurchaseOrder(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	orderID := ctx.Param("orderId")
+	if orderID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Purchase order ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	order, err := c.purchasingUC.CancelPurchaseOrder(orderID, businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
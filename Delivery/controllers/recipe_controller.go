@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecipeController struct {
+	recipeUC Usecases.RecipeUseCase
+}
+
+func NewRecipeController(recipeUC Usecases.RecipeUseCase) *RecipeController {
+	return &RecipeController{recipeUC: recipeUC}
+}
+
+// SetRecipe godoc
+// @Summary      Set product recipe
+// @Description  Create or replace the recipe of a menu item. Quantities are per unit sold, in each ingredient's own unit, with an optional waste factor (0.05 for 5%). Selling the item back-flushes the ingredients out of stock, and its cost price becomes the ingredient cost
+// @Tags         recipes
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                   true  "Business ID"
+// @Param        productId   path  string                   true  "Menu item product ID"
+// @Param        request     body  Domain.SetRecipeRequest  true  "Ingredients"
+// @Success      200  {object}  Domain.Recipe
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/recipe [put]
+// @Security     BearerAuth
+func (c *RecipeController) SetRecipe(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.SetRecipeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	recipe, err := c.recipeUC.SetRecipe(productID, businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
+
+// GetRecipe godoc
+// @Summary      Get product recipe
+// @Description  Get the recipe of a menu item with its current ingredient cost
+// @Tags         recipes
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        productId   path  string  true  "Menu item product ID"
+// @Success      200  {object}  Domain.Recipe
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/recipe [get]
+// @Security     BearerAuth
+func (c *RecipeController) GetRecipe(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	recipe, err := c.recipeUC.GetRecipe(productID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipe)
+}
+
+// DeleteRecipe godoc
+// @Summary      Delete product recipe
+// @Description  Remove the recipe of a menu item; it is then sold from its own stock again
+// @Tags         recipes
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        productId   path  string  true  "Menu item product ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/recipe [delete]
+// @Security     BearerAuth
+func (c *RecipeController) DeleteRecipe(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	if err := c.recipeUC.DeleteRecipe(productID, businessID); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Recipe deleted successfully"})
+}
+
+// GetRecipes godoc
+// @Summary      List recipes
+// @Description  Get every recipe of the business with its current ingredient cost
+// @Tags         recipes
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {array}   Domain.Recipe
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/recipes [get]
+// @Security     BearerAuth
+func (c *RecipeController) GetRecipes(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	recipes, err := c.recipeUC.GetRecipes(businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipes)
+}
+
+// GetUsageReport godoc
+// @Summary      Theoretical vs actual ingredient usage
+// @Description  Compare the ingredient usage back-flushed by menu item sales with the actual usage revealed by a stocktake. The period runs from the previous stocktake (or start_date) to when the stocktake was opened
+// @Tags         recipes
+// @Produce      json
+// @Param        businessId    path   string  true   "Business ID"
+// @Param        stocktake_id  query  string  true   "Stocktake the actual usage is taken from"
+// @Param        start_date    query  string  false  "Start of the period (YYYY-MM-DD); defaults to the previous stocktake"
+// @Success      200  {object}  Domain.UsageReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/recipes/usage [get]
+// @Security     BearerAuth
+func (c *RecipeController) GetUsageReport(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	stocktakeID := ctx.Query("stocktake_id")
+	if stocktakeID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Stocktake ID is required")
+		return
+	}
+
+	var startDate *time.Time
+	if startDateStr := ctx.Query("start_date"); startDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Start date must be YYYY-MM-DD")
+			return
+		}
+		startDate = &parsed
+	}
+
+	report, err := c.recipeUC.GetUsageReport(businessID, stocktakeID, startDate)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
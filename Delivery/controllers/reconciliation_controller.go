@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationController struct {
+	reconciliationUC Usecases.ReconciliationUseCase
+}
+
+func NewReconciliationController(reconciliationUC Usecases.ReconciliationUseCase) *ReconciliationController {
+	return &ReconciliationController{reconciliationUC: reconciliationUC}
+}
+
+// GetPaymentReconciliation godoc
+// @Summary      Payment reconciliation report
+// @Description  Completed sales per day by payment method and payment status, with the paid takings of cash, card, mobile and bank set against the settlements entered and each day and method's reconciliation status. Dates are days in the business's timezone; the range defaults to the last 7 days
+// @Tags         reports
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        start_date  query  string  false  "Start date (YYYY-MM-DD)"
+// @Param        end_date    query  string  false  "End date (YYYY-MM-DD, default today)"
+// @Success      200  {object}  Domain.PaymentReconciliationReport
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/payments [get]
+// @Security     BearerAuth
+func (c *ReconciliationController) GetPaymentReconciliation(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var req Domain.PaymentReconciliationRequest
+
+	if startStr := ctx.Query("start_date"); startStr != "" {
+		startDate, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Dates must be YYYY-MM-DD")
+			return
+		}
+		req.StartDate = &startDate
+	}
+
+	if endStr := ctx.Query("end_date"); endStr != "" {
+		endDate, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Dates must be YYYY-MM-DD")
+			return
+		}
+		req.EndDate = &endDate
+	}
+
+	report, err := c.reconciliationUC.GetPaymentReconciliation(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// RecordSettlement godoc
+// @Summary      Record a payment settlement
+// @Description  Enter the amount actually received for cash, card, mobile or bank takings on a day, from a bank deposit, card settlement or mobile money statement, for the business owner. The variance against the day's paid takings is recorded with it; entering the same day and method again replaces it
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                          true  "Business ID"
+// @Param        request     body  Domain.RecordSettlementRequest  true  "Settlement"
+// @Success      200  {object}  Domain.PaymentSettlement
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/payments/settlements [put]
+// @Security     BearerAuth
+func (c *ReconciliationController) RecordSettlement(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.RecordSettlementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	settlement, err := c.reconciliationUC.RecordSettlement(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settlement)
+}
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	Domain "ShopOps/Domain"
@@ -217,10 +219,11 @@ func (c *ReportController) GetProfitReport(ctx *gin.Context) {
 
 // GetInventoryReport godoc
 // @Summary      Get inventory status report
-// @Description  Generate inventory report with low stock alerts
+// @Description  Generate inventory report with low stock alerts, across all locations or for a single location
 // @Tags         reports
 // @Produce      json
-// @Param        businessId  path  string  true  "Business ID"
+// @Param        businessId   path   string  true   "Business ID"
+// @Param        location_id  query  string  false  "Report only the stock held at this location"
 // @Success      200  {object}  Domain.InventoryReport
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      401  {object}  map[string]interface{}
@@ -237,6 +240,10 @@ func (c *ReportController) GetInventoryReport(ctx *gin.Context) {
 	req.BusinessID = businessID
 	req.Type = Domain.ReportTypeInventory
 
+	if locationID := ctx.Query("location_id"); locationID != "" {
+		req.LocationID = &locationID
+	}
+
 	report, err := c.reportUC.GenerateReport(req)
 	if err != nil {
 		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
@@ -247,16 +254,20 @@ func (c *ReportController) GetInventoryReport(ctx *gin.Context) {
 }
 
 // ExportReport godoc
-// @Summary      Generate CSV export
-// @Description  Export report data to CSV format
+// @Summary      Export a report
+// @Description  Export report data as CSV, JSON, a printable PDF with the business letterhead, summary tables, charts and page numbers, or an XLSX workbook with typed numbers and dates in sheets for the summary, daily breakdown, top products and transactions
 // @Tags         reports
 // @Produce      text/csv
+// @Produce      application/pdf
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
 // @Param        businessId  path    string  true   "Business ID"
 // @Param        type        query   string  true   "Report type: sales, expenses, profit, inventory"
 // @Param        period      query   string  false  "Period: daily, weekly, monthly, yearly, custom"
 // @Param        start_date  query   string  false  "Start date (YYYY-MM-DD) for custom period"
 // @Param        end_date    query   string  false  "End date (YYYY-MM-DD) for custom period"
-// @Success      200  {string}  string  "CSV file"
+// @Param        format      query   string  false  "Format: csv (default), pdf, xlsx, json"
+// @Success      200  {string}  string  "Report file"
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      401  {object}  map[string]interface{}
 // @Router       /api/v1/businesses/{businessId}/reports/export [get]
@@ -299,8 +310,11 @@ func (c *ReportController) ExportReport(ctx *gin.Context) {
 		}
 	}
 
-	// Set format to CSV
-	format := "csv"
+	format := ctx.DefaultQuery("format", "csv")
+	if format != "csv" && format != "pdf" && format != "xlsx" && format != "json" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Format must be csv, pdf, xlsx or json")
+		return
+	}
 	req.Format = &format
 
 	data, filename, err := c.reportUC.ExportReport(req)
@@ -309,9 +323,19 @@ func (c *ReportController) ExportReport(ctx *gin.Context) {
 		return
 	}
 
-	ctx.Header("Content-Type", "text/csv")
+	contentType := "text/csv"
+	switch format {
+	case "pdf":
+		contentType = "application/pdf"
+	case "xlsx":
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case "json":
+		contentType = "application/json"
+	}
+
+	ctx.Header("Content-Type", contentType)
 	ctx.Header("Content-Disposition", "attachment; filename="+filename)
-	ctx.Data(http.StatusOK, "text/csv", data)
+	ctx.Data(http.StatusOK, contentType, data)
 }
 
 // GetProfitSummary godoc
@@ -395,3 +419,418 @@ func (c *ReportController) GetProfitTrends(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, trends)
 }
+
+// ComparePeriods godoc
+// @Summary      Compare two periods
+// @Description  Set sales, expenses, profit, transactions and average basket against an earlier period, with the categories and products behind the change in sales. Presets compare this week or month to date with the same days of the last one, or a period with the same dates a year earlier; custom compares any two periods, or the current one with the same number of days just before it when no previous dates are given. Dates are days in the business's timezone.
+// @Tags         reports
+// @Produce      json
+// @Param        businessId      path   string  true   "Business ID"
+// @Param        preset          query  string  false  "Preset: week_over_week, month_over_month (default without dates), year_over_year, custom (default with dates)"
+// @Param        current_start   query  string  false  "Current period start (YYYY-MM-DD) for custom and year_over_year"
+// @Param        current_end     query  string  false  "Current period end (YYYY-MM-DD) for custom and year_over_year"
+// @Param        previous_start  query  string  false  "Previous period start (YYYY-MM-DD) for custom"
+// @Param        previous_end    query  string  false  "Previous period end (YYYY-MM-DD) for custom"
+// @Param        limit           query  int     false  "Products in the contribution analysis (default 10)"
+// @Success      200  {object}  Domain.PeriodComparison
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/compare [get]
+// @Security     BearerAuth
+func (c *ReportController) ComparePeriods(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	req := Domain.PeriodComparisonRequest{
+		Preset: Domain.ComparisonPreset(ctx.Query("preset")),
+	}
+
+	dates := []struct {
+		param string
+		dest  **time.Time
+	}{
+		{"current_start", &req.CurrentStart},
+		{"current_end", &req.CurrentEnd},
+		{"previous_start", &req.PreviousStart},
+		{"previous_end", &req.PreviousEnd},
+	}
+	for _, date := range dates {
+		value := ctx.Query(date.param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Dates must be YYYY-MM-DD")
+			return
+		}
+		*date.dest = &parsed
+	}
+
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			req.Limit = l
+		}
+	}
+
+	comparison, err := c.reportUC.ComparePeriods(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comparison)
+}
+
+// GetAgingReport godoc
+// @Summary      Inventory aging report
+// @Description  Bucket each product's on-hand stock by days since it was last sold and last received, with the capital tied up in each bucket, and flag dead stock with no sales in dead_days days
+// @Tags         reports
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        dead_days   query  int     false  "Days without a sale before stock counts as dead (default 90)"
+// @Param        buckets     query  string  false  "Comma-separated upper day limits of the buckets (default 30,90,180)"
+// @Success      200  {object}  Domain.AgingReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/inventory/aging [get]
+// @Security     BearerAuth
+func (c *ReportController) GetAgingReport(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	req, err := parseAgingRequest(ctx)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Buckets must be comma-separated numbers of days")
+		return
+	}
+
+	report, err := c.reportUC.GetAgingReport(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// ExportAgingReport godoc
+// @Summary      Export inventory aging report
+// @Description  Download the inventory aging report as CSV or JSON
+// @Tags         reports
+// @Produce      text/csv
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        dead_days   query  int     false  "Days without a sale before stock counts as dead (default 90)"
+// @Param        buckets     query  string  false  "Comma-separated upper day limits of the buckets (default 30,90,180)"
+// @Param        format      query  string  false  "Format: csv (default), json"
+// @Success      200  {string}  string  "Aging report file"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/inventory/aging/export [get]
+// @Security     BearerAuth
+func (c *ReportController) ExportAgingReport(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	req, err := parseAgingRequest(ctx)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Buckets must be comma-separated numbers of days")
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "csv")
+
+	data, filename, err := c.reportUC.ExportAgingReport(businessID, req, format)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	contentType := "application/json"
+	if format == "csv" {
+		contentType = "text/csv"
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+func parseAgingRequest(ctx *gin.Context) (Domain.AgingReportRequest, error) {
+	var req Domain.AgingReportRequest
+
+	if deadDaysStr := ctx.Query("dead_days"); deadDaysStr != "" {
+		if d, err := strconv.Atoi(deadDaysStr); err == nil && d > 0 {
+			req.DeadStockDays = d
+		}
+	}
+
+	if bucketsStr := ctx.Query("buckets"); bucketsStr != "" {
+		for _, part := range strings.Split(bucketsStr, ",") {
+			days, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return req, err
+			}
+			req.Buckets = append(req.Buckets, days)
+		}
+	}
+
+	return req, nil
+}
+
+// GetSalesHeatmap godoc
+// @Summary      Sales heatmap
+// @Description  Count completed sales and revenue by day of the week and hour of the day in the business's timezone, against the range of the same number of days just before. Dates are days in the business's timezone; the range defaults to the last four weeks
+// @Tags         reports
+// @Produce      json
+// @Param        businessId      path   string  true   "Business ID"
+// @Param        start_date      query  string  false  "Start date (YYYY-MM-DD)"
+// @Param        end_date        query  string  false  "End date (YYYY-MM-DD, default today)"
+// @Param        product_id      query  string  false  "Only sales of this product"
+// @Param        category        query  string  false  "Only sales of products in this category or below it, by ID or name"
+// @Param        payment_method  query  string  false  "Only sales paid this way (cash, card, mobile, bank, credit, other)"
+// @Success      200  {object}  Domain.SalesHeatmap
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/sales/heatmap [get]
+// @Security     BearerAuth
+func (c *ReportController) GetSalesHeatmap(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	req, err := parseHeatmapRequest(ctx)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Dates must be YYYY-MM-DD")
+		return
+	}
+
+	heatmap, err := c.reportUC.GetSalesHeatmap(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, heatmap)
+}
+
+// ExportSalesHeatmap godoc
+// @Summary      Export sales heatmap
+// @Description  Download the sales heatmap as CSV or JSON
+// @Tags         reports
+// @Produce      text/csv
+// @Produce      json
+// @Param        businessId      path   string  true   "Business ID"
+// @Param        start_date      query  string  false  "Start date (YYYY-MM-DD)"
+// @Param        end_date        query  string  false  "End date (YYYY-MM-DD, default today)"
+// @Param        product_id      query  string  false  "Only sales of this product"
+// @Param        category        query  string  false  "Only sales of products in this category or below it, by ID or name"
+// @Param        payment_method  query  string  false  "Only sales paid this way (cash, card, mobile, bank, credit, other)"
+// @Param        format          query  string  false  "Format: csv (default), json"
+// @Success      200  {string}  string  "Sales heatmap file"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/sales/heatmap/export [get]
+// @Security     BearerAuth
+func (c *ReportController) ExportSalesHeatmap(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	req, err := parseHeatmapRequest(ctx)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Dates must be YYYY-MM-DD")
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "csv")
+
+	data, filename, err := c.reportUC.ExportSalesHeatmap(businessID, req, format)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	contentType := "application/json"
+	if format == "csv" {
+		contentType = "text/csv"
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+func parseHeatmapRequest(ctx *gin.Context) (Domain.SalesHeatmapRequest, error) {
+	req := Domain.SalesHeatmapRequest{
+		ProductID: ctx.Query("product_id"),
+		Category:  ctx.Query("category"),
+	}
+
+	if method := ctx.Query("payment_method"); method != "" {
+		paymentMethod := Domain.PaymentMethod(method)
+		req.PaymentMethod = &paymentMethod
+	}
+
+	if startStr := ctx.Query("start_date"); startStr != "" {
+		startDate, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			return req, err
+		}
+		req.StartDate = &startDate
+	}
+
+	if endStr := ctx.Query("end_date"); endStr != "" {
+		endDate, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			return req, err
+		}
+		req.EndDate = &endDate
+	}
+
+	return req, nil
+}
+
+// GetABCAnalysis godoc
+// @Summary      ABC product analysis
+// @Description  Rank every product in the catalogue, including those that did not sell, by revenue, gross profit and quantity sold over a range, and put each into class A, B or C by its cumulative share of each total. Dates are days in the business's timezone; the range defaults to the last 90 days
+// @Tags         reports
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        start_date  query  string  false  "Start date (YYYY-MM-DD)"
+// @Param        end_date    query  string  false  "End date (YYYY-MM-DD, default today)"
+// @Param        metric      query  string  false  "Order the products by: revenue (default), gross_profit, quantity"
+// @Param        category    query  string  false  "Only products in this category or below it, by ID or name"
+// @Param        class_a     query  number  false  "Percent of each total taken by class A (default 80)"
+// @Param        class_b     query  number  false  "Percent of each total taken by class B (default 15)"
+// @Success      200  {object}  Domain.ABCReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/products/abc [get]
+// @Security     BearerAuth
+func (c *ReportController) GetABCAnalysis(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	req, err := parseABCRequest(ctx)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	report, err := c.reportUC.GetABCAnalysis(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// ExportABCAnalysis godoc
+// @Summary      Export ABC product analysis
+// @Description  Download the ABC product analysis as CSV or JSON
+// @Tags         reports
+// @Produce      text/csv
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        start_date  query  string  false  "Start date (YYYY-MM-DD)"
+// @Param        end_date    query  string  false  "End date (YYYY-MM-DD, default today)"
+// @Param        metric      query  string  false  "Order the products by: revenue (default), gross_profit, quantity"
+// @Param        category    query  string  false  "Only products in this category or below it, by ID or name"
+// @Param        class_a     query  number  false  "Percent of each total taken by class A (default 80)"
+// @Param        class_b     query  number  false  "Percent of each total taken by class B (default 15)"
+// @Param        format      query  string  false  "Format: csv (default), json"
+// @Success      200  {string}  string  "ABC analysis file"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/products/abc/export [get]
+// @Security     BearerAuth
+func (c *ReportController) ExportABCAnalysis(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	req, err := parseABCRequest(ctx)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "csv")
+
+	data, filename, err := c.reportUC.ExportABCAnalysis(businessID, req, format)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	contentType := "application/json"
+	if format == "csv" {
+		contentType = "text/csv"
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+func parseABCRequest(ctx *gin.Context) (Domain.ABCReportRequest, error) {
+	req := Domain.ABCReportRequest{
+		Metric:   Domain.ABCMetric(ctx.Query("metric")),
+		Category: ctx.Query("category"),
+	}
+
+	if startStr := ctx.Query("start_date"); startStr != "" {
+		startDate, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			return req, fmt.Errorf("start date must be YYYY-MM-DD")
+		}
+		req.StartDate = &startDate
+	}
+
+	if endStr := ctx.Query("end_date"); endStr != "" {
+		endDate, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			return req, fmt.Errorf("end date must be YYYY-MM-DD")
+		}
+		req.EndDate = &endDate
+	}
+
+	if classAStr := ctx.Query("class_a"); classAStr != "" {
+		classA, err := strconv.ParseFloat(classAStr, 64)
+		if err != nil {
+			return req, fmt.Errorf("class A share must be a number")
+		}
+		req.ClassA = &classA
+	}
+
+	if classBStr := ctx.Query("class_b"); classBStr != "" {
+		classB, err := strconv.ParseFloat(classBStr, 64)
+		if err != nil {
+			return req, fmt.Errorf("class B share must be a number")
+		}
+		req.ClassB = &classB
+	}
+
+	return req, nil
+}
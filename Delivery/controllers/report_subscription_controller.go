@@ -0,0 +1,314 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportSubscriptionController struct {
+	subscriptionUC Usecases.ReportSubscriptionUseCase
+}
+
+func NewReportSubscriptionController(subscriptionUC Usecases.ReportSubscriptionUseCase) *ReportSubscriptionController {
+	return &ReportSubscriptionController{subscriptionUC: subscriptionUC}
+}
+
+// CreateSubscription godoc
+// @Summary      Subscribe to a report
+// @Description  Have a report emailed to you on a daily, weekly or monthly schedule. Each report covers the last full period before it is sent, in the business's timezone
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                                  true  "Business ID"
+// @Param        request     body  Domain.CreateReportSubscriptionRequest  true  "Report, format and schedule"
+// @Success      201  {object}  Domain.ReportSubscription
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/subscriptions [post]
+// @Security     BearerAuth
+func (c *ReportSubscriptionController) CreateSubscription(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.CreateReportSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	subscription, err := c.subscriptionUC.CreateSubscription(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, subscription)
+}
+
+// GetSubscriptions godoc
+// @Summary      List report subscriptions
+// @Description  Get your report subscriptions in the business
+// @Tags         reports
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {array}   Domain.ReportSubscription
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/subscriptions [get]
+// @Security     BearerAuth
+func (c *ReportSubscriptionController) GetSubscriptions(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	subscriptions, err := c.subscriptionUC.GetSubscriptions(businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, subscriptions)
+}
+
+// UpdateSubscription godoc
+// @Summary      Update a report subscription
+// @Description  Change a subscription's report, format or schedule, or pause it with active set to false
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Param        businessId      path  string                                  true  "Business ID"
+// @Param        subscriptionId  path  string                                  true  "Subscription ID"
+// @Param        request         body  Domain.UpdateReportSubscriptionRequest  true  "Fields to change"
+// @Success      200  {object}  Domain.ReportSubscription
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/subscriptions/{subscriptionId} [patch]
+// @Security     BearerAuth
+func (c *ReportSubscriptionController) UpdateSubscription(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	subscriptionID := ctx.Param("subscriptionId")
+	if subscriptionID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Subscription ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.UpdateReportSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	subscription, err := c.subscriptionUC.UpdateSubscription(subscriptionID, businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, subscription)
+}
+
+// DeleteSubscription godoc
+// @Summary      Delete a report subscription
+// @Description  Stop a subscription; its delivery history is kept
+// @Tags         reports
+// @Produce      json
+// @Param        businessId      path  string  true  "Business ID"
+// @Param        subscriptionId  path  string  true  "Subscription ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/subscriptions/{subscriptionId} [delete]
+// @Security     BearerAuth
+func (c *ReportSubscriptionController) DeleteSubscription(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	subscriptionID := ctx.Param("subscriptionId")
+	if subscriptionID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Subscription ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	if err := c.subscriptionUC.DeleteSubscription(subscriptionID, businessID, userID.(string)); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Report subscription deleted successfully"})
+}
+
+// SendNow godoc
+// @Summary      Send a subscribed report now
+// @Description  Email a subscription's report for the last full period straight away. A failed send is retried like a scheduled one
+// @Tags         reports
+// @Produce      json
+// @Param        businessId      path  string  true  "Business ID"
+// @Param        subscriptionId  path  string  true  "Subscription ID"
+// @Success      200  {object}  Domain.ReportDelivery
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/subscriptions/{subscriptionId}/send [post]
+// @Security     BearerAuth
+func (c *ReportSubscriptionController) SendNow(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	subscriptionID := ctx.Param("subscriptionId")
+	if subscriptionID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Subscription ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	delivery, err := c.subscriptionUC.SendNow(subscriptionID, businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
+
+// GetDeliveries godoc
+// @Summary      List report deliveries
+// @Description  Get the delivery history of your report subscriptions, newest first
+// @Tags         reports
+// @Produce      json
+// @Param        businessId       path   string  true   "Business ID"
+// @Param        subscription_id  query  string  false  "Filter by subscription"
+// @Param        status           query  string  false  "Filter by status (pending, sent, failed)"
+// @Param        limit            query  int     false  "Limit results (default 50)"
+// @Success      200  {array}   Domain.ReportDelivery
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/deliveries [get]
+// @Security     BearerAuth
+func (c *ReportSubscriptionController) GetDeliveries(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var filters Domain.ReportDeliveryFilters
+
+	if subscriptionID := ctx.Query("subscription_id"); subscriptionID != "" {
+		filters.SubscriptionID = &subscriptionID
+	}
+
+	if status := ctx.Query("status"); status != "" {
+		s := Domain.ReportDeliveryStatus(status)
+		filters.Status = &s
+	}
+
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filters.Limit = l
+		}
+	}
+
+	deliveries, err := c.subscriptionUC.GetDeliveries(businessID, userID.(string), filters)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// RetryDelivery godoc
+// @Summary      Retry a failed report delivery
+// @Description  Make one more attempt at a delivery that failed after all its retries
+// @Tags         reports
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        deliveryId  path  string  true  "Delivery ID"
+// @Success      200  {object}  Domain.ReportDelivery
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/deliveries/{deliveryId}/retry [post]
+// @Security     BearerAuth
+func (c *ReportSubscriptionController) RetryDelivery(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	deliveryID := ctx.Param("deliveryId")
+	if deliveryID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Delivery ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	delivery, err := c.subscriptionUC.RetryDelivery(deliveryID, businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"net/http"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SerialController struct {
+	serialUC Usecases.SerialUseCase
+}
+
+func NewSerialController(serialUC Usecases.SerialUseCase) *SerialController {
+	return &SerialController{serialUC: serialUC}
+}
+
+// ReceiveSerials godoc
+// @Summary      Receive serialised units
+// @Description  Receive units of a serial-tracked product into stock, one per serial number. With existing set, the serials are registered against stock already on hand and no stock is added
+// @Tags         inventory
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        productId   path  string                        true  "Product ID"
+// @Param        request     body  Domain.ReceiveSerialsRequest  true  "Serial numbers"
+// @Success      201  {array}   Domain.SerialNumber
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/serials [post]
+// @Security     BearerAuth
+func (c *SerialController) ReceiveSerials(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.ReceiveSerialsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	serials, err := c.serialUC.ReceiveSerials(productID, businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, serials)
+}
+
+// GetProductSerials godoc
+// @Summary      List product serial numbers
+// @Description  Get the serial numbers of a product with the stock on hand counted from serials still in stock
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        productId   path   string  true   "Product ID"
+// @Param        status      query  string  false  "Filter by status (in_stock, sold, written_off)"
+// @Success      200  {object}  Domain.ProductSerials
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/products/{productId}/serials [get]
+// @Security     BearerAuth
+func (c *SerialController) GetProductSerials(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	productID := ctx.Param("productId")
+	if productID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Product ID is required")
+		return
+	}
+
+	var status *Domain.SerialStatus
+	if statusStr := ctx.Query("status"); statusStr != "" {
+		s := Domain.SerialStatus(statusStr)
+		status = &s
+	}
+
+	serials, err := c.serialUC.GetProductSerials(productID, businessID, status)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, serials)
+}
+
+// LookupSerial godoc
+// @Summary      Look up a serial number
+// @Description  Get a serialised unit by its serial number or IMEI with its full history: received, sold to whom, returned, and its warranty expiry
+// @Tags         inventory
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        serial      path  string  true  "Serial number"
+// @Success      200  {object}  Domain.SerialNumber
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/serials/{serial} [get]
+// @Security     BearerAuth
+func (c *SerialController) LookupSerial(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	serial := ctx.Param("serial")
+	if serial == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Serial number is required")
+		return
+	}
+
+	found, err := c.serialUC.LookupSerial(businessID, serial)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, found)
+}
+
+// WriteOffSerial godoc
+// @Summary      Write off a serialised unit
+// @Description  Take a damaged or lost unit out of stock by its serial number
+// @Tags         inventory
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        serial      path  string                        true  "Serial number"
+// @Param        request     body  Domain.WriteOffSerialRequest  true  "Reason"
+// @Success      200  {object}  Domain.SerialNumber
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/serials/{serial}/write-off [post]
+// @Security     BearerAuth
+func (c *SerialController) WriteOffSerial(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	serial := ctx.Param("serial")
+	if serial == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Serial number is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.WriteOffSerialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	found, err := c.serialUC.WriteOffSerial(businessID, userID.(string), serial, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, found)
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StaffReportController struct {
+	staffReportUC Usecases.StaffReportUseCase
+}
+
+func NewStaffReportController(staffReportUC Usecases.StaffReportUseCase) *StaffReportController {
+	return &StaffReportController{staffReportUC: staffReportUC}
+}
+
+// GetStaffPerformance godoc
+// @Summary      Staff performance report
+// @Description  Sales, revenue, average basket, discounts, voids, edits and refunds per user over a period, for the business owner. Voids and edits of another user's sales are counted apart. Dates are days in the business's timezone; the period defaults to the last 30 days
+// @Tags         reports
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        start_date  query  string  false  "Start date (YYYY-MM-DD)"
+// @Param        end_date    query  string  false  "End date (YYYY-MM-DD, default today)"
+// @Success      200  {object}  Domain.StaffPerformanceReport
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/staff [get]
+// @Security     BearerAuth
+func (c *StaffReportController) GetStaffPerformance(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	req, err := parseStaffReportRequest(ctx)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Dates must be YYYY-MM-DD")
+		return
+	}
+
+	report, err := c.staffReportUC.GetStaffPerformance(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetStaffTransactions godoc
+// @Summary      Staff member's transactions
+// @Description  The sales a user recorded, voided or edited over a period, oldest first, for the business owner. Sales voided or edited by someone other than their seller are highlighted
+// @Tags         reports
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        staffId     path   string  true   "User ID of the staff member"
+// @Param        start_date  query  string  false  "Start date (YYYY-MM-DD)"
+// @Param        end_date    query  string  false  "End date (YYYY-MM-DD, default today)"
+// @Success      200  {object}  Domain.StaffTransactions
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/reports/staff/{staffId}/transactions [get]
+// @Security     BearerAuth
+func (c *StaffReportController) GetStaffTransactions(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	staffID := ctx.Param("staffId")
+	if staffID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Staff ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	req, err := parseStaffReportRequest(ctx)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "Dates must be YYYY-MM-DD")
+		return
+	}
+
+	transactions, err := c.staffReportUC.GetStaffTransactions(businessID, userID.(string), staffID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transactions)
+}
+
+func parseStaffReportRequest(ctx *gin.Context) (Domain.StaffReportRequest, error) {
+	var req Domain.StaffReportRequest
+
+	if startStr := ctx.Query("start_date"); startStr != "" {
+		startDate, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			return req, err
+		}
+		req.StartDate = &startDate
+	}
+
+	if endStr := ctx.Query("end_date"); endStr != "" {
+		endDate, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			return req, err
+		}
+		req.EndDate = &endDate
+	}
+
+	return req, nil
+}
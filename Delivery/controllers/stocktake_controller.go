@@ -0,0 +1,302 @@
+package controllers
+
+import (
+	"net/http"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StocktakeController struct {
+	stocktakeUC Usecases.StocktakeUseCase
+}
+
+func NewStocktakeController(stocktakeUC Usecases.StocktakeUseCase) *StocktakeController {
+	return &StocktakeController{stocktakeUC: stocktakeUC}
+}
+
+// CreateStocktake godoc
+// @Summary      Open a stocktake
+// @Description  Open a physical count session for all products or one category, snapshotting expected quantities
+// @Tags         stocktakes
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                         true  "Business ID"
+// @Param        request     body  Domain.CreateStocktakeRequest  true  "Stocktake details"
+// @Success      201  {object}  Domain.Stocktake
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/stocktakes [post]
+// @Security     BearerAuth
+func (c *StocktakeController) CreateStocktake(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.CreateStocktakeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	stocktake, err := c.stocktakeUC.CreateStocktake(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, stocktake)
+}
+
+// GetStocktakes godoc
+// @Summary      List stocktakes
+// @Description  Get the stocktake sessions of a business, newest first
+// @Tags         stocktakes
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        status      query  string  false  "Status: open, posted, cancelled"
+// @Success      200  {array}   Domain.Stocktake
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/stocktakes [get]
+// @Security     BearerAuth
+func (c *StocktakeController) GetStocktakes(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var status *Domain.StocktakeStatus
+	if statusStr := ctx.Query("status"); statusStr != "" {
+		s := Domain.StocktakeStatus(statusStr)
+		status = &s
+	}
+
+	stocktakes, err := c.stocktakeUC.GetStocktakes(businessID, status)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stocktakes)
+}
+
+// GetStocktake godoc
+// @Summary      Get a stocktake
+// @Description  Get a stocktake with expected and counted quantities and the variance of each item at cost
+// @Tags         stocktakes
+// @Produce      json
+// @Param        businessId   path  string  true  "Business ID"
+// @Param        stocktakeId  path  string  true  "Stocktake ID"
+// @Success      200  {object}  Domain.Stocktake
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId} [get]
+// @Security     BearerAuth
+func (c *StocktakeController) GetStocktake(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	stocktakeID := ctx.Param("stocktakeId")
+	if stocktakeID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Stocktake ID is required")
+		return
+	}
+
+	stocktake, err := c.stocktakeUC.GetStocktake(stocktakeID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stocktake)
+}
+
+// RecordCounts godoc
+// @Summary      Record counted quantities
+// @Description  Enter counted quantities by product ID or barcode. Counts add to the existing count unless mode is "set"; a barcode scan without a quantity counts one unit
+// @Tags         stocktakes
+// @Accept       json
+// @Produce      json
+// @Param        businessId   path  string                               true  "Business ID"
+// @Param        stocktakeId  path  string                               true  "Stocktake ID"
+// @Param        request      body  Domain.RecordStocktakeCountsRequest  true  "Counts"
+// @Success      200  {object}  Domain.Stocktake
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}/counts [post]
+// @Security     BearerAuth
+func (c *StocktakeController) RecordCounts(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	stocktakeID := ctx.Param("stocktakeId")
+	if stocktakeID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Stocktake ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.RecordStocktakeCountsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	stocktake, err := c.stocktakeUC.RecordCounts(stocktakeID, businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stocktake)
+}
+
+// PostStocktake godoc
+// @Summary      Post a stocktake
+// @Description  Close the stocktake and post the variance of every counted item as an adjust stock movement
+// @Tags         stocktakes
+// @Produce      json
+// @Param        businessId   path  string  true  "Business ID"
+// @Param        stocktakeId  path  string  true  "Stocktake ID"
+// @Success      200  {object}  Domain.PostStocktakeResult
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}/post [post]
+// @Security     BearerAuth
+func (c *StocktakeController) PostStocktake(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	stocktakeID := ctx.Param("stocktakeId")
+	if stocktakeID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Stocktake ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	result, err := c.stocktakeUC.PostStocktake(stocktakeID, businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// CancelStocktake godoc
+// @Summary      Cancel a stocktake
+// @Description  Cancel an open stocktake without changing stock
+// @Tags         stocktakes
+// @Produce      json
+// @Param        businessId   path  string  true  "Business ID"
+// @Param        stocktakeId  path  string  true  "Stocktake ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}/cancel [post]
+// @Security     BearerAuth
+func (c *StocktakeController) CancelStocktake(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	stocktakeID := ctx.Param("stocktakeId")
+	if stocktakeID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Stocktake ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	if err := c.stocktakeUC.CancelStocktake(stocktakeID, businessID, userID.(string)); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Stocktake cancelled successfully"})
+}
+
+// ExportStocktake godoc
+// @Summary      Export a stocktake
+// @Description  Download the count report with expected, counted and variance values as CSV or JSON
+// @Tags         stocktakes
+// @Produce      text/csv
+// @Produce      json
+// @Param        businessId   path   string  true   "Business ID"
+// @Param        stocktakeId  path   string  true   "Stocktake ID"
+// @Param        format       query  string  false  "Format: csv (default), json"
+// @Success      200  {string}  string  "Stocktake file"
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}/export [get]
+// @Security     BearerAuth
+func (c *StocktakeController) ExportStocktake(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	stocktakeID := ctx.Param("stocktakeId")
+	if stocktakeID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Stocktake ID is required")
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "csv")
+
+	data, filename, err := c.stocktakeUC.ExportStocktake(stocktakeID, businessID, format)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	contentType := "application/json"
+	if format == "csv" {
+		contentType = "text/csv"
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Data(http.StatusOK, contentType, data)
+}
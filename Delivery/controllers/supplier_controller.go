@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"net/http"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SupplierController struct {
+	supplierUC Usecases.SupplierUseCase
+}
+
+func NewSupplierController(supplierUC Usecases.SupplierUseCase) *SupplierController {
+	return &SupplierController{supplierUC: supplierUC}
+}
+
+// CreateSupplier godoc
+// @Summary      Create supplier
+// @Description  Add a supplier that products can be ordered from
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        request     body  Domain.CreateSupplierRequest  true  "Supplier details"
+// @Success      201  {object}  Domain.Supplier
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/suppliers [post]
+// @Security     BearerAuth
+func (c *SupplierController) CreateSupplier(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var req Domain.CreateSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	supplier, err := c.supplierUC.CreateSupplier(businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, supplier)
+}
+
+// GetSuppliers godoc
+// @Summary      List suppliers
+// @Description  Get all suppliers of a business by name
+// @Tags         suppliers
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Success      200  {array}   Domain.Supplier
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/suppliers [get]
+// @Security     BearerAuth
+func (c *SupplierController) GetSuppliers(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	suppliers, err := c.supplierUC.GetSuppliers(businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suppliers)
+}
+
+// GetSupplier godoc
+// @Summary      Get supplier
+// @Description  Get a supplier by ID
+// @Tags         suppliers
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        supplierId  path  string  true  "Supplier ID"
+// @Success      200  {object}  Domain.Supplier
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/suppliers/{supplierId} [get]
+// @Security     BearerAuth
+func (c *SupplierController) GetSupplier(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	supplierID := ctx.Param("supplierId")
+	if supplierID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Supplier ID is required")
+		return
+	}
+
+	supplier, err := c.supplierUC.GetSupplier(supplierID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, supplier)
+}
+
+// UpdateSupplier godoc
+// @Summary      Update supplier
+// @Description  Update a supplier's details, lead time or status
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        supplierId  path  string                        true  "Supplier ID"
+// @Param        request     body  Domain.UpdateSupplierRequest  true  "Supplier update details"
+// @Success      200  {object}  Domain.Supplier
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/suppliers/{supplierId} [patch]
+// @Security     BearerAuth
+func (c *SupplierController) UpdateSupplier(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	supplierID := ctx.Param("supplierId")
+	if supplierID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Supplier ID is required")
+		return
+	}
+
+	var req Domain.UpdateSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	supplier, err := c.supplierUC.UpdateSupplier(supplierID, businessID, req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, supplier)
+}
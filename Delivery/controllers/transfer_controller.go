@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"net/http"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+	Usecases "ShopOps/Usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferController struct {
+	transferUC Usecases.TransferUseCase
+}
+
+func NewTransferController(transferUC Usecases.TransferUseCase) *TransferController {
+	return &TransferController{transferUC: transferUC}
+}
+
+// CreateTransfer godoc
+// @Summary      Dispatch stock transfer
+// @Description  Move goods from one location to another. Stock leaves the source immediately and is in transit until received
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                        true  "Business ID"
+// @Param        request     body  Domain.CreateTransferRequest  true  "Transfer details"
+// @Success      201  {object}  Domain.StockTransfer
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/transfers [post]
+// @Security     BearerAuth
+func (c *TransferController) CreateTransfer(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	var req Domain.CreateTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	transfer, err := c.transferUC.CreateTransfer(businessID, userID.(string), req)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, transfer)
+}
+
+// GetTransfers godoc
+// @Summary      List stock transfers
+// @Description  Get the stock transfers of a business, newest first
+// @Tags         transfers
+// @Produce      json
+// @Param        businessId  path   string  true   "Business ID"
+// @Param        status      query  string  false  "Status: in_transit, received, cancelled"
+// @Success      200  {array}   Domain.StockTransfer
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/transfers [get]
+// @Security     BearerAuth
+func (c *TransferController) GetTransfers(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	var status *Domain.TransferStatus
+	if statusStr := ctx.Query("status"); statusStr != "" {
+		s := Domain.TransferStatus(statusStr)
+		status = &s
+	}
+
+	transfers, err := c.transferUC.GetTransfers(businessID, status)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusInternalServerError, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfers)
+}
+
+// GetTransfer godoc
+// @Summary      Get stock transfer
+// @Description  Get a stock transfer by ID
+// @Tags         transfers
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        transferId  path  string  true  "Transfer ID"
+// @Success      200  {object}  Domain.StockTransfer
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/transfers/{transferId} [get]
+// @Security     BearerAuth
+func (c *TransferController) GetTransfer(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	transferID := ctx.Param("transferId")
+	if transferID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Transfer ID is required")
+		return
+	}
+
+	transfer, err := c.transferUC.GetTransfer(transferID, businessID)
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusNotFound, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfer)
+}
+
+// ReceiveTransfer godoc
+// @Summary      Receive stock transfer
+// @Description  Book the goods of an in-transit transfer into the destination location
+// @Tags         transfers
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        transferId  path  string  true  "Transfer ID"
+// @Success      200  {object}  Domain.StockTransfer
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/transfers/{transferId}/receive [post]
+// @Security     BearerAuth
+func (c *TransferController) ReceiveTransfer(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	transferID := ctx.Param("transferId")
+	if transferID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Transfer ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	transfer, err := c.transferUC.ReceiveTransfer(transferID, businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfer)
+}
+
+// CancelTransfer godoc
+// @Summary      Cancel stock transfer
+// @Description  Cancel an in-transit transfer and return its goods to the source location
+// @Tags         transfers
+// @Produce      json
+// @Param        businessId  path  string  true  "Business ID"
+// @Param        transferId  path  string  true  "Transfer ID"
+// @Success      200  {object}  Domain.StockTransfer
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /api/v1/businesses/{businessId}/inventory/transfers/{transferId}/cancel [post]
+// @Security     BearerAuth
+func (c *TransferController) CancelTransfer(ctx *gin.Context) {
+	businessID := ctx.Param("businessId")
+	if businessID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Business ID is required")
+		return
+	}
+
+	transferID := ctx.Param("transferId")
+	if transferID == "" {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, nil, "Transfer ID is required")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		Infrastructure.JSONError(ctx, http.StatusUnauthorized, nil, "User not authenticated")
+		return
+	}
+
+	transfer, err := c.transferUC.CancelTransfer(transferID, businessID, userID.(string))
+	if err != nil {
+		Infrastructure.JSONError(ctx, http.StatusBadRequest, err, "")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfer)
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"os"
+	_ "time/tzdata" // Business timezones must load on hosts without a zoneinfo database
 
 	routers "ShopOps/Delivery/routers"
 	Infrastructure "ShopOps/Infrastructure"
+	Repositories "ShopOps/Repositories"
+	Usecases "ShopOps/Usecases"
 	_ "ShopOps/docs"
 )
 
@@ -22,12 +26,21 @@ import (
 // @in                          header
 // @name                        Authorization
 func main() {
+	backfillStats := flag.Bool("backfill-stats", false, "Rebuild the daily business stats rollups and exit")
+	backfillBusiness := flag.String("business", "", "With -backfill-stats, rebuild only this business")
+	flag.Parse()
+
 	// Initialize MongoDB
 	if err := Infrastructure.InitMongo(); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer Infrastructure.CloseMongo()
 
+	if *backfillStats {
+		backfillDailyStats(*backfillBusiness)
+		return
+	}
+
 	// Get port from environment
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -43,3 +56,29 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// backfillDailyStats rebuilds the daily rollups from the recorded sales and
+// expenses, of one business or of all of them.
+func backfillDailyStats(businessID string) {
+	db := Infrastructure.GetDB()
+	dailyStatsRepo := Repositories.NewDailyStatsRepository(db)
+	if err := dailyStatsRepo.EnsureIndexes(); err != nil {
+		log.Printf("Warning: failed to create daily stats indexes: %v", err)
+	}
+	dailyStatsUC := Usecases.NewDailyStatsUseCase(dailyStatsRepo, Repositories.NewBusinessRepository(db))
+
+	if businessID != "" {
+		days, err := dailyStatsUC.RebuildBusiness(businessID)
+		if err != nil {
+			log.Fatalf("Failed to rebuild daily stats: %v", err)
+		}
+		log.Printf("Rebuilt %d days of stats for business %s", days, businessID)
+		return
+	}
+
+	businesses, err := dailyStatsUC.RebuildAll()
+	if err != nil {
+		log.Fatalf("Failed to rebuild daily stats: %v", err)
+	}
+	log.Printf("Rebuilt daily stats for %d businesses", businesses)
+}
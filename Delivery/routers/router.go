@@ -1,6 +1,9 @@
 package routers
 
 import (
+	"log"
+	"time"
+
 	controllers "ShopOps/Delivery/controllers"
 	Infrastructure "ShopOps/Infrastructure"
 	Repositories "ShopOps/Repositories"
@@ -45,18 +48,71 @@ func SetupRouter(db *mongo.Database) *gin.Engine {
 	inventoryRepo := Repositories.NewInventoryRepository(db)
 	reportRepo := Repositories.NewReportRepository(db)
 	syncRepo := Repositories.NewSyncRepository(db)
+	batchRepo := Repositories.NewBatchRepository(db)
+	costLayerRepo := Repositories.NewCostLayerRepository(db)
+	stocktakeRepo := Repositories.NewStocktakeRepository(db)
+	locationRepo := Repositories.NewLocationRepository(db)
+	stockLevelRepo := Repositories.NewStockLevelRepository(db)
+	transferRepo := Repositories.NewTransferRepository(db)
+	supplierRepo := Repositories.NewSupplierRepository(db)
+	purchaseOrderRepo := Repositories.NewPurchaseOrderRepository(db)
+	importJobRepo := Repositories.NewImportJobRepository(db)
+	recipeRepo := Repositories.NewRecipeRepository(db)
+	priceHistoryRepo := Repositories.NewPriceHistoryRepository(db)
+	scheduledPriceRepo := Repositories.NewScheduledPriceRepository(db)
+	categoryRepo := Repositories.NewCategoryRepository(db)
+	serialRepo := Repositories.NewSerialRepository(db)
+	negativeStockRepo := Repositories.NewNegativeStockRepository(db)
+	reportSubscriptionRepo := Repositories.NewReportSubscriptionRepository(db)
+	reportDeliveryRepo := Repositories.NewReportDeliveryRepository(db)
+	dailyStatsRepo := Repositories.NewDailyStatsRepository(db)
+	settlementRepo := Repositories.NewPaymentSettlementRepository(db)
+
+	if err := inventoryRepo.EnsureIndexes(); err != nil {
+		// Existing products may already share a barcode. Keep serving them; the
+		// index is built on the first start after the conflicts are fixed
+		log.Printf("Warning: failed to create product indexes, barcodes are not kept unique until this is fixed: %v", err)
+	}
+	if err := serialRepo.EnsureIndexes(); err != nil {
+		log.Printf("Warning: failed to create serial number indexes: %v", err)
+	}
+	if err := dailyStatsRepo.EnsureIndexes(); err != nil {
+		log.Printf("Warning: failed to create daily stats indexes: %v", err)
+	}
+	if err := settlementRepo.EnsureIndexes(); err != nil {
+		log.Printf("Warning: failed to create payment settlement indexes: %v", err)
+	}
 
 	// Initialize sync service
 	syncService := Infrastructure.NewSyncService(db, salesRepo, expenseRepo, inventoryRepo, syncRepo)
 
 	// Initialize use cases
 	userUC := Usecases.NewUserUseCase(userRepo, jwtService)
-	businessUC := Usecases.NewBusinessUseCase(businessRepo, userRepo)
-	salesUC := Usecases.NewSalesUseCase(salesRepo, businessRepo, inventoryRepo)
-	expenseUC := Usecases.NewExpenseUseCase(expenseRepo, businessRepo)
-	inventoryUC := Usecases.NewInventoryUseCase(inventoryRepo, businessRepo)
-	reportUC := Usecases.NewReportUseCase(reportRepo, businessRepo, Infrastructure.NewExportService())
-	syncUC := Usecases.NewSyncUseCase(syncService, businessRepo, salesRepo, expenseRepo, inventoryRepo, syncRepo)
+	businessUC := Usecases.NewBusinessUseCase(businessRepo, userRepo, dailyStatsRepo)
+	costingUC := Usecases.NewCostingUseCase(inventoryRepo, costLayerRepo, salesRepo, businessRepo, locationRepo, stockLevelRepo, negativeStockRepo, dailyStatsRepo)
+	salesUC := Usecases.NewSalesUseCase(salesRepo, businessRepo, inventoryRepo, batchRepo, serialRepo, locationRepo, stockLevelRepo, recipeRepo, dailyStatsRepo, costingUC)
+	expenseUC := Usecases.NewExpenseUseCase(expenseRepo, businessRepo, dailyStatsRepo)
+	dailyStatsUC := Usecases.NewDailyStatsUseCase(dailyStatsRepo, businessRepo)
+	inventoryUC := Usecases.NewInventoryUseCase(inventoryRepo, businessRepo, supplierRepo, categoryRepo, priceHistoryRepo, costingUC)
+	exportService := Infrastructure.NewExportService()
+	reportUC := Usecases.NewReportUseCase(reportRepo, businessRepo, inventoryRepo, categoryRepo, salesRepo, expenseRepo, dailyStatsRepo, exportService)
+	batchUC := Usecases.NewBatchUseCase(batchRepo, inventoryRepo, businessRepo, locationRepo, costingUC)
+	stocktakeUC := Usecases.NewStocktakeUseCase(stocktakeRepo, inventoryRepo, businessRepo, categoryRepo, locationRepo, stockLevelRepo, costingUC, exportService)
+	locationUC := Usecases.NewLocationUseCase(locationRepo, stockLevelRepo, transferRepo, inventoryRepo, businessRepo)
+	transferUC := Usecases.NewTransferUseCase(transferRepo, locationRepo, stockLevelRepo, inventoryRepo)
+	supplierUC := Usecases.NewSupplierUseCase(supplierRepo, businessRepo)
+	purchasingUC := Usecases.NewPurchasingUseCase(purchaseOrderRepo, supplierRepo, inventoryRepo, businessRepo, locationRepo, serialRepo, costingUC)
+	catalogUC := Usecases.NewCatalogUseCase(importJobRepo, inventoryRepo, supplierRepo, businessRepo, inventoryUC, costingUC, exportService)
+	barcodeUC := Usecases.NewBarcodeUseCase(inventoryRepo, businessRepo, Infrastructure.NewLabelService())
+	recipeUC := Usecases.NewRecipeUseCase(recipeRepo, inventoryRepo, salesRepo, stocktakeRepo, priceHistoryRepo)
+	pricingUC := Usecases.NewPricingUseCase(inventoryRepo, businessRepo, categoryRepo, priceHistoryRepo, scheduledPriceRepo)
+	categoryUC := Usecases.NewCategoryUseCase(categoryRepo, inventoryRepo, businessRepo)
+	serialUC := Usecases.NewSerialUseCase(serialRepo, inventoryRepo, businessRepo, locationRepo, costingUC)
+	negativeStockUC := Usecases.NewNegativeStockUseCase(negativeStockRepo, businessRepo)
+	reportSubscriptionUC := Usecases.NewReportSubscriptionUseCase(reportUC, reportSubscriptionRepo, reportDeliveryRepo, businessRepo, userRepo, Infrastructure.NewSMTPMailerFromEnv())
+	staffReportUC := Usecases.NewStaffReportUseCase(salesRepo, businessRepo, userRepo)
+	reconciliationUC := Usecases.NewReconciliationUseCase(reportRepo, settlementRepo, businessRepo)
+	syncUC := Usecases.NewSyncUseCase(syncService, businessRepo, salesRepo, expenseRepo, inventoryRepo, syncRepo, dailyStatsRepo)
 
 	// Initialize controllers
 	userController := controllers.NewUserController(userUC)
@@ -66,6 +122,32 @@ func SetupRouter(db *mongo.Database) *gin.Engine {
 	inventoryController := controllers.NewInventoryController(inventoryUC)
 	reportController := controllers.NewReportController(reportUC)
 	syncController := controllers.NewSyncController(syncUC)
+	batchController := controllers.NewBatchController(batchUC)
+	costingController := controllers.NewCostingController(costingUC)
+	stocktakeController := controllers.NewStocktakeController(stocktakeUC)
+	locationController := controllers.NewLocationController(locationUC)
+	transferController := controllers.NewTransferController(transferUC)
+	supplierController := controllers.NewSupplierController(supplierUC)
+	purchasingController := controllers.NewPurchasingController(purchasingUC)
+	catalogController := controllers.NewCatalogController(catalogUC)
+	barcodeController := controllers.NewBarcodeController(barcodeUC)
+	recipeController := controllers.NewRecipeController(recipeUC)
+	pricingController := controllers.NewPricingController(pricingUC)
+	categoryController := controllers.NewCategoryController(categoryUC)
+	serialController := controllers.NewSerialController(serialUC)
+	negativeStockController := controllers.NewNegativeStockController(negativeStockUC)
+	reportSubscriptionController := controllers.NewReportSubscriptionController(reportSubscriptionUC)
+	staffReportController := controllers.NewStaffReportController(staffReportUC)
+	reconciliationController := controllers.NewReconciliationController(reconciliationUC)
+
+	// Background jobs
+	Infrastructure.RunEvery("scheduled price changes", time.Minute, pricingUC.ApplyDuePriceChanges)
+	Infrastructure.RunEvery("scheduled reports", time.Minute, reportSubscriptionUC.DeliverDueReports)
+	// Expired lots come off stock within the hour they expire
+	Infrastructure.RunEvery("expired batch write-off", time.Hour, batchUC.WriteOffAllExpired)
+	// Shops that traded before the daily rollups were kept get theirs built,
+	// so dashboards and summaries cover their earlier days
+	Infrastructure.RunOnce("daily stats backfill", dailyStatsUC.BackfillMissing)
 
 	// Public routes
 	router.POST("/api/v1/auth/register", userController.Register)
@@ -120,17 +202,135 @@ func SetupRouter(db *mongo.Database) *gin.Engine {
 			// Inventory routes
 			inventoryRoutes := businessSpecific.Group("/inventory")
 			{
+				categoryRoutes := inventoryRoutes.Group("/categories")
+				{
+					categoryRoutes.POST("", categoryController.CreateCategory)
+					categoryRoutes.GET("", categoryController.GetCategories)
+					categoryRoutes.POST("/reorder", categoryController.ReorderCategories)
+					categoryRoutes.POST("/migrate", categoryController.MigrateCategories)
+					categoryRoutes.GET("/:categoryId", categoryController.GetCategory)
+					categoryRoutes.PATCH("/:categoryId", categoryController.UpdateCategory)
+					categoryRoutes.DELETE("/:categoryId", categoryController.DeleteCategory)
+					categoryRoutes.POST("/:categoryId/merge", categoryController.MergeCategory)
+				}
+
 				productsRoutes := inventoryRoutes.Group("/products")
 				{
 					productsRoutes.POST("", inventoryController.CreateProduct)
 					productsRoutes.GET("", inventoryController.GetProducts)
 					productsRoutes.GET("/low-stock", inventoryController.GetLowStock)
+					productsRoutes.GET("/by-barcode/:code", barcodeController.GetProductByBarcode)
 					productsRoutes.GET("/:productId", inventoryController.GetProduct)
 					productsRoutes.PATCH("/:productId", inventoryController.UpdateProduct)
 					productsRoutes.DELETE("/:productId", inventoryController.DeleteProduct)
 					productsRoutes.POST("/:productId/adjust", inventoryController.AdjustStock)
 					productsRoutes.GET("/:productId/history", inventoryController.GetStockHistory)
+					productsRoutes.POST("/:productId/barcode", barcodeController.GenerateBarcode)
+					productsRoutes.PUT("/:productId/recipe", recipeController.SetRecipe)
+					productsRoutes.GET("/:productId/recipe", recipeController.GetRecipe)
+					productsRoutes.DELETE("/:productId/recipe", recipeController.DeleteRecipe)
+					productsRoutes.GET("/:productId/price-history", pricingController.GetPriceHistory)
+					productsRoutes.POST("/:productId/batches", batchController.ReceiveBatch)
+					productsRoutes.GET("/:productId/batches", batchController.GetBatches)
+					productsRoutes.POST("/:productId/serials", serialController.ReceiveSerials)
+					productsRoutes.GET("/:productId/serials", serialController.GetProductSerials)
+					productsRoutes.GET("/:productId/cost-layers", costingController.GetCostLayers)
+					productsRoutes.POST("/:productId/costs/rebuild", costingController.RebuildProductCosts)
+					productsRoutes.GET("/:productId/locations", locationController.GetProductStock)
+				}
+
+				batchRoutes := inventoryRoutes.Group("/batches")
+				{
+					batchRoutes.GET("/near-expiry", batchController.GetNearExpiry)
+					batchRoutes.POST("/write-off-expired", batchController.WriteOffExpired)
 				}
+
+				serialRoutes := inventoryRoutes.Group("/serials")
+				{
+					serialRoutes.GET("/:serial", serialController.LookupSerial)
+					serialRoutes.POST("/:serial/write-off", serialController.WriteOffSerial)
+				}
+
+				inventoryRoutes.POST("/costs/rebuild", costingController.RebuildBusinessCosts)
+				inventoryRoutes.GET("/ledger/integrity", costingController.CheckLedgerIntegrity)
+				inventoryRoutes.GET("/negative-stock", negativeStockController.GetNegativeStockReport)
+
+				stocktakeRoutes := inventoryRoutes.Group("/stocktakes")
+				{
+					stocktakeRoutes.POST("", stocktakeController.CreateStocktake)
+					stocktakeRoutes.GET("", stocktakeController.GetStocktakes)
+					stocktakeRoutes.GET("/:stocktakeId", stocktakeController.GetStocktake)
+					stocktakeRoutes.POST("/:stocktakeId/counts", stocktakeController.RecordCounts)
+					stocktakeRoutes.POST("/:stocktakeId/post", stocktakeController.PostStocktake)
+					stocktakeRoutes.POST("/:stocktakeId/cancel", stocktakeController.CancelStocktake)
+					stocktakeRoutes.GET("/:stocktakeId/export", stocktakeController.ExportStocktake)
+				}
+
+				locationRoutes := inventoryRoutes.Group("/locations")
+				{
+					locationRoutes.POST("", locationController.CreateLocation)
+					locationRoutes.GET("", locationController.GetLocations)
+					locationRoutes.GET("/:locationId", locationController.GetLocation)
+					locationRoutes.PATCH("/:locationId", locationController.UpdateLocation)
+					locationRoutes.GET("/:locationId/stock", locationController.GetLocationStock)
+					locationRoutes.PUT("/:locationId/products/:productId/min-stock", locationController.SetMinStock)
+				}
+
+				transferRoutes := inventoryRoutes.Group("/transfers")
+				{
+					transferRoutes.POST("", transferController.CreateTransfer)
+					transferRoutes.GET("", transferController.GetTransfers)
+					transferRoutes.GET("/:transferId", transferController.GetTransfer)
+					transferRoutes.POST("/:transferId/receive", transferController.ReceiveTransfer)
+					transferRoutes.POST("/:transferId/cancel", transferController.CancelTransfer)
+				}
+
+				supplierRoutes := inventoryRoutes.Group("/suppliers")
+				{
+					supplierRoutes.POST("", supplierController.CreateSupplier)
+					supplierRoutes.GET("", supplierController.GetSuppliers)
+					supplierRoutes.GET("/:supplierId", supplierController.GetSupplier)
+					supplierRoutes.PATCH("/:supplierId", supplierController.UpdateSupplier)
+				}
+
+				inventoryRoutes.GET("/reorder-suggestions", purchasingController.GetReorderSuggestions)
+
+				purchaseOrderRoutes := inventoryRoutes.Group("/purchase-orders")
+				{
+					purchaseOrderRoutes.POST("/from-suggestions", purchasingController.CreateDraftOrders)
+					purchaseOrderRoutes.GET("", purchasingController.GetPurchaseOrders)
+					purchaseOrderRoutes.GET("/:orderId", purchasingController.GetPurchaseOrder)
+					purchaseOrderRoutes.POST("/:orderId/order", purchasingController.MarkOrdered)
+					purchaseOrderRoutes.POST("/:orderId/receive", purchasingController.ReceivePurchaseOrder)
+					purchaseOrderRoutes.POST("/:orderId/cancel", purchasingController.CancelPurchaseOrder)
+				}
+
+				catalogRoutes := inventoryRoutes.Group("/catalog")
+				{
+					catalogRoutes.POST("/import", catalogController.ImportCatalog)
+					catalogRoutes.GET("/imports", catalogController.GetImportJobs)
+					catalogRoutes.GET("/imports/:jobId", catalogController.GetImportJob)
+					catalogRoutes.GET("/export", catalogController.ExportCatalog)
+					catalogRoutes.GET("/template", catalogController.GetCatalogTemplate)
+				}
+
+				inventoryRoutes.POST("/barcodes/generate", barcodeController.GenerateMissingBarcodes)
+				inventoryRoutes.POST("/labels", barcodeController.PrintLabels)
+
+				recipeRoutes := inventoryRoutes.Group("/recipes")
+				{
+					recipeRoutes.GET("", recipeController.GetRecipes)
+					recipeRoutes.GET("/usage", recipeController.GetUsageReport)
+				}
+
+				priceChangeRoutes := inventoryRoutes.Group("/price-changes")
+				{
+					priceChangeRoutes.GET("", pricingController.GetPriceChanges)
+					priceChangeRoutes.POST("/schedule", pricingController.SchedulePriceChanges)
+					priceChangeRoutes.GET("/scheduled", pricingController.GetScheduledPriceChanges)
+					priceChangeRoutes.POST("/scheduled/:scheduleId/cancel", pricingController.CancelScheduledPriceChange)
+				}
+				inventoryRoutes.GET("/prices", pricingController.GetPricesAt)
 			}
 
 			// Report routes
@@ -138,12 +338,40 @@ func SetupRouter(db *mongo.Database) *gin.Engine {
 			{
 				reportRoutes.GET("/dashboard", reportController.GetDashboard)
 				reportRoutes.GET("/sales", reportController.GetSalesReport)
+				reportRoutes.GET("/sales/heatmap", reportController.GetSalesHeatmap)
+				reportRoutes.GET("/sales/heatmap/export", reportController.ExportSalesHeatmap)
 				reportRoutes.GET("/expenses", reportController.GetExpensesReport)
 				reportRoutes.GET("/profit", reportController.GetProfitReport)
 				reportRoutes.GET("/inventory", reportController.GetInventoryReport)
+				reportRoutes.GET("/inventory/valuation", costingController.GetStockValuation)
+				reportRoutes.GET("/inventory/aging", reportController.GetAgingReport)
+				reportRoutes.GET("/inventory/aging/export", reportController.ExportAgingReport)
+				reportRoutes.GET("/products/abc", reportController.GetABCAnalysis)
+				reportRoutes.GET("/products/abc/export", reportController.ExportABCAnalysis)
 				reportRoutes.GET("/export", reportController.ExportReport)
 				reportRoutes.GET("/profit/summary", reportController.GetProfitSummary)
 				reportRoutes.GET("/profit/trends", reportController.GetProfitTrends)
+				reportRoutes.GET("/compare", reportController.ComparePeriods)
+				reportRoutes.POST("/subscriptions", reportSubscriptionController.CreateSubscription)
+				reportRoutes.GET("/subscriptions", reportSubscriptionController.GetSubscriptions)
+				reportRoutes.PATCH("/subscriptions/:subscriptionId", reportSubscriptionController.UpdateSubscription)
+				reportRoutes.DELETE("/subscriptions/:subscriptionId", reportSubscriptionController.DeleteSubscription)
+				reportRoutes.POST("/subscriptions/:subscriptionId/send", reportSubscriptionController.SendNow)
+				reportRoutes.GET("/deliveries", reportSubscriptionController.GetDeliveries)
+				reportRoutes.POST("/deliveries/:deliveryId/retry", reportSubscriptionController.RetryDelivery)
+
+				staffRoutes := reportRoutes.Group("/staff")
+				staffRoutes.Use(Infrastructure.OwnerOnlyMiddleware())
+				{
+					staffRoutes.GET("", staffReportController.GetStaffPerformance)
+					staffRoutes.GET("/:staffId/transactions", staffReportController.GetStaffTransactions)
+				}
+
+				paymentRoutes := reportRoutes.Group("/payments")
+				{
+					paymentRoutes.GET("", reconciliationController.GetPaymentReconciliation)
+					paymentRoutes.PUT("/settlements", Infrastructure.OwnerOnlyMiddleware(), reconciliationController.RecordSettlement)
+				}
 			}
 
 			// Sync routes
@@ -0,0 +1,151 @@
+package Domain
+
+import "time"
+
+// DefaultABCDays is how many days an ABC analysis covers when no start date
+// is given.
+const DefaultABCDays = 90
+
+// Default shares of the total, in percent, taken by classes A and B; class C
+// takes the rest.
+const (
+	DefaultABCClassA = 80.0
+	DefaultABCClassB = 15.0
+)
+
+// ABCMetric is a figure products are ranked and classified by.
+type ABCMetric string
+
+const (
+	ABCMetricRevenue     ABCMetric = "revenue"
+	ABCMetricGrossProfit ABCMetric = "gross_profit" // Gross margin earned, revenue less cost of goods
+	ABCMetricQuantity    ABCMetric = "quantity"
+)
+
+// ABCMetrics are the metrics every product is classified by, in the order
+// they are reported.
+var ABCMetrics = []ABCMetric{ABCMetricRevenue, ABCMetricGrossProfit, ABCMetricQuantity}
+
+type ABCClass string
+
+const (
+	ABCClassA ABCClass = "A"
+	ABCClassB ABCClass = "B"
+	ABCClassC ABCClass = "C"
+)
+
+// ABCReportRequest is the range of an ABC analysis, in calendar days in the
+// business's timezone with both ends included, and how to classify it. The
+// range defaults to the last 90 days up to today.
+type ABCReportRequest struct {
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	Metric    ABCMetric  `json:"metric,omitempty"`   // Orders the items; defaults to revenue
+	Category  string     `json:"category,omitempty"` // Only products in this category or below it, by ID or name
+	ClassA    *float64   `json:"class_a,omitempty"`  // Percent of the total taken by class A; defaults to 80
+	ClassB    *float64   `json:"class_b,omitempty"`  // Percent of the total taken by class B; defaults to 15
+}
+
+// ABCThresholds are the shares of the total, in percent, each class takes.
+type ABCThresholds struct {
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+	C float64 `json:"c"`
+}
+
+// ABCReport ranks every product in the catalogue by revenue, gross profit and
+// quantity sold over a period, and puts each into class A, B or C by its
+// cumulative share of the total: the best sellers that together make up the
+// first A percent are class A, the next B percent class B, and the rest,
+// including products that did not sell, class C.
+type ABCReport struct {
+	Period          ComparisonPeriod   `json:"period"`
+	Metric          ABCMetric          `json:"metric"`
+	Category        string             `json:"category,omitempty"`
+	Thresholds      ABCThresholds      `json:"thresholds"`
+	TotalProducts   int                `json:"total_products"`
+	ProductsNotSold int                `json:"products_not_sold"`
+	Revenue         float64            `json:"revenue"`
+	CostOfGoods     float64            `json:"cost_of_goods"`
+	GrossProfit     float64            `json:"gross_profit"`
+	Quantity        float64            `json:"quantity"`
+	Summaries       []ABCMetricSummary `json:"summaries"` // One per metric
+	Items           []ABCItem          `json:"items"`     // Ranked by the report's metric
+}
+
+// ABCMetricSummary is how the products and the total of one metric split
+// across the classes.
+type ABCMetricSummary struct {
+	Metric  ABCMetric         `json:"metric"`
+	Total   float64           `json:"total"` // Of the products' positive figures, which the shares are of
+	Classes []ABCClassSummary `json:"classes"`
+}
+
+type ABCClassSummary struct {
+	Class        ABCClass `json:"class"`
+	Products     int      `json:"products"`
+	ProductShare float64  `json:"product_share"` // Percent of the products
+	Value        float64  `json:"value"`
+	Share        float64  `json:"share"` // Percent of the total
+}
+
+// ABCItem is one product with its figures over the period and where it
+// stands by each metric. Products sold in the period but since deleted from
+// the catalogue are kept.
+type ABCItem struct {
+	ProductID     string     `json:"product_id"`
+	ProductName   string     `json:"product_name"`
+	SKU           string     `json:"sku,omitempty"`
+	Category      string     `json:"category,omitempty"`
+	Quantity      float64    `json:"quantity"`
+	Revenue       float64    `json:"revenue"`
+	CostOfGoods   float64    `json:"cost_of_goods"`
+	GrossProfit   float64    `json:"gross_profit"`
+	GrossMargin   float64    `json:"gross_margin"` // Percent of revenue
+	ByRevenue     ABCRanking `json:"by_revenue"`
+	ByGrossProfit ABCRanking `json:"by_gross_profit"`
+	ByQuantity    ABCRanking `json:"by_quantity"`
+}
+
+// ABCRanking is where a product stands by one metric. A product that made a
+// loss contributes nothing to the gross profit total.
+type ABCRanking struct {
+	Rank            int      `json:"rank"`
+	Share           float64  `json:"share"`            // Percent of the total
+	CumulativeShare float64  `json:"cumulative_share"` // Percent of the total up to and including this product
+	Class           ABCClass `json:"class"`
+}
+
+// Ranking returns the item's ranking by the metric.
+func (i *ABCItem) Ranking(metric ABCMetric) *ABCRanking {
+	switch metric {
+	case ABCMetricGrossProfit:
+		return &i.ByGrossProfit
+	case ABCMetricQuantity:
+		return &i.ByQuantity
+	default:
+		return &i.ByRevenue
+	}
+}
+
+// Value returns the item's figure for the metric.
+func (i *ABCItem) Value(metric ABCMetric) float64 {
+	switch metric {
+	case ABCMetricGrossProfit:
+		return i.GrossProfit
+	case ABCMetricQuantity:
+		return i.Quantity
+	default:
+		return i.Revenue
+	}
+}
+
+// IsValidABCMetric reports whether products can be ranked by the metric.
+func IsValidABCMetric(metric ABCMetric) bool {
+	for _, valid := range ABCMetrics {
+		if metric == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultAgingBuckets are the upper day limits of the aging buckets; stock
+// older than the last one falls into an open-ended bucket.
+var DefaultAgingBuckets = []int{30, 90, 180}
+
+// DefaultDeadStockDays is how long stock can go unsold before it is flagged.
+const DefaultDeadStockDays = 90
+
+type AgingReportRequest struct {
+	DeadStockDays int   `json:"dead_stock_days,omitempty"` // Flag stock unsold for this many days; defaults to 90
+	Buckets       []int `json:"buckets,omitempty"`         // Ascending upper day limits; defaults to 30, 90, 180
+}
+
+// AgingReport buckets on-hand stock by how long ago each product was last
+// sold and last received, with the capital tied up in each bucket.
+type AgingReport struct {
+	AsOf           time.Time     `json:"as_of"`
+	DeadStockDays  int           `json:"dead_stock_days"`
+	TotalProducts  int           `json:"total_products"`
+	TotalStock     float64       `json:"total_stock"`
+	TotalValue     float64       `json:"total_value"`
+	DeadStockCount int           `json:"dead_stock_count"`
+	DeadStockValue float64       `json:"dead_stock_value"`
+	SalesAging     []AgingBucket `json:"sales_aging"`   // By days since last sold
+	ReceiptAging   []AgingBucket `json:"receipt_aging"` // By days since last received
+	Items          []AgingItem   `json:"items"`
+}
+
+type AgingBucket struct {
+	Label    string  `json:"label"`
+	MinDays  int     `json:"min_days"`
+	MaxDays  *int    `json:"max_days,omitempty"` // Empty for the open-ended bucket
+	Products int     `json:"products"`
+	Stock    float64 `json:"stock"`
+	Value    float64 `json:"value"`
+}
+
+// AgingItem is one product with stock on hand. A product never sold or
+// received is aged from when it was created.
+type AgingItem struct {
+	ProductID         primitive.ObjectID `json:"product_id"`
+	ProductName       string             `json:"product_name"`
+	SKU               string             `json:"sku,omitempty"`
+	Category          string             `json:"category,omitempty"`
+	Stock             float64            `json:"stock"`
+	UnitCost          float64            `json:"unit_cost"`
+	Value             float64            `json:"value"`
+	LastSoldAt        *time.Time         `json:"last_sold_at,omitempty"`
+	LastReceivedAt    *time.Time         `json:"last_received_at,omitempty"`
+	DaysSinceSold     int                `json:"days_since_sold"`
+	DaysSinceReceived int                `json:"days_since_received"`
+	SalesBucket       string             `json:"sales_bucket"`
+	ReceiptBucket     string             `json:"receipt_bucket"`
+	DeadStock         bool               `json:"dead_stock"`
+}
+
+type ProductActivity struct {
+	ProductID primitive.ObjectID `bson:"_id" json:"product_id"`
+	LastAt    time.Time          `bson:"last_at" json:"last_at"`
+}
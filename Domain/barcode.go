@@ -0,0 +1,50 @@
+package Domain
+
+import "errors"
+
+// ErrBarcodeInUse is returned when a barcode already belongs to another
+// product of the business.
+var ErrBarcodeInUse = errors.New("barcode is already used by another product")
+
+type BarcodeFormat string
+
+const (
+	BarcodeFormatEAN13   BarcodeFormat = "ean13"
+	BarcodeFormatCode128 BarcodeFormat = "code128"
+)
+
+// InternalBarcodePrefix starts generated EAN-13 codes. Prefix 20 is kept for
+// in-store use, so generated codes never clash with manufacturer codes.
+const InternalBarcodePrefix = "20"
+
+type GenerateBarcodeRequest struct {
+	Format BarcodeFormat `json:"format,omitempty"` // Defaults to ean13
+}
+
+// GenerateBarcodesResult reports a bulk run that gave every product without a
+// barcode an internal one.
+type GenerateBarcodesResult struct {
+	Generated int      `json:"generated"`
+	Products  []string `json:"products"` // IDs of the products that got a barcode
+	Errors    []string `json:"errors,omitempty"`
+}
+
+type LabelFormat string
+
+const (
+	LabelFormatPDF LabelFormat = "pdf"
+	LabelFormatPNG LabelFormat = "png"
+)
+
+type LabelSheetRequest struct {
+	ProductIDs []string    `json:"product_ids" validate:"required"`
+	Copies     int         `json:"copies,omitempty"` // Labels per product; defaults to 1
+	Format     LabelFormat `json:"format,omitempty"` // Defaults to pdf
+}
+
+// Label is what gets printed on a single product label.
+type Label struct {
+	Name    string
+	Price   string
+	Barcode string
+}
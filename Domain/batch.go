@@ -0,0 +1,94 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StockBatch struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BusinessID   primitive.ObjectID  `bson:"business_id" json:"business_id"`
+	ProductID    primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	LotNumber    string              `bson:"lot_number" json:"lot_number" validate:"required"`
+	ExpiryDate   *time.Time          `bson:"expiry_date,omitempty" json:"expiry_date,omitempty"`
+	ReceivedDate time.Time           `bson:"received_date" json:"received_date"`
+	Quantity     float64             `bson:"quantity" json:"quantity"`   // Quantity originally received
+	Remaining    float64             `bson:"remaining" json:"remaining"` // Quantity still on hand
+	CostPrice    float64             `bson:"cost_price,omitempty" json:"cost_price,omitempty"`
+	LocationID   *primitive.ObjectID `bson:"location_id,omitempty" json:"location_id,omitempty"` // Where the lot was received
+	Status       BatchStatus         `bson:"status" json:"status"`
+	CreatedBy    primitive.ObjectID  `bson:"created_by" json:"created_by"`
+	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+type BatchStatus string
+
+const (
+	BatchStatusActive   BatchStatus = "active"
+	BatchStatusDepleted BatchStatus = "depleted"
+	BatchStatusExpired  BatchStatus = "expired"
+)
+
+// IsExpired reports whether the batch expiry date is on or before the given time.
+func (b *StockBatch) IsExpired(at time.Time) bool {
+	return b.ExpiryDate != nil && !b.ExpiryDate.After(at)
+}
+
+type ReceiveBatchRequest struct {
+	LotNumber    string     `json:"lot_number" validate:"required"`
+	Quantity     float64    `json:"quantity" validate:"required,gt=0"`
+	ExpiryDate   *time.Time `json:"expiry_date,omitempty"`
+	ReceivedDate *time.Time `json:"received_date,omitempty"`
+	CostPrice    float64    `json:"cost_price,omitempty"`
+	LocationID   *string    `json:"location_id,omitempty"`
+}
+
+// SaleBatchAllocation records how much of a sale was taken from a batch.
+type SaleBatchAllocation struct {
+	BatchID    primitive.ObjectID `bson:"batch_id" json:"batch_id"`
+	LotNumber  string             `bson:"lot_number" json:"lot_number"`
+	ExpiryDate *time.Time         `bson:"expiry_date,omitempty" json:"expiry_date,omitempty"`
+	Quantity   float64            `bson:"quantity" json:"quantity"`
+}
+
+type NearExpiryReport struct {
+	GeneratedAt   time.Time        `json:"generated_at"`
+	Days          int              `json:"days"`
+	TotalBatches  int              `json:"total_batches"`
+	TotalQuantity float64          `json:"total_quantity"`
+	TotalValue    float64          `json:"total_value"`
+	Items         []NearExpiryItem `json:"items"`
+}
+
+type NearExpiryItem struct {
+	BatchID      string    `json:"batch_id"`
+	ProductID    string    `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	LotNumber    string    `json:"lot_number"`
+	ExpiryDate   time.Time `json:"expiry_date"`
+	DaysToExpiry int       `json:"days_to_expiry"`
+	Expired      bool      `json:"expired"`
+	Remaining    float64   `json:"remaining"`
+	Value        float64   `json:"value"`
+}
+
+type ExpiryWriteOffResult struct {
+	BatchesWrittenOff int          `json:"batches_written_off"`
+	TotalQuantity     float64      `json:"total_quantity"`
+	TotalValue        float64      `json:"total_value"`
+	Batches           []StockBatch `json:"batches"`
+	Errors            []string     `json:"errors,omitempty"`
+}
+
+type BatchRepository interface {
+	Create(batch *StockBatch) error
+	FindByID(id string) (*StockBatch, error)
+	FindByProductID(productID string, includeDepleted bool) ([]StockBatch, error)
+	FindAvailable(productID string) ([]StockBatch, error)
+	FindExpiring(businessID string, before time.Time) ([]StockBatch, error)
+	AdjustRemaining(id string, delta float64) (*StockBatch, error)
+	UpdateStatus(id string, status BatchStatus) error
+	Delete(id string) error
+}
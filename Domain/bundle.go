@@ -0,0 +1,30 @@
+package Domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type ProductType string
+
+const (
+	ProductTypeStandard ProductType = "standard"
+	ProductTypeBundle   ProductType = "bundle"
+)
+
+// BundleComponent is one line of a bundle: a product and how many of it go
+// into a single bundle.
+type BundleComponent struct {
+	ProductID primitive.ObjectID `bson:"product_id" json:"product_id"`
+	Quantity  float64            `bson:"quantity" json:"quantity"`
+}
+
+type BundleComponentRequest struct {
+	ProductID string  `json:"product_id" validate:"required"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"`
+}
+
+// SaleComponent records the stock taken out for a bundle or recipe sale and
+// what it cost, so the sale can be reversed at the same cost.
+type SaleComponent struct {
+	ProductID   primitive.ObjectID `bson:"product_id" json:"product_id"`
+	Quantity    float64            `bson:"quantity" json:"quantity"`
+	CostOfGoods float64            `bson:"cost_of_goods" json:"cost_of_goods"`
+}
@@ -7,21 +7,23 @@ import (
 )
 
 type Business struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
-	Name         string             `bson:"name" json:"name" validate:"required"`
-	Description  string             `bson:"description,omitempty" json:"description,omitempty"`
-	BusinessType string             `bson:"business_type" json:"business_type" validate:"required"`
-	Currency     string             `bson:"currency" json:"currency" validate:"required"`
-	Timezone     string             `bson:"timezone" json:"timezone"`
-	Address      string             `bson:"address,omitempty" json:"address,omitempty"`
-	City         string             `bson:"city,omitempty" json:"city,omitempty"`
-	Country      string             `bson:"country,omitempty" json:"country,omitempty"`
-	Phone        string             `bson:"phone,omitempty" json:"phone,omitempty"`
-	Email        string             `bson:"email,omitempty" json:"email,omitempty"`
-	Status       BusinessStatus     `bson:"status" json:"status"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Name          string              `bson:"name" json:"name" validate:"required"`
+	Description   string              `bson:"description,omitempty" json:"description,omitempty"`
+	BusinessType  string              `bson:"business_type" json:"business_type" validate:"required"`
+	Currency      string              `bson:"currency" json:"currency" validate:"required"`
+	Timezone      string              `bson:"timezone" json:"timezone"`                                 // IANA name; report days run midnight to midnight here
+	CostingMethod CostingMethod       `bson:"costing_method,omitempty" json:"costing_method,omitempty"` // fifo or average
+	NegativeStock NegativeStockPolicy `bson:"negative_stock,omitempty" json:"negative_stock,omitempty"` // block (default), warn or allow
+	Address       string              `bson:"address,omitempty" json:"address,omitempty"`
+	City          string              `bson:"city,omitempty" json:"city,omitempty"`
+	Country       string              `bson:"country,omitempty" json:"country,omitempty"`
+	Phone         string              `bson:"phone,omitempty" json:"phone,omitempty"`
+	Email         string              `bson:"email,omitempty" json:"email,omitempty"`
+	Status        BusinessStatus      `bson:"status" json:"status"`
+	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time           `bson:"updated_at" json:"updated_at"`
 }
 
 type BusinessStatus string
@@ -32,30 +34,86 @@ const (
 	BusinessStatusClosed   BusinessStatus = "closed"
 )
 
+// Location returns the business's timezone, falling back to UTC when it is
+// unset or unknown.
+func (b *Business) Location() *time.Location {
+	if b == nil || b.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(b.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// StartOfDay returns the first instant of t's calendar day in t's location.
+// Where a DST change skips midnight the day starts at the transition.
+func StartOfDay(t time.Time) time.Time {
+	return DateIn(t, t.Location())
+}
+
+// DateIn returns the first instant in loc of the calendar date of t, read in
+// t's own location. It reinterprets dates parsed from YYYY-MM-DD strings,
+// which land at UTC midnight, as days in a business's timezone.
+func DateIn(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if start.Day() != day {
+		// Midnight fell in a DST gap and was resolved to the evening before
+		_, start = start.ZoneBounds()
+	}
+	return start
+}
+
+// AddDays returns the first instant of the calendar day the given number of
+// days after t's, or before it when days is negative.
+func AddDays(t time.Time, days int) time.Time {
+	year, month, day := t.Date()
+	// Noon is never skipped by a DST change
+	return StartOfDay(time.Date(year, month, day+days, 12, 0, 0, 0, t.Location()))
+}
+
+// EndOfDay returns the last instant of t's calendar day in t's location, so
+// a day always spans 23, 24 or 25 hours as the clocks dictate.
+func EndOfDay(t time.Time) time.Time {
+	return AddDays(t, 1).Add(-time.Nanosecond)
+}
+
+// LastDays returns the first instant of the given number of calendar days
+// ending with now's, so the last 7 days are today and the 6 before it.
+func LastDays(now time.Time, days int) time.Time {
+	return AddDays(now, 1-days)
+}
+
 type CreateBusinessRequest struct {
-	Name         string `json:"name" validate:"required"`
-	Description  string `json:"description,omitempty"`
-	BusinessType string `json:"business_type" validate:"required"`
-	Currency     string `json:"currency" validate:"required"`
-	Timezone     string `json:"timezone,omitempty"`
-	Address      string `json:"address,omitempty"`
-	City         string `json:"city,omitempty"`
-	Country      string `json:"country,omitempty"`
-	Phone        string `json:"phone,omitempty"`
-	Email        string `json:"email,omitempty"`
+	Name          string              `json:"name" validate:"required"`
+	Description   string              `json:"description,omitempty"`
+	BusinessType  string              `json:"business_type" validate:"required"`
+	Currency      string              `json:"currency" validate:"required"`
+	Timezone      string              `json:"timezone,omitempty"`
+	CostingMethod CostingMethod       `json:"costing_method,omitempty"`
+	NegativeStock NegativeStockPolicy `json:"negative_stock,omitempty"`
+	Address       string              `json:"address,omitempty"`
+	City          string              `json:"city,omitempty"`
+	Country       string              `json:"country,omitempty"`
+	Phone         string              `json:"phone,omitempty"`
+	Email         string              `json:"email,omitempty"`
 }
 
 type UpdateBusinessRequest struct {
-	Name         string `json:"name,omitempty"`
-	Description  string `json:"description,omitempty"`
-	BusinessType string `json:"business_type,omitempty"`
-	Currency     string `json:"currency,omitempty"`
-	Timezone     string `json:"timezone,omitempty"`
-	Address      string `json:"address,omitempty"`
-	City         string `json:"city,omitempty"`
-	Country      string `json:"country,omitempty"`
-	Phone        string `json:"phone,omitempty"`
-	Email        string `json:"email,omitempty"`
+	Name          string              `json:"name,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	BusinessType  string              `json:"business_type,omitempty"`
+	Currency      string              `json:"currency,omitempty"`
+	Timezone      string              `json:"timezone,omitempty"`
+	CostingMethod CostingMethod       `json:"costing_method,omitempty"`
+	NegativeStock NegativeStockPolicy `json:"negative_stock,omitempty"`
+	Address       string              `json:"address,omitempty"`
+	City          string              `json:"city,omitempty"`
+	Country       string              `json:"country,omitempty"`
+	Phone         string              `json:"phone,omitempty"`
+	Email         string              `json:"email,omitempty"`
 }
 
 type BusinessRepository interface {
@@ -66,4 +124,5 @@ type BusinessRepository interface {
 	UpdateStatus(id string, status BusinessStatus) error
 	Delete(id string) error
 	FindByPhone(phone string) (*Business, error)
+	FindAll() ([]Business, error)
 }
@@ -0,0 +1,220 @@
+package Domain
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return loc
+}
+
+// Santiago skips midnight on 2024-09-08, going from 23:59:59 -04 straight to
+// 01:00 -03, and repeats 23:00 to midnight on 2024-04-06.
+func TestDayBoundariesAcrossDST(t *testing.T) {
+	santiago := mustLoadLocation(t, "America/Santiago")
+
+	tests := []struct {
+		name      string
+		date      time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+		wantHours float64
+	}{
+		{
+			name:      "day before the gap",
+			date:      time.Date(2024, 9, 7, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, 9, 7, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 7, 23, 59, 59, 999999999, santiago),
+			wantHours: 24,
+		},
+		{
+			name:      "midnight in the gap",
+			date:      time.Date(2024, 9, 8, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 8, 23, 59, 59, 999999999, santiago),
+			wantHours: 23,
+		},
+		{
+			name:      "day after the gap",
+			date:      time.Date(2024, 9, 9, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, 9, 9, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 9, 23, 59, 59, 999999999, santiago),
+			wantHours: 24,
+		},
+		{
+			name:      "repeated hour",
+			date:      time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, 4, 6, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 4, 7, 0, 0, 0, 0, santiago).Add(-time.Nanosecond),
+			wantHours: 25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := DateIn(tt.date, santiago)
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("DateIn = %v, want %v", start, tt.wantStart)
+			}
+			if start.Location() != santiago {
+				t.Errorf("DateIn location = %v, want %v", start.Location(), santiago)
+			}
+
+			end := EndOfDay(start)
+			if !end.Equal(tt.wantEnd) {
+				t.Errorf("EndOfDay = %v, want %v", end, tt.wantEnd)
+			}
+			if hours := end.Add(time.Nanosecond).Sub(start).Hours(); hours != tt.wantHours {
+				t.Errorf("day spans %v hours, want %v", hours, tt.wantHours)
+			}
+
+			if got := StartOfDay(end); !got.Equal(start) {
+				t.Errorf("StartOfDay(end) = %v, want %v", got, start)
+			}
+		})
+	}
+}
+
+func TestAddDaysAcrossDST(t *testing.T) {
+	santiago := mustLoadLocation(t, "America/Santiago")
+
+	tests := []struct {
+		name string
+		from time.Time
+		days int
+		want time.Time
+	}{
+		{
+			name: "into the gap",
+			from: time.Date(2024, 9, 7, 18, 30, 0, 0, santiago),
+			days: 1,
+			want: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+		},
+		{
+			name: "out of the gap",
+			from: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+			days: 1,
+			want: time.Date(2024, 9, 9, 0, 0, 0, 0, santiago),
+		},
+		{
+			name: "back over the gap",
+			from: time.Date(2024, 9, 10, 9, 0, 0, 0, santiago),
+			days: -3,
+			want: time.Date(2024, 9, 7, 0, 0, 0, 0, santiago),
+		},
+		{
+			name: "from late evening",
+			from: time.Date(2024, 9, 7, 23, 59, 59, 0, santiago),
+			days: 0,
+			want: time.Date(2024, 9, 7, 0, 0, 0, 0, santiago),
+		},
+		{
+			name: "over the repeated hour",
+			from: time.Date(2024, 4, 6, 23, 30, 0, 0, santiago),
+			days: 1,
+			want: time.Date(2024, 4, 7, 0, 0, 0, 0, santiago),
+		},
+		{
+			name: "month end",
+			from: time.Date(2024, 8, 31, 12, 0, 0, 0, santiago),
+			days: 8,
+			want: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddDays(tt.from, tt.days); !got.Equal(tt.want) {
+				t.Errorf("AddDays(%v, %d) = %v, want %v", tt.from, tt.days, got, tt.want)
+			}
+		})
+	}
+}
+
+// Sales are stored in UTC. In Addis Ababa (UTC+3) a sale at 21:00 UTC or
+// later belongs to the next day, though it is still the evening before in UTC.
+func TestSaleLandsOnBusinessDay(t *testing.T) {
+	addisAbaba := mustLoadLocation(t, "Africa/Addis_Ababa")
+	business := &Business{Timezone: "Africa/Addis_Ababa"}
+
+	tests := []struct {
+		name     string
+		saleTime time.Time // As stored, in UTC
+		wantDate string
+	}{
+		{"late evening", time.Date(2024, 3, 10, 20, 45, 0, 0, time.UTC), "2024-03-10"},
+		{"last second of the day", time.Date(2024, 3, 10, 20, 59, 59, 0, time.UTC), "2024-03-10"},
+		{"just after midnight", time.Date(2024, 3, 10, 21, 0, 0, 0, time.UTC), "2024-03-11"},
+		{"past midnight, still the 10th in UTC", time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC), "2024-03-11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := tt.saleTime.In(business.Location())
+			day := StartOfDay(local)
+			if got := day.Format("2006-01-02"); got != tt.wantDate {
+				t.Errorf("sale at %v lands on %s, want %s", tt.saleTime, got, tt.wantDate)
+			}
+
+			// The business day, as a report asks for it, holds the sale
+			date, _ := time.Parse("2006-01-02", tt.wantDate)
+			start := DateIn(date, addisAbaba)
+			end := EndOfDay(start)
+			if tt.saleTime.Before(start) || tt.saleTime.After(end) {
+				t.Errorf("sale at %v is outside %s (%v to %v)", tt.saleTime, tt.wantDate, start, end)
+			}
+		})
+	}
+}
+
+func TestLocationFallsBackToUTC(t *testing.T) {
+	tests := []struct {
+		name     string
+		business *Business
+		want     string
+	}{
+		{"no business", nil, "UTC"},
+		{"no timezone", &Business{}, "UTC"},
+		{"unknown timezone", &Business{Timezone: "Mars/Olympus_Mons"}, "UTC"},
+		{"known timezone", &Business{Timezone: "Africa/Addis_Ababa"}, "Africa/Addis_Ababa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.business.Location().String(); got != tt.want {
+				t.Errorf("Location() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastDays(t *testing.T) {
+	santiago := mustLoadLocation(t, "America/Santiago")
+
+	tests := []struct {
+		name string
+		now  time.Time
+		days int
+		want time.Time
+	}{
+		{"today only", time.Date(2024, 9, 14, 23, 30, 0, 0, santiago), 1, time.Date(2024, 9, 14, 0, 0, 0, 0, santiago)},
+		{"week", time.Date(2024, 9, 14, 23, 30, 0, 0, santiago), 7, time.Date(2024, 9, 8, 1, 0, 0, 0, santiago)},
+		{"week after the gap", time.Date(2024, 9, 15, 0, 10, 0, 0, santiago), 7, time.Date(2024, 9, 9, 0, 0, 0, 0, santiago)},
+		{"month", time.Date(2024, 10, 7, 9, 0, 0, 0, santiago), 30, time.Date(2024, 9, 8, 1, 0, 0, 0, santiago)},
+		{"leap year", time.Date(2024, 12, 31, 9, 0, 0, 0, santiago), 365, time.Date(2024, 1, 2, 0, 0, 0, 0, santiago)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LastDays(tt.now, tt.days); !got.Equal(tt.want) {
+				t.Errorf("LastDays(%v, %d) = %v, want %v", tt.now, tt.days, got, tt.want)
+			}
+		})
+	}
+}
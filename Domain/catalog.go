@@ -0,0 +1,116 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CatalogField is a product field that can be imported and exported.
+type CatalogField string
+
+const (
+	CatalogFieldName         CatalogField = "name"
+	CatalogFieldSKU          CatalogField = "sku"
+	CatalogFieldBarcode      CatalogField = "barcode"
+	CatalogFieldDescription  CatalogField = "description"
+	CatalogFieldCategory     CatalogField = "category"
+	CatalogFieldUnit         CatalogField = "unit"
+	CatalogFieldCostPrice    CatalogField = "cost_price"
+	CatalogFieldSellingPrice CatalogField = "selling_price"
+	CatalogFieldStock        CatalogField = "stock"
+	CatalogFieldMinStock     CatalogField = "min_stock"
+	CatalogFieldMaxStock     CatalogField = "max_stock"
+	CatalogFieldSupplier     CatalogField = "supplier"
+	CatalogFieldLeadTimeDays CatalogField = "lead_time_days"
+	CatalogFieldTrackBatches CatalogField = "track_batches"
+)
+
+// CatalogColumn is a column of the catalog template.
+type CatalogColumn struct {
+	Field  CatalogField `json:"field"`
+	Header string       `json:"header"`
+}
+
+// CatalogColumns is the catalog template in column order. Exports use these
+// headers, and imports recognise them without a column mapping.
+var CatalogColumns = []CatalogColumn{
+	{CatalogFieldName, "Name"},
+	{CatalogFieldSKU, "SKU"},
+	{CatalogFieldBarcode, "Barcode"},
+	{CatalogFieldDescription, "Description"},
+	{CatalogFieldCategory, "Category"},
+	{CatalogFieldUnit, "Unit"},
+	{CatalogFieldCostPrice, "Cost Price"},
+	{CatalogFieldSellingPrice, "Selling Price"},
+	{CatalogFieldStock, "Stock"},
+	{CatalogFieldMinStock, "Min Stock"},
+	{CatalogFieldMaxStock, "Max Stock"},
+	{CatalogFieldSupplier, "Supplier"},
+	{CatalogFieldLeadTimeDays, "Lead Time Days"},
+	{CatalogFieldTrackBatches, "Track Batches"},
+}
+
+type CatalogFormat string
+
+const (
+	CatalogFormatCSV  CatalogFormat = "csv"
+	CatalogFormatXLSX CatalogFormat = "xlsx"
+)
+
+// CatalogImportJob tracks a catalog import. Rows are upserted by SKU, then
+// by barcode; a dry run validates every row without writing anything.
+type CatalogImportJob struct {
+	ID            primitive.ObjectID      `bson:"_id,omitempty" json:"id,omitempty"`
+	BusinessID    primitive.ObjectID      `bson:"business_id" json:"business_id"`
+	FileName      string                  `bson:"file_name" json:"file_name"`
+	Format        CatalogFormat           `bson:"format" json:"format"`
+	DryRun        bool                    `bson:"dry_run" json:"dry_run"`
+	Mapping       map[string]CatalogField `bson:"mapping" json:"mapping"` // File header to product field
+	Status        ImportJobStatus         `bson:"status" json:"status"`
+	TotalRows     int                     `bson:"total_rows" json:"total_rows"`
+	ProcessedRows int                     `bson:"processed_rows" json:"processed_rows"`
+	Progress      float64                 `bson:"progress" json:"progress"` // Percentage of rows processed
+	Created       int                     `bson:"created" json:"created"`
+	Updated       int                     `bson:"updated" json:"updated"`
+	Failed        int                     `bson:"failed" json:"failed"`
+	Errors        []ImportRowError        `bson:"errors" json:"errors"`
+	Error         string                  `bson:"error,omitempty" json:"error,omitempty"` // Why the whole job failed
+	CreatedBy     primitive.ObjectID      `bson:"created_by" json:"created_by"`
+	StartedAt     *time.Time              `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	CompletedAt   *time.Time              `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	CreatedAt     time.Time               `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time               `bson:"updated_at" json:"updated_at"`
+}
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatusPending   ImportJobStatus = "pending"
+	ImportJobStatusRunning   ImportJobStatus = "running"
+	ImportJobStatusCompleted ImportJobStatus = "completed"
+	ImportJobStatusFailed    ImportJobStatus = "failed"
+)
+
+// ImportRowError is a problem with one row of an import file. Rows are
+// numbered as in the file, the header being row 1.
+type ImportRowError struct {
+	Row     int    `bson:"row" json:"row"`
+	Column  string `bson:"column,omitempty" json:"column,omitempty"`
+	Message string `bson:"message" json:"message"`
+}
+
+type CatalogImportRequest struct {
+	FileName string
+	Format   CatalogFormat
+	Data     []byte
+	Mapping  map[string]CatalogField // Optional; template headers and field names are matched automatically
+	DryRun   bool
+}
+
+type ImportJobRepository interface {
+	Create(job *CatalogImportJob) error
+	FindByID(id string) (*CatalogImportJob, error)
+	FindByBusinessID(businessID string, limit int) ([]CatalogImportJob, error)
+	Update(job *CatalogImportJob) error
+}
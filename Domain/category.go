@@ -0,0 +1,111 @@
+package Domain
+
+import (
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Category is a node of a business's product category tree. Names are unique
+// within a business regardless of case.
+type Category struct {
+	ID         primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	BusinessID primitive.ObjectID   `bson:"business_id" json:"business_id"`
+	Name       string               `bson:"name" json:"name" validate:"required"`
+	ParentID   *primitive.ObjectID  `bson:"parent_id,omitempty" json:"parent_id,omitempty"` // Empty for top-level categories
+	Ancestors  []primitive.ObjectID `bson:"ancestors" json:"ancestors"`                     // From the top-level category down to the parent
+	SortOrder  int                  `bson:"sort_order" json:"sort_order"`                   // Position among its siblings
+	CreatedBy  primitive.ObjectID   `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time            `bson:"updated_at" json:"updated_at"`
+	Children   []Category           `bson:"-" json:"children,omitempty"`
+}
+
+// Depth is 0 for a top-level category, 1 for its children and so on.
+func (c *Category) Depth() int {
+	return len(c.Ancestors)
+}
+
+// HasAncestor reports whether the category sits anywhere below id.
+func (c *Category) HasAncestor(id primitive.ObjectID) bool {
+	for _, ancestor := range c.Ancestors {
+		if ancestor == id {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateCategoryRequest struct {
+	Name     string `json:"name" validate:"required"`
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+// UpdateCategoryRequest renames a category or moves it under another parent.
+// An empty parent ID moves it to the top level.
+type UpdateCategoryRequest struct {
+	Name     string  `json:"name,omitempty"`
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
+// ReorderCategoriesRequest sets the order of the children of a parent, or of
+// the top-level categories when the parent ID is empty.
+type ReorderCategoriesRequest struct {
+	ParentID    string   `json:"parent_id,omitempty"`
+	CategoryIDs []string `json:"category_ids" validate:"required"`
+}
+
+// MergeCategoryRequest folds a category into another: its products and
+// subcategories move to the target and it is deleted.
+type MergeCategoryRequest struct {
+	TargetID string `json:"target_id" validate:"required"`
+}
+
+type CategoryMigrationResult struct {
+	CategoriesCreated int `json:"categories_created"`
+	ProductsUpdated   int `json:"products_updated"`
+}
+
+// OrderCategoryTree returns the categories depth first, each parent followed
+// by its children in sort order.
+func OrderCategoryTree(categories []Category) []Category {
+	children := make(map[primitive.ObjectID][]Category)
+	var roots []Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentID] = append(children[*category.ParentID], category)
+	}
+
+	ordered := make([]Category, 0, len(categories))
+	var walk func(level []Category)
+	walk = func(level []Category) {
+		sort.SliceStable(level, func(i, j int) bool {
+			if level[i].SortOrder != level[j].SortOrder {
+				return level[i].SortOrder < level[j].SortOrder
+			}
+			return level[i].Name < level[j].Name
+		})
+		for _, category := range level {
+			ordered = append(ordered, category)
+			walk(children[category.ID])
+		}
+	}
+	walk(roots)
+
+	return ordered
+}
+
+type CategoryRepository interface {
+	Create(category *Category) error
+	FindByID(id string) (*Category, error)
+	// FindByName matches the name regardless of case.
+	FindByName(businessID, name string) (*Category, error)
+	FindByBusinessID(businessID string) ([]Category, error)
+	Update(category *Category) error
+	SetSortOrder(id string, sortOrder int) error
+	Delete(id string) error
+}
@@ -0,0 +1,110 @@
+package Domain
+
+import (
+	"math"
+	"time"
+)
+
+// ComparisonPreset picks the two periods of a comparison.
+type ComparisonPreset string
+
+const (
+	ComparisonWeekOverWeek   ComparisonPreset = "week_over_week"   // This week to date against the same days last week
+	ComparisonMonthOverMonth ComparisonPreset = "month_over_month" // This month to date against the same days last month
+	ComparisonYearOverYear   ComparisonPreset = "year_over_year"   // A period against the same dates a year earlier
+	ComparisonCustom         ComparisonPreset = "custom"           // Any two periods
+)
+
+// DefaultComparisonLimit is how many products the contribution analysis
+// lists when no limit is given.
+const DefaultComparisonLimit = 10
+
+// PeriodComparisonRequest describes the periods to compare. Dates are
+// calendar days in the business's timezone, both ends included. Custom
+// comparisons need the current dates and compare them with the days just
+// before unless previous dates are given too; a year-over-year comparison
+// takes the current dates and defaults to this month to date. Without a
+// preset, any date given makes the comparison custom.
+type PeriodComparisonRequest struct {
+	Preset        ComparisonPreset `json:"preset"`
+	CurrentStart  *time.Time       `json:"current_start,omitempty"`
+	CurrentEnd    *time.Time       `json:"current_end,omitempty"`
+	PreviousStart *time.Time       `json:"previous_start,omitempty"`
+	PreviousEnd   *time.Time       `json:"previous_end,omitempty"`
+	Limit         int              `json:"limit,omitempty"` // Products in the contribution analysis; defaults to 10
+}
+
+// PeriodComparison sets a period's figures against an earlier period's and
+// breaks the change in sales down by category and product.
+type PeriodComparison struct {
+	Preset        ComparisonPreset       `json:"preset"`
+	Current       ComparisonPeriod       `json:"current"`
+	Previous      ComparisonPeriod       `json:"previous"`
+	Sales         MetricChange           `json:"sales"`
+	Expenses      MetricChange           `json:"expenses"` // Cost of goods sold plus operating expenses
+	Profit        MetricChange           `json:"profit"`
+	Transactions  MetricChange           `json:"transactions"`
+	AverageBasket MetricChange           `json:"average_basket"`
+	Categories    []CategoryContribution `json:"categories"` // Every level of the category tree
+	Products      []ProductContribution  `json:"products"`   // The products whose sales moved most
+}
+
+type ComparisonPeriod struct {
+	Label     string    `json:"label"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// MetricChange is one figure in both periods.
+type MetricChange struct {
+	Current       float64  `json:"current"`
+	Previous      float64  `json:"previous"`
+	Change        float64  `json:"change"`
+	ChangePercent *float64 `json:"change_percent,omitempty"` // Empty when the previous figure is zero
+}
+
+// CategoryContribution is a category's share of the change in sales.
+type CategoryContribution struct {
+	CategoryID    string   `json:"category_id,omitempty"` // Empty for products without a category
+	Name          string   `json:"name"`
+	ParentID      string   `json:"parent_id,omitempty"`
+	Depth         int      `json:"depth"`
+	Current       float64  `json:"current"`
+	Previous      float64  `json:"previous"`
+	Change        float64  `json:"change"`
+	ChangePercent *float64 `json:"change_percent,omitempty"`
+	Contribution  *float64 `json:"contribution,omitempty"` // Percent of the total change in sales; empty when sales did not change
+}
+
+// ProductContribution is a product's share of the change in sales.
+type ProductContribution struct {
+	ProductID        string   `json:"product_id"`
+	ProductName      string   `json:"product_name"`
+	CurrentQuantity  float64  `json:"current_quantity"`
+	PreviousQuantity float64  `json:"previous_quantity"`
+	Current          float64  `json:"current"`
+	Previous         float64  `json:"previous"`
+	Change           float64  `json:"change"`
+	ChangePercent    *float64 `json:"change_percent,omitempty"`
+	Contribution     *float64 `json:"contribution,omitempty"`
+}
+
+// NewMetricChange compares a figure across two periods.
+func NewMetricChange(current, previous float64) MetricChange {
+	return MetricChange{
+		Current:       current,
+		Previous:      previous,
+		Change:        current - previous,
+		ChangePercent: PercentChange(current, previous),
+	}
+}
+
+// PercentChange returns the change from previous to current as a percentage
+// of previous, or nil when previous is zero.
+func PercentChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	percent := (current - previous) / math.Abs(previous) * 100
+	return &percent
+}
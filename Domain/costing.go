@@ -0,0 +1,44 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CostingMethod string
+
+const (
+	CostingMethodFIFO    CostingMethod = "fifo"
+	CostingMethodAverage CostingMethod = "average"
+)
+
+// CostLayer is a quantity of stock received at a single unit cost. Layers are
+// consumed oldest first as stock leaves the shop.
+type CostLayer struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BusinessID primitive.ObjectID  `bson:"business_id" json:"business_id"`
+	ProductID  primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	MovementID *primitive.ObjectID `bson:"movement_id,omitempty" json:"movement_id,omitempty"`
+	ReceivedAt time.Time           `bson:"received_at" json:"received_at"`
+	Quantity   float64             `bson:"quantity" json:"quantity"`
+	Remaining  float64             `bson:"remaining" json:"remaining"`
+	UnitCost   float64             `bson:"unit_cost" json:"unit_cost"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+}
+
+type CostRebuildResult struct {
+	ProductsProcessed  int      `json:"products_processed"`
+	MovementsProcessed int      `json:"movements_processed"`
+	SalesUpdated       int      `json:"sales_updated"`
+	Errors             []string `json:"errors,omitempty"`
+}
+
+type CostLayerRepository interface {
+	Create(layer *CostLayer) error
+	FindOpen(productID string) ([]CostLayer, error)
+	// Consume takes quantity out of the layer if it still holds that much,
+	// reporting whether it did. A negative quantity puts stock back.
+	Consume(id string, quantity float64) (bool, error)
+	DeleteByProductID(productID string) error
+}
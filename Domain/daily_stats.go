@@ -0,0 +1,104 @@
+package Domain
+
+import (
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DailyBusinessStats rolls up a business's completed sales and active
+// expenses for one calendar day in its timezone. Rollups are refreshed on
+// every write to the day's sales and expenses, so dashboards and summaries
+// read a handful of documents instead of aggregating the raw records.
+type DailyBusinessStats struct {
+	ID                primitive.ObjectID     `bson:"_id,omitempty" json:"-"`
+	BusinessID        primitive.ObjectID     `bson:"business_id" json:"business_id"`
+	Date              string                 `bson:"date" json:"date"`           // YYYY-MM-DD in the business's timezone
+	DayStart          time.Time              `bson:"day_start" json:"day_start"` // First instant of the day
+	Transactions      int                    `bson:"transactions" json:"transactions"`
+	ItemsSold         float64                `bson:"items_sold" json:"items_sold"`
+	Revenue           float64                `bson:"revenue" json:"revenue"` // Final amounts after discounts and tax
+	Discounts         float64                `bson:"discounts" json:"discounts"`
+	Tax               float64                `bson:"tax" json:"tax"`
+	CostOfGoods       float64                `bson:"cost_of_goods" json:"cost_of_goods"`
+	Expenses          float64                `bson:"expenses" json:"expenses"` // Including stock purchases
+	ExpenseCount      int                    `bson:"expense_count" json:"expense_count"`
+	ExpenseCategories []DailyExpenseCategory `bson:"expense_categories" json:"expense_categories"`
+	UpdatedAt         time.Time              `bson:"updated_at" json:"updated_at"`
+}
+
+type DailyExpenseCategory struct {
+	Category ExpenseCategory `bson:"category" json:"category"`
+	Amount   float64         `bson:"amount" json:"amount"`
+	Count    int             `bson:"count" json:"count"`
+}
+
+// StockPurchases is the part of the day's expenses spent on stock.
+func (s *DailyBusinessStats) StockPurchases() float64 {
+	var total float64
+	for _, category := range s.ExpenseCategories {
+		if category.Category == ExpenseCategoryStockPurchase {
+			total += category.Amount
+		}
+	}
+	return total
+}
+
+// SumDailyStats adds up the rollups of several days. The expense categories
+// of the total are sorted by amount, largest first.
+func SumDailyStats(days []DailyBusinessStats) DailyBusinessStats {
+	var total DailyBusinessStats
+	categories := make(map[ExpenseCategory]*DailyExpenseCategory)
+
+	for _, day := range days {
+		total.Transactions += day.Transactions
+		total.ItemsSold += day.ItemsSold
+		total.Revenue += day.Revenue
+		total.Discounts += day.Discounts
+		total.Tax += day.Tax
+		total.CostOfGoods += day.CostOfGoods
+		total.Expenses += day.Expenses
+		total.ExpenseCount += day.ExpenseCount
+
+		for _, category := range day.ExpenseCategories {
+			sum, ok := categories[category.Category]
+			if !ok {
+				sum = &DailyExpenseCategory{Category: category.Category}
+				categories[category.Category] = sum
+			}
+			sum.Amount += category.Amount
+			sum.Count += category.Count
+		}
+	}
+
+	total.ExpenseCategories = make([]DailyExpenseCategory, 0, len(categories))
+	for _, category := range categories {
+		total.ExpenseCategories = append(total.ExpenseCategories, *category)
+	}
+	sort.Slice(total.ExpenseCategories, func(i, j int) bool {
+		return total.ExpenseCategories[i].Amount > total.ExpenseCategories[j].Amount
+	})
+
+	return total
+}
+
+type DailyStatsRepository interface {
+	// Refresh recomputes the rollup of the calendar day that starts at
+	// dayStart, in dayStart's location, from the day's sales and expenses.
+	// A rollup stored by a refresh that read the day later is kept.
+	Refresh(businessID string, dayStart time.Time) error
+	// Rebuild replaces every rollup of the business, with days counted in
+	// loc, and returns the number of days with sales or expenses. Days are
+	// replaced one by one, so the previous rollups stay readable throughout.
+	Rebuild(businessID string, loc *time.Location) (int, error)
+	// FindRange returns the rollups of the days from startDate to endDate,
+	// both YYYY-MM-DD and included, oldest first. Days without sales or
+	// expenses may be missing.
+	FindRange(businessID string, startDate, endDate string) ([]DailyBusinessStats, error)
+	// CoversHistory reports whether the rollups reach back to the business's
+	// first completed sale and active expense. Businesses that traded before
+	// rollups were kept have none for their earlier days until a rebuild.
+	CoversHistory(businessID string) (bool, error)
+	EnsureIndexes() error
+}
@@ -0,0 +1,78 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultHeatmapDays is the range of a sales heatmap when none is given:
+// four whole weeks, so every weekday is counted the same number of times.
+const DefaultHeatmapDays = 28
+
+// SalesHeatmapRequest describes the range and sales of a heatmap. Dates are
+// calendar days in the business's timezone, both ends included; the range
+// defaults to the last four weeks up to today.
+type SalesHeatmapRequest struct {
+	StartDate     *time.Time     `json:"start_date,omitempty"`
+	EndDate       *time.Time     `json:"end_date,omitempty"`
+	ProductID     string         `json:"product_id,omitempty"`
+	Category      string         `json:"category,omitempty"` // Category ID or name, with everything below it
+	PaymentMethod *PaymentMethod `json:"payment_method,omitempty"`
+}
+
+// SalesHeatmap lays out when completed sales happen, by day of the week and
+// hour of the day in the business's timezone, against the range of the same
+// length just before.
+type SalesHeatmap struct {
+	Timezone      string           `json:"timezone"`
+	Current       ComparisonPeriod `json:"current"`
+	Previous      ComparisonPeriod `json:"previous"`
+	ProductID     string           `json:"product_id,omitempty"`
+	Category      string           `json:"category,omitempty"`
+	PaymentMethod *PaymentMethod   `json:"payment_method,omitempty"`
+	Transactions  MetricChange     `json:"transactions"`
+	Revenue       MetricChange     `json:"revenue"`
+	Cells         []HeatmapCell    `json:"cells"`    // Monday 00:00 first, then hour by hour through Sunday
+	Weekdays      []HeatmapTotal   `json:"weekdays"` // Monday first
+	Hours         []HeatmapTotal   `json:"hours"`
+	Peak          *HeatmapCell     `json:"peak,omitempty"` // The hour with the most revenue; empty without sales
+}
+
+// HeatmapCell is one hour of one day of the week.
+type HeatmapCell struct {
+	Weekday              int      `json:"weekday"` // 0 is Sunday
+	WeekdayName          string   `json:"weekday_name"`
+	Hour                 int      `json:"hour"`
+	Transactions         int      `json:"transactions"`
+	Revenue              float64  `json:"revenue"`
+	PreviousTransactions int      `json:"previous_transactions"`
+	PreviousRevenue      float64  `json:"previous_revenue"`
+	RevenueChangePercent *float64 `json:"revenue_change_percent,omitempty"` // Empty when there was no previous revenue
+}
+
+// HeatmapTotal sums a row or column of the heatmap.
+type HeatmapTotal struct {
+	Label                string   `json:"label"` // Weekday name or hour as 15:00
+	Transactions         int      `json:"transactions"`
+	Revenue              float64  `json:"revenue"`
+	PreviousTransactions int      `json:"previous_transactions"`
+	PreviousRevenue      float64  `json:"previous_revenue"`
+	RevenueChangePercent *float64 `json:"revenue_change_percent,omitempty"`
+}
+
+// SalesHeatmapFilters narrows the sales counted by
+// ReportRepository.GetSalesHeatmap. A non-nil ProductIDs matches only those
+// products, so an empty one matches no sales.
+type SalesHeatmapFilters struct {
+	ProductIDs    []primitive.ObjectID
+	PaymentMethod *PaymentMethod
+}
+
+// HeatmapCount is the completed sales of one hour of one day of the week.
+type HeatmapCount struct {
+	Weekday      int     `bson:"weekday"` // 0 is Sunday
+	Hour         int     `bson:"hour"`
+	Transactions int     `bson:"transactions"`
+	Revenue      float64 `bson:"revenue"`
+}
@@ -0,0 +1,181 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Location is a place where a business holds stock, such as a back store or
+// an outlet. The default location receives stock that is not assigned to a
+// location explicitly.
+type Location struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	BusinessID primitive.ObjectID `bson:"business_id" json:"business_id"`
+	Name       string             `bson:"name" json:"name" validate:"required"`
+	Code       string             `bson:"code,omitempty" json:"code,omitempty"`
+	Type       LocationType       `bson:"type" json:"type"`
+	Address    string             `bson:"address,omitempty" json:"address,omitempty"`
+	IsDefault  bool               `bson:"is_default" json:"is_default"`
+	Status     LocationStatus     `bson:"status" json:"status"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type LocationType string
+
+const (
+	LocationTypeStore     LocationType = "store"
+	LocationTypeOutlet    LocationType = "outlet"
+	LocationTypeWarehouse LocationType = "warehouse"
+)
+
+type LocationStatus string
+
+const (
+	LocationStatusActive   LocationStatus = "active"
+	LocationStatusInactive LocationStatus = "inactive"
+)
+
+// StockLevel is the quantity of a product held at one location. Product.Stock
+// stays the business-wide total: the sum of all levels plus goods in transit.
+type StockLevel struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	BusinessID primitive.ObjectID `bson:"business_id" json:"business_id"`
+	ProductID  primitive.ObjectID `bson:"product_id" json:"product_id"`
+	LocationID primitive.ObjectID `bson:"location_id" json:"location_id"`
+	Quantity   float64            `bson:"quantity" json:"quantity"`
+	MinStock   float64            `bson:"min_stock,omitempty" json:"min_stock,omitempty"` // Overrides the product minimum at this location
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type CreateLocationRequest struct {
+	Name      string       `json:"name" validate:"required"`
+	Code      string       `json:"code,omitempty"`
+	Type      LocationType `json:"type,omitempty"`
+	Address   string       `json:"address,omitempty"`
+	IsDefault bool         `json:"is_default,omitempty"`
+}
+
+type UpdateLocationRequest struct {
+	Name      string         `json:"name,omitempty"`
+	Code      string         `json:"code,omitempty"`
+	Type      LocationType   `json:"type,omitempty"`
+	Address   string         `json:"address,omitempty"`
+	IsDefault *bool          `json:"is_default,omitempty"`
+	Status    LocationStatus `json:"status,omitempty"`
+}
+
+type SetLocationMinStockRequest struct {
+	MinStock float64 `json:"min_stock" validate:"gte=0"`
+}
+
+type LocationStockItem struct {
+	ProductID   string  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	SKU         string  `json:"sku,omitempty"`
+	Quantity    float64 `json:"quantity"`
+	MinStock    float64 `json:"min_stock"`
+	Value       float64 `json:"value"`
+	LowStock    bool    `json:"low_stock"`
+}
+
+type LocationStockReport struct {
+	LocationID    string              `json:"location_id"`
+	LocationName  string              `json:"location_name"`
+	TotalProducts int                 `json:"total_products"`
+	TotalStock    float64             `json:"total_stock"`
+	TotalValue    float64             `json:"total_value"`
+	Items         []LocationStockItem `json:"items"`
+	LowStockItems []LowStockItem      `json:"low_stock_items"`
+}
+
+// ProductLocationStock is one product's stock broken down by location.
+type ProductLocationStock struct {
+	ProductID   string                 `json:"product_id"`
+	ProductName string                 `json:"product_name"`
+	TotalStock  float64                `json:"total_stock"`
+	InTransit   float64                `json:"in_transit"`
+	Locations   []LocationStockBalance `json:"locations"`
+}
+
+type LocationStockBalance struct {
+	LocationID   string  `json:"location_id"`
+	LocationName string  `json:"location_name"`
+	Quantity     float64 `json:"quantity"`
+}
+
+// StockTransfer moves goods between two locations. Stock leaves the source
+// when the transfer is dispatched and arrives at the destination when it is
+// received; in between it is in transit.
+type StockTransfer struct {
+	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BusinessID     primitive.ObjectID  `bson:"business_id" json:"business_id"`
+	FromLocationID primitive.ObjectID  `bson:"from_location_id" json:"from_location_id"`
+	ToLocationID   primitive.ObjectID  `bson:"to_location_id" json:"to_location_id"`
+	Items          []TransferItem      `bson:"items" json:"items"`
+	Status         TransferStatus      `bson:"status" json:"status"`
+	Notes          string              `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedBy      primitive.ObjectID  `bson:"created_by" json:"created_by"`
+	DispatchedAt   time.Time           `bson:"dispatched_at" json:"dispatched_at"`
+	ReceivedBy     *primitive.ObjectID `bson:"received_by,omitempty" json:"received_by,omitempty"`
+	ReceivedAt     *time.Time          `bson:"received_at,omitempty" json:"received_at,omitempty"`
+	CreatedAt      time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+type TransferStatus string
+
+const (
+	TransferStatusInTransit TransferStatus = "in_transit"
+	TransferStatusReceived  TransferStatus = "received"
+	TransferStatusCancelled TransferStatus = "cancelled"
+)
+
+type TransferItem struct {
+	ProductID   primitive.ObjectID `bson:"product_id" json:"product_id"`
+	ProductName string             `bson:"product_name" json:"product_name"`
+	Quantity    float64            `bson:"quantity" json:"quantity"`
+}
+
+type CreateTransferRequest struct {
+	FromLocationID string                      `json:"from_location_id" validate:"required"`
+	ToLocationID   string                      `json:"to_location_id" validate:"required"`
+	Items          []CreateTransferItemRequest `json:"items" validate:"required"`
+	Notes          string                      `json:"notes,omitempty"`
+}
+
+type CreateTransferItemRequest struct {
+	ProductID string  `json:"product_id" validate:"required"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type LocationRepository interface {
+	Create(location *Location) error
+	FindByID(id string) (*Location, error)
+	FindByBusinessID(businessID string) ([]Location, error)
+	FindDefault(businessID string) (*Location, error)
+	Update(location *Location) error
+	ClearDefault(businessID string) error
+}
+
+type StockLevelRepository interface {
+	Find(productID, locationID string) (*StockLevel, error)
+	FindByProductID(productID string) ([]StockLevel, error)
+	FindByLocationID(locationID string) ([]StockLevel, error)
+	// Adjust changes a level by delta, creating it when missing. A negative
+	// delta fails when the location does not hold enough stock.
+	Adjust(businessID, productID, locationID string, delta float64) (*StockLevel, error)
+	// ForceAdjust changes a level by delta even when it takes the location
+	// below zero, for sales under a negative stock policy.
+	ForceAdjust(businessID, productID, locationID string, delta float64) (*StockLevel, error)
+	SetMinStock(businessID, productID, locationID string, minStock float64) error
+}
+
+type TransferRepository interface {
+	Create(transfer *StockTransfer) error
+	FindByID(id string) (*StockTransfer, error)
+	FindByBusinessID(businessID string, status *TransferStatus) ([]StockTransfer, error)
+	FindInTransitByProductID(productID string) ([]StockTransfer, error)
+	UpdateStatus(id string, status TransferStatus, userID string) error
+}
@@ -0,0 +1,126 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NegativeStockPolicy decides whether a sale may take stock below zero.
+type NegativeStockPolicy string
+
+const (
+	NegativeStockBlock NegativeStockPolicy = "block" // Refuse the sale (default)
+	NegativeStockWarn  NegativeStockPolicy = "warn"  // Make the sale and return a warning
+	NegativeStockAllow NegativeStockPolicy = "allow" // Make the sale silently
+	// NegativeStockInherit clears a product policy on update so the business
+	// policy applies again. It is never stored.
+	NegativeStockInherit NegativeStockPolicy = "inherit"
+)
+
+// IsValidNegativeStockPolicy reports whether the policy can be stored.
+func IsValidNegativeStockPolicy(policy NegativeStockPolicy) bool {
+	return policy == NegativeStockBlock || policy == NegativeStockWarn || policy == NegativeStockAllow
+}
+
+// EffectiveNegativeStockPolicy returns the policy that applies to sales of a
+// product: its own policy, else the business policy, else block. Serial-tracked
+// stock is counted unit by unit and can never go negative.
+func EffectiveNegativeStockPolicy(business *Business, product *Product) NegativeStockPolicy {
+	if product.TrackSerials {
+		return NegativeStockBlock
+	}
+	if IsValidNegativeStockPolicy(product.NegativeStock) {
+		return product.NegativeStock
+	}
+	if business != nil && IsValidNegativeStockPolicy(business.NegativeStock) {
+		return business.NegativeStock
+	}
+	return NegativeStockBlock
+}
+
+// NegativeStockItem is a product whose stock is below zero.
+type NegativeStockItem struct {
+	ProductID   primitive.ObjectID `bson:"_id" json:"product_id"`
+	ProductName string             `bson:"name" json:"product_name"`
+	SKU         string             `bson:"sku,omitempty" json:"sku,omitempty"`
+	Stock       float64            `bson:"stock" json:"stock"`
+}
+
+// NegativeStockEvent records a sale that took a product below zero and the
+// later receipts that covered the shortfall.
+type NegativeStockEvent struct {
+	ID            primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	BusinessID    primitive.ObjectID   `bson:"business_id" json:"business_id"`
+	ProductID     primitive.ObjectID   `bson:"product_id" json:"product_id"`
+	ProductName   string               `bson:"product_name" json:"product_name"`
+	LocationID    *primitive.ObjectID  `bson:"location_id,omitempty" json:"location_id,omitempty"`
+	MovementID    primitive.ObjectID   `bson:"movement_id" json:"movement_id"`
+	ReferenceID   *primitive.ObjectID  `bson:"reference_id,omitempty" json:"reference_id,omitempty"`
+	ReferenceType string               `bson:"reference_type,omitempty" json:"reference_type,omitempty"`
+	Policy        NegativeStockPolicy  `bson:"policy,omitempty" json:"policy,omitempty"` // Set when a sale went below zero under the policy
+	Shortfall     float64              `bson:"shortfall" json:"shortfall"`               // Quantity sold beyond the stock on hand
+	Covered       float64              `bson:"covered" json:"covered"`
+	Status        NegativeStockStatus  `bson:"status" json:"status"`
+	Coverings     []NegativeStockCover `bson:"coverings" json:"coverings"`
+	CreatedBy     primitive.ObjectID   `bson:"created_by" json:"created_by"`
+	CreatedAt     time.Time            `bson:"created_at" json:"created_at"` // When the stock went negative
+	CoveredAt     *time.Time           `bson:"covered_at,omitempty" json:"covered_at,omitempty"`
+	UpdatedAt     time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+type NegativeStockStatus string
+
+const (
+	NegativeStockStatusOpen    NegativeStockStatus = "open"
+	NegativeStockStatusCovered NegativeStockStatus = "covered"
+)
+
+// Outstanding is the part of the shortfall no receipt has covered yet.
+func (e *NegativeStockEvent) Outstanding() float64 {
+	if e.Covered >= e.Shortfall {
+		return 0
+	}
+	return e.Shortfall - e.Covered
+}
+
+// NegativeStockCover is the part of a later stock receipt that went towards a
+// shortfall.
+type NegativeStockCover struct {
+	MovementID    primitive.ObjectID  `bson:"movement_id" json:"movement_id"`
+	Type          MovementType        `bson:"type" json:"type"`
+	ReferenceID   *primitive.ObjectID `bson:"reference_id,omitempty" json:"reference_id,omitempty"`
+	ReferenceType string              `bson:"reference_type,omitempty" json:"reference_type,omitempty"`
+	Reason        string              `bson:"reason,omitempty" json:"reason,omitempty"`
+	Quantity      float64             `bson:"quantity" json:"quantity"`
+	At            time.Time           `bson:"at" json:"at"`
+}
+
+type NegativeStockFilters struct {
+	Status    *NegativeStockStatus
+	ProductID *string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// NegativeStockReport reconciles the sales that went below zero with the
+// receipts that covered them.
+type NegativeStockReport struct {
+	GeneratedAt      time.Time            `json:"generated_at"`
+	OpenEvents       int                  `json:"open_events"`
+	CoveredEvents    int                  `json:"covered_events"`
+	TotalShortfall   float64              `json:"total_shortfall"`
+	TotalOutstanding float64              `json:"total_outstanding"`
+	Events           []NegativeStockEvent `json:"events"`
+}
+
+type NegativeStockRepository interface {
+	Create(event *NegativeStockEvent) error
+	// FindOpenByProductID returns the uncovered shortfalls of a product,
+	// oldest first.
+	FindOpenByProductID(productID string) ([]NegativeStockEvent, error)
+	FindByBusinessID(businessID string, filters NegativeStockFilters) ([]NegativeStockEvent, error)
+	// AddCover records part of a receipt against a shortfall and marks it
+	// covered once nothing is outstanding.
+	AddCover(id string, cover NegativeStockCover, covered bool) error
+}
@@ -0,0 +1,109 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PriceChangeSource string
+
+const (
+	PriceChangeSourceManual    PriceChangeSource = "manual"
+	PriceChangeSourceScheduled PriceChangeSource = "scheduled"
+	PriceChangeSourceRecipe    PriceChangeSource = "recipe"
+)
+
+// PriceChange records a change to a product's cost or selling price.
+type PriceChange struct {
+	ID              primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BusinessID      primitive.ObjectID  `bson:"business_id" json:"business_id"`
+	ProductID       primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	ProductName     string              `bson:"product_name" json:"product_name"`
+	OldCostPrice    float64             `bson:"old_cost_price" json:"old_cost_price"`
+	NewCostPrice    float64             `bson:"new_cost_price" json:"new_cost_price"`
+	OldSellingPrice float64             `bson:"old_selling_price" json:"old_selling_price"`
+	NewSellingPrice float64             `bson:"new_selling_price" json:"new_selling_price"`
+	Source          PriceChangeSource   `bson:"source" json:"source"`
+	ScheduleID      *primitive.ObjectID `bson:"schedule_id,omitempty" json:"schedule_id,omitempty"` // Set for scheduled changes
+	ChangedBy       primitive.ObjectID  `bson:"changed_by" json:"changed_by"`
+	ChangedAt       time.Time           `bson:"changed_at" json:"changed_at"`
+}
+
+type ScheduledPriceStatus string
+
+const (
+	ScheduledPriceStatusPending   ScheduledPriceStatus = "pending"
+	ScheduledPriceStatusApplied   ScheduledPriceStatus = "applied"
+	ScheduledPriceStatusCancelled ScheduledPriceStatus = "cancelled"
+	ScheduledPriceStatusFailed    ScheduledPriceStatus = "failed"
+)
+
+// ScheduledPriceChange is a price change for one product that takes effect
+// at a future time. A percentage change is worked out from the selling price
+// in effect when it is applied.
+type ScheduledPriceChange struct {
+	ID            primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	BusinessID    primitive.ObjectID   `bson:"business_id" json:"business_id"`
+	ProductID     primitive.ObjectID   `bson:"product_id" json:"product_id"`
+	ProductName   string               `bson:"product_name" json:"product_name"`
+	BatchID       primitive.ObjectID   `bson:"batch_id" json:"batch_id"` // Shared by the changes scheduled together
+	CostPrice     *float64             `bson:"cost_price,omitempty" json:"cost_price,omitempty"`
+	SellingPrice  *float64             `bson:"selling_price,omitempty" json:"selling_price,omitempty"`
+	PercentChange *float64             `bson:"percent_change,omitempty" json:"percent_change,omitempty"` // Applied to the selling price, e.g. 10 or -5
+	EffectiveAt   time.Time            `bson:"effective_at" json:"effective_at"`
+	Notes         string               `bson:"notes,omitempty" json:"notes,omitempty"`
+	Status        ScheduledPriceStatus `bson:"status" json:"status"`
+	Error         string               `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedBy     primitive.ObjectID   `bson:"created_by" json:"created_by"`
+	CreatedAt     time.Time            `bson:"created_at" json:"created_at"`
+	AppliedAt     *time.Time           `bson:"applied_at,omitempty" json:"applied_at,omitempty"`
+}
+
+// SchedulePriceChangeRequest schedules new prices for the listed products or
+// for a whole category. Give either new prices or a percentage change of the
+// selling price.
+type SchedulePriceChangeRequest struct {
+	ProductIDs    []string  `json:"product_ids,omitempty"`
+	Category      string    `json:"category,omitempty"` // Category ID or name; includes its subcategories
+	CostPrice     *float64  `json:"cost_price,omitempty"`
+	SellingPrice  *float64  `json:"selling_price,omitempty"`
+	PercentChange *float64  `json:"percent_change,omitempty"`
+	EffectiveAt   time.Time `json:"effective_at" validate:"required"`
+	Notes         string    `json:"notes,omitempty"`
+}
+
+// ProductPrice is the price of a product in effect at a point in time.
+type ProductPrice struct {
+	ProductID    primitive.ObjectID `json:"product_id"`
+	ProductName  string             `json:"product_name"`
+	SKU          string             `json:"sku,omitempty"`
+	Category     string             `json:"category,omitempty"`
+	CostPrice    float64            `json:"cost_price"`
+	SellingPrice float64            `json:"selling_price"`
+}
+
+type PriceHistoryRepository interface {
+	Create(change *PriceChange) error
+	FindByProductID(productID string, limit int) ([]PriceChange, error)
+	// FindByBusinessID returns changes made between two dates, newest first.
+	FindByBusinessID(businessID string, startDate, endDate time.Time) ([]PriceChange, error)
+	// FindLatestBefore returns the last change of each product of the
+	// business made before the given time.
+	FindLatestBefore(businessID string, at time.Time) ([]PriceChange, error)
+	// FindFirstAfter returns the first change of each product of the business
+	// made after the given time.
+	FindFirstAfter(businessID string, at time.Time) ([]PriceChange, error)
+}
+
+type ScheduledPriceRepository interface {
+	CreateMany(changes []ScheduledPriceChange) error
+	FindByID(id string) (*ScheduledPriceChange, error)
+	FindByBusinessID(businessID string, status *ScheduledPriceStatus) ([]ScheduledPriceChange, error)
+	// FindDue returns pending changes whose effective time has passed,
+	// oldest first.
+	FindDue(now time.Time, limit int) ([]ScheduledPriceChange, error)
+	// UpdateStatus moves a change on from one of the given statuses and fails
+	// when it is in none of them.
+	UpdateStatus(id string, status ScheduledPriceStatus, from []ScheduledPriceStatus, errorMessage string) error
+}
@@ -7,24 +7,45 @@ import (
 )
 
 type Product struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	BusinessID   primitive.ObjectID `bson:"business_id" json:"business_id"`
-	Name         string             `bson:"name" json:"name" validate:"required"`
-	Description  string             `bson:"description,omitempty" json:"description,omitempty"`
-	SKU          string             `bson:"sku,omitempty" json:"sku,omitempty"`
-	Barcode      string             `bson:"barcode,omitempty" json:"barcode,omitempty"`
-	Category     string             `bson:"category,omitempty" json:"category,omitempty"`
-	Unit         string             `bson:"unit,omitempty" json:"unit,omitempty"`
-	CostPrice    float64            `bson:"cost_price" json:"cost_price" validate:"required,gt=0"`
-	SellingPrice float64            `bson:"selling_price" json:"selling_price" validate:"required,gt=0"`
-	Stock        float64            `bson:"stock" json:"stock" validate:"gte=0"`
-	MinStock     float64            `bson:"min_stock,omitempty" json:"min_stock,omitempty"`
-	MaxStock     float64            `bson:"max_stock,omitempty" json:"max_stock,omitempty"`
-	ImageURL     string             `bson:"image_url,omitempty" json:"image_url,omitempty"`
-	Status       ProductStatus      `bson:"status" json:"status"`
-	CreatedBy    primitive.ObjectID `bson:"created_by" json:"created_by"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BusinessID   primitive.ObjectID  `bson:"business_id" json:"business_id"`
+	Name         string              `bson:"name" json:"name" validate:"required"`
+	Type         ProductType         `bson:"type,omitempty" json:"type,omitempty"` // Empty for standard products
+	Description  string              `bson:"description,omitempty" json:"description,omitempty"`
+	SKU          string              `bson:"sku,omitempty" json:"sku,omitempty"`
+	Barcode      string              `bson:"barcode,omitempty" json:"barcode,omitempty"`
+	CategoryID   *primitive.ObjectID `bson:"category_id,omitempty" json:"category_id,omitempty"`
+	Category     string              `bson:"category,omitempty" json:"category,omitempty"` // Name of the category, kept for display and exports
+	Unit         string              `bson:"unit,omitempty" json:"unit,omitempty"`
+	CostPrice    float64             `bson:"cost_price" json:"cost_price" validate:"required,gt=0"`
+	AverageCost  float64             `bson:"average_cost,omitempty" json:"average_cost,omitempty"` // Moving average of received stock
+	SellingPrice float64             `bson:"selling_price" json:"selling_price" validate:"required,gt=0"`
+	Stock        float64             `bson:"stock" json:"stock" validate:"gte=0"`
+	MinStock     float64             `bson:"min_stock,omitempty" json:"min_stock,omitempty"`
+	MaxStock     float64             `bson:"max_stock,omitempty" json:"max_stock,omitempty"`
+	ImageURL     string              `bson:"image_url,omitempty" json:"image_url,omitempty"`
+	TrackBatches bool                `bson:"track_batches" json:"track_batches"` // Stock held per lot with expiry dates
+	TrackSerials bool                `bson:"track_serials" json:"track_serials"` // Each unit carries a serial number, such as an IMEI
+	SupplierID   *primitive.ObjectID `bson:"supplier_id,omitempty" json:"supplier_id,omitempty"`
+	LeadTimeDays int                 `bson:"lead_time_days,omitempty" json:"lead_time_days,omitempty"` // Overrides the supplier lead time
+	// WarrantyMonths is how long the warranty of a serialised unit runs from
+	// its sale date.
+	WarrantyMonths int `bson:"warranty_months,omitempty" json:"warranty_months,omitempty"`
+	// NegativeStock overrides the business policy for selling below zero.
+	NegativeStock NegativeStockPolicy `bson:"negative_stock,omitempty" json:"negative_stock,omitempty"`
+	// Components make up a bundle. A bundle holds no stock of its own: its
+	// stock is how many can be built from the components, and its cost is
+	// the sum of their costs.
+	Components []BundleComponent  `bson:"components,omitempty" json:"components,omitempty"`
+	Status     ProductStatus      `bson:"status" json:"status"`
+	CreatedBy  primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// IsBundle reports whether the product is a bundle of other products.
+func (p *Product) IsBundle() bool {
+	return p.Type == ProductTypeBundle
 }
 
 type ProductStatus string
@@ -44,8 +65,11 @@ type StockMovement struct {
 	Previous      float64             `bson:"previous" json:"previous"`
 	New           float64             `bson:"new" json:"new"`
 	Reason        string              `bson:"reason" json:"reason"`
+	UnitCost      float64             `bson:"unit_cost,omitempty" json:"unit_cost,omitempty"`
+	TotalCost     float64             `bson:"total_cost,omitempty" json:"total_cost,omitempty"`
 	ReferenceID   *primitive.ObjectID `bson:"reference_id,omitempty" json:"reference_id,omitempty"`
 	ReferenceType string              `bson:"reference_type,omitempty" json:"reference_type,omitempty"`
+	LocationID    *primitive.ObjectID `bson:"location_id,omitempty" json:"location_id,omitempty"`
 	CreatedBy     primitive.ObjectID  `bson:"created_by" json:"created_by"`
 	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
 }
@@ -62,23 +86,39 @@ const (
 )
 
 type CreateProductRequest struct {
-	Name         string  `json:"name" validate:"required"`
-	Description  string  `json:"description,omitempty"`
-	SKU          string  `json:"sku,omitempty"`
-	Barcode      string  `json:"barcode,omitempty"`
-	Category     string  `json:"category,omitempty"`
-	Unit         string  `json:"unit,omitempty"`
-	CostPrice    float64 `json:"cost_price" validate:"required,gt=0"`
-	SellingPrice float64 `json:"selling_price" validate:"required,gt=0"`
-	Stock        float64 `json:"stock" validate:"gte=0"`
-	MinStock     float64 `json:"min_stock,omitempty"`
-	MaxStock     float64 `json:"max_stock,omitempty"`
+	Name         string                   `json:"name" validate:"required"`
+	Description  string                   `json:"description,omitempty"`
+	SKU          string                   `json:"sku,omitempty"`
+	Barcode      string                   `json:"barcode,omitempty"`
+	CategoryID   string                   `json:"category_id,omitempty"`
+	Category     string                   `json:"category,omitempty"` // Category name, used when no ID is given; created when it does not exist
+	Unit         string                   `json:"unit,omitempty"`
+	CostPrice    float64                  `json:"cost_price" validate:"required,gt=0"`
+	SellingPrice float64                  `json:"selling_price" validate:"required,gt=0"`
+	Stock        float64                  `json:"stock" validate:"gte=0"`
+	MinStock     float64                  `json:"min_stock,omitempty"`
+	MaxStock     float64                  `json:"max_stock,omitempty"`
+	TrackBatches bool                     `json:"track_batches,omitempty"`
+	TrackSerials bool                     `json:"track_serials,omitempty"`
+	SupplierID   *string                  `json:"supplier_id,omitempty"`
+	LeadTimeDays int                      `json:"lead_time_days,omitempty"`
+	LocationID   *string                  `json:"location_id,omitempty"` // Where the opening stock is held; defaults to the default location
+	Type         ProductType              `json:"type,omitempty"`        // standard (default) or bundle
+	Components   []BundleComponentRequest `json:"components,omitempty"`  // Required for bundles; replaces the existing lines on update
+	// WarrantyMonths applies to serialised products; zero leaves sold units
+	// without a warranty expiry.
+	WarrantyMonths int `json:"warranty_months,omitempty"`
+	// NegativeStock is block, warn or allow; empty keeps the current policy
+	// and inherit falls back to the business policy.
+	NegativeStock NegativeStockPolicy `json:"negative_stock,omitempty"`
 }
 
 type AdjustStockRequest struct {
-	Quantity float64      `json:"quantity" validate:"required"`
-	Type     MovementType `json:"type" validate:"required"`
-	Reason   string       `json:"reason" validate:"required"`
+	Quantity   float64      `json:"quantity" validate:"required"`
+	Type       MovementType `json:"type" validate:"required"`
+	Reason     string       `json:"reason" validate:"required"`
+	UnitCost   float64      `json:"unit_cost,omitempty"`   // Purchase cost per unit; defaults to the product cost price
+	LocationID *string      `json:"location_id,omitempty"` // Defaults to the default location
 }
 
 type ProductRepository interface {
@@ -87,16 +127,45 @@ type ProductRepository interface {
 	FindByBusinessID(businessID string, filters ProductFilters) ([]Product, error)
 	Update(product *Product) error
 	Delete(id string) error
-	AdjustStock(productID string, quantity float64, movementType MovementType, reason string, referenceID *string, referenceType string, userID string) error
+	// AdjustStock moves stock and records the movement. Stock going out fails
+	// when there is not enough of it, unless allowNegative is set.
+	AdjustStock(productID string, quantity float64, movementType MovementType, reason string, referenceID *string, referenceType string, userID string, locationID *string, allowNegative bool) (*StockMovement, error)
 	GetLowStock(businessID string, threshold float64) ([]Product, error)
 	GetStockHistory(productID string, limit int) ([]StockMovement, error)
+	// GetMovementsUntil returns the stock movements of a business made up to
+	// a point in time, oldest first.
+	GetMovementsUntil(businessID string, until time.Time) ([]StockMovement, error)
+	SetMovementCost(movementID string, unitCost, totalCost float64) error
+	UpdateAverageCost(productID string, averageCost float64) error
+	// GetDemand returns the net quantity of each product sold since a date:
+	// sale movements less returns.
+	GetDemand(businessID string, since time.Time) ([]ProductDemand, error)
+	// FindByBarcode returns the product a barcode is scanned as, leaving out
+	// discontinued products.
+	FindByBarcode(businessID, barcode string) (*Product, error)
+	SetBarcode(productID, barcode string) error
+	// MoveCategory moves every product of a category to another one, or
+	// renames it on the products when both IDs are the same.
+	MoveCategory(fromID, toID primitive.ObjectID, name string) (int64, error)
+	// EnsureIndexes creates the indexes products rely on, including the
+	// unique barcode per business. When products already share a barcode the
+	// error names them.
+	EnsureIndexes() error
+}
+
+type ProductDemand struct {
+	ProductID primitive.ObjectID `bson:"_id" json:"product_id"`
+	Quantity  float64            `bson:"quantity" json:"quantity"`
 }
 
 type ProductFilters struct {
-	Category *string
-	Status   *ProductStatus
-	LowStock *bool
-	Search   *string
-	Limit    int
-	Offset   int
+	// Category is a category ID or name; it matches the category together
+	// with everything below it once resolved into CategoryIDs.
+	Category    *string
+	CategoryIDs []primitive.ObjectID
+	Status      *ProductStatus
+	LowStock    *bool
+	Search      *string
+	Limit       int
+	Offset      int
 }
@@ -0,0 +1,169 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultLeadTimeDays is assumed when neither the product nor its supplier
+// has a lead time.
+const DefaultLeadTimeDays = 7
+
+type Supplier struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	BusinessID   primitive.ObjectID `bson:"business_id" json:"business_id"`
+	Name         string             `bson:"name" json:"name" validate:"required"`
+	ContactName  string             `bson:"contact_name,omitempty" json:"contact_name,omitempty"`
+	Phone        string             `bson:"phone,omitempty" json:"phone,omitempty"`
+	Email        string             `bson:"email,omitempty" json:"email,omitempty"`
+	LeadTimeDays int                `bson:"lead_time_days" json:"lead_time_days"` // Days from ordering to delivery
+	Notes        string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	Status       SupplierStatus     `bson:"status" json:"status"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type SupplierStatus string
+
+const (
+	SupplierStatusActive   SupplierStatus = "active"
+	SupplierStatusInactive SupplierStatus = "inactive"
+)
+
+type CreateSupplierRequest struct {
+	Name         string `json:"name" validate:"required"`
+	ContactName  string `json:"contact_name,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	Email        string `json:"email,omitempty"`
+	LeadTimeDays int    `json:"lead_time_days,omitempty"`
+	Notes        string `json:"notes,omitempty"`
+}
+
+type UpdateSupplierRequest struct {
+	Name         string         `json:"name,omitempty"`
+	ContactName  string         `json:"contact_name,omitempty"`
+	Phone        string         `json:"phone,omitempty"`
+	Email        string         `json:"email,omitempty"`
+	LeadTimeDays *int           `json:"lead_time_days,omitempty"`
+	Notes        string         `json:"notes,omitempty"`
+	Status       SupplierStatus `json:"status,omitempty"`
+}
+
+// ReorderOptions tune the reorder advisor. Zero values fall back to the
+// defaults noted on each field.
+type ReorderOptions struct {
+	LookbackDays int    // Sales history used for demand; 30 days
+	SafetyDays   int    // Extra cover held against demand spikes; 7 days
+	CoverDays    int    // Cover ordered beyond the reorder point when the product has no maximum stock; 30 days
+	SupplierID   string // Only products of this supplier
+}
+
+// ReorderSuggestion is the advice for one product. A product is due for
+// reorder when its stock, including stock already on order, falls to the
+// demand expected over the lead time plus safety days.
+type ReorderSuggestion struct {
+	ProductID          string   `json:"product_id"`
+	ProductName        string   `json:"product_name"`
+	SKU                string   `json:"sku,omitempty"`
+	Unit               string   `json:"unit,omitempty"`
+	CurrentStock       float64  `json:"current_stock"`
+	OnOrder            float64  `json:"on_order"` // Quantity on open purchase orders
+	MinStock           float64  `json:"min_stock"`
+	MaxStock           float64  `json:"max_stock"`
+	SoldInPeriod       float64  `json:"sold_in_period"`
+	AverageDailyDemand float64  `json:"average_daily_demand"`
+	DaysOfCover        *float64 `json:"days_of_cover,omitempty"` // Nil when the product has not sold
+	LeadTimeDays       int      `json:"lead_time_days"`
+	ReorderPoint       float64  `json:"reorder_point"`
+	SuggestedQuantity  float64  `json:"suggested_quantity"`
+	UnitCost           float64  `json:"unit_cost"`
+	EstimatedCost      float64  `json:"estimated_cost"`
+}
+
+// SupplierReorderGroup holds the suggestions of one supplier. Products
+// without a supplier are grouped under an empty supplier ID.
+type SupplierReorderGroup struct {
+	SupplierID    string              `json:"supplier_id,omitempty"`
+	SupplierName  string              `json:"supplier_name"`
+	Items         []ReorderSuggestion `json:"items"`
+	EstimatedCost float64             `json:"estimated_cost"`
+}
+
+type ReorderSuggestionsReport struct {
+	LookbackDays  int                    `json:"lookback_days"`
+	SafetyDays    int                    `json:"safety_days"`
+	GeneratedAt   time.Time              `json:"generated_at"`
+	TotalItems    int                    `json:"total_items"`
+	EstimatedCost float64                `json:"estimated_cost"`
+	Suppliers     []SupplierReorderGroup `json:"suppliers"`
+}
+
+type PurchaseOrder struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BusinessID   primitive.ObjectID  `bson:"business_id" json:"business_id"`
+	SupplierID   *primitive.ObjectID `bson:"supplier_id,omitempty" json:"supplier_id,omitempty"`
+	SupplierName string              `bson:"supplier_name" json:"supplier_name"`
+	Items        []PurchaseOrderItem `bson:"items" json:"items"`
+	TotalCost    float64             `bson:"total_cost" json:"total_cost"`
+	Status       PurchaseOrderStatus `bson:"status" json:"status"`
+	Notes        string              `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedBy    primitive.ObjectID  `bson:"created_by" json:"created_by"`
+	OrderedAt    *time.Time          `bson:"ordered_at,omitempty" json:"ordered_at,omitempty"`
+	ReceivedBy   *primitive.ObjectID `bson:"received_by,omitempty" json:"received_by,omitempty"`
+	ReceivedAt   *time.Time          `bson:"received_at,omitempty" json:"received_at,omitempty"`
+	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft     PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusOrdered   PurchaseOrderStatus = "ordered"
+	PurchaseOrderStatusReceived  PurchaseOrderStatus = "received"
+	PurchaseOrderStatusCancelled PurchaseOrderStatus = "cancelled"
+)
+
+type PurchaseOrderItem struct {
+	ProductID   primitive.ObjectID `bson:"product_id" json:"product_id"`
+	ProductName string             `bson:"product_name" json:"product_name"`
+	SKU         string             `bson:"sku,omitempty" json:"sku,omitempty"`
+	Quantity    float64            `bson:"quantity" json:"quantity"`
+	UnitCost    float64            `bson:"unit_cost" json:"unit_cost"`
+	TotalCost   float64            `bson:"total_cost" json:"total_cost"`
+}
+
+// CreateDraftOrdersRequest turns the current reorder suggestions into one
+// draft purchase order per supplier.
+type CreateDraftOrdersRequest struct {
+	SupplierID   string   `json:"supplier_id,omitempty"`   // Only draft the order of this supplier
+	ProductIDs   []string `json:"product_ids,omitempty"`   // Only include these products; all suggestions when empty
+	LookbackDays int      `json:"lookback_days,omitempty"` // Defaults to 30
+	SafetyDays   int      `json:"safety_days,omitempty"`   // Defaults to 7
+	CoverDays    int      `json:"cover_days,omitempty"`    // Defaults to 30
+	Notes        string   `json:"notes,omitempty"`
+}
+
+type ReceivePurchaseOrderRequest struct {
+	LocationID *string `json:"location_id,omitempty"` // Defaults to the default location
+	// Serials holds the serial numbers received for each serialised product,
+	// keyed by product ID, one per unit ordered.
+	Serials map[string][]string `json:"serials,omitempty"`
+}
+
+type SupplierRepository interface {
+	Create(supplier *Supplier) error
+	FindByID(id string) (*Supplier, error)
+	FindByBusinessID(businessID string) ([]Supplier, error)
+	Update(supplier *Supplier) error
+}
+
+type PurchaseOrderRepository interface {
+	Create(order *PurchaseOrder) error
+	FindByID(id string) (*PurchaseOrder, error)
+	FindByBusinessID(businessID string, statuses []PurchaseOrderStatus) ([]PurchaseOrder, error)
+	// UpdateStatus moves an order to a new status, failing unless it is
+	// currently in one of the given statuses.
+	UpdateStatus(id string, status PurchaseOrderStatus, from []PurchaseOrderStatus, userID string) error
+}
@@ -0,0 +1,84 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Recipe links a menu item to the ingredients one unit of it consumes. Selling
+// the item back-flushes the ingredients out of stock instead of taking the
+// item's own stock.
+type Recipe struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	BusinessID  primitive.ObjectID `bson:"business_id" json:"business_id"`
+	ProductID   primitive.ObjectID `bson:"product_id" json:"product_id"` // The menu item
+	Ingredients []RecipeIngredient `bson:"ingredients" json:"ingredients"`
+	Notes       string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	Cost        float64            `bson:"-" json:"cost"` // Ingredient cost of one unit, waste included
+	CreatedBy   primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// RecipeIngredient is a quantity of an ingredient, in the ingredient's own
+// unit (e.g. 0.018 kg of coffee), used for one unit of the menu item.
+type RecipeIngredient struct {
+	ProductID   primitive.ObjectID `bson:"product_id" json:"product_id"`
+	Quantity    float64            `bson:"quantity" json:"quantity"`
+	WasteFactor float64            `bson:"waste_factor,omitempty" json:"waste_factor,omitempty"` // Fraction lost in preparation, e.g. 0.05 for 5%
+}
+
+// Usage is the quantity of the ingredient consumed for one unit of the menu
+// item, waste included.
+func (i RecipeIngredient) Usage() float64 {
+	return i.Quantity * (1 + i.WasteFactor)
+}
+
+type RecipeIngredientRequest struct {
+	ProductID   string  `json:"product_id" validate:"required"`
+	Quantity    float64 `json:"quantity" validate:"required,gt=0"`
+	WasteFactor float64 `json:"waste_factor,omitempty"`
+}
+
+type SetRecipeRequest struct {
+	Ingredients []RecipeIngredientRequest `json:"ingredients" validate:"required"`
+	Notes       string                    `json:"notes,omitempty"`
+}
+
+// IngredientUsage compares what the recipes say an ingredient should have
+// used with what a stocktake shows was actually used.
+type IngredientUsage struct {
+	ProductID        primitive.ObjectID `json:"product_id"`
+	ProductName      string             `json:"product_name"`
+	Unit             string             `json:"unit,omitempty"`
+	TheoreticalUsage float64            `json:"theoretical_usage"` // Back-flushed by sales of menu items
+	ActualUsage      float64            `json:"actual_usage"`      // Theoretical usage plus the stocktake shortfall
+	Variance         float64            `json:"variance"`          // Actual minus theoretical
+	VariancePercent  float64            `json:"variance_percent"`  // Variance as a share of theoretical usage
+	UnitCost         float64            `json:"unit_cost"`
+	VarianceValue    float64            `json:"variance_value"`
+}
+
+// UsageReport covers the period between the previous stocktake and the one
+// the actual usage is taken from.
+type UsageReport struct {
+	StocktakeID           primitive.ObjectID `json:"stocktake_id"`
+	StocktakeName         string             `json:"stocktake_name"`
+	StartDate             time.Time          `json:"start_date"`
+	EndDate               time.Time          `json:"end_date"`
+	Items                 []IngredientUsage  `json:"items"`
+	Uncounted             []string           `json:"uncounted,omitempty"` // Ingredients the stocktake did not count
+	TotalTheoreticalValue float64            `json:"total_theoretical_value"`
+	TotalActualValue      float64            `json:"total_actual_value"`
+	TotalVarianceValue    float64            `json:"total_variance_value"`
+	GeneratedAt           time.Time          `json:"generated_at"`
+}
+
+type RecipeRepository interface {
+	// Save creates the recipe of its product or replaces the existing one.
+	Save(recipe *Recipe) error
+	FindByProductID(productID string) (*Recipe, error)
+	FindByBusinessID(businessID string) ([]Recipe, error)
+	Delete(productID string) error
+}
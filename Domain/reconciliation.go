@@ -0,0 +1,162 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SettledPaymentMethods are the methods whose takings are settled into the
+// business's hands separately and can be reconciled: the cash counted, and
+// the card, mobile money and bank transfer settlements.
+var SettledPaymentMethods = []PaymentMethod{
+	PaymentMethodCash,
+	PaymentMethodCard,
+	PaymentMethodMobile,
+	PaymentMethodBank,
+}
+
+// IsSettledPaymentMethod reports whether takings by the method are reconciled.
+func IsSettledPaymentMethod(method PaymentMethod) bool {
+	for _, settled := range SettledPaymentMethods {
+		if method == settled {
+			return true
+		}
+	}
+	return false
+}
+
+// ReconciliationTolerance is the largest variance that still reconciles, to
+// absorb rounding.
+const ReconciliationTolerance = 0.005
+
+type ReconciliationStatus string
+
+const (
+	ReconciliationUnreconciled ReconciliationStatus = "unreconciled" // No settlement entered yet
+	ReconciliationReconciled   ReconciliationStatus = "reconciled"   // The settlement matches the takings
+	ReconciliationDiscrepancy  ReconciliationStatus = "discrepancy"  // The settlement differs from the takings
+	ReconciliationNotRequired  ReconciliationStatus = "not_required" // Credit and other methods are not settled
+)
+
+// ReconciliationStatusOf compares a settlement with the takings it should
+// match; a nil actual amount means none was entered.
+func ReconciliationStatusOf(expected float64, actual *float64) ReconciliationStatus {
+	if actual == nil {
+		return ReconciliationUnreconciled
+	}
+	variance := *actual - expected
+	if variance < ReconciliationTolerance && variance > -ReconciliationTolerance {
+		return ReconciliationReconciled
+	}
+	return ReconciliationDiscrepancy
+}
+
+// PaymentSettlement is the amount actually received for one payment method
+// on one day, as entered by the owner from a bank deposit, card settlement or
+// mobile money statement. Expected, Variance and Status are as they stood
+// when it was entered; the reconciliation report compares it with the
+// takings as they stand now.
+type PaymentSettlement struct {
+	ID            primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	BusinessID    primitive.ObjectID   `bson:"business_id" json:"business_id"`
+	Date          string               `bson:"date" json:"date"` // YYYY-MM-DD in the business's timezone
+	PaymentMethod PaymentMethod        `bson:"payment_method" json:"payment_method"`
+	Expected      float64              `bson:"expected" json:"expected"`
+	Actual        float64              `bson:"actual" json:"actual"`
+	Variance      float64              `bson:"variance" json:"variance"` // Actual less expected
+	Status        ReconciliationStatus `bson:"status" json:"status"`
+	Reference     string               `bson:"reference,omitempty" json:"reference,omitempty"` // Deposit slip or statement reference
+	Notes         string               `bson:"notes,omitempty" json:"notes,omitempty"`
+	RecordedBy    primitive.ObjectID   `bson:"recorded_by" json:"recorded_by"`
+	CreatedAt     time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// RecordSettlementRequest enters the amount received for a method on a day,
+// replacing any amount entered before.
+type RecordSettlementRequest struct {
+	Date          string        `json:"date" validate:"required"` // YYYY-MM-DD
+	PaymentMethod PaymentMethod `json:"payment_method" validate:"required"`
+	Actual        *float64      `json:"actual" validate:"required"`
+	Reference     string        `json:"reference,omitempty"`
+	Notes         string        `json:"notes,omitempty"`
+}
+
+// PaymentReconciliationRequest is the range of a reconciliation report, in
+// calendar days in the business's timezone with both ends included. It
+// defaults to the last 7 days up to today.
+type PaymentReconciliationRequest struct {
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+}
+
+// PaymentReconciliationReport totals completed sales by payment method and
+// payment status per day, and sets the paid takings of each settled method
+// against the amount received.
+type PaymentReconciliationReport struct {
+	Period        ComparisonPeriod           `json:"period"`
+	Timezone      string                     `json:"timezone"`
+	Expected      float64                    `json:"expected"` // Paid takings of the settled methods
+	Actual        float64                    `json:"actual"`   // Settlements entered
+	Variance      float64                    `json:"variance"` // Over the days and methods with a settlement
+	Unreconciled  int                        `json:"unreconciled"`
+	Discrepancies int                        `json:"discrepancies"`
+	Methods       []PaymentMethodSummary     `json:"methods"`
+	Days          []PaymentReconciliationDay `json:"days"` // Days with sales or settlements, oldest first
+}
+
+// PaymentMethodSummary totals a payment method over the report's period.
+type PaymentMethodSummary struct {
+	PaymentMethod PaymentMethod        `json:"payment_method"`
+	Transactions  int                  `json:"transactions"`
+	Amount        float64              `json:"amount"`   // Every payment status
+	Expected      float64              `json:"expected"` // Paid sales only
+	Actual        float64              `json:"actual"`
+	Variance      float64              `json:"variance"`
+	Statuses      []PaymentStatusTotal `json:"statuses"`
+}
+
+type PaymentReconciliationDay struct {
+	Date    string                      `json:"date"`
+	Methods []PaymentReconciliationLine `json:"methods"`
+}
+
+// PaymentReconciliationLine is one payment method on one day.
+type PaymentReconciliationLine struct {
+	PaymentMethod PaymentMethod        `json:"payment_method"`
+	Transactions  int                  `json:"transactions"`
+	Amount        float64              `json:"amount"`
+	Expected      float64              `json:"expected"`
+	Actual        *float64             `json:"actual,omitempty"`   // Empty until a settlement is entered
+	Variance      *float64             `json:"variance,omitempty"` // Actual less expected
+	Status        ReconciliationStatus `json:"status"`
+	Statuses      []PaymentStatusTotal `json:"statuses"`
+	Settlement    *PaymentSettlement   `json:"settlement,omitempty"`
+}
+
+type PaymentStatusTotal struct {
+	PaymentStatus PaymentStatus `json:"payment_status"`
+	Transactions  int           `json:"transactions"`
+	Amount        float64       `json:"amount"`
+}
+
+// PaymentTotal is the completed sales of one day, payment method and payment
+// status.
+type PaymentTotal struct {
+	Date          string        `bson:"date"`
+	PaymentMethod PaymentMethod `bson:"payment_method"`
+	PaymentStatus PaymentStatus `bson:"payment_status"`
+	Transactions  int           `bson:"transactions"`
+	Amount        float64       `bson:"amount"`
+}
+
+type PaymentSettlementRepository interface {
+	// Save stores the settlement of its day and method, replacing the one
+	// entered before.
+	Save(settlement *PaymentSettlement) error
+	// FindRange returns the settlements of the days from startDate to
+	// endDate, both YYYY-MM-DD and included.
+	FindRange(businessID string, startDate, endDate string) ([]PaymentSettlement, error)
+	EnsureIndexes() error
+}
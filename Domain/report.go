@@ -9,6 +9,11 @@ const (
 	ReportTypeExpenses  ReportType = "expenses"
 	ReportTypeProfit    ReportType = "profit"
 	ReportTypeInventory ReportType = "inventory"
+	ReportTypeStocktake ReportType = "stocktake"
+	ReportTypeCatalog   ReportType = "catalog"
+	ReportTypeAging     ReportType = "aging"
+	ReportTypeHeatmap   ReportType = "heatmap"
+	ReportTypeABC       ReportType = "abc"
 )
 
 type PeriodType string
@@ -28,7 +33,8 @@ type ReportRequest struct {
 	StartDate  *time.Time `json:"start_date,omitempty"`
 	EndDate    *time.Time `json:"end_date,omitempty"`
 	Category   *string    `json:"category,omitempty"`
-	Format     *string    `json:"format,omitempty"` // json, csv
+	Format     *string    `json:"format,omitempty"` // json, csv, pdf, xlsx
+	LocationID *string    `json:"location_id,omitempty"`
 }
 
 type SalesReport struct {
@@ -39,6 +45,21 @@ type SalesReport struct {
 	AverageSale       float64      `json:"average_sale"`
 	TopProducts       []TopProduct `json:"top_products,omitempty"`
 	DailyBreakdown    []DailySales `json:"daily_breakdown,omitempty"`
+	// Categories covers every level of the category tree; the figures of a
+	// category include those of its subcategories.
+	Categories []CategorySales `json:"categories,omitempty"`
+}
+
+type CategorySales struct {
+	CategoryID  string  `json:"category_id,omitempty"` // Empty for products without a category
+	Name        string  `json:"name"`
+	ParentID    string  `json:"parent_id,omitempty"`
+	Depth       int     `json:"depth"`
+	Quantity    float64 `json:"quantity"`
+	Revenue     float64 `json:"revenue"`
+	CostOfGoods float64 `json:"cost_of_goods"`
+	GrossProfit float64 `json:"gross_profit"`
+	GrossMargin float64 `json:"gross_margin"`
 }
 
 type TopProduct struct {
@@ -46,6 +67,7 @@ type TopProduct struct {
 	ProductName string  `json:"product_name"`
 	Quantity    float64 `json:"quantity"`
 	TotalAmount float64 `json:"total_amount"`
+	CostOfGoods float64 `json:"cost_of_goods,omitempty"` // Set by GetProductSales
 }
 
 type DailySales struct {
@@ -76,13 +98,18 @@ type DailyExpense struct {
 }
 
 type ProfitReport struct {
-	Period        string        `json:"period"`
-	TotalSales    float64       `json:"total_sales"`
-	TotalExpenses float64       `json:"total_expenses"`
-	GrossProfit   float64       `json:"gross_profit"`
-	NetProfit     float64       `json:"net_profit"`
-	ProfitMargin  float64       `json:"profit_margin"`
-	Trends        []ProfitTrend `json:"trends,omitempty"`
+	Period            string        `json:"period"`
+	TotalSales        float64       `json:"total_sales"`
+	TotalExpenses     float64       `json:"total_expenses"`
+	Revenue           float64       `json:"revenue"`
+	CostOfGoodsSold   float64       `json:"cost_of_goods_sold"`
+	GrossProfit       float64       `json:"gross_profit"`
+	GrossMargin       float64       `json:"gross_margin"`
+	OperatingExpenses float64       `json:"operating_expenses"` // Expenses other than stock purchases
+	StockPurchases    float64       `json:"stock_purchases"`    // Already counted through cost of goods sold
+	NetProfit         float64       `json:"net_profit"`
+	ProfitMargin      float64       `json:"profit_margin"`
+	Trends            []ProfitTrend `json:"trends,omitempty"`
 }
 
 type ProfitTrend struct {
@@ -93,11 +120,31 @@ type ProfitTrend struct {
 }
 
 type InventoryReport struct {
-	TotalProducts int             `json:"total_products"`
-	TotalStock    float64         `json:"total_stock"`
-	TotalValue    float64         `json:"total_value"`
-	LowStockItems []LowStockItem  `json:"low_stock_items"`
-	StockMovement []StockMovement `json:"stock_movement,omitempty"`
+	LocationID    string                 `json:"location_id,omitempty"` // Set when the report covers a single location
+	TotalProducts int                    `json:"total_products"`
+	TotalStock    float64                `json:"total_stock"`
+	TotalValue    float64                `json:"total_value"`
+	LowStockItems []LowStockItem         `json:"low_stock_items"`
+	Locations     []LocationStockSummary `json:"locations,omitempty"` // Stock per location in the aggregate report
+	StockMovement []StockMovement        `json:"stock_movement,omitempty"`
+	Categories    []CategoryStock        `json:"categories,omitempty"` // Every level of the category tree, including subcategories
+}
+
+type CategoryStock struct {
+	CategoryID string  `json:"category_id,omitempty"` // Empty for products without a category
+	Name       string  `json:"name"`
+	ParentID   string  `json:"parent_id,omitempty"`
+	Depth      int     `json:"depth"`
+	Products   int     `json:"products"`
+	Stock      float64 `json:"stock"`
+	StockValue float64 `json:"stock_value"`
+}
+
+type LocationStockSummary struct {
+	LocationID   string  `json:"location_id"`
+	LocationName string  `json:"location_name"`
+	TotalStock   float64 `json:"total_stock"`
+	TotalValue   float64 `json:"total_value"`
 }
 
 type LowStockItem struct {
@@ -120,13 +167,34 @@ type DashboardData struct {
 	MonthProfit     float64 `json:"month_profit"`
 	LowStockCount   int     `json:"low_stock_count"`
 	PendingPayments float64 `json:"pending_payments"`
+	// NegativeStock lists the products currently below zero, sold before
+	// their receipts were entered.
+	NegativeStockCount int                 `json:"negative_stock_count"`
+	NegativeStock      []NegativeStockItem `json:"negative_stock"`
 }
 
 type ReportRepository interface {
 	GenerateSalesReport(businessID string, startDate, endDate time.Time) (*SalesReport, error)
 	GenerateExpensesReport(businessID string, startDate, endDate time.Time) (*ExpensesReport, error)
 	GenerateProfitReport(businessID string, startDate, endDate time.Time) (*ProfitReport, error)
-	GenerateInventoryReport(businessID string) (*InventoryReport, error)
-	GetDashboardData(businessID string) (*DashboardData, error)
+	GenerateInventoryReport(businessID string, locationID *string) (*InventoryReport, error)
+	// GetDashboardData reports the days leading up to now, with day
+	// boundaries taken from now's location.
+	GetDashboardData(businessID string, now time.Time) (*DashboardData, error)
+	// GetProductSales returns the quantity, revenue and cost of goods of
+	// every product sold in the range, best selling first.
+	GetProductSales(businessID string, startDate, endDate time.Time) ([]TopProduct, error)
+	// GetLastSaleDates returns when each product was last sold, directly or
+	// as a component of a bundle or recipe.
+	GetLastSaleDates(businessID string) ([]ProductActivity, error)
+	// GetLastReceiptDates returns when stock of each product was last
+	// purchased or received.
+	GetLastReceiptDates(businessID string) ([]ProductActivity, error)
+	// GetSalesHeatmap counts completed sales in the range by day of the week
+	// and hour of the day, read in startDate's location.
+	GetSalesHeatmap(businessID string, startDate, endDate time.Time, filters SalesHeatmapFilters) ([]HeatmapCount, error)
+	// GetPaymentTotals totals completed sales in the range by day, payment
+	// method and payment status, with days read in startDate's location.
+	GetPaymentTotals(businessID string, startDate, endDate time.Time) ([]PaymentTotal, error)
 	ExportCSV(report interface{}, reportType ReportType) ([]byte, error)
 }
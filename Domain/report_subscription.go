@@ -0,0 +1,176 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReportSchedule is how often a subscribed report is sent.
+type ReportSchedule string
+
+const (
+	ReportScheduleDaily   ReportSchedule = "daily"
+	ReportScheduleWeekly  ReportSchedule = "weekly"  // On Weekday
+	ReportScheduleMonthly ReportSchedule = "monthly" // On DayOfMonth
+)
+
+// MaxReportDeliveryAttempts is how many times a report is tried before its
+// delivery is marked failed.
+const MaxReportDeliveryAttempts = 4
+
+// ReportSubscription sends a user a report by email on a schedule. Each report
+// covers the last full period before it is sent: yesterday for a daily
+// report, the seven days to yesterday for a weekly one, and the previous
+// calendar month or year for a monthly or yearly one.
+type ReportSubscription struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	BusinessID primitive.ObjectID `bson:"business_id" json:"business_id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Email      string             `bson:"email" json:"email"`
+	ReportType ReportType         `bson:"report_type" json:"report_type"`
+	Period     PeriodType         `bson:"period" json:"period"`
+	Format     string             `bson:"format" json:"format"` // pdf, xlsx or csv
+	Schedule   ReportSchedule     `bson:"schedule" json:"schedule"`
+	Weekday    int                `bson:"weekday" json:"weekday"`           // 0 (Sunday) to 6, for weekly reports
+	DayOfMonth int                `bson:"day_of_month" json:"day_of_month"` // 1 to 28, for monthly reports
+	Hour       int                `bson:"hour" json:"hour"`                 // Hour of the day in the business's timezone
+	Active     bool               `bson:"active" json:"active"`
+	NextRunAt  time.Time          `bson:"next_run_at" json:"next_run_at"`
+	LastRunAt  *time.Time         `bson:"last_run_at,omitempty" json:"last_run_at,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// NextRun returns the first scheduled time after the given time, in loc.
+func (s *ReportSubscription) NextRun(after time.Time, loc *time.Location) time.Time {
+	local := after.In(loc)
+	day := StartOfDay(local)
+
+	for {
+		if s.runsOn(day) {
+			run := time.Date(day.Year(), day.Month(), day.Day(), s.Hour, 0, 0, 0, loc)
+			if run.After(after) {
+				return run
+			}
+		}
+		day = AddDays(day, 1)
+	}
+}
+
+func (s *ReportSubscription) runsOn(day time.Time) bool {
+	switch s.Schedule {
+	case ReportScheduleWeekly:
+		return int(day.Weekday()) == s.Weekday
+	case ReportScheduleMonthly:
+		return day.Day() == s.DayOfMonth
+	default:
+		return true
+	}
+}
+
+// CoveredPeriod returns the calendar days, in loc, that a report sent at runAt
+// covers.
+func (s *ReportSubscription) CoveredPeriod(runAt time.Time, loc *time.Location) (time.Time, time.Time) {
+	today := StartOfDay(runAt.In(loc))
+	yesterday := AddDays(today, -1)
+
+	switch s.Period {
+	case PeriodTypeWeekly:
+		return AddDays(today, -7), yesterday
+	case PeriodTypeMonthly:
+		thisMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return DateIn(thisMonth.AddDate(0, -1, 0), loc), AddDays(DateIn(thisMonth, loc), -1)
+	case PeriodTypeYearly:
+		thisYear := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return DateIn(thisYear.AddDate(-1, 0, 0), loc), AddDays(DateIn(thisYear, loc), -1)
+	default:
+		return yesterday, yesterday
+	}
+}
+
+type CreateReportSubscriptionRequest struct {
+	Email      string         `json:"email,omitempty" validate:"omitempty,email"` // Defaults to the user's email
+	ReportType ReportType     `json:"report_type" validate:"required"`
+	Period     PeriodType     `json:"period,omitempty"` // Defaults to the schedule's period
+	Format     string         `json:"format,omitempty"` // Defaults to pdf
+	Schedule   ReportSchedule `json:"schedule" validate:"required"`
+	Weekday    *int           `json:"weekday,omitempty"`      // Defaults to Monday
+	DayOfMonth *int           `json:"day_of_month,omitempty"` // Defaults to 1
+	Hour       *int           `json:"hour,omitempty"`         // Defaults to 7
+}
+
+type UpdateReportSubscriptionRequest struct {
+	Email      *string         `json:"email,omitempty" validate:"omitempty,email"`
+	ReportType *ReportType     `json:"report_type,omitempty"`
+	Period     *PeriodType     `json:"period,omitempty"`
+	Format     *string         `json:"format,omitempty"`
+	Schedule   *ReportSchedule `json:"schedule,omitempty"`
+	Weekday    *int            `json:"weekday,omitempty"`
+	DayOfMonth *int            `json:"day_of_month,omitempty"`
+	Hour       *int            `json:"hour,omitempty"`
+	Active     *bool           `json:"active,omitempty"`
+}
+
+type ReportDeliveryStatus string
+
+const (
+	ReportDeliveryPending ReportDeliveryStatus = "pending" // Waiting for its first or next attempt
+	ReportDeliverySent    ReportDeliveryStatus = "sent"
+	ReportDeliveryFailed  ReportDeliveryStatus = "failed" // Gave up after MaxReportDeliveryAttempts
+)
+
+// ReportDelivery is one report sent, or being sent, for a subscription.
+type ReportDelivery struct {
+	ID             primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	SubscriptionID primitive.ObjectID   `bson:"subscription_id" json:"subscription_id"`
+	BusinessID     primitive.ObjectID   `bson:"business_id" json:"business_id"`
+	UserID         primitive.ObjectID   `bson:"user_id" json:"user_id"`
+	Email          string               `bson:"email" json:"email"`
+	ReportType     ReportType           `bson:"report_type" json:"report_type"`
+	Format         string               `bson:"format" json:"format"`
+	PeriodStart    time.Time            `bson:"period_start" json:"period_start"`
+	PeriodEnd      time.Time            `bson:"period_end" json:"period_end"`
+	Status         ReportDeliveryStatus `bson:"status" json:"status"`
+	Attempts       int                  `bson:"attempts" json:"attempts"`
+	NextAttemptAt  *time.Time           `bson:"next_attempt_at,omitempty" json:"next_attempt_at,omitempty"`
+	LastError      string               `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	Filename       string               `bson:"filename,omitempty" json:"filename,omitempty"`
+	SentAt         *time.Time           `bson:"sent_at,omitempty" json:"sent_at,omitempty"`
+	CreatedAt      time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+type ReportDeliveryFilters struct {
+	SubscriptionID *string
+	Status         *ReportDeliveryStatus
+	Limit          int
+}
+
+type ReportSubscriptionRepository interface {
+	Create(subscription *ReportSubscription) error
+	FindByID(id string) (*ReportSubscription, error)
+	FindByUserID(businessID, userID string) ([]ReportSubscription, error)
+	Update(subscription *ReportSubscription) error
+	Delete(id string) error
+	// FindDue returns active subscriptions whose next run has come, oldest
+	// first.
+	FindDue(now time.Time, limit int) ([]ReportSubscription, error)
+	// AdvanceRun moves a subscription from the run at from to the run at next,
+	// and reports false when another server got there first.
+	AdvanceRun(id string, from, next time.Time) (bool, error)
+}
+
+type ReportDeliveryRepository interface {
+	Create(delivery *ReportDelivery) error
+	FindByID(id string) (*ReportDelivery, error)
+	FindByUserID(businessID, userID string, filters ReportDeliveryFilters) ([]ReportDelivery, error)
+	// FindDue returns pending deliveries whose next attempt has come, oldest
+	// first.
+	FindDue(now time.Time, limit int) ([]ReportDelivery, error)
+	// Claim takes a pending delivery due at dueAt for an attempt, holding it
+	// until leaseUntil so a server that stops mid-attempt leaves it to be
+	// tried again. It reports false when another server got there first.
+	Claim(id string, dueAt, leaseUntil time.Time) (bool, error)
+	Update(delivery *ReportDelivery) error
+}
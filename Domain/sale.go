@@ -7,27 +7,42 @@ import (
 )
 
 type Sale struct {
-	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
-	BusinessID    primitive.ObjectID  `bson:"business_id" json:"business_id"`
-	LocalID       string              `bson:"local_id,omitempty" json:"local_id,omitempty"` // For offline sync
-	ProductID     *primitive.ObjectID `bson:"product_id,omitempty" json:"product_id,omitempty"`
-	CustomerName  string              `bson:"customer_name,omitempty" json:"customer_name,omitempty"`
-	CustomerPhone string              `bson:"customer_phone,omitempty" json:"customer_phone,omitempty"`
-	Quantity      float64             `bson:"quantity" json:"quantity" validate:"required,gt=0"`
-	UnitPrice     float64             `bson:"unit_price" json:"unit_price" validate:"required,gt=0"`
-	TotalAmount   float64             `bson:"total_amount" json:"total_amount"`
-	Discount      float64             `bson:"discount,omitempty" json:"discount,omitempty"`
-	Tax           float64             `bson:"tax,omitempty" json:"tax,omitempty"`
-	FinalAmount   float64             `bson:"final_amount" json:"final_amount"`
-	PaymentMethod PaymentMethod       `bson:"payment_method" json:"payment_method"`
-	PaymentStatus PaymentStatus       `bson:"payment_status" json:"payment_status"`
-	Notes         string              `bson:"notes,omitempty" json:"notes,omitempty"`
-	Status        SaleStatus          `bson:"status" json:"status"`
-	Synced        bool                `bson:"synced" json:"synced"`
-	SyncedAt      *time.Time          `bson:"synced_at,omitempty" json:"synced_at,omitempty"`
-	CreatedBy     primitive.ObjectID  `bson:"created_by" json:"created_by"`
-	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
-	UpdatedAt     time.Time           `bson:"updated_at" json:"updated_at"`
+	ID            primitive.ObjectID    `bson:"_id,omitempty" json:"id"`
+	BusinessID    primitive.ObjectID    `bson:"business_id" json:"business_id"`
+	LocalID       string                `bson:"local_id,omitempty" json:"local_id,omitempty"` // For offline sync
+	ProductID     *primitive.ObjectID   `bson:"product_id,omitempty" json:"product_id,omitempty"`
+	CustomerName  string                `bson:"customer_name,omitempty" json:"customer_name,omitempty"`
+	CustomerPhone string                `bson:"customer_phone,omitempty" json:"customer_phone,omitempty"`
+	Quantity      float64               `bson:"quantity" json:"quantity" validate:"required,gt=0"`
+	UnitPrice     float64               `bson:"unit_price" json:"unit_price" validate:"required,gt=0"`
+	TotalAmount   float64               `bson:"total_amount" json:"total_amount"`
+	Discount      float64               `bson:"discount,omitempty" json:"discount,omitempty"`
+	Tax           float64               `bson:"tax,omitempty" json:"tax,omitempty"`
+	FinalAmount   float64               `bson:"final_amount" json:"final_amount"`
+	CostOfGoods   float64               `bson:"cost_of_goods" json:"cost_of_goods"`
+	PaymentMethod PaymentMethod         `bson:"payment_method" json:"payment_method"`
+	PaymentStatus PaymentStatus         `bson:"payment_status" json:"payment_status"`
+	Notes         string                `bson:"notes,omitempty" json:"notes,omitempty"`
+	Batches       []SaleBatchAllocation `bson:"batches,omitempty" json:"batches,omitempty"`
+	Serials       []string              `bson:"serials,omitempty" json:"serials,omitempty"`       // Serial numbers of the units sold
+	Components    []SaleComponent       `bson:"components,omitempty" json:"components,omitempty"` // Stock taken for a bundle or recipe
+	LocationID    *primitive.ObjectID   `bson:"location_id,omitempty" json:"location_id,omitempty"`
+	Warnings      []string              `bson:"-" json:"warnings,omitempty"` // Set when the sale took stock below zero under the warn policy
+	Status        SaleStatus            `bson:"status" json:"status"`
+	VoidedBy      *primitive.ObjectID   `bson:"voided_by,omitempty" json:"voided_by,omitempty"`
+	VoidedAt      *time.Time            `bson:"voided_at,omitempty" json:"voided_at,omitempty"`
+	Edits         []SaleEdit            `bson:"edits,omitempty" json:"edits,omitempty"` // Changes made after the sale was recorded, oldest first
+	Synced        bool                  `bson:"synced" json:"synced"`
+	SyncedAt      *time.Time            `bson:"synced_at,omitempty" json:"synced_at,omitempty"`
+	CreatedBy     primitive.ObjectID    `bson:"created_by" json:"created_by"`
+	CreatedAt     time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+// SaleEdit records who changed a sale and when.
+type SaleEdit struct {
+	EditedBy primitive.ObjectID `bson:"edited_by" json:"edited_by"`
+	EditedAt time.Time          `bson:"edited_at" json:"edited_at"`
 }
 
 type SaleStatus string
@@ -49,6 +64,15 @@ const (
 	PaymentMethodOther  PaymentMethod = "other"
 )
 
+// IsValidPaymentMethod reports whether the method is one sales are taken with.
+func IsValidPaymentMethod(method PaymentMethod) bool {
+	switch method {
+	case PaymentMethodCash, PaymentMethodCard, PaymentMethodMobile, PaymentMethodBank, PaymentMethodCredit, PaymentMethodOther:
+		return true
+	}
+	return false
+}
+
 type PaymentStatus string
 
 const (
@@ -67,7 +91,10 @@ type CreateSaleRequest struct {
 	Tax           float64       `json:"tax,omitempty"`
 	PaymentMethod PaymentMethod `json:"payment_method" validate:"required"`
 	Notes         string        `json:"notes,omitempty"`
-	LocalID       string        `json:"local_id,omitempty"` // For offline sync
+	BatchID       *string       `json:"batch_id,omitempty"`    // Overrides FEFO batch selection
+	Serials       []string      `json:"serials,omitempty"`     // Required for serialised products, one per unit
+	LocationID    *string       `json:"location_id,omitempty"` // Location the goods leave from; defaults to the default location
+	LocalID       string        `json:"local_id,omitempty"`    // For offline sync
 }
 
 type SaleSummary struct {
@@ -94,6 +121,19 @@ type SaleRepository interface {
 	FindByLocalID(businessID, localID string) (*Sale, error)
 	Update(sale *Sale) error
 	UpdateStatus(id string, status SaleStatus) error
+	// Void marks a completed sale voided by the given user. It reports false
+	// when the sale is no longer completed.
+	Void(id string, voidedBy primitive.ObjectID) (bool, error)
+	// FindStaffActivity returns the sales recorded, voided or edited in the
+	// range, by the given user only when userID is set, oldest first.
+	FindStaffActivity(businessID string, userID *primitive.ObjectID, startDate, endDate time.Time) ([]Sale, error)
+	SetCostOfGoods(id string, costOfGoods float64) error
+	// SetComponents stores the component lines of a bundle sale together
+	// with its cost of goods.
+	SetComponents(id string, components []SaleComponent, costOfGoods float64) error
+	// GetComponentUsage totals the component stock taken by completed sales
+	// of the given products, optionally at a single location.
+	GetComponentUsage(businessID string, productIDs []primitive.ObjectID, locationID *string, startDate, endDate time.Time) ([]ProductDemand, error)
 	Delete(id string) error
 	GetSummary(businessID string, startDate, endDate time.Time) (*SaleSummary, error)
 	GetStats(businessID string, period string) (*SaleStats, error)
@@ -0,0 +1,113 @@
+package Domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrSerialExists is returned when a serial number has already been recorded
+// for the business.
+var ErrSerialExists = errors.New("serial number is already recorded")
+
+// SerialNumber is a single serialised unit, such as a phone with its IMEI,
+// followed from goods receipt to sale and back.
+type SerialNumber struct {
+	ID                primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BusinessID        primitive.ObjectID  `bson:"business_id" json:"business_id"`
+	ProductID         primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	ProductName       string              `bson:"product_name" json:"product_name"`
+	Serial            string              `bson:"serial" json:"serial"`
+	Status            SerialStatus        `bson:"status" json:"status"`
+	CostPrice         float64             `bson:"cost_price,omitempty" json:"cost_price,omitempty"`
+	ReceivedAt        time.Time           `bson:"received_at" json:"received_at"`
+	SaleID            *primitive.ObjectID `bson:"sale_id,omitempty" json:"sale_id,omitempty"`
+	CustomerName      string              `bson:"customer_name,omitempty" json:"customer_name,omitempty"`
+	CustomerPhone     string              `bson:"customer_phone,omitempty" json:"customer_phone,omitempty"`
+	SoldAt            *time.Time          `bson:"sold_at,omitempty" json:"sold_at,omitempty"`
+	WarrantyExpiresAt *time.Time          `bson:"warranty_expires_at,omitempty" json:"warranty_expires_at,omitempty"`
+	Events            []SerialEvent       `bson:"events" json:"events"` // Full lifecycle, oldest first
+	CreatedBy         primitive.ObjectID  `bson:"created_by" json:"created_by"`
+	CreatedAt         time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+type SerialStatus string
+
+const (
+	SerialStatusInStock    SerialStatus = "in_stock"
+	SerialStatusSold       SerialStatus = "sold"
+	SerialStatusWrittenOff SerialStatus = "written_off"
+)
+
+type SerialEventType string
+
+const (
+	SerialEventReceived   SerialEventType = "received"
+	SerialEventSold       SerialEventType = "sold"
+	SerialEventReturned   SerialEventType = "returned"
+	SerialEventWrittenOff SerialEventType = "written_off"
+)
+
+// SerialEvent is one step in the life of a serial number.
+type SerialEvent struct {
+	Type          SerialEventType     `bson:"type" json:"type"`
+	At            time.Time           `bson:"at" json:"at"`
+	ReferenceID   *primitive.ObjectID `bson:"reference_id,omitempty" json:"reference_id,omitempty"`
+	ReferenceType string              `bson:"reference_type,omitempty" json:"reference_type,omitempty"` // purchase_order, sale or empty
+	CustomerName  string              `bson:"customer_name,omitempty" json:"customer_name,omitempty"`
+	CustomerPhone string              `bson:"customer_phone,omitempty" json:"customer_phone,omitempty"`
+	Note          string              `bson:"note,omitempty" json:"note,omitempty"`
+	UserID        primitive.ObjectID  `bson:"user_id" json:"user_id"`
+}
+
+// UnderWarranty reports whether the unit was sold and its warranty runs past
+// the given time.
+func (s *SerialNumber) UnderWarranty(at time.Time) bool {
+	return s.Status == SerialStatusSold && s.WarrantyExpiresAt != nil && s.WarrantyExpiresAt.After(at)
+}
+
+type ReceiveSerialsRequest struct {
+	Serials    []string `json:"serials" validate:"required"`
+	CostPrice  float64  `json:"cost_price,omitempty"`  // Defaults to the product cost price
+	LocationID *string  `json:"location_id,omitempty"` // Defaults to the default location
+	// Existing registers serials for units already counted in stock, such as
+	// when serial tracking is switched on for a stocked product. No stock
+	// movement is recorded for them.
+	Existing bool `json:"existing,omitempty"`
+}
+
+type WriteOffSerialRequest struct {
+	Reason     string  `json:"reason" validate:"required"`
+	LocationID *string `json:"location_id,omitempty"` // Defaults to the default location
+}
+
+// ProductSerials lists the serial numbers of a product. InStock is the stock
+// on hand as counted from serials; Untracked is product stock not yet
+// accounted for by a serial.
+type ProductSerials struct {
+	ProductID   primitive.ObjectID `json:"product_id"`
+	ProductName string             `json:"product_name"`
+	Stock       float64            `json:"stock"`
+	InStock     int                `json:"in_stock"`
+	Untracked   float64            `json:"untracked"`
+	Serials     []SerialNumber     `json:"serials"`
+}
+
+type SerialRepository interface {
+	Create(serial *SerialNumber) error
+	// FindBySerial returns the serial number of the business, or nil when it
+	// has never been recorded.
+	FindBySerial(businessID, serial string) (*SerialNumber, error)
+	FindByProductID(productID string, status *SerialStatus) ([]SerialNumber, error)
+	CountInStock(productID string) (int, error)
+	// UpdateStatus saves the status and sale details of the serial and
+	// appends the event, failing unless the serial is currently in the given
+	// status.
+	UpdateStatus(serial *SerialNumber, from SerialStatus, event SerialEvent) error
+	// Delete removes a serial recorded by a receipt that did not complete.
+	Delete(id string) error
+	// EnsureIndexes creates the unique serial per business index.
+	EnsureIndexes() error
+}
@@ -0,0 +1,79 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StaffReportRequest is the range of a staff report, in calendar days in the
+// business's timezone with both ends included. It defaults to the last 30
+// days up to today.
+type StaffReportRequest struct {
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+}
+
+// StaffPerformanceReport sets out what each user did at the till over a
+// period, busiest seller first.
+type StaffPerformanceReport struct {
+	Period ComparisonPeriod   `json:"period"`
+	Staff  []StaffPerformance `json:"staff"`
+}
+
+// StaffPerformance is one user's sales and corrections over a period. Sales
+// figures count the completed sales the user recorded; voids and edits count
+// what the user did to any sale, with those made to another user's sales
+// counted apart.
+type StaffPerformance struct {
+	UserID        primitive.ObjectID `json:"user_id"`
+	Name          string             `json:"name"`
+	Role          UserRole           `json:"role,omitempty"`
+	Sales         int                `json:"sales"`
+	Revenue       float64            `json:"revenue"`
+	ItemsSold     float64            `json:"items_sold"`
+	AverageBasket float64            `json:"average_basket"`
+	Discounts     float64            `json:"discounts"`
+	Voids         int                `json:"voids"`
+	VoidedAmount  float64            `json:"voided_amount"`
+	VoidsOfOthers int                `json:"voids_of_others"` // Voids of sales another user recorded
+	Edits         int                `json:"edits"`
+	EditsOfOthers int                `json:"edits_of_others"` // Edits of sales another user recorded
+	// Refunds counts the user's own sales that were later refunded.
+	Refunds        int     `json:"refunds"`
+	RefundedAmount float64 `json:"refunded_amount"`
+	// SalesVoidedByOthers and SalesEditedByOthers count the user's own sales
+	// that another user voided or changed.
+	SalesVoidedByOthers int `json:"sales_voided_by_others"`
+	SalesEditedByOthers int `json:"sales_edited_by_others"`
+}
+
+// StaffAction is something a user did to a sale.
+type StaffAction string
+
+const (
+	StaffActionSold   StaffAction = "sold"
+	StaffActionVoided StaffAction = "voided"
+	StaffActionEdited StaffAction = "edited"
+)
+
+// StaffTransactions lists the sales a user recorded, voided or edited over a
+// period, oldest first.
+type StaffTransactions struct {
+	UserID       primitive.ObjectID `json:"user_id"`
+	Name         string             `json:"name"`
+	Period       ComparisonPeriod   `json:"period"`
+	Transactions []StaffTransaction `json:"transactions"`
+}
+
+// StaffTransaction is one sale with what the user did to it. Highlighted is
+// set when the sale was voided or edited by someone other than its seller.
+type StaffTransaction struct {
+	Sale         Sale          `json:"sale"`
+	SellerName   string        `json:"seller_name"`
+	VoidedByName string        `json:"voided_by_name,omitempty"`
+	EditorNames  []string      `json:"editor_names,omitempty"` // Users other than the seller who edited the sale
+	Actions      []StaffAction `json:"actions"`
+	Highlighted  bool          `json:"highlighted"`
+	Notes        []string      `json:"notes,omitempty"` // Why the sale is highlighted
+}
@@ -0,0 +1,141 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Stocktake is a physical count session. Expected quantities are snapshotted
+// when the session is opened and taken again each time an item is counted,
+// so sales and receipts made before the count are already in the figure the
+// count is compared with.
+type Stocktake struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BusinessID primitive.ObjectID  `bson:"business_id" json:"business_id"`
+	Name       string              `bson:"name" json:"name"`
+	Category   string              `bson:"category,omitempty" json:"category,omitempty"`       // Empty when counting all products
+	LocationID *primitive.ObjectID `bson:"location_id,omitempty" json:"location_id,omitempty"` // Counted location; the whole business when empty
+	Notes      string              `bson:"notes,omitempty" json:"notes,omitempty"`
+	Status     StocktakeStatus     `bson:"status" json:"status"`
+	Items      []StocktakeItem     `bson:"items" json:"items"`
+	Summary    StocktakeSummary    `bson:"-" json:"summary"`
+	CreatedBy  primitive.ObjectID  `bson:"created_by" json:"created_by"`
+	PostedBy   *primitive.ObjectID `bson:"posted_by,omitempty" json:"posted_by,omitempty"`
+	PostedAt   *time.Time          `bson:"posted_at,omitempty" json:"posted_at,omitempty"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+type StocktakeStatus string
+
+const (
+	StocktakeStatusOpen      StocktakeStatus = "open"
+	StocktakeStatusPosted    StocktakeStatus = "posted"
+	StocktakeStatusCancelled StocktakeStatus = "cancelled"
+)
+
+type StocktakeItem struct {
+	ProductID        primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	ProductName      string              `bson:"product_name" json:"product_name"`
+	SKU              string              `bson:"sku,omitempty" json:"sku,omitempty"`
+	Barcode          string              `bson:"barcode,omitempty" json:"barcode,omitempty"`
+	Unit             string              `bson:"unit,omitempty" json:"unit,omitempty"`
+	ExpectedQuantity float64             `bson:"expected_quantity" json:"expected_quantity"`                   // Stock held when last counted, or when the session was opened
+	CountedQuantity  *float64            `bson:"counted_quantity,omitempty" json:"counted_quantity,omitempty"` // Nil until counted
+	UnitCost         float64             `bson:"unit_cost" json:"unit_cost"`
+	Variance         float64             `bson:"variance" json:"variance"`             // Counted minus expected
+	VarianceValue    float64             `bson:"variance_value" json:"variance_value"` // Variance at unit cost
+	CountedBy        *primitive.ObjectID `bson:"counted_by,omitempty" json:"counted_by,omitempty"`
+	CountedAt        *time.Time          `bson:"counted_at,omitempty" json:"counted_at,omitempty"`
+}
+
+type StocktakeSummary struct {
+	TotalItems        int     `json:"total_items"`
+	CountedItems      int     `json:"counted_items"`
+	UncountedItems    int     `json:"uncounted_items"`
+	ItemsWithVariance int     `json:"items_with_variance"`
+	ShortageValue     float64 `json:"shortage_value"`
+	SurplusValue      float64 `json:"surplus_value"`
+	NetVarianceValue  float64 `json:"net_variance_value"`
+}
+
+// CalculateVariances fills in the variance of every counted item and the
+// session summary. Uncounted items carry no variance.
+func (s *Stocktake) CalculateVariances() {
+	summary := StocktakeSummary{TotalItems: len(s.Items)}
+
+	for i := range s.Items {
+		item := &s.Items[i]
+		item.Variance = 0
+		item.VarianceValue = 0
+
+		if item.CountedQuantity == nil {
+			summary.UncountedItems++
+			continue
+		}
+
+		summary.CountedItems++
+		item.Variance = *item.CountedQuantity - item.ExpectedQuantity
+		item.VarianceValue = item.Variance * item.UnitCost
+
+		if item.Variance == 0 {
+			continue
+		}
+
+		summary.ItemsWithVariance++
+		if item.VarianceValue < 0 {
+			summary.ShortageValue += -item.VarianceValue
+		} else {
+			summary.SurplusValue += item.VarianceValue
+		}
+		summary.NetVarianceValue += item.VarianceValue
+	}
+
+	s.Summary = summary
+}
+
+type CreateStocktakeRequest struct {
+	Name       string `json:"name" validate:"required"`
+	Category   string `json:"category,omitempty"`    // Count only this category (ID or name) and its subcategories; all products when empty
+	LocationID string `json:"location_id,omitempty"` // Count only the stock held at this location
+	Notes      string `json:"notes,omitempty"`
+}
+
+type StocktakeCountMode string
+
+const (
+	StocktakeCountModeSet StocktakeCountMode = "set" // Replace the counted quantity
+	StocktakeCountModeAdd StocktakeCountMode = "add" // Add to the counted quantity, e.g. a second shelf or a scan
+)
+
+// StocktakeCount is a single count entry. The product is identified by ID or
+// by barcode; a barcode scan without a quantity counts one unit.
+type StocktakeCount struct {
+	ProductID string             `json:"product_id,omitempty"`
+	Barcode   string             `json:"barcode,omitempty"`
+	Quantity  float64            `json:"quantity"`
+	Mode      StocktakeCountMode `json:"mode,omitempty"` // Defaults to add
+}
+
+type RecordStocktakeCountsRequest struct {
+	Counts []StocktakeCount `json:"counts" validate:"required"`
+}
+
+type PostStocktakeResult struct {
+	Stocktake        *Stocktake `json:"stocktake"`
+	AdjustmentsMade  int        `json:"adjustments_made"`
+	NetVarianceValue float64    `json:"net_variance_value"`
+	Errors           []string   `json:"errors,omitempty"`
+}
+
+type StocktakeRepository interface {
+	Create(stocktake *Stocktake) error
+	FindByID(id string) (*Stocktake, error)
+	FindByBusinessID(businessID string, status *StocktakeStatus) ([]Stocktake, error)
+	// RecordCount sets or adds to an item's counted quantity and sets its
+	// expected quantity to the stock held as it was counted.
+	RecordCount(id, productID string, quantity float64, add bool, expected float64, userID string) error
+	UpdateItems(id string, items []StocktakeItem) error
+	UpdateStatus(id string, status StocktakeStatus, userID string) error
+}
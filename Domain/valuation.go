@@ -0,0 +1,52 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StockValuation is the stock held and its value at a point in time, rebuilt
+// from the stock movement ledger.
+type StockValuation struct {
+	AsOf          time.Time            `json:"as_of"`
+	CostingMethod CostingMethod        `json:"costing_method"`
+	TotalProducts int                  `json:"total_products"`
+	TotalQuantity float64              `json:"total_quantity"`
+	TotalValue    float64              `json:"total_value"`
+	Items         []StockValuationItem `json:"items"`
+}
+
+type StockValuationItem struct {
+	ProductID      primitive.ObjectID `json:"product_id"`
+	ProductName    string             `json:"product_name"`
+	SKU            string             `json:"sku,omitempty"`
+	Category       string             `json:"category,omitempty"`
+	Quantity       float64            `json:"quantity"`
+	UnitCost       float64            `json:"unit_cost"` // Value divided by quantity
+	Value          float64            `json:"value"`
+	LastMovementAt *time.Time         `json:"last_movement_at,omitempty"`
+}
+
+// LedgerIntegrityReport lists the products whose stock disagrees with their
+// stock movement history.
+type LedgerIntegrityReport struct {
+	CheckedAt        time.Time           `json:"checked_at"`
+	ProductsChecked  int                 `json:"products_checked"`
+	MovementsChecked int                 `json:"movements_checked"`
+	Discrepancies    []LedgerDiscrepancy `json:"discrepancies"`
+}
+
+type LedgerDiscrepancy struct {
+	ProductID    primitive.ObjectID `json:"product_id"`
+	ProductName  string             `json:"product_name"`
+	CurrentStock float64            `json:"current_stock"`
+	LedgerStock  float64            `json:"ledger_stock"` // New quantity of the last movement
+	Difference   float64            `json:"difference"`   // Current stock less ledger stock
+	// BrokenLinks counts movements whose previous quantity is not the new
+	// quantity of the movement before them, or whose change does not match
+	// their quantity.
+	BrokenLinks  int        `json:"broken_links"`
+	FirstBreakAt *time.Time `json:"first_break_at,omitempty"`
+	Problems     []string   `json:"problems"`
+}
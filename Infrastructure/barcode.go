@@ -0,0 +1,147 @@
+package Infrastructure
+
+import (
+	"fmt"
+
+	Domain "ShopOps/Domain"
+)
+
+// EAN13CheckDigit computes the check digit of the first twelve digits of an
+// EAN-13 code.
+func EAN13CheckDigit(digits string) (byte, error) {
+	if len(digits) < 12 {
+		return 0, fmt.Errorf("EAN-13 needs 12 digits before the check digit")
+	}
+
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := digits[i]
+		if d < '0' || d > '9' {
+			return 0, fmt.Errorf("EAN-13 codes can only contain digits")
+		}
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(d-'0') * weight
+	}
+
+	return byte('0' + (10-sum%10)%10), nil
+}
+
+// IsEAN13 reports whether code is a valid EAN-13 code.
+func IsEAN13(code string) bool {
+	if len(code) != 13 {
+		return false
+	}
+	check, err := EAN13CheckDigit(code)
+	return err == nil && check == code[12]
+}
+
+// EncodeBarcode returns the modules of a barcode, true for a bar. Valid
+// EAN-13 and UPC-A codes are drawn as EAN-13; anything else as Code 128.
+func EncodeBarcode(code string) ([]bool, Domain.BarcodeFormat, error) {
+	if len(code) == 12 && IsEAN13("0"+code) {
+		code = "0" + code
+	}
+	if IsEAN13(code) {
+		modules, err := encodeEAN13(code)
+		return modules, Domain.BarcodeFormatEAN13, err
+	}
+
+	modules, err := encodeCode128(code)
+	return modules, Domain.BarcodeFormatCode128, err
+}
+
+var (
+	ean13LCodes = []string{"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011"}
+	ean13GCodes = []string{"0100111", "0110011", "0011011", "0100001", "0011101", "0111001", "0000101", "0010001", "0001001", "0010111"}
+	ean13RCodes = []string{"1110010", "1100110", "1101100", "1000010", "1011100", "1001110", "1010000", "1000100", "1001000", "1110100"}
+
+	// ean13Parity is the L/G pattern of the left half, set by the first digit.
+	ean13Parity = []string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+)
+
+func encodeEAN13(code string) ([]bool, error) {
+	if !IsEAN13(code) {
+		return nil, fmt.Errorf("invalid EAN-13 code: %s", code)
+	}
+
+	// Eleven quiet modules lead the code and seven follow it
+	pattern := "00000000000" + "101"
+	parity := ean13Parity[code[0]-'0']
+	for i := 1; i <= 6; i++ {
+		digit := code[i] - '0'
+		if parity[i-1] == 'L' {
+			pattern += ean13LCodes[digit]
+		} else {
+			pattern += ean13GCodes[digit]
+		}
+	}
+	pattern += "01010"
+	for i := 7; i <= 12; i++ {
+		pattern += ean13RCodes[code[i]-'0']
+	}
+	pattern += "101" + "0000000"
+
+	modules := make([]bool, len(pattern))
+	for i := range pattern {
+		modules[i] = pattern[i] == '1'
+	}
+	return modules, nil
+}
+
+// code128Patterns holds the bar and space widths of every Code 128 symbol.
+var code128Patterns = []string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128Stop   = 106
+)
+
+// encodeCode128 encodes printable ASCII text with code set B, surrounded by
+// ten-module quiet zones.
+func encodeCode128(text string) ([]bool, error) {
+	if text == "" {
+		return nil, fmt.Errorf("barcode is empty")
+	}
+
+	symbols := []int{code128StartB}
+	checksum := code128StartB
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if ch < 32 || ch > 126 {
+			return nil, fmt.Errorf("barcode %q contains characters that cannot be printed", text)
+		}
+		value := int(ch) - 32
+		symbols = append(symbols, value)
+		checksum += value * (i + 1)
+	}
+	symbols = append(symbols, checksum%103, code128Stop)
+
+	modules := make([]bool, 10)
+	for _, symbol := range symbols {
+		bar := true
+		for _, width := range code128Patterns[symbol] {
+			for w := 0; w < int(width-'0'); w++ {
+				modules = append(modules, bar)
+			}
+			bar = !bar
+		}
+	}
+	modules = append(modules, make([]bool, 10)...)
+
+	return modules, nil
+}
@@ -13,6 +13,13 @@ import (
 type ExportService interface {
 	ExportToCSV(data interface{}, reportType Domain.ReportType) ([]byte, error)
 	ExportToJSON(data interface{}) ([]byte, error)
+	ExportToXLSX(sheetName string, rows [][]string) ([]byte, error)
+	// ExportToPDF renders a report under the business's letterhead, dated in
+	// its timezone.
+	ExportToPDF(data interface{}, reportType Domain.ReportType, business *Domain.Business) ([]byte, error)
+	// ExportReportToXLSX builds a workbook of a report and the sales and
+	// expenses behind it, with typed numbers and dates.
+	ExportReportToXLSX(data interface{}, reportType Domain.ReportType, business *Domain.Business, records ReportRecords) ([]byte, error)
 }
 
 type exportService struct{}
@@ -107,6 +114,246 @@ func (s *exportService) ExportToCSV(data interface{}, reportType Domain.ReportTy
 				})
 			}
 		}
+
+	case Domain.ReportTypeCatalog:
+		// Catalog rows are already laid out as the import template
+		if rows, ok := data.([][]string); ok {
+			records = rows
+		}
+
+	case Domain.ReportTypeStocktake:
+		if stocktake, ok := data.(*Domain.Stocktake); ok {
+			// Add header
+			records = append(records, []string{
+				"Product ID", "Product", "SKU", "Barcode", "Unit",
+				"Expected", "Counted", "Variance", "Unit Cost", "Variance Value",
+				"Counted At",
+			})
+
+			// Add data rows
+			for _, item := range stocktake.Items {
+				counted := ""
+				if item.CountedQuantity != nil {
+					counted = fmt.Sprintf("%.2f", *item.CountedQuantity)
+				}
+
+				countedAt := ""
+				if item.CountedAt != nil {
+					countedAt = item.CountedAt.Format("2006-01-02 15:04:05")
+				}
+
+				records = append(records, []string{
+					item.ProductID.Hex(),
+					item.ProductName,
+					item.SKU,
+					item.Barcode,
+					item.Unit,
+					fmt.Sprintf("%.2f", item.ExpectedQuantity),
+					counted,
+					fmt.Sprintf("%.2f", item.Variance),
+					fmt.Sprintf("%.2f", item.UnitCost),
+					fmt.Sprintf("%.2f", item.VarianceValue),
+					countedAt,
+				})
+			}
+
+			// Add totals
+			records = append(records, []string{
+				"", "Total shortage", "", "", "", "", "", "", "",
+				fmt.Sprintf("%.2f", -stocktake.Summary.ShortageValue), "",
+			})
+			records = append(records, []string{
+				"", "Total surplus", "", "", "", "", "", "", "",
+				fmt.Sprintf("%.2f", stocktake.Summary.SurplusValue), "",
+			})
+			records = append(records, []string{
+				"", "Net variance", "", "", "", "", "", "", "",
+				fmt.Sprintf("%.2f", stocktake.Summary.NetVarianceValue), "",
+			})
+		}
+
+	case Domain.ReportTypeAging:
+		if report, ok := data.(*Domain.AgingReport); ok {
+			// Add header
+			records = append(records, []string{
+				"Product ID", "Product", "SKU", "Category", "Stock", "Unit Cost", "Value",
+				"Last Sold", "Days Since Sold", "Sales Bucket",
+				"Last Received", "Days Since Received", "Receipt Bucket", "Dead Stock",
+			})
+
+			// Add data rows
+			for _, item := range report.Items {
+				lastSold := ""
+				if item.LastSoldAt != nil {
+					lastSold = item.LastSoldAt.Format("2006-01-02")
+				}
+
+				lastReceived := ""
+				if item.LastReceivedAt != nil {
+					lastReceived = item.LastReceivedAt.Format("2006-01-02")
+				}
+
+				deadStock := "no"
+				if item.DeadStock {
+					deadStock = "yes"
+				}
+
+				records = append(records, []string{
+					item.ProductID.Hex(),
+					item.ProductName,
+					item.SKU,
+					item.Category,
+					fmt.Sprintf("%.2f", item.Stock),
+					fmt.Sprintf("%.2f", item.UnitCost),
+					fmt.Sprintf("%.2f", item.Value),
+					lastSold,
+					fmt.Sprintf("%d", item.DaysSinceSold),
+					item.SalesBucket,
+					lastReceived,
+					fmt.Sprintf("%d", item.DaysSinceReceived),
+					item.ReceiptBucket,
+					deadStock,
+				})
+			}
+
+			// Add the capital tied up per bucket
+			records = append(records, []string{})
+			records = append(records, []string{"Days Since Sold", "Products", "Stock", "Value"})
+			for _, bucket := range report.SalesAging {
+				records = append(records, []string{
+					bucket.Label,
+					fmt.Sprintf("%d", bucket.Products),
+					fmt.Sprintf("%.2f", bucket.Stock),
+					fmt.Sprintf("%.2f", bucket.Value),
+				})
+			}
+			records = append(records, []string{
+				fmt.Sprintf("Dead stock (%d+ days)", report.DeadStockDays),
+				fmt.Sprintf("%d", report.DeadStockCount), "",
+				fmt.Sprintf("%.2f", report.DeadStockValue),
+			})
+		}
+
+	case Domain.ReportTypeHeatmap:
+		if heatmap, ok := data.(*Domain.SalesHeatmap); ok {
+			records = append(records, []string{"Range", heatmap.Current.Label})
+			records = append(records, []string{"Previous range", heatmap.Previous.Label})
+			records = append(records, []string{"Timezone", heatmap.Timezone})
+
+			// Revenue and transactions as weekday by hour grids
+			grids := []struct {
+				title string
+				value func(cell Domain.HeatmapCell) string
+			}{
+				{"Revenue", func(cell Domain.HeatmapCell) string { return fmt.Sprintf("%.2f", cell.Revenue) }},
+				{"Transactions", func(cell Domain.HeatmapCell) string { return fmt.Sprintf("%d", cell.Transactions) }},
+			}
+			for _, grid := range grids {
+				records = append(records, []string{})
+				header := []string{grid.title}
+				for _, hour := range heatmap.Hours {
+					header = append(header, hour.Label)
+				}
+				records = append(records, header)
+
+				for row, weekday := range heatmap.Weekdays {
+					record := []string{weekday.Label}
+					for _, cell := range heatmap.Cells[row*24 : (row+1)*24] {
+						record = append(record, grid.value(cell))
+					}
+					records = append(records, record)
+				}
+			}
+
+			// Every cell against the previous range
+			records = append(records, []string{})
+			records = append(records, []string{
+				"Weekday", "Hour", "Transactions", "Revenue",
+				"Previous Transactions", "Previous Revenue", "Revenue Change %",
+			})
+			for _, cell := range heatmap.Cells {
+				change := ""
+				if cell.RevenueChangePercent != nil {
+					change = fmt.Sprintf("%.2f", *cell.RevenueChangePercent)
+				}
+				records = append(records, []string{
+					cell.WeekdayName,
+					fmt.Sprintf("%02d:00", cell.Hour),
+					fmt.Sprintf("%d", cell.Transactions),
+					fmt.Sprintf("%.2f", cell.Revenue),
+					fmt.Sprintf("%d", cell.PreviousTransactions),
+					fmt.Sprintf("%.2f", cell.PreviousRevenue),
+					change,
+				})
+			}
+			totalChange := ""
+			if heatmap.Revenue.ChangePercent != nil {
+				totalChange = fmt.Sprintf("%.2f", *heatmap.Revenue.ChangePercent)
+			}
+			records = append(records, []string{
+				"Total", "",
+				fmt.Sprintf("%.0f", heatmap.Transactions.Current),
+				fmt.Sprintf("%.2f", heatmap.Revenue.Current),
+				fmt.Sprintf("%.0f", heatmap.Transactions.Previous),
+				fmt.Sprintf("%.2f", heatmap.Revenue.Previous),
+				totalChange,
+			})
+		}
+	case Domain.ReportTypeABC:
+		if report, ok := data.(*Domain.ABCReport); ok {
+			records = append(records, []string{"Period", report.Period.Label})
+			records = append(records, []string{"Ranked by", string(report.Metric)})
+			if report.Category != "" {
+				records = append(records, []string{"Category", report.Category})
+			}
+			records = append(records, []string{"Class shares",
+				fmt.Sprintf("A %.2f%% / B %.2f%% / C %.2f%%", report.Thresholds.A, report.Thresholds.B, report.Thresholds.C)})
+
+			// How each metric splits across the classes
+			records = append(records, []string{})
+			records = append(records, []string{"Metric", "Class", "Products", "Product Share %", "Value", "Share %"})
+			for _, summary := range report.Summaries {
+				for _, class := range summary.Classes {
+					records = append(records, []string{
+						string(summary.Metric),
+						string(class.Class),
+						fmt.Sprintf("%d", class.Products),
+						fmt.Sprintf("%.2f", class.ProductShare),
+						fmt.Sprintf("%.2f", class.Value),
+						fmt.Sprintf("%.2f", class.Share),
+					})
+				}
+			}
+
+			records = append(records, []string{})
+			records = append(records, []string{
+				"Rank", "Product ID", "Product", "SKU", "Category",
+				"Quantity", "Revenue", "Cost of Goods", "Gross Profit", "Gross Margin %",
+				"Revenue Class", "Revenue Cumulative %",
+				"Gross Profit Class", "Gross Profit Cumulative %",
+				"Quantity Class", "Quantity Cumulative %",
+			})
+			for _, item := range report.Items {
+				records = append(records, []string{
+					fmt.Sprintf("%d", item.Ranking(report.Metric).Rank),
+					item.ProductID,
+					item.ProductName,
+					item.SKU,
+					item.Category,
+					fmt.Sprintf("%.2f", item.Quantity),
+					fmt.Sprintf("%.2f", item.Revenue),
+					fmt.Sprintf("%.2f", item.CostOfGoods),
+					fmt.Sprintf("%.2f", item.GrossProfit),
+					fmt.Sprintf("%.2f", item.GrossMargin),
+					string(item.ByRevenue.Class),
+					fmt.Sprintf("%.2f", item.ByRevenue.CumulativeShare),
+					string(item.ByGrossProfit.Class),
+					fmt.Sprintf("%.2f", item.ByGrossProfit.CumulativeShare),
+					string(item.ByQuantity.Class),
+					fmt.Sprintf("%.2f", item.ByQuantity.CumulativeShare),
+				})
+			}
+		}
 	}
 
 	// Write CSV
@@ -129,6 +376,18 @@ func (s *exportService) ExportToJSON(data interface{}) ([]byte, error) {
 	return json.MarshalIndent(data, "", "  ")
 }
 
+func (s *exportService) ExportToXLSX(sheetName string, rows [][]string) ([]byte, error) {
+	return WriteXLSX(sheetName, rows)
+}
+
+func (s *exportService) ExportToPDF(data interface{}, reportType Domain.ReportType, business *Domain.Business) ([]byte, error) {
+	return RenderReportPDF(data, reportType, business, time.Now().In(business.Location()))
+}
+
+func (s *exportService) ExportReportToXLSX(data interface{}, reportType Domain.ReportType, business *Domain.Business, records ReportRecords) ([]byte, error) {
+	return RenderReportXLSX(data, reportType, business, records, time.Now().In(business.Location()))
+}
+
 // GenerateFilename generates a filename for export
 func GenerateFilename(reportType Domain.ReportType, timestamp time.Time) string {
 	return fmt.Sprintf("%s_%s.csv",
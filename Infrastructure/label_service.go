@@ -0,0 +1,215 @@
+package Infrastructure
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	Domain "ShopOps/Domain"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// LabelService renders product labels with name, price and barcode onto
+// printable sheets.
+type LabelService interface {
+	RenderPDF(labels []Domain.Label) ([]byte, error)
+	RenderPNG(labels []Domain.Label) ([]byte, error)
+}
+
+type labelService struct{}
+
+func NewLabelService() LabelService {
+	return &labelService{}
+}
+
+// Sheets hold three columns of eight labels, the common 24-up A4 layout.
+const (
+	labelColumns    = 3
+	labelRows       = 8
+	labelSheetInset = 10.0
+)
+
+func (s *labelService) RenderPDF(labels []Domain.Label) ([]byte, error) {
+	doc := NewPDFDocument(PageA4Width, PageA4Height)
+
+	labelWidth := (PageA4Width - 2*labelSheetInset) / labelColumns
+	labelHeight := (PageA4Height - 2*labelSheetInset) / labelRows
+	const padding = 8.0
+
+	for i, label := range labels {
+		slot := i % (labelColumns * labelRows)
+		if slot == 0 {
+			doc.AddPage()
+		}
+
+		x := labelSheetInset + float64(slot%labelColumns)*labelWidth
+		y := labelSheetInset + float64(slot/labelColumns)*labelHeight
+
+		// Faint cut guides
+		doc.SetStrokeColor(0.85, 0.85, 0.85)
+		doc.SetLineWidth(0.5)
+		doc.StrokeRect(x, y, labelWidth, labelHeight)
+
+		doc.SetFillColor(0, 0, 0)
+		doc.Text(x+padding, y+padding+9, 9, true, FitText(label.Name, 9, true, labelWidth-2*padding))
+		doc.Text(x+padding, y+padding+24, 12, true, label.Price)
+
+		modules, _, err := EncodeBarcode(label.Barcode)
+		if err != nil {
+			return nil, fmt.Errorf("label for %s: %w", label.Name, err)
+		}
+
+		barTop := y + padding + 32
+		barHeight := labelHeight - 2*padding - 32 - 12
+		moduleWidth := (labelWidth - 2*padding) / float64(len(modules))
+		if moduleWidth > 1.5 {
+			moduleWidth = 1.5
+		}
+		barLeft := x + (labelWidth-moduleWidth*float64(len(modules)))/2
+
+		for m := 0; m < len(modules); {
+			if !modules[m] {
+				m++
+				continue
+			}
+			start := m
+			for m < len(modules) && modules[m] {
+				m++
+			}
+			doc.FillRect(barLeft+float64(start)*moduleWidth, barTop, float64(m-start)*moduleWidth, barHeight)
+		}
+
+		codeWidth := TextWidth(label.Barcode, 8, false)
+		doc.Text(x+(labelWidth-codeWidth)/2, y+labelHeight-padding, 8, false, label.Barcode)
+	}
+
+	return doc.Bytes()
+}
+
+// PNG labels are drawn at a fixed pixel size, laid out three to a row on a
+// single image.
+const (
+	labelPixelWidth  = 400
+	labelPixelHeight = 200
+)
+
+func (s *labelService) RenderPNG(labels []Domain.Label) ([]byte, error) {
+	rows := (len(labels) + labelColumns - 1) / labelColumns
+	if rows == 0 {
+		rows = 1
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, labelColumns*labelPixelWidth, rows*labelPixelHeight))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	guide := image.NewUniform(color.Gray{Y: 0xd9})
+	const padding = 12
+	face := basicfont.Face7x13
+
+	// The bitmap face only covers ASCII and draws a replacement glyph for
+	// anything else; such text is drawn in the UNICODE_FONT_PATH font instead
+	// when one is configured
+	faceFor := func(text string) font.Face { return face }
+	if embedded := unicodeLabelFont(labels); embedded != nil {
+		unicodeFace, err := embedded.face(13)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load label font: %w", err)
+		}
+		defer unicodeFace.Close()
+		faceFor = func(text string) font.Face {
+			if outsideASCII(text) {
+				return unicodeFace
+			}
+			return face
+		}
+	}
+
+	for i, label := range labels {
+		x := (i % labelColumns) * labelPixelWidth
+		y := (i / labelColumns) * labelPixelHeight
+
+		// Faint cut guides along the right and bottom edges
+		draw.Draw(img, image.Rect(x+labelPixelWidth-1, y, x+labelPixelWidth, y+labelPixelHeight), guide, image.Point{}, draw.Src)
+		draw.Draw(img, image.Rect(x, y+labelPixelHeight-1, x+labelPixelWidth, y+labelPixelHeight), guide, image.Point{}, draw.Src)
+
+		name := []rune(label.Name)
+		for len(name) > 0 && font.MeasureString(faceFor(string(name)), string(name)).Ceil() > labelPixelWidth-2*padding {
+			name = name[:len(name)-1]
+		}
+		drawPNGText(img, faceFor(string(name)), x+padding, y+padding+13, string(name))
+		drawPNGText(img, faceFor(label.Price), x+padding, y+padding+31, label.Price)
+
+		modules, _, err := EncodeBarcode(label.Barcode)
+		if err != nil {
+			return nil, fmt.Errorf("label for %s: %w", label.Name, err)
+		}
+
+		moduleWidth := (labelPixelWidth - 2*padding) / len(modules)
+		if moduleWidth < 1 {
+			moduleWidth = 1
+		}
+		if moduleWidth > 3 {
+			moduleWidth = 3
+		}
+		barLeft := x + (labelPixelWidth-moduleWidth*len(modules))/2
+		barTop := y + padding + 42
+		barBottom := y + labelPixelHeight - padding - 18
+
+		for m, bar := range modules {
+			if bar {
+				left := barLeft + m*moduleWidth
+				draw.Draw(img, image.Rect(left, barTop, left+moduleWidth, barBottom), image.Black, image.Point{}, draw.Src)
+			}
+		}
+
+		codeWidth := font.MeasureString(face, label.Barcode).Ceil()
+		drawPNGText(img, face, x+(labelPixelWidth-codeWidth)/2, y+labelPixelHeight-padding-2, label.Barcode)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// unicodeLabelFont returns the font to draw label text beyond ASCII in, or
+// nil when the labels need none or none is configured.
+func unicodeLabelFont(labels []Domain.Label) *textFont {
+	for _, label := range labels {
+		if outsideASCII(label.Name) || outsideASCII(label.Price) {
+			regular, _ := loadTextFonts()
+			if regular == nil {
+				warnMissingTextFont()
+			}
+			return regular
+		}
+	}
+	return nil
+}
+
+func outsideASCII(text string) bool {
+	for _, r := range text {
+		if r < 32 || r >= 127 {
+			return true
+		}
+	}
+	return false
+}
+
+func drawPNGText(img draw.Image, face font.Face, x, y int, text string) {
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.Black,
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
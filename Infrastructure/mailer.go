@@ -0,0 +1,157 @@
+package Infrastructure
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mailer sends email. SMTPMailer is the production implementation; tests and
+// other transports can supply their own.
+type Mailer interface {
+	Send(message MailMessage) error
+}
+
+type MailMessage struct {
+	To          []string
+	Subject     string
+	Body        string // Plain text
+	Attachments []MailAttachment
+}
+
+type MailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// SMTPMailer sends mail through an SMTP server, upgrading to TLS when the
+// server offers it. Without a username it sends unauthenticated, as local
+// SMTP sinks expect.
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// NewSMTPMailerFromEnv reads the SMTP settings from SMTP_HOST, SMTP_PORT,
+// SMTP_USERNAME, SMTP_PASSWORD and SMTP_FROM.
+func NewSMTPMailerFromEnv() *SMTPMailer {
+	port, err := strconv.Atoi(GetEnv("SMTP_PORT", "587"))
+	if err != nil {
+		port = 587
+	}
+
+	return &SMTPMailer{
+		Host:     GetEnv("SMTP_HOST", ""),
+		Port:     port,
+		Username: GetEnv("SMTP_USERNAME", ""),
+		Password: GetEnv("SMTP_PASSWORD", ""),
+		From:     GetEnv("SMTP_FROM", "ShopOps <reports@shopops.local>"),
+	}
+}
+
+func (m *SMTPMailer) Send(message MailMessage) error {
+	if m.Host == "" {
+		return fmt.Errorf("email is not configured: set SMTP_HOST")
+	}
+	if len(message.To) == 0 {
+		return fmt.Errorf("email has no recipients")
+	}
+
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	data, err := buildMailMessage(m.From, message)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	addr := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+	if err := smtp.SendMail(addr, auth, from.Address, message.To, data); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// buildMailMessage writes a MIME message with a plain text part and a part
+// per attachment.
+func buildMailMessage(from string, message MailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	header := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+	header("From", from)
+	header("To", strings.Join(message.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", message.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "multipart/mixed; boundary="+writer.Boundary())
+	buf.WriteString("\r\n")
+
+	body, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+	text := quotedprintable.NewWriter(body)
+	if _, err := text.Write([]byte(message.Body)); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+	if err := text.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	for _, attachment := range message.Attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+
+		// Base64 in lines of 76 characters
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for len(encoded) > 76 {
+			if _, err := part.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+				return nil, fmt.Errorf("failed to build email: %w", err)
+			}
+			encoded = encoded[76:]
+		}
+		if _, err := part.Write([]byte(encoded + "\r\n")); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,182 @@
+package Infrastructure
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// smtpSink is an SMTP server that accepts every message and keeps it.
+type smtpSink struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     []byte
+	commands []string
+	done     chan struct{}
+}
+
+func startSMTPSink(t *testing.T) *smtpSink {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	sink := &smtpSink{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+
+	go sink.serve()
+	return sink
+}
+
+func (s *smtpSink) addr() (string, int) {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+// serve handles a single session, as smtp.SendMail opens one per message.
+func (s *smtpSink) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 sink ready")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		s.commands = append(s.commands, command)
+
+		switch command {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 sink")
+		case "MAIL":
+			s.from = strings.TrimSuffix(strings.TrimPrefix(line[len("MAIL FROM:"):], "<"), ">")
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			s.to = append(s.to, strings.TrimSuffix(strings.TrimPrefix(line[len("RCPT TO:"):], "<"), ">"))
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 send the message")
+			s.data, err = text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			text.PrintfLine("250 queued")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	sink := startSMTPSink(t)
+	host, port := sink.addr()
+	mailer := &SMTPMailer{Host: host, Port: port, From: "ShopOps <reports@shopops.local>"}
+
+	// Long enough to wrap, and with bytes that are not valid text
+	attachment := bytes.Repeat([]byte{0x00, 0xff, 'P', 'K', 0x03, 0x04}, 40)
+	message := MailMessage{
+		To:      []string{"owner@example.com", "manager@example.com"},
+		Subject: "Weekly report – Café",
+		Body:    "Sales are up 12%.\n.\nSee the attached report.",
+		Attachments: []MailAttachment{{
+			Filename:    "weekly report.xlsx",
+			ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			Data:        attachment,
+		}},
+	}
+
+	if err := mailer.Send(message); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-sink.done
+
+	if got, want := strings.Join(sink.commands, " "), "EHLO MAIL RCPT RCPT DATA QUIT"; got != want {
+		t.Errorf("commands = %s, want %s", got, want)
+	}
+	if sink.from != "reports@shopops.local" {
+		t.Errorf("MAIL FROM = %s, want the bare sender address", sink.from)
+	}
+	if got := strings.Join(sink.to, ", "); got != "owner@example.com, manager@example.com" {
+		t.Errorf("RCPT TO = %s", got)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(sink.data))
+	if err != nil {
+		t.Fatalf("failed to parse the message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != message.Subject {
+		t.Errorf("Subject = %q (%v), want %q", subject, err, message.Subject)
+	}
+	if got := msg.Header.Get("To"); got != "owner@example.com, manager@example.com" {
+		t.Errorf("To = %s", got)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %s (%v), want multipart/mixed", mediaType, err)
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+
+	// The reader decodes quoted-printable parts itself
+	body, err := parts.NextPart()
+	if err != nil {
+		t.Fatalf("failed to read the body: %v", err)
+	}
+	text, _ := io.ReadAll(body)
+	if string(text) != message.Body {
+		t.Errorf("body = %q, want %q", text, message.Body)
+	}
+
+	part, err := parts.NextRawPart()
+	if err != nil {
+		t.Fatalf("failed to read the attachment: %v", err)
+	}
+	if got := part.Header.Get("Content-Type"); got != message.Attachments[0].ContentType {
+		t.Errorf("attachment Content-Type = %s", got)
+	}
+	if got := part.FileName(); got != "weekly report.xlsx" {
+		t.Errorf("attachment filename = %q", got)
+	}
+	// The sink reads the message with bare newlines, as textproto hands it over
+	raw, _ := io.ReadAll(part)
+	for _, line := range strings.Split(strings.TrimRight(string(raw), "\n"), "\n") {
+		if len(line) > 76 {
+			t.Errorf("base64 line of %d characters, want at most 76", len(line))
+		}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(raw), "\n", ""))
+	if err != nil || !bytes.Equal(decoded, attachment) {
+		t.Errorf("attachment does not decode to the data sent (%v)", err)
+	}
+
+	if _, err := parts.NextPart(); err != io.EOF {
+		t.Errorf("want two parts, got more (%v)", err)
+	}
+}
+
+func TestSMTPMailerSendWithoutHost(t *testing.T) {
+	mailer := &SMTPMailer{From: "reports@shopops.local"}
+	if err := mailer.Send(MailMessage{To: []string{"owner@example.com"}}); err == nil {
+		t.Error("want an error without SMTP_HOST")
+	}
+}
@@ -0,0 +1,298 @@
+package Infrastructure
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// A4 page size in points.
+const (
+	PageA4Width  = 595.28
+	PageA4Height = 841.89
+)
+
+// PDFDocument builds a PDF page by page with the built-in Helvetica fonts, so
+// no font files are needed for Western European text; other text is set in
+// the font UNICODE_FONT_PATH names, if any. Coordinates are in points from the
+// top-left corner of the page; text is positioned by its baseline.
+type PDFDocument struct {
+	width   float64
+	height  float64
+	pages   []*bytes.Buffer
+	current int
+	fonts   []*textFont                                 // Embedded fonts in the order they were first used
+	glyphs  map[*textFont]map[sfnt.GlyphIndex]usedGlyph // Glyphs set in each embedded font
+}
+
+func NewPDFDocument(width, height float64) *PDFDocument {
+	return &PDFDocument{width: width, height: height, current: -1}
+}
+
+// AddPage starts a new page and makes it the current one.
+func (d *PDFDocument) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.current = len(d.pages) - 1
+}
+
+// SetPage makes an earlier page current again, e.g. to add page numbers once
+// the page count is known. Pages are numbered from 1.
+func (d *PDFDocument) SetPage(number int) {
+	if number >= 1 && number <= len(d.pages) {
+		d.current = number - 1
+	}
+}
+
+func (d *PDFDocument) PageCount() int {
+	return len(d.pages)
+}
+
+func (d *PDFDocument) Width() float64  { return d.width }
+func (d *PDFDocument) Height() float64 { return d.height }
+
+func (d *PDFDocument) write(format string, args ...interface{}) {
+	if d.current < 0 {
+		d.AddPage()
+	}
+	fmt.Fprintf(d.pages[d.current], format, args...)
+}
+
+// SetFillColor sets the colour of filled shapes and text; components run
+// from 0 to 1.
+func (d *PDFDocument) SetFillColor(r, g, b float64) {
+	d.write("%.3f %.3f %.3f rg\n", r, g, b)
+}
+
+func (d *PDFDocument) SetStrokeColor(r, g, b float64) {
+	d.write("%.3f %.3f %.3f RG\n", r, g, b)
+}
+
+func (d *PDFDocument) SetLineWidth(width float64) {
+	d.write("%.2f w\n", width)
+}
+
+func (d *PDFDocument) FillRect(x, y, w, h float64) {
+	d.write("%.2f %.2f %.2f %.2f re f\n", x, d.height-y-h, w, h)
+}
+
+func (d *PDFDocument) StrokeRect(x, y, w, h float64) {
+	d.write("%.2f %.2f %.2f %.2f re S\n", x, d.height-y-h, w, h)
+}
+
+func (d *PDFDocument) Line(x1, y1, x2, y2 float64) {
+	d.write("%.2f %.2f m %.2f %.2f l S\n", x1, d.height-y1, x2, d.height-y2)
+}
+
+// Text writes a line of text with its baseline at y.
+func (d *PDFDocument) Text(x, y, size float64, bold bool, text string) {
+	if embedded := textFontFor(text, bold); embedded != nil {
+		d.write("BT /%s %.2f Tf %.2f %.2f Td <%s> Tj ET\n", d.fontResource(embedded), size, x, d.height-y, d.glyphString(embedded, text))
+		return
+	}
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	d.write("BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.height-y, pdfString(text))
+}
+
+// fontResource returns the name pages refer to an embedded font by.
+func (d *PDFDocument) fontResource(embedded *textFont) string {
+	for i, used := range d.fonts {
+		if used == embedded {
+			return fmt.Sprintf("U%d", i+1)
+		}
+	}
+	d.fonts = append(d.fonts, embedded)
+	return fmt.Sprintf("U%d", len(d.fonts))
+}
+
+// glyphString writes text as the hex glyph IDs of an embedded font and notes
+// the glyphs for the font's widths and ToUnicode map.
+func (d *PDFDocument) glyphString(embedded *textFont, text string) string {
+	if d.glyphs == nil {
+		d.glyphs = make(map[*textFont]map[sfnt.GlyphIndex]usedGlyph)
+	}
+	glyphs := d.glyphs[embedded]
+	if glyphs == nil {
+		glyphs = make(map[sfnt.GlyphIndex]usedGlyph)
+		d.glyphs[embedded] = glyphs
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		index, width := embedded.glyph(r)
+		if _, ok := glyphs[index]; !ok {
+			glyphs[index] = usedGlyph{text: r, width: width}
+		}
+		fmt.Fprintf(&b, "%04X", uint16(index))
+	}
+	return b.String()
+}
+
+// TextWidth measures text at the given size, in Helvetica or in the font it
+// is set in when Helvetica cannot show it.
+func TextWidth(text string, size float64, bold bool) float64 {
+	if embedded := textFontFor(text, bold); embedded != nil {
+		var total float64
+		for _, r := range text {
+			_, width := embedded.glyph(r)
+			total += width
+		}
+		return total * size / 1000
+	}
+
+	widths := helveticaWidths
+	if bold {
+		widths = helveticaBoldWidths
+	}
+
+	total := 0
+	for _, r := range text {
+		if r >= 32 && r < 127 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// FitText shortens text with an ellipsis until it fits the given width.
+func FitText(text string, size float64, bold bool, width float64) string {
+	if TextWidth(text, size, bold) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "..."
+		if TextWidth(candidate, size, bold) <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Bytes assembles the document.
+func (d *PDFDocument) Bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+
+	addObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page then takes
+	// two objects, the page and its content stream, and each embedded font
+	// five more after the pages
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	fontsStart := 5 + len(d.pages)*2
+	fonts := "/F1 3 0 R /F2 4 0 R"
+	for i := range d.fonts {
+		fonts += fmt.Sprintf(" /U%d %d 0 R", i+1, fontsStart+i*5)
+	}
+
+	addObject("<< /Type /Catalog /Pages 2 0 R >>")
+	addObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		addObject(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			d.width, d.height, fonts, 6+i*2))
+
+		compressed, err := deflate(page.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress PDF page: %w", err)
+		}
+		addObject(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+			len(compressed), compressed))
+	}
+
+	for i, embedded := range d.fonts {
+		if err := embedded.fontObjects(fontsStart+i*5, d.glyphs[embedded], addObject); err != nil {
+			return nil, err
+		}
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes(), nil
+}
+
+// deflate compresses a stream for the FlateDecode filter.
+func deflate(data []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// pdfString escapes text for a PDF string in WinAnsi encoding. Characters the
+// encoding lacks are replaced with a question mark; Text sets text with such
+// characters in the UNICODE_FONT_PATH font instead when one is configured.
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r == '€':
+			b.WriteString("\\200")
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// Glyph widths of printable ASCII in thousandths of the font size.
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = []int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
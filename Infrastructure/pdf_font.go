@@ -0,0 +1,247 @@
+package Infrastructure
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// textFont is a TrueType font for text the built-in fonts cannot show. They
+// only cover WinAnsi, roughly Western European text, so names in other
+// scripts, such as Amharic, are set in the font UNICODE_FONT_PATH names, and
+// the font is embedded in each PDF that uses it. Without one such characters
+// are printed as '?'.
+type textFont struct {
+	data       []byte
+	font       *sfnt.Font
+	name       string
+	unitsPerEm float64
+	bbox       [4]float64 // In thousandths of the font size, as are the metrics below
+	ascent     float64
+	descent    float64
+	capHeight  float64
+}
+
+var (
+	textFontsOnce   sync.Once
+	textFontRegular *textFont
+	textFontBold    *textFont
+	missingFontOnce sync.Once
+)
+
+// loadTextFonts reads the fonts named by UNICODE_FONT_PATH and
+// UNICODE_BOLD_FONT_PATH the first time text needs them. Bold text uses the
+// regular font when no bold one is set.
+func loadTextFonts() (regular, bold *textFont) {
+	textFontsOnce.Do(func() {
+		if path := GetEnv("UNICODE_FONT_PATH", ""); path != "" {
+			f, err := readTextFont(path)
+			if err != nil {
+				log.Printf("Warning: failed to load UNICODE_FONT_PATH: %v", err)
+			}
+			textFontRegular = f
+		}
+		textFontBold = textFontRegular
+		if path := GetEnv("UNICODE_BOLD_FONT_PATH", ""); path != "" && textFontRegular != nil {
+			f, err := readTextFont(path)
+			if err != nil {
+				log.Printf("Warning: failed to load UNICODE_BOLD_FONT_PATH: %v", err)
+			} else {
+				textFontBold = f
+			}
+		}
+	})
+	return textFontRegular, textFontBold
+}
+
+func readTextFont(path string) (*textFont, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseTextFont(data)
+}
+
+func parseTextFont(data []byte) (*textFont, error) {
+	// PDF embeds TrueType outlines as FontFile2; CFF-based OpenType fonts
+	// would need a different font program
+	if !bytes.HasPrefix(data, []byte{0, 1, 0, 0}) && !bytes.HasPrefix(data, []byte("true")) {
+		return nil, fmt.Errorf("not a TrueType font")
+	}
+
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %w", err)
+	}
+
+	var buf sfnt.Buffer
+	unitsPerEm := float64(parsed.UnitsPerEm())
+	ppem := fixed.I(int(parsed.UnitsPerEm()))
+	scale := func(v fixed.Int26_6) float64 {
+		return float64(v) / 64 * 1000 / unitsPerEm
+	}
+
+	bounds, err := parsed.Bounds(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font bounds: %w", err)
+	}
+	metrics, err := parsed.Metrics(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font metrics: %w", err)
+	}
+
+	name, _ := parsed.Name(&buf, sfnt.NameIDPostScript)
+	name = strings.Map(func(r rune) rune {
+		if r <= 32 || r >= 127 || strings.ContainsRune("()<>[]{}/%#", r) {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "ShopOpsText"
+	}
+
+	// Bounds and metrics grow downwards; PDF measures up from the baseline
+	return &textFont{
+		data:       data,
+		font:       parsed,
+		name:       name,
+		unitsPerEm: unitsPerEm,
+		bbox:       [4]float64{scale(bounds.Min.X), -scale(bounds.Max.Y), scale(bounds.Max.X), -scale(bounds.Min.Y)},
+		ascent:     scale(metrics.Ascent),
+		descent:    -scale(metrics.Descent),
+		capHeight:  scale(metrics.CapHeight),
+	}, nil
+}
+
+// glyph returns the font's glyph for a rune, 0 when it has none, and the
+// glyph's width in thousandths of the font size.
+func (f *textFont) glyph(r rune) (sfnt.GlyphIndex, float64) {
+	var buf sfnt.Buffer
+	index, err := f.font.GlyphIndex(&buf, r)
+	if err != nil {
+		index = 0
+	}
+	advance, err := f.font.GlyphAdvance(&buf, index, fixed.I(int(f.unitsPerEm)), font.HintingNone)
+	if err != nil {
+		return index, 0
+	}
+	return index, float64(advance) / 64 * 1000 / f.unitsPerEm
+}
+
+// face returns a face of the font for drawing onto images at the given pixel
+// size. Faces are not safe for concurrent use, so each caller takes its own.
+func (f *textFont) face(size float64) (font.Face, error) {
+	return opentype.NewFace(f.font, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+// inWinAnsi reports whether the built-in fonts can show a rune.
+func inWinAnsi(r rune) bool {
+	return r >= 32 && r < 127 || r == '€' || r >= 160 && r <= 255
+}
+
+// textFontFor returns the font to set text in when the built-in fonts cannot
+// show all of it, or nil to use them.
+func textFontFor(text string, bold bool) *textFont {
+	for _, r := range text {
+		if inWinAnsi(r) {
+			continue
+		}
+		regular, boldFont := loadTextFonts()
+		if regular == nil {
+			warnMissingTextFont()
+			return nil
+		}
+		if bold {
+			return boldFont
+		}
+		return regular
+	}
+	return nil
+}
+
+// warnMissingTextFont logs, once, that text is printed without the glyphs it
+// needs.
+func warnMissingTextFont() {
+	missingFontOnce.Do(func() {
+		log.Printf("Warning: text the built-in fonts lack is printed as '?'; set UNICODE_FONT_PATH to a TrueType font that covers it")
+	})
+}
+
+// usedGlyph is a glyph set in a document, with the text it stands for.
+type usedGlyph struct {
+	text  rune
+	width float64
+}
+
+// fontObjects writes the objects that embed a font as a composite font with
+// two-byte glyph IDs, starting at object number first: the Type0 font, its
+// descendant CIDFont, the descriptor, the font program and a ToUnicode map
+// so the text can be searched and copied.
+func (f *textFont) fontObjects(first int, glyphs map[sfnt.GlyphIndex]usedGlyph, addObject func(string)) error {
+	indices := make([]int, 0, len(glyphs))
+	for index := range glyphs {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	var widths, unicode strings.Builder
+	for _, index := range indices {
+		glyph := glyphs[sfnt.GlyphIndex(index)]
+		fmt.Fprintf(&widths, "%d [%.0f] ", index, glyph.width)
+	}
+	for start := 0; start < len(indices); start += 100 {
+		block := indices[start:min(start+100, len(indices))]
+		fmt.Fprintf(&unicode, "%d beginbfchar\n", len(block))
+		for _, index := range block {
+			if index == 0 {
+				// Characters the font lacks all show its missing glyph box
+				fmt.Fprintf(&unicode, "<0000> <FFFD>\n")
+				continue
+			}
+			fmt.Fprintf(&unicode, "<%04X> <", index)
+			for _, unit := range utf16.Encode([]rune{glyphs[sfnt.GlyphIndex(index)].text}) {
+				fmt.Fprintf(&unicode, "%04X", unit)
+			}
+			unicode.WriteString(">\n")
+		}
+		unicode.WriteString("endbfchar\n")
+	}
+
+	program, err := deflate(f.data)
+	if err != nil {
+		return fmt.Errorf("failed to compress font: %w", err)
+	}
+	toUnicode, err := deflate([]byte("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n" +
+		unicode.String() +
+		"endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend"))
+	if err != nil {
+		return fmt.Errorf("failed to compress font: %w", err)
+	}
+
+	addObject(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, first+1, first+4))
+	addObject(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW 1000 /W [%s] /CIDToGIDMap /Identity >>",
+		f.name, first+2, strings.TrimSpace(widths.String())))
+	addObject(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%.0f %.0f %.0f %.0f] /ItalicAngle 0 /Ascent %.0f /Descent %.0f /CapHeight %.0f /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, f.bbox[0], f.bbox[1], f.bbox[2], f.bbox[3], f.ascent, f.descent, f.capHeight, first+3))
+	addObject(fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+		len(program), len(f.data), program))
+	addObject(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+		len(toUnicode), toUnicode))
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package Infrastructure
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"strings"
+	"testing"
+
+	Domain "ShopOps/Domain"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// useTextFont configures a font as UNICODE_FONT_PATH would for the test.
+func useTextFont(t *testing.T, data []byte) {
+	t.Helper()
+	loaded, err := parseTextFont(data)
+	if err != nil {
+		t.Fatalf("parseTextFont: %v", err)
+	}
+	textFontsOnce.Do(func() {})
+	textFontRegular, textFontBold = loaded, loaded
+	t.Cleanup(func() { textFontRegular, textFontBold = nil, nil })
+}
+
+// pdfStreams inflates every stream of a document.
+func pdfStreams(t *testing.T, pdf []byte) []string {
+	t.Helper()
+	var streams []string
+	for _, chunk := range strings.Split(string(pdf), ">>\nstream\n")[1:] {
+		end := strings.Index(chunk, "\nendstream")
+		if end < 0 {
+			continue
+		}
+		reader, err := zlib.NewReader(strings.NewReader(chunk[:end]))
+		if err != nil {
+			t.Fatalf("failed to inflate stream: %v", err)
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("failed to inflate stream: %v", err)
+		}
+		streams = append(streams, string(data))
+	}
+	return streams
+}
+
+func TestPDFTextBeyondWinAnsi(t *testing.T) {
+	useTextFont(t, goregular.TTF)
+
+	doc := NewPDFDocument(PageA4Width, PageA4Height)
+	doc.Text(40, 40, 12, false, "Café")
+	doc.Text(40, 60, 12, true, "Молоко 1л")
+	pdf, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+
+	for _, want := range []string{"/Subtype /Type0", "/Encoding /Identity-H", "/CIDFontType2", "/FontFile2", "/ToUnicode"} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("PDF lacks %s", want)
+		}
+	}
+
+	streams := pdfStreams(t, pdf)
+	page := streams[0]
+	if !strings.Contains(page, "(Caf\\351) Tj") {
+		t.Errorf("WinAnsi text is not set in Helvetica: %q", page)
+	}
+	if !strings.Contains(page, "/U1 12.00 Tf") || strings.Contains(page, "?") {
+		t.Errorf("Cyrillic text is not set in the embedded font: %q", page)
+	}
+
+	// Text copied out of the PDF reads as it was written
+	var toUnicode string
+	for _, stream := range streams {
+		if strings.Contains(stream, "beginbfchar") {
+			toUnicode = stream
+		}
+	}
+	for _, want := range []string{"<041C>", "<043E>", "<043B>", "<0031>"} {
+		if !strings.Contains(toUnicode, want) {
+			t.Errorf("ToUnicode map lacks %s", want)
+		}
+	}
+
+	if width := TextWidth("Молоко", 10, false); width <= 0 || width == 6*5.56 {
+		t.Errorf("TextWidth = %v, want the embedded font's widths", width)
+	}
+}
+
+func TestPDFTextWithoutFont(t *testing.T) {
+	if got := pdfString("Injera እንጀራ"); got != "Injera ????" {
+		t.Errorf("pdfString = %q, want the characters WinAnsi lacks as '?'", got)
+	}
+}
+
+func TestLabelsBeyondASCII(t *testing.T) {
+	useTextFont(t, goregular.TTF)
+
+	labels := []Domain.Label{{Name: "Молоко", Price: "45.00 ETB", Barcode: "2000000000015"}}
+	service := NewLabelService()
+	if _, err := service.RenderPNG(labels); err != nil {
+		t.Errorf("RenderPNG: %v", err)
+	}
+	if _, err := service.RenderPDF(labels); err != nil {
+		t.Errorf("RenderPDF: %v", err)
+	}
+}
@@ -0,0 +1,693 @@
+package Infrastructure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	Domain "ShopOps/Domain"
+)
+
+// Report pages are A4 portrait. Content keeps clear of the margins and of the
+// footer band that carries the page numbers.
+const (
+	reportMargin       = 40.0
+	reportFooterHeight = 24.0
+	reportRowHeight    = 16.0
+	reportChartHeight  = 150.0
+	reportChartGutter  = 48.0 // Room for the value axis labels
+	reportMaxBars      = 10
+)
+
+// Chart colours, used in turn for bars and series.
+var reportPalette = [][3]float64{
+	{0.20, 0.42, 0.69},
+	{0.87, 0.45, 0.16},
+	{0.30, 0.62, 0.33},
+	{0.75, 0.22, 0.24},
+	{0.49, 0.36, 0.66},
+}
+
+// RenderReportPDF lays out a sales, expenses, profit or inventory report as a
+// printable PDF with the business letterhead, summary tables, charts and page
+// numbers.
+func RenderReportPDF(data interface{}, reportType Domain.ReportType, business *Domain.Business, generatedAt time.Time) ([]byte, error) {
+	r := &reportPDF{
+		doc:      NewPDFDocument(PageA4Width, PageA4Height),
+		business: business,
+	}
+
+	switch report := data.(type) {
+	case *Domain.SalesReport:
+		r.letterhead("Sales Report", report.Period, generatedAt)
+		r.salesReport(report)
+	case *Domain.ExpensesReport:
+		r.letterhead("Expenses Report", report.Period, generatedAt)
+		r.expensesReport(report)
+	case *Domain.ProfitReport:
+		r.letterhead("Profit and Loss", report.Period, generatedAt)
+		r.profitReport(report)
+	case *Domain.InventoryReport:
+		r.letterhead("Inventory Report", "As of "+generatedAt.Format("2006-01-02"), generatedAt)
+		r.inventoryReport(report)
+	default:
+		return nil, fmt.Errorf("PDF export is not available for %s reports", reportType)
+	}
+
+	r.pageNumbers()
+
+	return r.doc.Bytes()
+}
+
+type reportPDF struct {
+	doc      *PDFDocument
+	business *Domain.Business
+	title    string
+	y        float64
+}
+
+func (r *reportPDF) salesReport(report *Domain.SalesReport) {
+	r.heading("Summary")
+	r.keyValues([][2]string{
+		{"Revenue", r.money(report.TotalAmount)},
+		{"Items sold", formatQuantity(report.TotalSales)},
+		{"Transactions", fmt.Sprintf("%d", report.TotalTransactions)},
+		{"Average sale", r.money(report.AverageSale)},
+	})
+
+	if len(report.DailyBreakdown) > 0 {
+		labels := make([]string, len(report.DailyBreakdown))
+		values := make([]float64, len(report.DailyBreakdown))
+		for i, day := range report.DailyBreakdown {
+			labels[i] = shortDate(day.Date)
+			values[i] = day.Amount
+		}
+		r.lineChart("Daily revenue", labels, []chartSeries{{name: "Revenue", values: values}})
+	}
+
+	var bars []chartBar
+	for _, category := range report.Categories {
+		if category.Depth == 0 {
+			bars = append(bars, chartBar{label: category.Name, value: category.Revenue})
+		}
+	}
+	if len(bars) > 0 {
+		r.barChart("Revenue by category", bars)
+	}
+
+	if len(report.TopProducts) > 0 {
+		r.heading("Top products")
+		rows := make([][]string, len(report.TopProducts))
+		for i, product := range report.TopProducts {
+			rows[i] = []string{product.ProductName, formatQuantity(product.Quantity), r.money(product.TotalAmount)}
+		}
+		r.table([]reportColumn{
+			{title: "Product", width: 0.6},
+			{title: "Quantity", width: 0.15, right: true},
+			{title: "Revenue", width: 0.25, right: true},
+		}, rows)
+	}
+
+	if len(report.Categories) > 0 {
+		r.heading("Categories")
+		rows := make([][]string, len(report.Categories))
+		for i, category := range report.Categories {
+			rows[i] = []string{
+				strings.Repeat("    ", category.Depth) + category.Name,
+				formatQuantity(category.Quantity),
+				r.money(category.Revenue),
+				r.money(category.GrossProfit),
+				fmt.Sprintf("%.1f%%", category.GrossMargin),
+			}
+		}
+		r.table([]reportColumn{
+			{title: "Category", width: 0.34},
+			{title: "Quantity", width: 0.12, right: true},
+			{title: "Revenue", width: 0.2, right: true},
+			{title: "Gross profit", width: 0.2, right: true},
+			{title: "Margin", width: 0.14, right: true},
+		}, rows)
+	}
+}
+
+func (r *reportPDF) expensesReport(report *Domain.ExpensesReport) {
+	entries := 0
+	for _, category := range report.CategoryBreakdown {
+		entries += category.Count
+	}
+
+	r.heading("Summary")
+	r.keyValues([][2]string{
+		{"Total expenses", r.money(report.TotalExpenses)},
+		{"Entries", fmt.Sprintf("%d", entries)},
+	})
+
+	if len(report.CategoryBreakdown) > 0 {
+		bars := make([]chartBar, len(report.CategoryBreakdown))
+		for i, category := range report.CategoryBreakdown {
+			bars[i] = chartBar{label: titleCase(string(category.Category)), value: category.TotalAmount}
+		}
+		r.barChart("Expenses by category", bars)
+	}
+
+	if len(report.DailyExpenses) > 0 {
+		labels := make([]string, len(report.DailyExpenses))
+		values := make([]float64, len(report.DailyExpenses))
+		for i, day := range report.DailyExpenses {
+			labels[i] = shortDate(day.Date)
+			values[i] = day.Amount
+		}
+		r.lineChart("Daily expenses", labels, []chartSeries{{name: "Expenses", values: values}})
+	}
+
+	if len(report.CategoryBreakdown) > 0 {
+		r.heading("Categories")
+		rows := make([][]string, len(report.CategoryBreakdown))
+		for i, category := range report.CategoryBreakdown {
+			rows[i] = []string{
+				titleCase(string(category.Category)),
+				fmt.Sprintf("%d", category.Count),
+				r.money(category.TotalAmount),
+				fmt.Sprintf("%.1f%%", category.Percentage),
+			}
+		}
+		r.table([]reportColumn{
+			{title: "Category", width: 0.4},
+			{title: "Entries", width: 0.15, right: true},
+			{title: "Amount", width: 0.27, right: true},
+			{title: "Share", width: 0.18, right: true},
+		}, rows)
+	}
+}
+
+func (r *reportPDF) profitReport(report *Domain.ProfitReport) {
+	r.heading("Summary")
+	r.keyValues([][2]string{
+		{"Revenue", r.money(report.Revenue)},
+		{"Cost of goods sold", r.money(report.CostOfGoodsSold)},
+		{"Gross profit", r.money(report.GrossProfit)},
+		{"Gross margin", fmt.Sprintf("%.1f%%", report.GrossMargin)},
+		{"Operating expenses", r.money(report.OperatingExpenses)},
+		{"Stock purchases (in cost of goods)", r.money(report.StockPurchases)},
+		{"Net profit", r.money(report.NetProfit)},
+		{"Profit margin", fmt.Sprintf("%.1f%%", report.ProfitMargin)},
+	})
+
+	r.barChart("Revenue to net profit", []chartBar{
+		{label: "Revenue", value: report.Revenue},
+		{label: "Cost of goods", value: report.CostOfGoodsSold},
+		{label: "Gross profit", value: report.GrossProfit},
+		{label: "Operating expenses", value: report.OperatingExpenses},
+		{label: "Net profit", value: report.NetProfit},
+	})
+
+	if len(report.Trends) > 0 {
+		labels := make([]string, len(report.Trends))
+		sales := make([]float64, len(report.Trends))
+		expenses := make([]float64, len(report.Trends))
+		profit := make([]float64, len(report.Trends))
+		for i, trend := range report.Trends {
+			labels[i] = trend.Period
+			sales[i] = trend.Sales
+			expenses[i] = trend.Expenses
+			profit[i] = trend.Profit
+		}
+		r.lineChart("Trend", labels, []chartSeries{
+			{name: "Sales", values: sales},
+			{name: "Expenses", values: expenses},
+			{name: "Profit", values: profit},
+		})
+	}
+}
+
+func (r *reportPDF) inventoryReport(report *Domain.InventoryReport) {
+	r.heading("Summary")
+	r.keyValues([][2]string{
+		{"Products", fmt.Sprintf("%d", report.TotalProducts)},
+		{"Units in stock", formatQuantity(report.TotalStock)},
+		{"Stock value", r.money(report.TotalValue)},
+		{"Low stock items", fmt.Sprintf("%d", len(report.LowStockItems))},
+	})
+
+	var bars []chartBar
+	for _, category := range report.Categories {
+		if category.Depth == 0 {
+			bars = append(bars, chartBar{label: category.Name, value: category.StockValue})
+		}
+	}
+	if len(bars) > 0 {
+		r.barChart("Stock value by category", bars)
+	}
+
+	if len(report.Locations) > 0 {
+		r.heading("Locations")
+		rows := make([][]string, len(report.Locations))
+		for i, location := range report.Locations {
+			rows[i] = []string{location.LocationName, formatQuantity(location.TotalStock), r.money(location.TotalValue)}
+		}
+		r.table([]reportColumn{
+			{title: "Location", width: 0.5},
+			{title: "Units", width: 0.2, right: true},
+			{title: "Value", width: 0.3, right: true},
+		}, rows)
+	}
+
+	if len(report.LowStockItems) > 0 {
+		r.heading("Low stock")
+		rows := make([][]string, len(report.LowStockItems))
+		for i, item := range report.LowStockItems {
+			rows[i] = []string{
+				item.ProductName,
+				formatQuantity(item.Current),
+				formatQuantity(item.Minimum),
+				formatQuantity(item.Difference),
+			}
+		}
+		r.table([]reportColumn{
+			{title: "Product", width: 0.49},
+			{title: "In stock", width: 0.17, right: true},
+			{title: "Minimum", width: 0.17, right: true},
+			{title: "Short by", width: 0.17, right: true},
+		}, rows)
+	}
+}
+
+func (r *reportPDF) contentWidth() float64 {
+	return r.doc.Width() - 2*reportMargin
+}
+
+// letterhead opens the first page with the business's name and contact
+// details on the left and the report title on the right.
+func (r *reportPDF) letterhead(title, period string, generatedAt time.Time) {
+	r.title = title
+	r.doc.AddPage()
+
+	right := r.doc.Width() - reportMargin
+	top := reportMargin
+
+	r.doc.SetFillColor(0, 0, 0)
+	r.doc.Text(reportMargin, top+16, 16, true, FitText(r.business.Name, 16, true, r.contentWidth()*0.55))
+
+	var contact []string
+	if r.business.Address != "" {
+		contact = append(contact, r.business.Address)
+	}
+	if place := joinNonEmpty(", ", r.business.City, r.business.Country); place != "" {
+		contact = append(contact, place)
+	}
+	if reach := joinNonEmpty("  |  ", r.business.Phone, r.business.Email); reach != "" {
+		contact = append(contact, reach)
+	}
+
+	r.doc.SetFillColor(0.35, 0.35, 0.35)
+	lineY := top + 32
+	for _, line := range contact {
+		r.doc.Text(reportMargin, lineY, 9, false, FitText(line, 9, false, r.contentWidth()*0.55))
+		lineY += 12
+	}
+
+	r.doc.SetFillColor(0, 0, 0)
+	r.doc.Text(right-TextWidth(title, 14, true), top+16, 14, true, title)
+	r.doc.SetFillColor(0.35, 0.35, 0.35)
+	r.doc.Text(right-TextWidth(period, 9, false), top+32, 9, false, period)
+	generated := "Generated " + generatedAt.Format("2006-01-02 15:04 MST")
+	r.doc.Text(right-TextWidth(generated, 9, false), top+44, 9, false, generated)
+
+	r.y = math.Max(lineY, top+44) + 8
+	r.rule()
+}
+
+// newPage continues the report on a fresh page under a running header.
+func (r *reportPDF) newPage() {
+	r.doc.AddPage()
+
+	r.doc.SetFillColor(0.35, 0.35, 0.35)
+	r.doc.Text(reportMargin, reportMargin+9, 9, true, FitText(r.business.Name, 9, true, r.contentWidth()/2))
+	r.doc.Text(r.doc.Width()-reportMargin-TextWidth(r.title, 9, false), reportMargin+9, 9, false, r.title)
+
+	r.y = reportMargin + 16
+	r.rule()
+}
+
+func (r *reportPDF) rule() {
+	r.doc.SetStrokeColor(0.75, 0.75, 0.75)
+	r.doc.SetLineWidth(0.75)
+	r.doc.Line(reportMargin, r.y, r.doc.Width()-reportMargin, r.y)
+	r.y += 6
+}
+
+// ensure starts a new page unless height points still fit on this one.
+func (r *reportPDF) ensure(height float64) {
+	if r.y+height > r.doc.Height()-reportMargin-reportFooterHeight {
+		r.newPage()
+	}
+}
+
+func (r *reportPDF) heading(text string) {
+	// Keep a heading with at least two rows of what follows
+	r.ensure(26 + 3*reportRowHeight)
+	r.y += 18
+	r.doc.SetFillColor(0, 0, 0)
+	r.doc.Text(reportMargin, r.y, 12, true, text)
+	r.y += 8
+}
+
+// keyValues lays out a two-column summary table.
+func (r *reportPDF) keyValues(rows [][2]string) {
+	width := r.contentWidth() * 0.6
+	for i, row := range rows {
+		r.ensure(reportRowHeight)
+		if i%2 == 0 {
+			r.doc.SetFillColor(0.95, 0.95, 0.95)
+			r.doc.FillRect(reportMargin, r.y, width, reportRowHeight)
+		}
+		r.doc.SetFillColor(0, 0, 0)
+		r.doc.Text(reportMargin+6, r.y+11, 9, false, row[0])
+		r.doc.Text(reportMargin+width-6-TextWidth(row[1], 9, true), r.y+11, 9, true, row[1])
+		r.y += reportRowHeight
+	}
+}
+
+// reportColumn is a table column; widths are fractions of the content width.
+type reportColumn struct {
+	title string
+	width float64
+	right bool
+}
+
+// table lays out rows under a header that repeats on every page the table
+// runs onto.
+func (r *reportPDF) table(columns []reportColumn, rows [][]string) {
+	header := func() {
+		r.doc.SetFillColor(0.20, 0.42, 0.69)
+		r.doc.FillRect(reportMargin, r.y, r.contentWidth(), reportRowHeight)
+		r.doc.SetFillColor(1, 1, 1)
+		r.tableRow(columns, func(i int) string { return columns[i].title }, true)
+	}
+
+	r.ensure(2 * reportRowHeight)
+	header()
+	for i, row := range rows {
+		if r.y+reportRowHeight > r.doc.Height()-reportMargin-reportFooterHeight {
+			r.newPage()
+			header()
+		}
+		if i%2 == 1 {
+			r.doc.SetFillColor(0.95, 0.95, 0.95)
+			r.doc.FillRect(reportMargin, r.y, r.contentWidth(), reportRowHeight)
+		}
+		r.doc.SetFillColor(0, 0, 0)
+		r.tableRow(columns, func(i int) string { return row[i] }, false)
+	}
+}
+
+func (r *reportPDF) tableRow(columns []reportColumn, cell func(i int) string, bold bool) {
+	const padding = 6.0
+	x := reportMargin
+	for i, column := range columns {
+		width := column.width * r.contentWidth()
+		text := FitText(cell(i), 9, bold, width-2*padding)
+		if column.right {
+			r.doc.Text(x+width-padding-TextWidth(text, 9, bold), r.y+11, 9, bold, text)
+		} else {
+			r.doc.Text(x+padding, r.y+11, 9, bold, text)
+		}
+		x += width
+	}
+	r.y += reportRowHeight
+}
+
+type chartBar struct {
+	label string
+	value float64
+}
+
+// barChart draws one bar per value, largest first when there are more than
+// fit, with the value axis running through zero.
+func (r *reportPDF) barChart(title string, bars []chartBar) {
+	if len(bars) > reportMaxBars {
+		bars = append([]chartBar(nil), bars...)
+		sort.SliceStable(bars, func(i, j int) bool { return math.Abs(bars[i].value) > math.Abs(bars[j].value) })
+		bars = bars[:reportMaxBars]
+	}
+
+	values := make([]float64, len(bars))
+	for i, bar := range bars {
+		values[i] = bar.value
+	}
+
+	plot := r.chartFrame(title, values)
+	slot := plot.width / float64(len(bars))
+	barWidth := slot * 0.6
+
+	for i, bar := range bars {
+		x := plot.left + float64(i)*slot + (slot-barWidth)/2
+		top, bottom := plot.yFor(math.Max(bar.value, 0)), plot.yFor(math.Min(bar.value, 0))
+		color := reportPalette[0]
+		if bar.value < 0 {
+			color = reportPalette[3]
+		}
+		r.doc.SetFillColor(color[0], color[1], color[2])
+		r.doc.FillRect(x, top, barWidth, bottom-top)
+
+		r.doc.SetFillColor(0.25, 0.25, 0.25)
+		label := FitText(bar.label, 7, false, slot-4)
+		r.doc.Text(plot.left+float64(i)*slot+(slot-TextWidth(label, 7, false))/2, plot.bottom+11, 7, false, label)
+	}
+
+	r.y = plot.bottom + 20
+}
+
+type chartSeries struct {
+	name   string
+	values []float64
+}
+
+// lineChart plots each series across the labels, marking the points when
+// there are few enough to tell apart.
+func (r *reportPDF) lineChart(title string, labels []string, series []chartSeries) {
+	var values []float64
+	for _, s := range series {
+		values = append(values, s.values...)
+	}
+
+	plot := r.chartFrame(title, values)
+
+	xFor := func(i int) float64 {
+		if len(labels) == 1 {
+			return plot.left + plot.width/2
+		}
+		return plot.left + plot.width*float64(i)/float64(len(labels)-1)
+	}
+
+	for n, s := range series {
+		color := reportPalette[n%len(reportPalette)]
+		r.doc.SetStrokeColor(color[0], color[1], color[2])
+		r.doc.SetFillColor(color[0], color[1], color[2])
+		r.doc.SetLineWidth(1.5)
+		for i := 1; i < len(s.values); i++ {
+			r.doc.Line(xFor(i-1), plot.yFor(s.values[i-1]), xFor(i), plot.yFor(s.values[i]))
+		}
+		if len(s.values) <= 31 {
+			for i, value := range s.values {
+				r.doc.FillRect(xFor(i)-1.5, plot.yFor(value)-1.5, 3, 3)
+			}
+		}
+	}
+
+	// Label about eight evenly spaced points so the dates stay legible
+	step := int(math.Ceil(float64(len(labels)) / 8))
+	r.doc.SetFillColor(0.25, 0.25, 0.25)
+	for i := 0; i < len(labels); i += step {
+		label := FitText(labels[i], 7, false, plot.width/8)
+		r.doc.Text(xFor(i)-TextWidth(label, 7, false)/2, plot.bottom+11, 7, false, label)
+	}
+
+	r.y = plot.bottom + 20
+
+	if len(series) > 1 {
+		x := plot.left
+		for n, s := range series {
+			color := reportPalette[n%len(reportPalette)]
+			r.doc.SetFillColor(color[0], color[1], color[2])
+			r.doc.FillRect(x, r.y-7, 8, 8)
+			r.doc.SetFillColor(0.25, 0.25, 0.25)
+			r.doc.Text(x+12, r.y, 8, false, s.name)
+			x += 24 + TextWidth(s.name, 8, false)
+		}
+		r.y += 10
+	}
+}
+
+// chartPlot is the area a chart draws its data into.
+type chartPlot struct {
+	left, width   float64
+	top, bottom   float64
+	lower, higher float64
+}
+
+func (p chartPlot) yFor(value float64) float64 {
+	return p.bottom - (value-p.lower)/(p.higher-p.lower)*(p.bottom-p.top)
+}
+
+// chartFrame titles a chart, draws its value axis and gridlines to fit the
+// values, and returns the plot area.
+func (r *reportPDF) chartFrame(title string, values []float64) chartPlot {
+	r.ensure(reportChartHeight + 60)
+
+	r.y += 18
+	r.doc.SetFillColor(0, 0, 0)
+	r.doc.Text(reportMargin, r.y, 11, true, title)
+	r.y += 10
+
+	lower, higher, step := chartScale(values)
+	plot := chartPlot{
+		left:   reportMargin + reportChartGutter,
+		width:  r.contentWidth() - reportChartGutter,
+		top:    r.y,
+		bottom: r.y + reportChartHeight,
+		lower:  lower,
+		higher: higher,
+	}
+
+	r.doc.SetLineWidth(0.5)
+	for value := lower; value <= higher+step/2; value += step {
+		y := plot.yFor(value)
+		if math.Abs(value) < step/2 {
+			r.doc.SetStrokeColor(0.4, 0.4, 0.4)
+		} else {
+			r.doc.SetStrokeColor(0.88, 0.88, 0.88)
+		}
+		r.doc.Line(plot.left, y, plot.left+plot.width, y)
+
+		label := formatCompact(value)
+		r.doc.SetFillColor(0.35, 0.35, 0.35)
+		r.doc.Text(plot.left-6-TextWidth(label, 7, false), y+2.5, 7, false, label)
+	}
+
+	return plot
+}
+
+// chartScale picks a value axis that includes zero and steps in round
+// numbers of 1, 2 or 5 times a power of ten.
+func chartScale(values []float64) (lower, higher, step float64) {
+	for _, value := range values {
+		lower = math.Min(lower, value)
+		higher = math.Max(higher, value)
+	}
+	if higher == lower {
+		higher = lower + 1
+	}
+
+	raw := (higher - lower) / 4
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step = 10 * magnitude
+	for _, factor := range []float64{1, 2, 5} {
+		if raw <= factor*magnitude {
+			step = factor * magnitude
+			break
+		}
+	}
+
+	return math.Floor(lower/step) * step, math.Ceil(higher/step) * step, step
+}
+
+// pageNumbers stamps every page with its number once the count is known.
+func (r *reportPDF) pageNumbers() {
+	total := r.doc.PageCount()
+	for page := 1; page <= total; page++ {
+		r.doc.SetPage(page)
+		y := r.doc.Height() - reportMargin + 4
+		label := fmt.Sprintf("Page %d of %d", page, total)
+		r.doc.SetFillColor(0.45, 0.45, 0.45)
+		r.doc.Text(reportMargin, y, 8, false, FitText(r.business.Name+" - "+r.title, 8, false, r.contentWidth()-80))
+		r.doc.Text(r.doc.Width()-reportMargin-TextWidth(label, 8, false), y, 8, false, label)
+	}
+}
+
+func (r *reportPDF) money(amount float64) string {
+	return strings.TrimSpace(r.business.Currency + " " + formatThousands(amount, 2))
+}
+
+// formatQuantity shows whole quantities without decimals.
+func formatQuantity(quantity float64) string {
+	if quantity == math.Trunc(quantity) {
+		return formatThousands(quantity, 0)
+	}
+	return formatThousands(quantity, 2)
+}
+
+// formatThousands formats a number with comma thousands separators.
+func formatThousands(value float64, decimals int) string {
+	text := fmt.Sprintf("%.*f", decimals, math.Abs(value))
+	whole, fraction := text, ""
+	if dot := strings.IndexByte(text, '.'); dot >= 0 {
+		whole, fraction = text[:dot], text[dot:]
+	}
+
+	var b strings.Builder
+	if value < 0 && strings.Trim(text, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	b.WriteString(fraction)
+	return b.String()
+}
+
+// formatCompact shortens axis values, e.g. 12500 to 12.5k.
+func formatCompact(value float64) string {
+	abs := math.Abs(value)
+	switch {
+	case abs >= 1e9:
+		return roundedFloat(value/1e9, 1) + "B"
+	case abs >= 1e6:
+		return roundedFloat(value/1e6, 1) + "M"
+	case abs >= 1e3:
+		return roundedFloat(value/1e3, 1) + "k"
+	default:
+		return roundedFloat(value, 2)
+	}
+}
+
+// roundedFloat formats a value to at most the given decimals, dropping
+// trailing zeros.
+func roundedFloat(value float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	return strconv.FormatFloat(math.Round(value*scale)/scale, 'f', -1, 64)
+}
+
+// shortDate turns a YYYY-MM-DD day into a short axis label.
+func shortDate(day string) string {
+	parsed, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return day
+	}
+	return parsed.Format("Jan 2")
+}
+
+// titleCase turns an identifier such as stock_purchase into Stock purchase.
+func titleCase(identifier string) string {
+	text := strings.ReplaceAll(identifier, "_", " ")
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+func joinNonEmpty(separator string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, separator)
+}
@@ -0,0 +1,308 @@
+package Infrastructure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	Domain "ShopOps/Domain"
+)
+
+// ReportRecords are the sales and expenses behind a report, listed on the
+// transaction sheets of its workbook.
+type ReportRecords struct {
+	Sales        []Domain.Sale
+	Expenses     []Domain.Expense
+	ProductNames map[string]string // Product names by ID
+}
+
+// RenderReportXLSX builds a workbook for a report with a summary sheet and a
+// sheet per breakdown. Amounts carry the business's currency and times are
+// shown in its timezone.
+func RenderReportXLSX(data interface{}, reportType Domain.ReportType, business *Domain.Business, records ReportRecords, generatedAt time.Time) ([]byte, error) {
+	loc := business.Location()
+	workbook := XLSXWorkbook{Currency: business.Currency}
+
+	switch report := data.(type) {
+	case *Domain.SalesReport:
+		workbook.Sheets = []XLSXSheet{
+			summarySheet(business, reportType, report.Period, generatedAt, [][]XLSXCell{
+				{TextCell("Total sales"), MoneyCell(report.TotalAmount)},
+				{TextCell("Items sold"), NumberCell(report.TotalSales)},
+				{TextCell("Transactions"), IntegerCell(report.TotalTransactions)},
+				{TextCell("Average sale"), MoneyCell(report.AverageSale)},
+			}),
+			dailySalesSheet(report.DailyBreakdown),
+			topProductsSheet(report.TopProducts),
+			salesCategoriesSheet(report.Categories),
+			salesSheet("Transactions", records, loc),
+		}
+	case *Domain.ExpensesReport:
+		workbook.Sheets = []XLSXSheet{
+			summarySheet(business, reportType, report.Period, generatedAt, [][]XLSXCell{
+				{TextCell("Total expenses"), MoneyCell(report.TotalExpenses)},
+			}),
+			dailyExpensesSheet(report.DailyExpenses),
+			expenseCategoriesSheet(report.CategoryBreakdown),
+			expensesSheet("Transactions", records.Expenses, loc),
+		}
+	case *Domain.ProfitReport:
+		workbook.Sheets = []XLSXSheet{
+			summarySheet(business, reportType, report.Period, generatedAt, [][]XLSXCell{
+				{TextCell("Revenue"), MoneyCell(report.Revenue)},
+				{TextCell("Cost of goods sold"), MoneyCell(report.CostOfGoodsSold)},
+				{TextCell("Gross profit"), MoneyCell(report.GrossProfit)},
+				{TextCell("Gross margin"), PercentCell(report.GrossMargin)},
+				{TextCell("Operating expenses"), MoneyCell(report.OperatingExpenses)},
+				{TextCell("Stock purchases"), MoneyCell(report.StockPurchases)},
+				{TextCell("Net profit"), MoneyCell(report.NetProfit)},
+				{TextCell("Profit margin"), PercentCell(report.ProfitMargin)},
+			}),
+			dailyProfitSheet(records, loc),
+			salesSheet("Sales", records, loc),
+			expensesSheet("Expenses", records.Expenses, loc),
+		}
+	case *Domain.InventoryReport:
+		workbook.Sheets = []XLSXSheet{
+			summarySheet(business, reportType, "", generatedAt, [][]XLSXCell{
+				{TextCell("Products"), IntegerCell(report.TotalProducts)},
+				{TextCell("Units in stock"), NumberCell(report.TotalStock)},
+				{TextCell("Stock value"), MoneyCell(report.TotalValue)},
+				{TextCell("Low stock items"), IntegerCell(len(report.LowStockItems))},
+			}),
+			lowStockSheet(report.LowStockItems),
+			locationsSheet(report.Locations),
+			stockCategoriesSheet(report.Categories),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported report type for XLSX: %s", reportType)
+	}
+
+	return WriteWorkbook(workbook)
+}
+
+func headerRow(titles ...string) []XLSXCell {
+	row := make([]XLSXCell, len(titles))
+	for i, title := range titles {
+		row[i] = BoldCell(title)
+	}
+	return row
+}
+
+func summarySheet(business *Domain.Business, reportType Domain.ReportType, period string, generatedAt time.Time, figures [][]XLSXCell) XLSXSheet {
+	rows := [][]XLSXCell{
+		headerRow("Item", "Value"),
+		{TextCell("Business"), TextCell(business.Name)},
+		{TextCell("Report"), TextCell(titleCase(string(reportType)))},
+	}
+	if period != "" {
+		rows = append(rows, []XLSXCell{TextCell("Period"), TextCell(period)})
+	}
+	rows = append(rows, []XLSXCell{TextCell("Generated"), DateTimeCell(generatedAt)})
+	rows = append(rows, figures...)
+
+	return XLSXSheet{Name: "Summary", Rows: rows, FreezeHeader: true}
+}
+
+// reportDateCell shows a YYYY-MM-DD breakdown date as a date, or as text if
+// it does not parse.
+func reportDateCell(date string) XLSXCell {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return TextCell(date)
+	}
+	return DateCell(parsed)
+}
+
+func dailySalesSheet(days []Domain.DailySales) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Date", "Sales", "Items sold", "Transactions")}
+	for _, day := range days {
+		rows = append(rows, []XLSXCell{
+			reportDateCell(day.Date),
+			MoneyCell(day.Amount),
+			NumberCell(day.Sales),
+			IntegerCell(day.Transactions),
+		})
+	}
+	return XLSXSheet{Name: "Daily", Rows: rows, FreezeHeader: true}
+}
+
+func topProductsSheet(products []Domain.TopProduct) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Product", "Quantity", "Sales")}
+	for _, product := range products {
+		rows = append(rows, []XLSXCell{
+			TextCell(product.ProductName),
+			NumberCell(product.Quantity),
+			MoneyCell(product.TotalAmount),
+		})
+	}
+	return XLSXSheet{Name: "Top Products", Rows: rows, FreezeHeader: true}
+}
+
+func salesCategoriesSheet(categories []Domain.CategorySales) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Category", "Quantity", "Revenue", "Cost of goods", "Gross profit", "Gross margin")}
+	for _, category := range categories {
+		rows = append(rows, []XLSXCell{
+			TextCell(strings.Repeat("    ", category.Depth) + category.Name),
+			NumberCell(category.Quantity),
+			MoneyCell(category.Revenue),
+			MoneyCell(category.CostOfGoods),
+			MoneyCell(category.GrossProfit),
+			PercentCell(category.GrossMargin),
+		})
+	}
+	return XLSXSheet{Name: "Categories", Rows: rows, FreezeHeader: true}
+}
+
+func salesSheet(name string, records ReportRecords, loc *time.Location) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Date", "Sale ID", "Product", "Customer", "Quantity", "Unit price",
+		"Discount", "Tax", "Total", "Cost of goods", "Payment method", "Payment status")}
+	for _, sale := range records.Sales {
+		product := ""
+		if sale.ProductID != nil {
+			product = records.ProductNames[sale.ProductID.Hex()]
+		}
+		rows = append(rows, []XLSXCell{
+			DateTimeCell(sale.CreatedAt.In(loc)),
+			TextCell(sale.ID.Hex()),
+			TextCell(product),
+			TextCell(sale.CustomerName),
+			NumberCell(sale.Quantity),
+			MoneyCell(sale.UnitPrice),
+			MoneyCell(sale.Discount),
+			MoneyCell(sale.Tax),
+			MoneyCell(sale.FinalAmount),
+			MoneyCell(sale.CostOfGoods),
+			TextCell(string(sale.PaymentMethod)),
+			TextCell(string(sale.PaymentStatus)),
+		})
+	}
+	return XLSXSheet{Name: name, Rows: rows, FreezeHeader: true}
+}
+
+func dailyExpensesSheet(days []Domain.DailyExpense) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Date", "Expenses", "Count")}
+	for _, day := range days {
+		rows = append(rows, []XLSXCell{
+			reportDateCell(day.Date),
+			MoneyCell(day.Amount),
+			IntegerCell(day.Count),
+		})
+	}
+	return XLSXSheet{Name: "Daily", Rows: rows, FreezeHeader: true}
+}
+
+func expenseCategoriesSheet(categories []Domain.CategoryExpense) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Category", "Amount", "Count", "Share")}
+	for _, category := range categories {
+		rows = append(rows, []XLSXCell{
+			TextCell(titleCase(string(category.Category))),
+			MoneyCell(category.TotalAmount),
+			IntegerCell(category.Count),
+			PercentCell(category.Percentage),
+		})
+	}
+	return XLSXSheet{Name: "Categories", Rows: rows, FreezeHeader: true}
+}
+
+func expensesSheet(name string, expenses []Domain.Expense, loc *time.Location) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Date", "Expense ID", "Category", "Description", "Amount")}
+	for _, expense := range expenses {
+		rows = append(rows, []XLSXCell{
+			DateCell(expense.Date.In(loc)),
+			TextCell(expense.ID.Hex()),
+			TextCell(titleCase(string(expense.Category))),
+			TextCell(expense.Description),
+			MoneyCell(expense.Amount),
+		})
+	}
+	return XLSXSheet{Name: name, Rows: rows, FreezeHeader: true}
+}
+
+// dailyProfitSheet totals the sales and expenses of each day. Stock purchases
+// are left out as the profit report counts them through cost of goods sold.
+func dailyProfitSheet(records ReportRecords, loc *time.Location) XLSXSheet {
+	type dayTotals struct {
+		revenue, costOfGoods, expenses float64
+	}
+	days := make(map[string]*dayTotals)
+	day := func(t time.Time) *dayTotals {
+		key := t.In(loc).Format("2006-01-02")
+		if days[key] == nil {
+			days[key] = &dayTotals{}
+		}
+		return days[key]
+	}
+
+	for _, sale := range records.Sales {
+		totals := day(sale.CreatedAt)
+		totals.revenue += sale.FinalAmount
+		totals.costOfGoods += sale.CostOfGoods
+	}
+	for _, expense := range records.Expenses {
+		if expense.Category == Domain.ExpenseCategoryStockPurchase {
+			continue
+		}
+		day(expense.Date).expenses += expense.Amount
+	}
+
+	dates := make([]string, 0, len(days))
+	for date := range days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	rows := [][]XLSXCell{headerRow("Date", "Revenue", "Cost of goods", "Gross profit", "Operating expenses", "Net profit")}
+	for _, date := range dates {
+		totals := days[date]
+		grossProfit := totals.revenue - totals.costOfGoods
+		rows = append(rows, []XLSXCell{
+			reportDateCell(date),
+			MoneyCell(totals.revenue),
+			MoneyCell(totals.costOfGoods),
+			MoneyCell(grossProfit),
+			MoneyCell(totals.expenses),
+			MoneyCell(grossProfit - totals.expenses),
+		})
+	}
+	return XLSXSheet{Name: "Daily", Rows: rows, FreezeHeader: true}
+}
+
+func lowStockSheet(items []Domain.LowStockItem) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Product", "Current", "Minimum", "Difference")}
+	for _, item := range items {
+		rows = append(rows, []XLSXCell{
+			TextCell(item.ProductName),
+			NumberCell(item.Current),
+			NumberCell(item.Minimum),
+			NumberCell(item.Difference),
+		})
+	}
+	return XLSXSheet{Name: "Low Stock", Rows: rows, FreezeHeader: true}
+}
+
+func locationsSheet(locations []Domain.LocationStockSummary) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Location", "Units in stock", "Stock value")}
+	for _, location := range locations {
+		rows = append(rows, []XLSXCell{
+			TextCell(location.LocationName),
+			NumberCell(location.TotalStock),
+			MoneyCell(location.TotalValue),
+		})
+	}
+	return XLSXSheet{Name: "Locations", Rows: rows, FreezeHeader: true}
+}
+
+func stockCategoriesSheet(categories []Domain.CategoryStock) XLSXSheet {
+	rows := [][]XLSXCell{headerRow("Category", "Products", "Units in stock", "Stock value")}
+	for _, category := range categories {
+		rows = append(rows, []XLSXCell{
+			TextCell(strings.Repeat("    ", category.Depth) + category.Name),
+			IntegerCell(category.Products),
+			NumberCell(category.Stock),
+			MoneyCell(category.StockValue),
+		})
+	}
+	return XLSXSheet{Name: "Categories", Rows: rows, FreezeHeader: true}
+}
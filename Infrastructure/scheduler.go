@@ -0,0 +1,38 @@
+package Infrastructure
+
+import (
+	"log"
+	"time"
+)
+
+// RunEvery runs a job in the background at a fixed interval for as long as
+// the server runs. A run that fails or panics is logged and the next run goes
+// ahead as usual.
+func RunEvery(name string, interval time.Duration, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			runJob(name, job)
+		}
+	}()
+}
+
+// RunOnce runs a job once in the background, as RunEvery runs each of its
+// runs.
+func RunOnce(name string, job func() error) {
+	go runJob(name, job)
+}
+
+func runJob(name string, job func() error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %q stopped unexpectedly: %v", name, r)
+		}
+	}()
+
+	if err := job(); err != nil {
+		log.Printf("Job %q failed: %v", name, err)
+	}
+}
@@ -0,0 +1,512 @@
+package Infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadCSV returns the records of a CSV file. Rows may have differing numbers
+// of fields, and a leading byte order mark is ignored.
+func ReadCSV(data []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ReadXLSX returns the cells of the first worksheet of an XLSX workbook as
+// text, one slice per row. Empty rows are kept so row numbers match the file.
+func ReadXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Items []xlsxStringItem `xml:"si"`
+		}
+		if err := decodeZipXML(f, &sst); err != nil {
+			return nil, fmt.Errorf("failed to read shared strings: %w", err)
+		}
+		for _, item := range sst.Items {
+			sharedStrings = append(sharedStrings, item.text())
+		}
+	}
+
+	sheetFile, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("invalid XLSX file: worksheet %s is missing", sheetPath)
+	}
+
+	var sheet struct {
+		Rows []struct {
+			Number int `xml:"r,attr"`
+			Cells  []struct {
+				Ref    string         `xml:"r,attr"`
+				Type   string         `xml:"t,attr"`
+				Value  string         `xml:"v"`
+				Inline xlsxStringItem `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeZipXML(sheetFile, &sheet); err != nil {
+		return nil, fmt.Errorf("failed to read worksheet: %w", err)
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		// Pad skipped rows so indexes follow the sheet's row numbers
+		for row.Number > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+
+		var cells []string
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				col = columnIndex(cell.Ref)
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				var index int
+				if _, err := fmt.Sscan(cell.Value, &index); err == nil && index < len(sharedStrings) {
+					cells[col] = sharedStrings[index]
+				}
+			case "inlineStr":
+				cells[col] = cell.Inline.text()
+			default:
+				cells[col] = cell.Value
+			}
+		}
+		rows = append(rows, cells)
+	}
+
+	return rows, nil
+}
+
+// WriteXLSX builds a single-sheet XLSX workbook holding the rows as text.
+func WriteXLSX(sheetName string, rows [][]string) ([]byte, error) {
+	cells := make([][]XLSXCell, len(rows))
+	for r, row := range rows {
+		cells[r] = make([]XLSXCell, len(row))
+		for c, value := range row {
+			cells[r][c] = TextCell(value)
+		}
+	}
+
+	return WriteWorkbook(XLSXWorkbook{Sheets: []XLSXSheet{{Name: sheetName, Rows: cells}}})
+}
+
+type xlsxCellKind int
+
+const (
+	xlsxText xlsxCellKind = iota
+	xlsxNumber
+	xlsxInteger
+	xlsxMoney
+	xlsxPercent
+	xlsxDate
+	xlsxDateTime
+)
+
+// XLSXCell is a typed worksheet cell. Numbers and dates are stored as values
+// with a display format, so spreadsheets can sum and sort them.
+type XLSXCell struct {
+	kind   xlsxCellKind
+	text   string
+	number float64
+	bold   bool
+}
+
+func TextCell(text string) XLSXCell { return XLSXCell{kind: xlsxText, text: text} }
+
+// BoldCell is text in bold, e.g. for header rows.
+func BoldCell(text string) XLSXCell { return XLSXCell{kind: xlsxText, text: text, bold: true} }
+
+func NumberCell(value float64) XLSXCell { return XLSXCell{kind: xlsxNumber, number: value} }
+func IntegerCell(value int) XLSXCell    { return XLSXCell{kind: xlsxInteger, number: float64(value)} }
+func MoneyCell(amount float64) XLSXCell { return XLSXCell{kind: xlsxMoney, number: amount} }
+
+// PercentCell takes a percentage such as 12.5 and shows it as 12.50%.
+func PercentCell(percent float64) XLSXCell { return XLSXCell{kind: xlsxPercent, number: percent / 100} }
+
+// DateCell and DateTimeCell keep the wall clock time of t, so callers convert
+// to the business's timezone first.
+func DateCell(t time.Time) XLSXCell     { return XLSXCell{kind: xlsxDate, number: excelSerial(t)} }
+func DateTimeCell(t time.Time) XLSXCell { return XLSXCell{kind: xlsxDateTime, number: excelSerial(t)} }
+
+type XLSXSheet struct {
+	Name         string
+	Rows         [][]XLSXCell
+	FreezeHeader bool // Keep the first row in view while scrolling
+}
+
+type XLSXWorkbook struct {
+	Currency string // Currency code shown with money cells
+	Sheets   []XLSXSheet
+}
+
+// Cell style indexes into the cellXfs of the stylesheet WriteWorkbook writes.
+var xlsxStyles = map[xlsxCellKind]int{
+	xlsxNumber:   2,
+	xlsxInteger:  3,
+	xlsxMoney:    4,
+	xlsxPercent:  5,
+	xlsxDate:     6,
+	xlsxDateTime: 7,
+}
+
+// WriteWorkbook builds an XLSX workbook with a sheet per XLSXSheet.
+func WriteWorkbook(workbook XLSXWorkbook) ([]byte, error) {
+	if len(workbook.Sheets) == 0 {
+		return nil, fmt.Errorf("failed to write XLSX: workbook has no sheets")
+	}
+
+	type part struct {
+		name    string
+		content string
+	}
+
+	var contentTypes, sheets, rels strings.Builder
+	var sheetParts []part
+	used := make(map[string]bool)
+	for i, sheet := range workbook.Sheets {
+		name := uniqueSheetName(sheet.Name, used)
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(name), i+1, i+1)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+		sheetParts = append(sheetParts, part{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheetXML(sheet)})
+	}
+	stylesID := len(workbook.Sheets) + 1
+
+	parts := []part{
+		{"[Content_Types].xml", xml.Header +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			contentTypes.String() +
+			`</Types>`},
+		{"_rels/.rels", xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + sheets.String() + `</sheets>` +
+			`</workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() +
+			fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, stylesID) +
+			`</Relationships>`},
+		{"xl/styles.xml", stylesXML(workbook.Currency)},
+	}
+	parts = append(parts, sheetParts...)
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, part := range parts {
+		w, err := archive.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write XLSX: %w", err)
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write XLSX: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func worksheetXML(sheet XLSXSheet) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if sheet.FreezeHeader && len(sheet.Rows) > 0 {
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+			`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+			`<selection pane="bottomLeft" activeCell="A2" sqref="A2"/>` +
+			`</sheetView></sheetViews>`)
+	}
+
+	// Size each column to its widest value, within reason
+	var widths []int
+	for _, row := range sheet.Rows {
+		for c, cell := range row {
+			for len(widths) <= c {
+				widths = append(widths, 8)
+			}
+			if w := cell.displayWidth(); w > widths[c] {
+				widths[c] = w
+			}
+		}
+	}
+	if len(widths) > 0 {
+		b.WriteString(`<cols>`)
+		for c, width := range widths {
+			if width > 60 {
+				width = 60
+			}
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, c+1, c+1, width+2)
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+	for r, row := range sheet.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := fmt.Sprintf("%s%d", columnName(c), r+1)
+			switch {
+			case cell.kind == xlsxText && cell.text == "":
+				continue
+			case cell.kind == xlsxText && cell.bold:
+				fmt.Fprintf(&b, `<c r="%s" s="1" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeXML(cell.text))
+			case cell.kind == xlsxText:
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeXML(cell.text))
+			case math.IsNaN(cell.number) || math.IsInf(cell.number, 0):
+				continue
+			default:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyles[cell.kind], strconv.FormatFloat(cell.number, 'f', -1, 64))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+	b.WriteString(`</worksheet>`)
+
+	return b.String()
+}
+
+// displayWidth estimates the characters a cell needs once formatted.
+func (c XLSXCell) displayWidth() int {
+	switch c.kind {
+	case xlsxText:
+		return len([]rune(c.text))
+	case xlsxDate:
+		return 10
+	case xlsxDateTime:
+		return 16
+	default:
+		// Digits, thousands separators, decimals and a currency code
+		return len(strconv.FormatFloat(math.Abs(c.number), 'f', 0, 64))*4/3 + 8
+	}
+}
+
+// stylesXML declares the fonts and number formats of the cell styles: plain,
+// bold, number, integer, money, percent, date and date-time.
+func stylesXML(currency string) string {
+	moneyFormat := "#,##0.00"
+	if currency != "" {
+		quoted := `"` + strings.ReplaceAll(currency, `"`, "") + ` "`
+		moneyFormat = quoted + "#,##0.00;-" + quoted + "#,##0.00"
+	}
+
+	return xml.Header +
+		`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<numFmts count="3">` +
+		`<numFmt numFmtId="164" formatCode="` + escapeXMLAttr(moneyFormat) + `"/>` +
+		`<numFmt numFmtId="165" formatCode="yyyy-mm-dd"/>` +
+		`<numFmt numFmtId="166" formatCode="yyyy-mm-dd hh:mm"/>` +
+		`</numFmts>` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="8">` +
+		`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+		`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="3" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="10" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="166" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`</cellXfs>` +
+		`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+		`</styleSheet>`
+}
+
+// excelSerial converts the wall clock time of t to a spreadsheet date serial:
+// days since 1899-12-30, with the time of day as the fraction.
+func excelSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	return wall.Sub(epoch).Hours() / 24
+}
+
+// uniqueSheetName trims a sheet name to the 31 characters spreadsheets allow,
+// drops the characters they reject, and numbers repeats.
+func uniqueSheetName(name string, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "Sheet"
+	}
+
+	base := []rune(name)
+	if len(base) > 31 {
+		base = base[:31]
+	}
+	candidate := string(base)
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		trimmed := base
+		if len(trimmed)+len(suffix) > 31 {
+			trimmed = trimmed[:31-len(suffix)]
+		}
+		candidate = string(trimmed) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+
+	return candidate
+}
+
+// xlsxStringItem is a shared or inline string, either plain or split into
+// formatted runs.
+type xlsxStringItem struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (s xlsxStringItem) text() string {
+	if len(s.Runs) == 0 {
+		return s.Text
+	}
+	var b strings.Builder
+	for _, run := range s.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+// firstSheetPath resolves the part holding the workbook's first worksheet.
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("invalid XLSX file: workbook is missing")
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeZipXML(workbookFile, &workbook); err != nil {
+		return "", fmt.Errorf("failed to read workbook: %w", err)
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(workbook.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeZipXML(relsFile, &rels); err != nil {
+		return "", fmt.Errorf("failed to read workbook relationships: %w", err)
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+
+	return fallback, nil
+}
+
+func decodeZipXML(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// columnIndex converts the letters of a cell reference such as "AB12" to a
+// zero-based column index.
+func columnIndex(ref string) int {
+	index := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		index = index*26 + int(ch-'A'+1)
+	}
+	return index - 1
+}
+
+// columnName converts a zero-based column index to its letters.
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func escapeXMLAttr(s string) string {
+	return strings.ReplaceAll(escapeXML(s), `"`, "&quot;")
+}
@@ -0,0 +1,262 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type BatchRepository struct {
+	collection *mongo.Collection
+}
+
+func NewBatchRepository(db *mongo.Database) Domain.BatchRepository {
+	return &BatchRepository{
+		collection: db.Collection("stock_batches"),
+	}
+}
+
+func (r *BatchRepository) Create(batch *Domain.StockBatch) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if batch.ReceivedDate.IsZero() {
+		batch.ReceivedDate = time.Now()
+	}
+	batch.Status = Domain.BatchStatusActive
+	batch.CreatedAt = time.Now()
+	batch.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	batch.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *BatchRepository) FindByID(id string) (*Domain.StockBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch ID: %w", err)
+	}
+
+	var batch Domain.StockBatch
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&batch)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find batch: %w", err)
+	}
+
+	return &batch, nil
+}
+
+func (r *BatchRepository) FindByProductID(productID string, includeDepleted bool) ([]Domain.StockBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	query := bson.M{"product_id": objProductID}
+	if !includeDepleted {
+		query["status"] = Domain.BatchStatusActive
+	}
+
+	opts := options.Find().SetSort(bson.M{"received_date": -1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find batches: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var batches []Domain.StockBatch
+	if err := cursor.All(ctx, &batches); err != nil {
+		return nil, fmt.Errorf("failed to decode batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// FindAvailable returns the active batches of a product that still hold stock,
+// ordered first-expiry, first-out. Batches without an expiry date come last.
+func (r *BatchRepository) FindAvailable(productID string) ([]Domain.StockBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"product_id": objProductID,
+		"status":     Domain.BatchStatusActive,
+		"remaining":  bson.M{"$gt": 0},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find available batches: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var batches []Domain.StockBatch
+	if err := cursor.All(ctx, &batches); err != nil {
+		return nil, fmt.Errorf("failed to decode batches: %w", err)
+	}
+
+	// MongoDB sorts missing expiry dates first, so order in memory instead
+	sort.SliceStable(batches, func(i, j int) bool {
+		a, b := batches[i].ExpiryDate, batches[j].ExpiryDate
+		switch {
+		case a == nil && b == nil:
+			return batches[i].ReceivedDate.Before(batches[j].ReceivedDate)
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		case a.Equal(*b):
+			return batches[i].ReceivedDate.Before(batches[j].ReceivedDate)
+		default:
+			return a.Before(*b)
+		}
+	})
+
+	return batches, nil
+}
+
+func (r *BatchRepository) FindExpiring(businessID string, before time.Time) ([]Domain.StockBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	query := bson.M{
+		"business_id": objBusinessID,
+		"status":      Domain.BatchStatusActive,
+		"remaining":   bson.M{"$gt": 0},
+		"expiry_date": bson.M{"$lte": before},
+	}
+
+	opts := options.Find().SetSort(bson.M{"expiry_date": 1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expiring batches: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var batches []Domain.StockBatch
+	if err := cursor.All(ctx, &batches); err != nil {
+		return nil, fmt.Errorf("failed to decode batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+func (r *BatchRepository) AdjustRemaining(id string, delta float64) (*Domain.StockBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch ID: %w", err)
+	}
+
+	filter := bson.M{"_id": objID}
+	if delta < 0 {
+		// Never let concurrent sales take a batch below zero
+		filter["remaining"] = bson.M{"$gte": -delta}
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"remaining": delta},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var batch Domain.StockBatch
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&batch)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("insufficient quantity in batch")
+		}
+		return nil, fmt.Errorf("failed to update batch quantity: %w", err)
+	}
+
+	// Keep status in line with the remaining quantity
+	var status Domain.BatchStatus
+	if batch.Remaining <= 0 && batch.Status == Domain.BatchStatusActive {
+		status = Domain.BatchStatusDepleted
+	} else if batch.Remaining > 0 && batch.Status == Domain.BatchStatusDepleted {
+		status = Domain.BatchStatusActive
+	}
+
+	if status != "" {
+		if _, err := r.collection.UpdateByID(ctx, objID, bson.M{"$set": bson.M{"status": status}}); err != nil {
+			return nil, fmt.Errorf("failed to update batch status: %w", err)
+		}
+		batch.Status = status
+	}
+
+	return &batch, nil
+}
+
+func (r *BatchRepository) UpdateStatus(id string, status Domain.BatchStatus) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid batch ID: %w", err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"updated_at": time.Now(),
+		},
+	}
+
+	_, err = r.collection.UpdateByID(ctx, objID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update batch status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BatchRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid batch ID: %w", err)
+	}
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID}); err != nil {
+		return fmt.Errorf("failed to delete batch: %w", err)
+	}
+
+	return nil
+}
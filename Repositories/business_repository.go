@@ -28,7 +28,9 @@ func (r *BusinessRepository) Create(business *Domain.Business) error {
 	business.CreatedAt = time.Now()
 	business.UpdatedAt = time.Now()
 	business.Status = Domain.BusinessStatusActive
-	business.Timezone = "UTC"
+	if business.Timezone == "" {
+		business.Timezone = "UTC"
+	}
 
 	result, err := r.collection.InsertOne(ctx, business)
 	if err != nil {
@@ -91,17 +93,19 @@ func (r *BusinessRepository) Update(business *Domain.Business) error {
 
 	update := bson.M{
 		"$set": bson.M{
-			"name":          business.Name,
-			"description":   business.Description,
-			"business_type": business.BusinessType,
-			"currency":      business.Currency,
-			"timezone":      business.Timezone,
-			"address":       business.Address,
-			"city":          business.City,
-			"country":       business.Country,
-			"phone":         business.Phone,
-			"email":         business.Email,
-			"updated_at":    business.UpdatedAt,
+			"name":           business.Name,
+			"description":    business.Description,
+			"business_type":  business.BusinessType,
+			"currency":       business.Currency,
+			"timezone":       business.Timezone,
+			"costing_method": business.CostingMethod,
+			"negative_stock": business.NegativeStock,
+			"address":        business.Address,
+			"city":           business.City,
+			"country":        business.Country,
+			"phone":          business.Phone,
+			"email":          business.Email,
+			"updated_at":     business.UpdatedAt,
 		},
 	}
 
@@ -173,3 +177,21 @@ func (r *BusinessRepository) FindByPhone(phone string) (*Domain.Business, error)
 
 	return &business, nil
 }
+
+func (r *BusinessRepository) FindAll() ([]Domain.Business, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find businesses: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var businesses []Domain.Business
+	if err := cursor.All(ctx, &businesses); err != nil {
+		return nil, fmt.Errorf("failed to decode businesses: %w", err)
+	}
+
+	return businesses, nil
+}
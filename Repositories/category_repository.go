@@ -0,0 +1,174 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CategoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCategoryRepository(db *mongo.Database) Domain.CategoryRepository {
+	return &CategoryRepository{
+		collection: db.Collection("categories"),
+	}
+}
+
+func (r *CategoryRepository) Create(category *Domain.Category) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+	if category.Ancestors == nil {
+		category.Ancestors = []primitive.ObjectID{}
+	}
+
+	result, err := r.collection.InsertOne(ctx, category)
+	if err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	category.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *CategoryRepository) FindByID(id string) (*Domain.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	var category Domain.Category
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&category)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	return &category, nil
+}
+
+func (r *CategoryRepository) FindByName(businessID, name string) (*Domain.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	filter := bson.M{
+		"business_id": objBusinessID,
+		"name":        bson.M{"$regex": "^" + regexp.QuoteMeta(name) + "$", "$options": "i"},
+	}
+
+	var category Domain.Category
+	err = r.collection.FindOne(ctx, filter).Decode(&category)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	return &category, nil
+}
+
+func (r *CategoryRepository) FindByBusinessID(businessID string) ([]Domain.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "sort_order", Value: 1}, {Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"business_id": objBusinessID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find categories: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var categories []Domain.Category
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, fmt.Errorf("failed to decode categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) Update(category *Domain.Category) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	category.UpdatedAt = time.Now()
+	if category.Ancestors == nil {
+		category.Ancestors = []primitive.ObjectID{}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":       category.Name,
+			"parent_id":  category.ParentID,
+			"ancestors":  category.Ancestors,
+			"sort_order": category.SortOrder,
+			"updated_at": category.UpdatedAt,
+		},
+	}
+
+	if _, err := r.collection.UpdateByID(ctx, category.ID, update); err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) SetSortOrder(id string, sortOrder int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{"sort_order": sortOrder, "updated_at": time.Now()}}
+	if _, err := r.collection.UpdateByID(ctx, objID, update); err != nil {
+		return fmt.Errorf("failed to reorder category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID}); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return nil
+}
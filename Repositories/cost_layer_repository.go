@@ -0,0 +1,109 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CostLayerRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCostLayerRepository(db *mongo.Database) Domain.CostLayerRepository {
+	return &CostLayerRepository{
+		collection: db.Collection("cost_layers"),
+	}
+}
+
+func (r *CostLayerRepository) Create(layer *Domain.CostLayer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if layer.ReceivedAt.IsZero() {
+		layer.ReceivedAt = time.Now()
+	}
+	layer.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, layer)
+	if err != nil {
+		return fmt.Errorf("failed to create cost layer: %w", err)
+	}
+
+	layer.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindOpen returns the layers of a product that still hold stock, oldest first.
+func (r *CostLayerRepository) FindOpen(productID string) ([]Domain.CostLayer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "received_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"product_id": objProductID,
+		"remaining":  bson.M{"$gt": 0},
+	}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cost layers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var layers []Domain.CostLayer
+	if err := cursor.All(ctx, &layers); err != nil {
+		return nil, fmt.Errorf("failed to decode cost layers: %w", err)
+	}
+
+	return layers, nil
+}
+
+func (r *CostLayerRepository) Consume(id string, quantity float64) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid cost layer ID: %w", err)
+	}
+
+	// The filter keeps two movements from taking the same stock
+	filter := bson.M{
+		"_id":       objID,
+		"remaining": bson.M{"$gte": quantity},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"remaining": -quantity}})
+	if err != nil {
+		return false, fmt.Errorf("failed to update cost layer: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+func (r *CostLayerRepository) DeleteByProductID(productID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"product_id": objProductID})
+	if err != nil {
+		return fmt.Errorf("failed to delete cost layers: %w", err)
+	}
+
+	return nil
+}
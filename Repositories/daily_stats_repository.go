@@ -0,0 +1,389 @@
+package Repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DailyStatsRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewDailyStatsRepository(db *mongo.Database) Domain.DailyStatsRepository {
+	return &DailyStatsRepository{
+		db:         db,
+		collection: db.Collection("daily_business_stats"),
+	}
+}
+
+func (r *DailyStatsRepository) Refresh(businessID string, dayStart time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	dayStart = Domain.StartOfDay(dayStart)
+	computedAt := time.Now()
+	days, err := r.aggregateDays(ctx, objBusinessID, dayStart.Location(), &dayStart, computedAt)
+	if err != nil {
+		return err
+	}
+
+	// Two refreshes of a day can race. Only figures computed after the stored
+	// ones are written, so the refresh that read the day last wins
+	date := dayStart.Format("2006-01-02")
+	filter := bson.M{
+		"business_id": objBusinessID,
+		"date":        date,
+		"updated_at":  bson.M{"$lte": computedAt},
+	}
+
+	day, ok := days[date]
+	if !ok {
+		if _, err := r.collection.DeleteOne(ctx, filter); err != nil {
+			return fmt.Errorf("failed to delete daily stats: %w", err)
+		}
+		return nil
+	}
+
+	// When newer figures are stored the filter matches nothing and the upsert
+	// runs into the day's unique index
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.collection.ReplaceOne(ctx, filter, day, opts); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("failed to save daily stats: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DailyStatsRepository) Rebuild(businessID string, loc *time.Location) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	computedAt := time.Now()
+	days, err := r.aggregateDays(ctx, objBusinessID, loc, nil, computedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	// Each day is replaced in place and only then are the days left over
+	// removed, so readers never find the rollups missing mid-rebuild and a
+	// failure leaves the previous rollups standing. As in Refresh, days
+	// refreshed since the rebuild read them are left as they are
+	dates := make([]string, 0, len(days))
+	models := make([]mongo.WriteModel, 0, len(days))
+	for date, day := range days {
+		dates = append(dates, date)
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"business_id": objBusinessID, "date": date, "updated_at": bson.M{"$lte": computedAt}}).
+			SetReplacement(day).
+			SetUpsert(true))
+	}
+
+	if len(models) > 0 {
+		if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil && !onlyDuplicateKeys(err) {
+			return 0, fmt.Errorf("failed to save daily stats: %w", err)
+		}
+	}
+
+	stale := bson.M{
+		"business_id": objBusinessID,
+		"date":        bson.M{"$nin": dates},
+		"updated_at":  bson.M{"$lte": computedAt},
+	}
+	if _, err := r.collection.DeleteMany(ctx, stale); err != nil {
+		return 0, fmt.Errorf("failed to delete stale daily stats: %w", err)
+	}
+
+	return len(dates), nil
+}
+
+func (r *DailyStatsRepository) FindRange(businessID string, startDate, endDate string) ([]Domain.DailyBusinessStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	filter := bson.M{
+		"business_id": objBusinessID,
+		"date": bson.M{
+			"$gte": startDate,
+			"$lte": endDate,
+		},
+	}
+
+	opts := options.Find().SetSort(bson.M{"date": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find daily stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var days []Domain.DailyBusinessStats
+	if err := cursor.All(ctx, &days); err != nil {
+		return nil, fmt.Errorf("failed to decode daily stats: %w", err)
+	}
+
+	return days, nil
+}
+
+func (r *DailyStatsRepository) CoversHistory(businessID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return false, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	var first Domain.DailyBusinessStats
+	err = r.collection.FindOne(ctx, bson.M{"business_id": objBusinessID},
+		options.FindOne().SetSort(bson.M{"day_start": 1})).Decode(&first)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return false, fmt.Errorf("failed to find daily stats: %w", err)
+	}
+	covered := func(t time.Time) bool {
+		return err == nil && !t.Before(first.DayStart)
+	}
+
+	var sale Domain.Sale
+	saleErr := r.db.Collection("sales").FindOne(ctx,
+		bson.M{"business_id": objBusinessID, "status": Domain.SaleStatusCompleted},
+		options.FindOne().SetSort(bson.M{"created_at": 1})).Decode(&sale)
+	if saleErr == nil && !covered(sale.CreatedAt) {
+		return false, nil
+	}
+	if saleErr != nil && saleErr != mongo.ErrNoDocuments {
+		return false, fmt.Errorf("failed to find first sale: %w", saleErr)
+	}
+
+	var expense Domain.Expense
+	expenseErr := r.db.Collection("expenses").FindOne(ctx,
+		bson.M{"business_id": objBusinessID, "status": Domain.ExpenseStatusActive},
+		options.FindOne().SetSort(bson.M{"date": 1})).Decode(&expense)
+	if expenseErr == nil && !covered(expense.Date) {
+		return false, nil
+	}
+	if expenseErr != nil && expenseErr != mongo.ErrNoDocuments {
+		return false, fmt.Errorf("failed to find first expense: %w", expenseErr)
+	}
+
+	return true, nil
+}
+
+func (r *DailyStatsRepository) EnsureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "business_id", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetName("business_date_unique").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create daily stats indexes: %w", err)
+	}
+
+	return nil
+}
+
+// aggregateDays computes the rollups of the business's days in loc from its
+// completed sales and active expenses, keyed by date. With day set only that
+// day is computed; otherwise every day with sales or expenses is. The rollups
+// are stamped as computed at computedAt.
+func (r *DailyStatsRepository) aggregateDays(ctx context.Context, businessID primitive.ObjectID, loc *time.Location, day *time.Time, computedAt time.Time) (map[string]*Domain.DailyBusinessStats, error) {
+	timezone := mongoTimezone(time.Now().In(loc))
+
+	salesMatch := bson.M{
+		"business_id": businessID,
+		"status":      Domain.SaleStatusCompleted,
+	}
+	expensesMatch := bson.M{
+		"business_id": businessID,
+		"status":      Domain.ExpenseStatusActive,
+	}
+	if day != nil {
+		timezone = mongoTimezone(*day)
+		dayRange := bson.M{
+			"$gte": *day,
+			"$lte": Domain.EndOfDay(*day),
+		}
+		salesMatch["created_at"] = dayRange
+		expensesMatch["date"] = dayRange
+	}
+
+	// Sales recorded before costing was introduced fall back to the
+	// product's cost price, as in the profit report
+	salesPipeline := []bson.M{
+		{"$match": salesMatch},
+		{
+			"$lookup": bson.M{
+				"from":         "products",
+				"localField":   "product_id",
+				"foreignField": "_id",
+				"as":           "product",
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id": bson.M{"$dateToString": bson.M{
+					"format":   "%Y-%m-%d",
+					"date":     "$created_at",
+					"timezone": timezone,
+				}},
+				"transactions": bson.M{"$sum": 1},
+				"items_sold":   bson.M{"$sum": "$quantity"},
+				"revenue":      bson.M{"$sum": "$final_amount"},
+				"discounts":    bson.M{"$sum": "$discount"},
+				"tax":          bson.M{"$sum": "$tax"},
+				"cost_of_goods": bson.M{"$sum": bson.M{
+					"$ifNull": bson.A{
+						"$cost_of_goods",
+						bson.M{"$multiply": bson.A{
+							"$quantity",
+							bson.M{"$ifNull": bson.A{bson.M{"$first": "$product.cost_price"}, 0}},
+						}},
+					},
+				}},
+			},
+		},
+	}
+
+	cursor, err := r.db.Collection("sales").Aggregate(ctx, salesPipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate daily sales: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var salesDays []struct {
+		Date         string  `bson:"_id"`
+		Transactions int     `bson:"transactions"`
+		ItemsSold    float64 `bson:"items_sold"`
+		Revenue      float64 `bson:"revenue"`
+		Discounts    float64 `bson:"discounts"`
+		Tax          float64 `bson:"tax"`
+		CostOfGoods  float64 `bson:"cost_of_goods"`
+	}
+	if err := cursor.All(ctx, &salesDays); err != nil {
+		return nil, fmt.Errorf("failed to decode daily sales: %w", err)
+	}
+
+	expensesPipeline := []bson.M{
+		{"$match": expensesMatch},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"date": bson.M{"$dateToString": bson.M{
+						"format":   "%Y-%m-%d",
+						"date":     "$date",
+						"timezone": timezone,
+					}},
+					"category": "$category",
+				},
+				"amount": bson.M{"$sum": "$amount"},
+				"count":  bson.M{"$sum": 1},
+			},
+		},
+	}
+
+	expensesCursor, err := r.db.Collection("expenses").Aggregate(ctx, expensesPipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate daily expenses: %w", err)
+	}
+	defer expensesCursor.Close(ctx)
+
+	var expenseDays []struct {
+		ID struct {
+			Date     string                 `bson:"date"`
+			Category Domain.ExpenseCategory `bson:"category"`
+		} `bson:"_id"`
+		Amount float64 `bson:"amount"`
+		Count  int     `bson:"count"`
+	}
+	if err := expensesCursor.All(ctx, &expenseDays); err != nil {
+		return nil, fmt.Errorf("failed to decode daily expenses: %w", err)
+	}
+
+	days := make(map[string]*Domain.DailyBusinessStats)
+	dayFor := func(date string) *Domain.DailyBusinessStats {
+		if stats, ok := days[date]; ok {
+			return stats
+		}
+		dayStart, _ := time.ParseInLocation("2006-01-02", date, loc)
+		stats := &Domain.DailyBusinessStats{
+			BusinessID:        businessID,
+			Date:              date,
+			DayStart:          dayStart,
+			ExpenseCategories: []Domain.DailyExpenseCategory{},
+			UpdatedAt:         computedAt,
+		}
+		days[date] = stats
+		return stats
+	}
+
+	for _, sales := range salesDays {
+		stats := dayFor(sales.Date)
+		stats.Transactions = sales.Transactions
+		stats.ItemsSold = sales.ItemsSold
+		stats.Revenue = sales.Revenue
+		stats.Discounts = sales.Discounts
+		stats.Tax = sales.Tax
+		stats.CostOfGoods = sales.CostOfGoods
+	}
+
+	for _, expenses := range expenseDays {
+		stats := dayFor(expenses.ID.Date)
+		stats.Expenses += expenses.Amount
+		stats.ExpenseCount += expenses.Count
+		stats.ExpenseCategories = append(stats.ExpenseCategories, Domain.DailyExpenseCategory{
+			Category: expenses.ID.Category,
+			Amount:   expenses.Amount,
+			Count:    expenses.Count,
+		})
+	}
+
+	for _, stats := range days {
+		sort.Slice(stats.ExpenseCategories, func(i, j int) bool {
+			return stats.ExpenseCategories[i].Amount > stats.ExpenseCategories[j].Amount
+		})
+	}
+
+	return days, nil
+}
+
+// onlyDuplicateKeys reports whether every write of a bulk write that failed
+// ran into a unique index, as conditional upserts do when newer rollups are
+// already stored.
+func onlyDuplicateKeys(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if !writeErr.HasErrorCode(11000) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ImportJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewImportJobRepository(db *mongo.Database) Domain.ImportJobRepository {
+	return &ImportJobRepository{
+		collection: db.Collection("catalog_imports"),
+	}
+}
+
+func (r *ImportJobRepository) Create(job *Domain.CatalogImportJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job.Status = Domain.ImportJobStatusPending
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	job.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *ImportJobRepository) FindByID(id string) (*Domain.CatalogImportJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job ID: %w", err)
+	}
+
+	var job Domain.CatalogImportJob
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find import job: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (r *ImportJobRepository) FindByBusinessID(businessID string, limit int) ([]Domain.CatalogImportJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	// Row errors can be long; they are returned by FindByID
+	opts.SetProjection(bson.M{"errors": 0})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"business_id": objBusinessID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []Domain.CatalogImportJob
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode import jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (r *ImportJobRepository) Update(job *Domain.CatalogImportJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":         job.Status,
+			"total_rows":     job.TotalRows,
+			"processed_rows": job.ProcessedRows,
+			"progress":       job.Progress,
+			"created":        job.Created,
+			"updated":        job.Updated,
+			"failed":         job.Failed,
+			"errors":         job.Errors,
+			"error":          job.Error,
+			"started_at":     job.StartedAt,
+			"completed_at":   job.CompletedAt,
+			"updated_at":     job.UpdatedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateByID(ctx, job.ID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update import job: %w", err)
+	}
+
+	return nil
+}
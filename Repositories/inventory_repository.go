@@ -3,6 +3,7 @@ package Repositories
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	Domain "ShopOps/Domain"
@@ -29,11 +30,15 @@ func (r *InventoryRepository) Create(product *Domain.Product) error {
 	defer cancel()
 
 	product.Status = Domain.ProductStatusActive
+	product.AverageCost = product.CostPrice
 	product.CreatedAt = time.Now()
 	product.UpdatedAt = time.Now()
 
 	result, err := r.productsCollection.InsertOne(ctx, product)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return Domain.ErrBarcodeInUse
+		}
 		return fmt.Errorf("failed to create product: %w", err)
 	}
 
@@ -49,6 +54,8 @@ func (r *InventoryRepository) Create(product *Domain.Product) error {
 			Previous:   0,
 			New:        product.Stock,
 			Reason:     "Initial stock",
+			UnitCost:   product.CostPrice,
+			TotalCost:  product.Stock * product.CostPrice,
 			CreatedBy:  product.CreatedBy,
 			CreatedAt:  time.Now(),
 		}
@@ -95,7 +102,9 @@ func (r *InventoryRepository) FindByBusinessID(businessID string, filters Domain
 
 	query := bson.M{"business_id": objBusinessID}
 
-	if filters.Category != nil {
+	if len(filters.CategoryIDs) > 0 {
+		query["category_id"] = bson.M{"$in": filters.CategoryIDs}
+	} else if filters.Category != nil {
 		query["category"] = *filters.Category
 	}
 
@@ -147,25 +156,37 @@ func (r *InventoryRepository) Update(product *Domain.Product) error {
 
 	update := bson.M{
 		"$set": bson.M{
-			"name":          product.Name,
-			"description":   product.Description,
-			"sku":           product.SKU,
-			"barcode":       product.Barcode,
-			"category":      product.Category,
-			"unit":          product.Unit,
-			"cost_price":    product.CostPrice,
-			"selling_price": product.SellingPrice,
-			"stock":         product.Stock,
-			"min_stock":     product.MinStock,
-			"max_stock":     product.MaxStock,
-			"image_url":     product.ImageURL,
-			"status":        product.Status,
-			"updated_at":    product.UpdatedAt,
+			"name":            product.Name,
+			"type":            product.Type,
+			"components":      product.Components,
+			"description":     product.Description,
+			"sku":             product.SKU,
+			"barcode":         product.Barcode,
+			"category_id":     product.CategoryID,
+			"category":        product.Category,
+			"unit":            product.Unit,
+			"cost_price":      product.CostPrice,
+			"selling_price":   product.SellingPrice,
+			"stock":           product.Stock,
+			"min_stock":       product.MinStock,
+			"max_stock":       product.MaxStock,
+			"image_url":       product.ImageURL,
+			"track_batches":   product.TrackBatches,
+			"track_serials":   product.TrackSerials,
+			"warranty_months": product.WarrantyMonths,
+			"negative_stock":  product.NegativeStock,
+			"supplier_id":     product.SupplierID,
+			"lead_time_days":  product.LeadTimeDays,
+			"status":          product.Status,
+			"updated_at":      product.UpdatedAt,
 		},
 	}
 
 	_, err := r.productsCollection.UpdateByID(ctx, product.ID, update)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return Domain.ErrBarcodeInUse
+		}
 		return fmt.Errorf("failed to update product: %w", err)
 	}
 
@@ -193,25 +214,25 @@ func (r *InventoryRepository) Delete(id string) error {
 	return err
 }
 
-func (r *InventoryRepository) AdjustStock(productID string, quantity float64, movementType Domain.MovementType, reason string, referenceID *string, referenceType string, userID string) error {
+func (r *InventoryRepository) AdjustStock(productID string, quantity float64, movementType Domain.MovementType, reason string, referenceID *string, referenceType string, userID string, locationID *string, allowNegative bool) (*Domain.StockMovement, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	objProductID, err := primitive.ObjectIDFromHex(productID)
 	if err != nil {
-		return fmt.Errorf("invalid product ID: %w", err)
+		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
 	objUserID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		return fmt.Errorf("invalid user ID: %w", err)
+		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
 	// Get current product
 	var product Domain.Product
 	err = r.productsCollection.FindOne(ctx, bson.M{"_id": objProductID}).Decode(&product)
 	if err != nil {
-		return fmt.Errorf("failed to find product: %w", err)
+		return nil, fmt.Errorf("failed to find product: %w", err)
 	}
 
 	// Calculate new stock based on movement type
@@ -223,8 +244,8 @@ func (r *InventoryRepository) AdjustStock(productID string, quantity float64, mo
 		newStock = previousStock + quantity
 	case Domain.MovementTypeSale, Domain.MovementTypeDamage, Domain.MovementTypeTheft:
 		newStock = previousStock - quantity
-		if newStock < 0 {
-			return fmt.Errorf("insufficient stock. Available: %.2f, Required: %.2f", previousStock, quantity)
+		if newStock < 0 && !allowNegative {
+			return nil, fmt.Errorf("insufficient stock. Available: %.2f, Required: %.2f", previousStock, quantity)
 		}
 	}
 
@@ -238,7 +259,7 @@ func (r *InventoryRepository) AdjustStock(productID string, quantity float64, mo
 
 	_, err = r.productsCollection.UpdateByID(ctx, objProductID, update)
 	if err != nil {
-		return fmt.Errorf("failed to update product stock: %w", err)
+		return nil, fmt.Errorf("failed to update product stock: %w", err)
 	}
 
 	// Create stock movement record
@@ -262,12 +283,20 @@ func (r *InventoryRepository) AdjustStock(productID string, quantity float64, mo
 		}
 	}
 
-	_, err = r.movementsCollection.InsertOne(ctx, movement)
+	if locationID != nil {
+		objLocationID, err := primitive.ObjectIDFromHex(*locationID)
+		if err == nil {
+			movement.LocationID = &objLocationID
+		}
+	}
+
+	result, err := r.movementsCollection.InsertOne(ctx, movement)
 	if err != nil {
-		return fmt.Errorf("failed to create stock movement: %w", err)
+		return nil, fmt.Errorf("failed to create stock movement: %w", err)
 	}
 
-	return nil
+	movement.ID = result.InsertedID.(primitive.ObjectID)
+	return &movement, nil
 }
 
 func (r *InventoryRepository) GetLowStock(businessID string, threshold float64) ([]Domain.Product, error) {
@@ -282,6 +311,7 @@ func (r *InventoryRepository) GetLowStock(businessID string, threshold float64)
 	query := bson.M{
 		"business_id": objBusinessID,
 		"status":      Domain.ProductStatusActive,
+		"type":        bson.M{"$ne": Domain.ProductTypeBundle}, // Bundles hold no stock of their own
 		"$expr":       bson.M{"$lt": []interface{}{"$stock", "$min_stock"}},
 	}
 
@@ -326,3 +356,272 @@ func (r *InventoryRepository) GetStockHistory(productID string, limit int) ([]Do
 
 	return movements, nil
 }
+
+func (r *InventoryRepository) GetMovementsUntil(businessID string, until time.Time) ([]Domain.StockMovement, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	filter := bson.M{
+		"business_id": objBusinessID,
+		"created_at":  bson.M{"$lte": until},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.movementsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stock movements: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var movements []Domain.StockMovement
+	if err := cursor.All(ctx, &movements); err != nil {
+		return nil, fmt.Errorf("failed to decode movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+func (r *InventoryRepository) SetMovementCost(movementID string, unitCost, totalCost float64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objMovementID, err := primitive.ObjectIDFromHex(movementID)
+	if err != nil {
+		return fmt.Errorf("invalid movement ID: %w", err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"unit_cost":  unitCost,
+			"total_cost": totalCost,
+		},
+	}
+
+	_, err = r.movementsCollection.UpdateByID(ctx, objMovementID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update movement cost: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InventoryRepository) UpdateAverageCost(productID string, averageCost float64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"average_cost": averageCost,
+			"updated_at":   time.Now(),
+		},
+	}
+
+	_, err = r.productsCollection.UpdateByID(ctx, objProductID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update average cost: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InventoryRepository) GetDemand(businessID string, since time.Time) ([]Domain.ProductDemand, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	// Returns booked against a sale (voids and edits) take back the demand
+	// the sale recorded
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"business_id": objBusinessID,
+			"created_at":  bson.M{"$gte": since},
+			"$or": []bson.M{
+				{"type": Domain.MovementTypeSale},
+				{"type": Domain.MovementTypeReturn, "reference_type": "sale"},
+			},
+		}},
+		{"$group": bson.M{
+			"_id": "$product_id",
+			"quantity": bson.M{"$sum": bson.M{"$cond": []interface{}{
+				bson.M{"$eq": []interface{}{"$type", Domain.MovementTypeSale}},
+				"$quantity",
+				bson.M{"$multiply": []interface{}{"$quantity", -1}},
+			}}},
+		}},
+	}
+
+	cursor, err := r.movementsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate demand: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var demand []Domain.ProductDemand
+	if err := cursor.All(ctx, &demand); err != nil {
+		return nil, fmt.Errorf("failed to decode demand: %w", err)
+	}
+
+	return demand, nil
+}
+
+func (r *InventoryRepository) FindByBarcode(businessID, barcode string) (*Domain.Product, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	var product Domain.Product
+	err = r.productsCollection.FindOne(ctx, bson.M{
+		"business_id": objBusinessID,
+		"barcode":     barcode,
+		"status":      bson.M{"$ne": Domain.ProductStatusDiscontinued},
+	}).Decode(&product)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *InventoryRepository) SetBarcode(productID, barcode string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"barcode":    barcode,
+			"updated_at": time.Now(),
+		},
+	}
+
+	_, err = r.productsCollection.UpdateByID(ctx, objProductID, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return Domain.ErrBarcodeInUse
+		}
+		return fmt.Errorf("failed to set barcode: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InventoryRepository) EnsureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Products without a barcode are left out so they do not collide
+	_, err := r.productsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "business_id", Value: 1}, {Key: "barcode", Value: 1}},
+		Options: options.Index().
+			SetName("business_barcode_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"barcode": bson.M{"$type": "string", "$gt": ""}}),
+	})
+	if err != nil {
+		// Most often products already share a barcode; name them so they can
+		// be fixed
+		duplicates, findErr := r.findDuplicateBarcodes(ctx)
+		if findErr != nil || len(duplicates) == 0 {
+			return fmt.Errorf("failed to create barcode index: %w", err)
+		}
+		return fmt.Errorf("failed to create barcode index, barcodes shared by more than one product: %s: %w",
+			strings.Join(duplicates, "; "), err)
+	}
+
+	return nil
+}
+
+// findDuplicateBarcodes describes each barcode held by more than one product
+// of a business, with the products holding it.
+func (r *InventoryRepository) findDuplicateBarcodes(ctx context.Context) ([]string, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"barcode": bson.M{"$type": "string", "$gt": ""}}},
+		{
+			"$group": bson.M{
+				"_id":      bson.M{"business_id": "$business_id", "barcode": "$barcode"},
+				"products": bson.M{"$push": bson.M{"id": "$_id", "name": "$name"}},
+				"count":    bson.M{"$sum": 1},
+			},
+		},
+		{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+		{"$sort": bson.M{"_id.business_id": 1, "_id.barcode": 1}},
+	}
+
+	cursor, err := r.productsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate barcodes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var duplicates []string
+	for cursor.Next(ctx) {
+		var result struct {
+			ID struct {
+				BusinessID primitive.ObjectID `bson:"business_id"`
+				Barcode    string             `bson:"barcode"`
+			} `bson:"_id"`
+			Products []struct {
+				ID   primitive.ObjectID `bson:"id"`
+				Name string             `bson:"name"`
+			} `bson:"products"`
+		}
+
+		if err := cursor.Decode(&result); err != nil {
+			continue
+		}
+
+		products := make([]string, 0, len(result.Products))
+		for _, product := range result.Products {
+			products = append(products, fmt.Sprintf("%s (%s)", product.Name, product.ID.Hex()))
+		}
+		duplicates = append(duplicates, fmt.Sprintf("barcode %s in business %s: %s",
+			result.ID.Barcode, result.ID.BusinessID.Hex(), strings.Join(products, ", ")))
+	}
+
+	return duplicates, nil
+}
+
+func (r *InventoryRepository) MoveCategory(fromID, toID primitive.ObjectID, name string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"category_id": toID,
+			"category":    name,
+			"updated_at":  time.Now(),
+		},
+	}
+
+	result, err := r.productsCollection.UpdateMany(ctx, bson.M{"category_id": fromID}, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move category products: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,158 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LocationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLocationRepository(db *mongo.Database) Domain.LocationRepository {
+	return &LocationRepository{
+		collection: db.Collection("locations"),
+	}
+}
+
+func (r *LocationRepository) Create(location *Domain.Location) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if location.Status == "" {
+		location.Status = Domain.LocationStatusActive
+	}
+	location.CreatedAt = time.Now()
+	location.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, location)
+	if err != nil {
+		return fmt.Errorf("failed to create location: %w", err)
+	}
+
+	location.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *LocationRepository) FindByID(id string) (*Domain.Location, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location ID: %w", err)
+	}
+
+	var location Domain.Location
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&location)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find location: %w", err)
+	}
+
+	return &location, nil
+}
+
+func (r *LocationRepository) FindByBusinessID(businessID string) ([]Domain.Location, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "is_default", Value: -1}, {Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"business_id": objBusinessID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find locations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var locations []Domain.Location
+	if err := cursor.All(ctx, &locations); err != nil {
+		return nil, fmt.Errorf("failed to decode locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+func (r *LocationRepository) FindDefault(businessID string) (*Domain.Location, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	var location Domain.Location
+	err = r.collection.FindOne(ctx, bson.M{
+		"business_id": objBusinessID,
+		"is_default":  true,
+	}).Decode(&location)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find default location: %w", err)
+	}
+
+	return &location, nil
+}
+
+func (r *LocationRepository) Update(location *Domain.Location) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	location.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":       location.Name,
+			"code":       location.Code,
+			"type":       location.Type,
+			"address":    location.Address,
+			"is_default": location.IsDefault,
+			"status":     location.Status,
+			"updated_at": location.UpdatedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateByID(ctx, location.ID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LocationRepository) ClearDefault(businessID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"business_id": objBusinessID, "is_default": true},
+		bson.M{"$set": bson.M{"is_default": false, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to clear default location: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NegativeStockRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNegativeStockRepository(db *mongo.Database) Domain.NegativeStockRepository {
+	return &NegativeStockRepository{
+		collection: db.Collection("negative_stock_events"),
+	}
+}
+
+func (r *NegativeStockRepository) Create(event *Domain.NegativeStockEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	event.Status = Domain.NegativeStockStatusOpen
+	event.Coverings = []Domain.NegativeStockCover{}
+	event.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to create negative stock event: %w", err)
+	}
+
+	event.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *NegativeStockRepository) FindOpenByProductID(productID string) ([]Domain.NegativeStockEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"product_id": objProductID,
+		"status":     Domain.NegativeStockStatusOpen,
+	}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find negative stock events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []Domain.NegativeStockEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode negative stock events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *NegativeStockRepository) FindByBusinessID(businessID string, filters Domain.NegativeStockFilters) ([]Domain.NegativeStockEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	query := bson.M{"business_id": objBusinessID}
+
+	if filters.Status != nil {
+		query["status"] = *filters.Status
+	}
+
+	if filters.ProductID != nil {
+		objProductID, err := primitive.ObjectIDFromHex(*filters.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %w", err)
+		}
+		query["product_id"] = objProductID
+	}
+
+	if filters.StartDate != nil || filters.EndDate != nil {
+		dateFilter := bson.M{}
+		if filters.StartDate != nil {
+			dateFilter["$gte"] = *filters.StartDate
+		}
+		if filters.EndDate != nil {
+			dateFilter["$lte"] = *filters.EndDate
+		}
+		query["created_at"] = dateFilter
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find negative stock events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []Domain.NegativeStockEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode negative stock events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *NegativeStockRepository) AddCover(id string, cover Domain.NegativeStockCover, covered bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid negative stock event ID: %w", err)
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+	if covered {
+		set["status"] = Domain.NegativeStockStatusCovered
+		set["covered_at"] = cover.At
+	}
+
+	update := bson.M{
+		"$inc":  bson.M{"covered": cover.Quantity},
+		"$push": bson.M{"coverings": cover},
+		"$set":  set,
+	}
+
+	_, err = r.collection.UpdateByID(ctx, objID, update)
+	if err != nil {
+		return fmt.Errorf("failed to record negative stock cover: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PaymentSettlementRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewPaymentSettlementRepository(db *mongo.Database) Domain.PaymentSettlementRepository {
+	return &PaymentSettlementRepository{
+		db:         db,
+		collection: db.Collection("payment_settlements"),
+	}
+}
+
+func (r *PaymentSettlementRepository) Save(settlement *Domain.PaymentSettlement) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"business_id":    settlement.BusinessID,
+		"date":           settlement.Date,
+		"payment_method": settlement.PaymentMethod,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"expected":    settlement.Expected,
+			"actual":      settlement.Actual,
+			"variance":    settlement.Variance,
+			"status":      settlement.Status,
+			"reference":   settlement.Reference,
+			"notes":       settlement.Notes,
+			"recorded_by": settlement.RecordedBy,
+			"updated_at":  now,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": now,
+		},
+	}
+
+	// Entering a day and method again replaces its settlement
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(settlement); err != nil {
+		return fmt.Errorf("failed to save payment settlement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PaymentSettlementRepository) FindRange(businessID string, startDate, endDate string) ([]Domain.PaymentSettlement, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	filter := bson.M{
+		"business_id": objBusinessID,
+		"date": bson.M{
+			"$gte": startDate,
+			"$lte": endDate,
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "payment_method", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment settlements: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var settlements []Domain.PaymentSettlement
+	if err := cursor.All(ctx, &settlements); err != nil {
+		return nil, fmt.Errorf("failed to decode payment settlements: %w", err)
+	}
+
+	return settlements, nil
+}
+
+func (r *PaymentSettlementRepository) EnsureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "business_id", Value: 1},
+			{Key: "date", Value: 1},
+			{Key: "payment_method", Value: 1},
+		},
+		Options: options.Index().SetName("business_date_method_unique").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create payment settlement indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PriceHistoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPriceHistoryRepository(db *mongo.Database) Domain.PriceHistoryRepository {
+	return &PriceHistoryRepository{
+		collection: db.Collection("price_history"),
+	}
+}
+
+func (r *PriceHistoryRepository) Create(change *Domain.PriceChange) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if change.ChangedAt.IsZero() {
+		change.ChangedAt = time.Now()
+	}
+
+	result, err := r.collection.InsertOne(ctx, change)
+	if err != nil {
+		return fmt.Errorf("failed to record price change: %w", err)
+	}
+
+	change.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *PriceHistoryRepository) FindByProductID(productID string, limit int) ([]Domain.PriceChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.M{"changed_at": -1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"product_id": objProductID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find price history: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var changes []Domain.PriceChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode price history: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (r *PriceHistoryRepository) FindByBusinessID(businessID string, startDate, endDate time.Time) ([]Domain.PriceChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	filter := bson.M{
+		"business_id": objBusinessID,
+		"changed_at": bson.M{
+			"$gte": startDate,
+			"$lte": endDate,
+		},
+	}
+	opts := options.Find().SetSort(bson.M{"changed_at": -1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find price history: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var changes []Domain.PriceChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode price history: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (r *PriceHistoryRepository) FindLatestBefore(businessID string, at time.Time) ([]Domain.PriceChange, error) {
+	return r.findEdge(businessID, bson.M{"$lte": at}, -1)
+}
+
+func (r *PriceHistoryRepository) FindFirstAfter(businessID string, at time.Time) ([]Domain.PriceChange, error) {
+	return r.findEdge(businessID, bson.M{"$gt": at}, 1)
+}
+
+// findEdge returns one change per product: the first in the given sort
+// order among the changes matching the time condition.
+func (r *PriceHistoryRepository) findEdge(businessID string, changedAt bson.M, order int) ([]Domain.PriceChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"business_id": objBusinessID,
+			"changed_at":  changedAt,
+		}},
+		{"$sort": bson.M{"changed_at": order}},
+		{"$group": bson.M{
+			"_id":    "$product_id",
+			"change": bson.M{"$first": "$$ROOT"},
+		}},
+		{"$replaceRoot": bson.M{"newRoot": "$change"}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate price history: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var changes []Domain.PriceChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode price history: %w", err)
+	}
+
+	return changes, nil
+}
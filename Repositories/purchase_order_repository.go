@@ -0,0 +1,133 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PurchaseOrderRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPurchaseOrderRepository(db *mongo.Database) Domain.PurchaseOrderRepository {
+	return &PurchaseOrderRepository{
+		collection: db.Collection("purchase_orders"),
+	}
+}
+
+func (r *PurchaseOrderRepository) Create(order *Domain.PurchaseOrder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	order.Status = Domain.PurchaseOrderStatusDraft
+	order.CreatedAt = time.Now()
+	order.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, order)
+	if err != nil {
+		return fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	order.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *PurchaseOrderRepository) FindByID(id string) (*Domain.PurchaseOrder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid purchase order ID: %w", err)
+	}
+
+	var order Domain.PurchaseOrder
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&order)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find purchase order: %w", err)
+	}
+
+	return &order, nil
+}
+
+func (r *PurchaseOrderRepository) FindByBusinessID(businessID string, statuses []Domain.PurchaseOrderStatus) ([]Domain.PurchaseOrder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	query := bson.M{"business_id": objBusinessID}
+	if len(statuses) > 0 {
+		query["status"] = bson.M{"$in": statuses}
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find purchase orders: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var orders []Domain.PurchaseOrder
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, fmt.Errorf("failed to decode purchase orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+func (r *PurchaseOrderRepository) UpdateStatus(id string, status Domain.PurchaseOrderStatus, from []Domain.PurchaseOrderStatus, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid purchase order ID: %w", err)
+	}
+
+	now := time.Now()
+	set := bson.M{
+		"status":     status,
+		"updated_at": now,
+	}
+
+	switch status {
+	case Domain.PurchaseOrderStatusOrdered:
+		set["ordered_at"] = now
+	case Domain.PurchaseOrderStatusReceived:
+		objUserID, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return fmt.Errorf("invalid user ID: %w", err)
+		}
+		set["received_by"] = objUserID
+		set["received_at"] = now
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":    objID,
+		"status": bson.M{"$in": from},
+	}, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("failed to update purchase order status: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("purchase order cannot be %s", status)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RecipeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRecipeRepository(db *mongo.Database) Domain.RecipeRepository {
+	return &RecipeRepository{
+		collection: db.Collection("recipes"),
+	}
+}
+
+func (r *RecipeRepository) Save(recipe *Domain.Recipe) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	recipe.UpdatedAt = now
+
+	update := bson.M{
+		"$set": bson.M{
+			"ingredients": recipe.Ingredients,
+			"notes":       recipe.Notes,
+			"updated_at":  recipe.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"business_id": recipe.BusinessID,
+			"created_by":  recipe.CreatedBy,
+			"created_at":  now,
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved Domain.Recipe
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"product_id": recipe.ProductID}, update, opts).Decode(&saved)
+	if err != nil {
+		return fmt.Errorf("failed to save recipe: %w", err)
+	}
+
+	recipe.ID = saved.ID
+	recipe.CreatedBy = saved.CreatedBy
+	recipe.CreatedAt = saved.CreatedAt
+	return nil
+}
+
+func (r *RecipeRepository) FindByProductID(productID string) (*Domain.Recipe, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	var recipe Domain.Recipe
+	err = r.collection.FindOne(ctx, bson.M{"product_id": objProductID}).Decode(&recipe)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find recipe: %w", err)
+	}
+
+	return &recipe, nil
+}
+
+func (r *RecipeRepository) FindByBusinessID(businessID string) ([]Domain.Recipe, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"business_id": objBusinessID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recipes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var recipes []Domain.Recipe
+	if err := cursor.All(ctx, &recipes); err != nil {
+		return nil, fmt.Errorf("failed to decode recipes: %w", err)
+	}
+
+	return recipes, nil
+}
+
+func (r *RecipeRepository) Delete(productID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"product_id": objProductID})
+	if err != nil {
+		return fmt.Errorf("failed to delete recipe: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("recipe not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReportDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReportDeliveryRepository(db *mongo.Database) Domain.ReportDeliveryRepository {
+	return &ReportDeliveryRepository{
+		collection: db.Collection("report_deliveries"),
+	}
+}
+
+func (r *ReportDeliveryRepository) Create(delivery *Domain.ReportDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	delivery.ID = primitive.NewObjectID()
+	delivery.CreatedAt = time.Now()
+	delivery.UpdatedAt = delivery.CreatedAt
+
+	if _, err := r.collection.InsertOne(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to create report delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReportDeliveryRepository) FindByID(id string) (*Domain.ReportDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report delivery ID: %w", err)
+	}
+
+	var delivery Domain.ReportDelivery
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find report delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+func (r *ReportDeliveryRepository) FindByUserID(businessID, userID string, filters Domain.ReportDeliveryFilters) ([]Domain.ReportDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	filter := bson.M{
+		"business_id": objBusinessID,
+		"user_id":     objUserID,
+	}
+	if filters.SubscriptionID != nil {
+		objSubscriptionID, err := primitive.ObjectIDFromHex(*filters.SubscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid report subscription ID: %w", err)
+		}
+		filter["subscription_id"] = objSubscriptionID
+	}
+	if filters.Status != nil {
+		filter["status"] = *filters.Status
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+	if filters.Limit > 0 {
+		opts.SetLimit(int64(filters.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find report deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []Domain.ReportDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("failed to decode report deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *ReportDeliveryRepository) FindDue(now time.Time, limit int) ([]Domain.ReportDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":          Domain.ReportDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+
+	opts := options.Find().SetSort(bson.M{"next_attempt_at": 1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due report deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []Domain.ReportDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("failed to decode due report deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *ReportDeliveryRepository) Claim(id string, dueAt, leaseUntil time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid report delivery ID: %w", err)
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":             objID,
+		"status":          Domain.ReportDeliveryPending,
+		"next_attempt_at": dueAt,
+	}, bson.M{"$set": bson.M{
+		"next_attempt_at": leaseUntil,
+		"updated_at":      time.Now(),
+	}})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim report delivery: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+func (r *ReportDeliveryRepository) Update(delivery *Domain.ReportDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	delivery.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"last_error":      delivery.LastError,
+			"filename":        delivery.Filename,
+			"sent_at":         delivery.SentAt,
+			"updated_at":      delivery.UpdatedAt,
+		},
+	}
+
+	if _, err := r.collection.UpdateByID(ctx, delivery.ID, update); err != nil {
+		return fmt.Errorf("failed to update report delivery: %w", err)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type ReportRepository struct {
@@ -96,6 +97,17 @@ func (r *ReportRepository) GenerateSalesReport(businessID string, startDate, end
 		{
 			"$limit": 10,
 		},
+		{
+			"$lookup": bson.M{
+				"from":         "products",
+				"localField":   "_id",
+				"foreignField": "_id",
+				"as":           "product",
+			},
+		},
+		{
+			"$addFields": bson.M{"product_name": bson.M{"$first": "$product.name"}},
+		},
 	}
 
 	cursor, err = salesCollection.Aggregate(ctx, productsPipeline)
@@ -108,6 +120,7 @@ func (r *ReportRepository) GenerateSalesReport(businessID string, startDate, end
 	for cursor.Next(ctx) {
 		var result struct {
 			ProductID   primitive.ObjectID `bson:"_id"`
+			ProductName string             `bson:"product_name"`
 			Quantity    float64            `bson:"quantity"`
 			TotalAmount float64            `bson:"total_amount"`
 		}
@@ -116,15 +129,70 @@ func (r *ReportRepository) GenerateSalesReport(businessID string, startDate, end
 			continue
 		}
 
-		// Get product name (simplified - in production, join with products collection)
 		topProducts = append(topProducts, Domain.TopProduct{
 			ProductID:   result.ProductID.Hex(),
-			ProductName: "Product", // Would fetch from products collection
+			ProductName: result.ProductName,
 			Quantity:    result.Quantity,
 			TotalAmount: result.TotalAmount,
 		})
 	}
 
+	// Daily totals, with days counted in the timezone of the range
+	dailyPipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"business_id": objBusinessID,
+				"created_at": bson.M{
+					"$gte": startDate,
+					"$lte": endDate,
+				},
+				"status": Domain.SaleStatusCompleted,
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id": bson.M{"$dateToString": bson.M{
+					"format":   "%Y-%m-%d",
+					"date":     "$created_at",
+					"timezone": mongoTimezone(startDate),
+				}},
+				"sales":        bson.M{"$sum": "$quantity"},
+				"amount":       bson.M{"$sum": "$final_amount"},
+				"transactions": bson.M{"$sum": 1},
+			},
+		},
+		{
+			"$sort": bson.M{"_id": 1},
+		},
+	}
+
+	cursor, err = salesCollection.Aggregate(ctx, dailyPipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate daily sales: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var dailyBreakdown []Domain.DailySales
+	for cursor.Next(ctx) {
+		var result struct {
+			Date         string  `bson:"_id"`
+			Sales        float64 `bson:"sales"`
+			Amount       float64 `bson:"amount"`
+			Transactions int     `bson:"transactions"`
+		}
+
+		if err := cursor.Decode(&result); err != nil {
+			continue
+		}
+
+		dailyBreakdown = append(dailyBreakdown, Domain.DailySales{
+			Date:         result.Date,
+			Sales:        result.Sales,
+			Amount:       result.Amount,
+			Transactions: result.Transactions,
+		})
+	}
+
 	report := &Domain.SalesReport{
 		Period:            fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")),
 		TotalSales:        totalResult.TotalSales,
@@ -132,12 +200,18 @@ func (r *ReportRepository) GenerateSalesReport(businessID string, startDate, end
 		TotalTransactions: totalResult.TotalTransactions,
 		AverageSale:       0,
 		TopProducts:       topProducts,
+		DailyBreakdown:    dailyBreakdown,
 	}
 
 	if totalResult.TotalTransactions > 0 {
 		report.AverageSale = totalResult.TotalAmount / float64(totalResult.TotalTransactions)
 	}
 
+	report.Categories, err = r.getCategorySales(ctx, objBusinessID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
 	return report, nil
 }
 
@@ -212,10 +286,64 @@ func (r *ReportRepository) GenerateExpensesReport(businessID string, startDate,
 		}
 	}
 
+	// Daily totals, with days counted in the timezone of the range
+	dailyPipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"business_id": objBusinessID,
+				"date": bson.M{
+					"$gte": startDate,
+					"$lte": endDate,
+				},
+				"status": Domain.ExpenseStatusActive,
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id": bson.M{"$dateToString": bson.M{
+					"format":   "%Y-%m-%d",
+					"date":     "$date",
+					"timezone": mongoTimezone(startDate),
+				}},
+				"amount": bson.M{"$sum": "$amount"},
+				"count":  bson.M{"$sum": 1},
+			},
+		},
+		{
+			"$sort": bson.M{"_id": 1},
+		},
+	}
+
+	dailyCursor, err := expensesCollection.Aggregate(ctx, dailyPipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate daily expenses: %w", err)
+	}
+	defer dailyCursor.Close(ctx)
+
+	var dailyExpenses []Domain.DailyExpense
+	for dailyCursor.Next(ctx) {
+		var result struct {
+			Date   string  `bson:"_id"`
+			Amount float64 `bson:"amount"`
+			Count  int     `bson:"count"`
+		}
+
+		if err := dailyCursor.Decode(&result); err != nil {
+			continue
+		}
+
+		dailyExpenses = append(dailyExpenses, Domain.DailyExpense{
+			Date:   result.Date,
+			Amount: result.Amount,
+			Count:  result.Count,
+		})
+	}
+
 	report := &Domain.ExpensesReport{
 		Period:            fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")),
 		TotalExpenses:     totalAmount,
 		CategoryBreakdown: categoryBreakdown,
+		DailyExpenses:     dailyExpenses,
 	}
 
 	return report, nil
@@ -234,25 +362,124 @@ func (r *ReportRepository) GenerateProfitReport(businessID string, startDate, en
 		return nil, fmt.Errorf("failed to get expenses data: %w", err)
 	}
 
-	grossProfit := salesReport.TotalAmount - expensesReport.TotalExpenses
+	costOfGoods, err := r.getCostOfGoodsSold(businessID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost of goods sold: %w", err)
+	}
+
+	// Stock purchases become cost of goods sold as the stock is sold, so
+	// only the remaining expenses are deducted from gross profit
+	var stockPurchases float64
+	for _, category := range expensesReport.CategoryBreakdown {
+		if category.Category == Domain.ExpenseCategoryStockPurchase {
+			stockPurchases += category.TotalAmount
+		}
+	}
+	operatingExpenses := expensesReport.TotalExpenses - stockPurchases
+
+	revenue := salesReport.TotalAmount
+	grossProfit := revenue - costOfGoods
+	netProfit := grossProfit - operatingExpenses
+
+	grossMargin := 0.0
 	profitMargin := 0.0
-	if salesReport.TotalAmount > 0 {
-		profitMargin = (grossProfit / salesReport.TotalAmount) * 100
+	if revenue > 0 {
+		grossMargin = (grossProfit / revenue) * 100
+		profitMargin = (netProfit / revenue) * 100
 	}
 
 	report := &Domain.ProfitReport{
-		Period:        fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")),
-		TotalSales:    salesReport.TotalAmount,
-		TotalExpenses: expensesReport.TotalExpenses,
-		GrossProfit:   grossProfit,
-		NetProfit:     grossProfit, // Would deduct taxes, fees, etc.
-		ProfitMargin:  profitMargin,
+		Period:            fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")),
+		TotalSales:        revenue,
+		TotalExpenses:     expensesReport.TotalExpenses,
+		Revenue:           revenue,
+		CostOfGoodsSold:   costOfGoods,
+		GrossProfit:       grossProfit,
+		GrossMargin:       grossMargin,
+		OperatingExpenses: operatingExpenses,
+		StockPurchases:    stockPurchases,
+		NetProfit:         netProfit, // Would deduct taxes, fees, etc.
+		ProfitMargin:      profitMargin,
 	}
 
 	return report, nil
 }
 
-func (r *ReportRepository) GenerateInventoryReport(businessID string) (*Domain.InventoryReport, error) {
+// getCostOfGoodsSold sums the recorded cost of goods of completed sales. Sales
+// recorded before costing was introduced fall back to the product's cost price.
+func (r *ReportRepository) getCostOfGoodsSold(businessID string, startDate, endDate time.Time) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	salesCollection := r.db.Collection("sales")
+
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"business_id": objBusinessID,
+				"created_at": bson.M{
+					"$gte": startDate,
+					"$lte": endDate,
+				},
+				"status": Domain.SaleStatusCompleted,
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"from":         "products",
+				"localField":   "product_id",
+				"foreignField": "_id",
+				"as":           "product",
+			},
+		},
+		{
+			"$project": bson.M{
+				"cost": bson.M{
+					"$ifNull": bson.A{
+						"$cost_of_goods",
+						bson.M{"$multiply": bson.A{
+							"$quantity",
+							bson.M{"$ifNull": bson.A{bson.M{"$first": "$product.cost_price"}, 0}},
+						}},
+					},
+				},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id":   nil,
+				"total": bson.M{"$sum": "$cost"},
+			},
+		},
+	}
+
+	cursor, err := salesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("failed to aggregate cost of goods: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Total float64 `bson:"total"`
+	}
+
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return 0, fmt.Errorf("failed to decode cost of goods: %w", err)
+		}
+	}
+
+	return result.Total, nil
+}
+
+// GenerateInventoryReport reports stock across the business, broken down by
+// location, or the stock held at a single location when locationID is set.
+func (r *ReportRepository) GenerateInventoryReport(businessID string, locationID *string) (*Domain.InventoryReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -263,10 +490,11 @@ func (r *ReportRepository) GenerateInventoryReport(businessID string) (*Domain.I
 
 	productsCollection := r.db.Collection("products")
 
-	// Get all active products
+	// Get all active products; bundle stock is held by their components
 	cursor, err := productsCollection.Find(ctx, bson.M{
 		"business_id": objBusinessID,
 		"status":      Domain.ProductStatusActive,
+		"type":        bson.M{"$ne": Domain.ProductTypeBundle},
 	})
 
 	if err != nil {
@@ -279,23 +507,50 @@ func (r *ReportRepository) GenerateInventoryReport(businessID string) (*Domain.I
 		return nil, fmt.Errorf("failed to decode products: %w", err)
 	}
 
+	// Stock levels of the requested location, keyed by product
+	var levels map[primitive.ObjectID]Domain.StockLevel
+	if locationID != nil && *locationID != "" {
+		levels, err = r.getLocationLevels(ctx, *locationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var totalProducts int
 	var totalStock float64
 	var totalValue float64
 	var lowStockItems []Domain.LowStockItem
+	productCategories := make(map[primitive.ObjectID]primitive.ObjectID, len(products))
+	productFigures := make(map[primitive.ObjectID]categoryFigures, len(products))
 
 	for _, product := range products {
+		stock := product.Stock
+		minStock := product.MinStock
+
+		if levels != nil {
+			level := levels[product.ID]
+			stock = level.Quantity
+			if level.MinStock > 0 {
+				minStock = level.MinStock
+			}
+		}
+
 		totalProducts++
-		totalStock += product.Stock
-		totalValue += product.Stock * product.CostPrice
+		totalStock += stock
+		totalValue += stock * product.CostPrice
+
+		if product.CategoryID != nil {
+			productCategories[product.ID] = *product.CategoryID
+		}
+		productFigures[product.ID] = categoryFigures{products: 1, stock: stock, stockValue: stock * product.CostPrice}
 
-		if product.MinStock > 0 && product.Stock < product.MinStock {
+		if minStock > 0 && stock < minStock {
 			lowStockItems = append(lowStockItems, Domain.LowStockItem{
 				ProductID:   product.ID.Hex(),
 				ProductName: product.Name,
-				Current:     product.Stock,
-				Minimum:     product.MinStock,
-				Difference:  product.MinStock - product.Stock,
+				Current:     stock,
+				Minimum:     minStock,
+				Difference:  minStock - stock,
 			})
 		}
 	}
@@ -307,59 +562,564 @@ func (r *ReportRepository) GenerateInventoryReport(businessID string) (*Domain.I
 		LowStockItems: lowStockItems,
 	}
 
+	categories, err := r.getCategories(ctx, objBusinessID)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rollUpCategories(categories, productCategories, productFigures) {
+		report.Categories = append(report.Categories, Domain.CategoryStock{
+			CategoryID: row.categoryID,
+			Name:       row.name,
+			ParentID:   row.parentID,
+			Depth:      row.depth,
+			Products:   row.products,
+			Stock:      row.stock,
+			StockValue: row.stockValue,
+		})
+	}
+
+	if levels != nil {
+		report.LocationID = *locationID
+		return report, nil
+	}
+
+	report.Locations, err = r.getLocationSummaries(ctx, objBusinessID)
+	if err != nil {
+		return nil, err
+	}
+
 	return report, nil
 }
 
-func (r *ReportRepository) GetDashboardData(businessID string) (*Domain.DashboardData, error) {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+func (r *ReportRepository) getLocationLevels(ctx context.Context, locationID string) (map[primitive.ObjectID]Domain.StockLevel, error) {
+	objLocationID, err := primitive.ObjectIDFromHex(locationID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location ID: %w", err)
+	}
 
-	// Today's sales
-	todaySales, _ := r.getSalesTotal(businessID, today, today.Add(24*time.Hour))
+	cursor, err := r.db.Collection("stock_levels").Find(ctx, bson.M{"location_id": objLocationID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stock levels: %w", err)
+	}
+	defer cursor.Close(ctx)
 
-	// Today's expenses
-	todayExpenses, _ := r.getExpensesTotal(businessID, today, today.Add(24*time.Hour))
+	var stockLevels []Domain.StockLevel
+	if err := cursor.All(ctx, &stockLevels); err != nil {
+		return nil, fmt.Errorf("failed to decode stock levels: %w", err)
+	}
 
-	// Week's data (last 7 days)
-	weekStart := today.AddDate(0, 0, -7)
-	weekSales, _ := r.getSalesTotal(businessID, weekStart, today)
-	weekExpenses, _ := r.getExpensesTotal(businessID, weekStart, today)
+	levels := make(map[primitive.ObjectID]Domain.StockLevel, len(stockLevels))
+	for _, level := range stockLevels {
+		levels[level.ProductID] = level
+	}
 
-	// Month's data (last 30 days)
-	monthStart := today.AddDate(0, 0, -30)
-	monthSales, _ := r.getSalesTotal(businessID, monthStart, today)
-	monthExpenses, _ := r.getExpensesTotal(businessID, monthStart, today)
+	return levels, nil
+}
 
-	// Low stock count
-	lowStockCount, _ := r.getLowStockCount(businessID)
+// getLocationSummaries totals the stock and value held at each location.
+func (r *ReportRepository) getLocationSummaries(ctx context.Context, businessID primitive.ObjectID) ([]Domain.LocationStockSummary, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"business_id": businessID}},
+		{
+			"$lookup": bson.M{
+				"from":         "products",
+				"localField":   "product_id",
+				"foreignField": "_id",
+				"as":           "product",
+			},
+		},
+		{"$unwind": "$product"},
+		{"$match": bson.M{"product.status": Domain.ProductStatusActive}},
+		{
+			"$group": bson.M{
+				"_id":         "$location_id",
+				"total_stock": bson.M{"$sum": "$quantity"},
+				"total_value": bson.M{"$sum": bson.M{"$multiply": bson.A{"$quantity", "$product.cost_price"}}},
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"from":         "locations",
+				"localField":   "_id",
+				"foreignField": "_id",
+				"as":           "location",
+			},
+		},
+		{"$unwind": "$location"},
+		{"$sort": bson.M{"location.name": 1}},
+	}
 
-	data := &Domain.DashboardData{
-		TodaySales:      todaySales,
-		TodayExpenses:   todayExpenses,
-		TodayProfit:     todaySales - todayExpenses,
-		WeekSales:       weekSales,
-		WeekExpenses:    weekExpenses,
-		WeekProfit:      weekSales - weekExpenses,
-		MonthSales:      monthSales,
-		MonthExpenses:   monthExpenses,
-		MonthProfit:     monthSales - monthExpenses,
-		LowStockCount:   lowStockCount,
-		PendingPayments: 0, // Would calculate from sales with pending status
+	cursor, err := r.db.Collection("stock_levels").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate stock levels: %w", err)
 	}
+	defer cursor.Close(ctx)
 
-	return data, nil
+	var summaries []Domain.LocationStockSummary
+	for cursor.Next(ctx) {
+		var result struct {
+			LocationID primitive.ObjectID `bson:"_id"`
+			TotalStock float64            `bson:"total_stock"`
+			TotalValue float64            `bson:"total_value"`
+			Location   Domain.Location    `bson:"location"`
+		}
+
+		if err := cursor.Decode(&result); err != nil {
+			continue
+		}
+
+		summaries = append(summaries, Domain.LocationStockSummary{
+			LocationID:   result.LocationID.Hex(),
+			LocationName: result.Location.Name,
+			TotalStock:   result.TotalStock,
+			TotalValue:   result.TotalValue,
+		})
+	}
+
+	return summaries, nil
 }
 
-func (r *ReportRepository) getSalesTotal(businessID string, startDate, endDate time.Time) (float64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// categoryFigures are the figures of a product or, once rolled up, of a
+// category and everything below it.
+type categoryFigures struct {
+	products    int
+	quantity    float64
+	revenue     float64
+	costOfGoods float64
+	stock       float64
+	stockValue  float64
+}
+
+type categoryRow struct {
+	categoryFigures
+	categoryID string
+	name       string
+	parentID   string
+	depth      int
+}
+
+func (r *ReportRepository) getCategories(ctx context.Context, businessID primitive.ObjectID) ([]Domain.Category, error) {
+	cursor, err := r.db.Collection("categories").Find(ctx, bson.M{"business_id": businessID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find categories: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var categories []Domain.Category
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, fmt.Errorf("failed to decode categories: %w", err)
+	}
+
+	return Domain.OrderCategoryTree(categories), nil
+}
+
+// rollUpCategories adds the figures of each product to its category and every
+// category above it. The rows follow the category tree, with products that
+// have no category in a last row of their own.
+func rollUpCategories(categories []Domain.Category, productCategories map[primitive.ObjectID]primitive.ObjectID, productFigures map[primitive.ObjectID]categoryFigures) []categoryRow {
+	byID := make(map[primitive.ObjectID]Domain.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	totals := make(map[primitive.ObjectID]*categoryFigures, len(categories))
+	add := func(id primitive.ObjectID, figures categoryFigures) {
+		total, ok := totals[id]
+		if !ok {
+			total = &categoryFigures{}
+			totals[id] = total
+		}
+		total.products += figures.products
+		total.quantity += figures.quantity
+		total.revenue += figures.revenue
+		total.costOfGoods += figures.costOfGoods
+		total.stock += figures.stock
+		total.stockValue += figures.stockValue
+	}
+
+	for productID, figures := range productFigures {
+		category, ok := byID[productCategories[productID]]
+		if !ok {
+			add(primitive.NilObjectID, figures)
+			continue
+		}
+		add(category.ID, figures)
+		for _, ancestor := range category.Ancestors {
+			add(ancestor, figures)
+		}
+	}
+
+	rows := make([]categoryRow, 0, len(categories)+1)
+	for _, category := range categories {
+		row := categoryRow{
+			categoryID: category.ID.Hex(),
+			name:       category.Name,
+			depth:      category.Depth(),
+		}
+		if category.ParentID != nil {
+			row.parentID = category.ParentID.Hex()
+		}
+		if total, ok := totals[category.ID]; ok {
+			row.categoryFigures = *total
+		}
+		rows = append(rows, row)
+	}
+
+	if total, ok := totals[primitive.NilObjectID]; ok {
+		rows = append(rows, categoryRow{categoryFigures: *total, name: "Uncategorised"})
+	}
+
+	return rows
+}
+
+// getCategorySales breaks the revenue and margin of completed sales down by
+// category. Sales recorded before costing fall back to the product's cost
+// price, as in the profit report.
+func (r *ReportRepository) getCategorySales(ctx context.Context, businessID primitive.ObjectID, startDate, endDate time.Time) ([]Domain.CategorySales, error) {
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"business_id": businessID,
+				"created_at": bson.M{
+					"$gte": startDate,
+					"$lte": endDate,
+				},
+				"status": Domain.SaleStatusCompleted,
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"from":         "products",
+				"localField":   "product_id",
+				"foreignField": "_id",
+				"as":           "product",
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id":         "$product_id",
+				"category_id": bson.M{"$first": bson.M{"$first": "$product.category_id"}},
+				"quantity":    bson.M{"$sum": "$quantity"},
+				"revenue":     bson.M{"$sum": "$final_amount"},
+				"cost_of_goods": bson.M{"$sum": bson.M{
+					"$ifNull": bson.A{
+						"$cost_of_goods",
+						bson.M{"$multiply": bson.A{
+							"$quantity",
+							bson.M{"$ifNull": bson.A{bson.M{"$first": "$product.cost_price"}, 0}},
+						}},
+					},
+				}},
+			},
+		},
+	}
+
+	cursor, err := r.db.Collection("sales").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate category sales: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	productCategories := make(map[primitive.ObjectID]primitive.ObjectID)
+	productFigures := make(map[primitive.ObjectID]categoryFigures)
+	for cursor.Next(ctx) {
+		var result struct {
+			ProductID   *primitive.ObjectID `bson:"_id"`
+			CategoryID  *primitive.ObjectID `bson:"category_id"`
+			Quantity    float64             `bson:"quantity"`
+			Revenue     float64             `bson:"revenue"`
+			CostOfGoods float64             `bson:"cost_of_goods"`
+		}
+
+		if err := cursor.Decode(&result); err != nil {
+			continue
+		}
+
+		// Sales without a product are grouped under the nil ID
+		productID := primitive.NilObjectID
+		if result.ProductID != nil {
+			productID = *result.ProductID
+		}
+		if result.CategoryID != nil {
+			productCategories[productID] = *result.CategoryID
+		}
+		productFigures[productID] = categoryFigures{
+			quantity:    result.Quantity,
+			revenue:     result.Revenue,
+			costOfGoods: result.CostOfGoods,
+		}
+	}
+
+	categories, err := r.getCategories(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sales []Domain.CategorySales
+	for _, row := range rollUpCategories(categories, productCategories, productFigures) {
+		category := Domain.CategorySales{
+			CategoryID:  row.categoryID,
+			Name:        row.name,
+			ParentID:    row.parentID,
+			Depth:       row.depth,
+			Quantity:    row.quantity,
+			Revenue:     row.revenue,
+			CostOfGoods: row.costOfGoods,
+			GrossProfit: row.revenue - row.costOfGoods,
+		}
+		if row.revenue > 0 {
+			category.GrossMargin = (category.GrossProfit / row.revenue) * 100
+		}
+		sales = append(sales, category)
+	}
+
+	return sales, nil
+}
+
+func (r *ReportRepository) GetProductSales(businessID string, startDate, endDate time.Time) ([]Domain.TopProduct, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("invalid business ID: %w", err)
 	}
 
-	salesCollection := r.db.Collection("sales")
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"business_id": objBusinessID,
+				"created_at": bson.M{
+					"$gte": startDate,
+					"$lte": endDate,
+				},
+				"status":     Domain.SaleStatusCompleted,
+				"product_id": bson.M{"$ne": nil},
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"from":         "products",
+				"localField":   "product_id",
+				"foreignField": "_id",
+				"as":           "product",
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id":          "$product_id",
+				"product_name": bson.M{"$first": bson.M{"$first": "$product.name"}},
+				"quantity":     bson.M{"$sum": "$quantity"},
+				"total_amount": bson.M{"$sum": "$final_amount"},
+				"cost_of_goods": bson.M{"$sum": bson.M{
+					"$ifNull": bson.A{
+						"$cost_of_goods",
+						bson.M{"$multiply": bson.A{
+							"$quantity",
+							bson.M{"$ifNull": bson.A{bson.M{"$first": "$product.cost_price"}, 0}},
+						}},
+					},
+				}},
+			},
+		},
+		{
+			"$sort": bson.M{"total_amount": -1},
+		},
+	}
+
+	cursor, err := r.db.Collection("sales").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate product sales: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var products []Domain.TopProduct
+	for cursor.Next(ctx) {
+		var result struct {
+			ProductID   primitive.ObjectID `bson:"_id"`
+			ProductName string             `bson:"product_name"`
+			Quantity    float64            `bson:"quantity"`
+			TotalAmount float64            `bson:"total_amount"`
+			CostOfGoods float64            `bson:"cost_of_goods"`
+		}
+
+		if err := cursor.Decode(&result); err != nil {
+			continue
+		}
+
+		products = append(products, Domain.TopProduct{
+			ProductID:   result.ProductID.Hex(),
+			ProductName: result.ProductName,
+			Quantity:    result.Quantity,
+			TotalAmount: result.TotalAmount,
+			CostOfGoods: result.CostOfGoods,
+		})
+	}
+
+	return products, nil
+}
+
+func (r *ReportRepository) GetLastSaleDates(businessID string) ([]Domain.ProductActivity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	// A bundle or recipe sale takes stock out of its components, so they
+	// count as sold too
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"business_id": objBusinessID,
+				"status":      Domain.SaleStatusCompleted,
+			},
+		},
+		{
+			"$project": bson.M{
+				"created_at": 1,
+				"product_ids": bson.M{"$concatArrays": bson.A{
+					bson.A{"$product_id"},
+					bson.M{"$ifNull": bson.A{"$components.product_id", bson.A{}}},
+				}},
+			},
+		},
+		{"$unwind": "$product_ids"},
+		{"$match": bson.M{"product_ids": bson.M{"$ne": nil}}},
+		{
+			"$group": bson.M{
+				"_id":     "$product_ids",
+				"last_at": bson.M{"$max": "$created_at"},
+			},
+		},
+	}
+
+	cursor, err := r.db.Collection("sales").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate last sales: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var activity []Domain.ProductActivity
+	if err := cursor.All(ctx, &activity); err != nil {
+		return nil, fmt.Errorf("failed to decode last sales: %w", err)
+	}
+
+	return activity, nil
+}
+
+func (r *ReportRepository) GetLastReceiptDates(businessID string) ([]Domain.ProductActivity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"business_id": objBusinessID,
+				"type":        Domain.MovementTypePurchase,
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id":     "$product_id",
+				"last_at": bson.M{"$max": "$created_at"},
+			},
+		},
+	}
+
+	cursor, err := r.db.Collection("stock_movements").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate last receipts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var activity []Domain.ProductActivity
+	if err := cursor.All(ctx, &activity); err != nil {
+		return nil, fmt.Errorf("failed to decode last receipts: %w", err)
+	}
+
+	return activity, nil
+}
+
+func (r *ReportRepository) GetSalesHeatmap(businessID string, startDate, endDate time.Time, filters Domain.SalesHeatmapFilters) ([]Domain.HeatmapCount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	match := bson.M{
+		"business_id": objBusinessID,
+		"created_at": bson.M{
+			"$gte": startDate,
+			"$lte": endDate,
+		},
+		"status": Domain.SaleStatusCompleted,
+	}
+	if filters.ProductIDs != nil {
+		match["product_id"] = bson.M{"$in": filters.ProductIDs}
+	}
+	if filters.PaymentMethod != nil {
+		match["payment_method"] = *filters.PaymentMethod
+	}
+
+	timezone := mongoTimezone(startDate)
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"weekday": bson.M{"$dayOfWeek": bson.M{"date": "$created_at", "timezone": timezone}},
+					"hour":    bson.M{"$hour": bson.M{"date": "$created_at", "timezone": timezone}},
+				},
+				"transactions": bson.M{"$sum": 1},
+				"revenue":      bson.M{"$sum": "$final_amount"},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id": 0,
+				// MongoDB counts weekdays from 1 for Sunday
+				"weekday":      bson.M{"$subtract": bson.A{"$_id.weekday", 1}},
+				"hour":         "$_id.hour",
+				"transactions": 1,
+				"revenue":      1,
+			},
+		},
+	}
+
+	cursor, err := r.db.Collection("sales").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate sales heatmap: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var counts []Domain.HeatmapCount
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("failed to decode sales heatmap: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *ReportRepository) GetPaymentTotals(businessID string, startDate, endDate time.Time) ([]Domain.PaymentTotal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
 
 	pipeline := []bson.M{
 		{
@@ -374,80 +1134,138 @@ func (r *ReportRepository) getSalesTotal(businessID string, startDate, endDate t
 		},
 		{
 			"$group": bson.M{
-				"_id":   nil,
-				"total": bson.M{"$sum": "$final_amount"},
+				"_id": bson.M{
+					"date": bson.M{"$dateToString": bson.M{
+						"format":   "%Y-%m-%d",
+						"date":     "$created_at",
+						"timezone": mongoTimezone(startDate),
+					}},
+					"payment_method": "$payment_method",
+					"payment_status": "$payment_status",
+				},
+				"transactions": bson.M{"$sum": 1},
+				"amount":       bson.M{"$sum": "$final_amount"},
 			},
 		},
+		{
+			"$project": bson.M{
+				"_id":            0,
+				"date":           "$_id.date",
+				"payment_method": "$_id.payment_method",
+				"payment_status": "$_id.payment_status",
+				"transactions":   1,
+				"amount":         1,
+			},
+		},
+		{"$sort": bson.M{"date": 1, "payment_method": 1, "payment_status": 1}},
 	}
 
-	cursor, err := salesCollection.Aggregate(ctx, pipeline)
+	cursor, err := r.db.Collection("sales").Aggregate(ctx, pipeline)
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("failed to aggregate payment totals: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var result struct {
-		Total float64 `bson:"total"`
+	var totals []Domain.PaymentTotal
+	if err := cursor.All(ctx, &totals); err != nil {
+		return nil, fmt.Errorf("failed to decode payment totals: %w", err)
 	}
 
-	if cursor.Next(ctx) {
-		if err := cursor.Decode(&result); err != nil {
-			return 0, err
-		}
-		return result.Total, nil
+	return totals, nil
+}
+
+func (r *ReportRepository) GetDashboardData(businessID string, now time.Time) (*Domain.DashboardData, error) {
+	// Sales and expenses come from the daily rollups
+	today := Domain.StartOfDay(now)
+
+	// Today's sales and expenses
+	todaySales, todayExpenses, _ := r.getDailyTotals(businessID, today, today)
+
+	// Week's data (last 7 days, today included)
+	weekSales, weekExpenses, _ := r.getDailyTotals(businessID, Domain.LastDays(now, 7), today)
+
+	// Month's data (last 30 days, today included)
+	monthSales, monthExpenses, _ := r.getDailyTotals(businessID, Domain.LastDays(now, 30), today)
+
+	// Low stock count
+	lowStockCount, _ := r.getLowStockCount(businessID)
+
+	// Products sold below zero
+	negativeStock, _ := r.getNegativeStock(businessID)
+	if negativeStock == nil {
+		negativeStock = []Domain.NegativeStockItem{}
 	}
 
-	return 0, nil
+	data := &Domain.DashboardData{
+		TodaySales:      todaySales,
+		TodayExpenses:   todayExpenses,
+		TodayProfit:     todaySales - todayExpenses,
+		WeekSales:       weekSales,
+		WeekExpenses:    weekExpenses,
+		WeekProfit:      weekSales - weekExpenses,
+		MonthSales:      monthSales,
+		MonthExpenses:   monthExpenses,
+		MonthProfit:     monthSales - monthExpenses,
+		LowStockCount:   lowStockCount,
+		PendingPayments: 0, // Would calculate from sales with pending status
+
+		NegativeStockCount: len(negativeStock),
+		NegativeStock:      negativeStock,
+	}
+
+	return data, nil
 }
 
-func (r *ReportRepository) getExpensesTotal(businessID string, startDate, endDate time.Time) (float64, error) {
+// getDailyTotals sums the sales and expenses in the daily rollups of the
+// calendar days from startDate's to endDate's.
+func (r *ReportRepository) getDailyTotals(businessID string, startDate, endDate time.Time) (float64, float64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	expensesCollection := r.db.Collection("expenses")
+	statsCollection := r.db.Collection("daily_business_stats")
 
 	pipeline := []bson.M{
 		{
 			"$match": bson.M{
 				"business_id": objBusinessID,
 				"date": bson.M{
-					"$gte": startDate,
-					"$lte": endDate,
+					"$gte": startDate.Format("2006-01-02"),
+					"$lte": endDate.Format("2006-01-02"),
 				},
-				"status": Domain.ExpenseStatusActive,
 			},
 		},
 		{
 			"$group": bson.M{
-				"_id":   nil,
-				"total": bson.M{"$sum": "$amount"},
+				"_id":      nil,
+				"sales":    bson.M{"$sum": "$revenue"},
+				"expenses": bson.M{"$sum": "$expenses"},
 			},
 		},
 	}
 
-	cursor, err := expensesCollection.Aggregate(ctx, pipeline)
+	cursor, err := statsCollection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 	defer cursor.Close(ctx)
 
 	var result struct {
-		Total float64 `bson:"total"`
+		Sales    float64 `bson:"sales"`
+		Expenses float64 `bson:"expenses"`
 	}
 
 	if cursor.Next(ctx) {
 		if err := cursor.Decode(&result); err != nil {
-			return 0, err
+			return 0, 0, err
 		}
-		return result.Total, nil
 	}
 
-	return 0, nil
+	return result.Sales, result.Expenses, nil
 }
 
 func (r *ReportRepository) getLowStockCount(businessID string) (int, error) {
@@ -474,8 +1292,52 @@ func (r *ReportRepository) getLowStockCount(businessID string) (int, error) {
 	return int(count), nil
 }
 
+// getNegativeStock returns the active products whose stock is below zero,
+// most negative first.
+func (r *ReportRepository) getNegativeStock(businessID string) ([]Domain.NegativeStockItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	productsCollection := r.db.Collection("products")
+
+	opts := options.Find().
+		SetSort(bson.M{"stock": 1}).
+		SetProjection(bson.M{"name": 1, "sku": 1, "stock": 1})
+
+	cursor, err := productsCollection.Find(ctx, bson.M{
+		"business_id": objBusinessID,
+		"status":      Domain.ProductStatusActive,
+		"stock":       bson.M{"$lt": 0},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []Domain.NegativeStockItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
 func (r *ReportRepository) ExportCSV(report interface{}, reportType Domain.ReportType) ([]byte, error) {
 	// Implementation would convert report to CSV
 	// For now, return empty
 	return []byte{}, nil
 }
+
+// mongoTimezone names t's location for MongoDB date operators, which know
+// IANA zones but not Go's Local; that falls back to its UTC offset at t.
+func mongoTimezone(t time.Time) string {
+	if name := t.Location().String(); name != "Local" {
+		return name
+	}
+	return t.Format("-07:00")
+}
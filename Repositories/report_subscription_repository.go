@@ -0,0 +1,187 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReportSubscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReportSubscriptionRepository(db *mongo.Database) Domain.ReportSubscriptionRepository {
+	return &ReportSubscriptionRepository{
+		collection: db.Collection("report_subscriptions"),
+	}
+}
+
+func (r *ReportSubscriptionRepository) Create(subscription *Domain.ReportSubscription) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	subscription.ID = primitive.NewObjectID()
+	subscription.CreatedAt = time.Now()
+	subscription.UpdatedAt = subscription.CreatedAt
+
+	if _, err := r.collection.InsertOne(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to create report subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionRepository) FindByID(id string) (*Domain.ReportSubscription, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report subscription ID: %w", err)
+	}
+
+	var subscription Domain.ReportSubscription
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&subscription)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find report subscription: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+func (r *ReportSubscriptionRepository) FindByUserID(businessID, userID string) ([]Domain.ReportSubscription, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"business_id": objBusinessID,
+		"user_id":     objUserID,
+	}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find report subscriptions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []Domain.ReportSubscription
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to decode report subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *ReportSubscriptionRepository) Update(subscription *Domain.ReportSubscription) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	subscription.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"email":        subscription.Email,
+			"report_type":  subscription.ReportType,
+			"period":       subscription.Period,
+			"format":       subscription.Format,
+			"schedule":     subscription.Schedule,
+			"weekday":      subscription.Weekday,
+			"day_of_month": subscription.DayOfMonth,
+			"hour":         subscription.Hour,
+			"active":       subscription.Active,
+			"next_run_at":  subscription.NextRunAt,
+			"updated_at":   subscription.UpdatedAt,
+		},
+	}
+
+	if _, err := r.collection.UpdateByID(ctx, subscription.ID, update); err != nil {
+		return fmt.Errorf("failed to update report subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid report subscription ID: %w", err)
+	}
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID}); err != nil {
+		return fmt.Errorf("failed to delete report subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReportSubscriptionRepository) FindDue(now time.Time, limit int) ([]Domain.ReportSubscription, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"active":      true,
+		"next_run_at": bson.M{"$lte": now},
+	}
+
+	opts := options.Find().SetSort(bson.M{"next_run_at": 1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due report subscriptions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []Domain.ReportSubscription
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to decode due report subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *ReportSubscriptionRepository) AdvanceRun(id string, from, next time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid report subscription ID: %w", err)
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":         objID,
+		"next_run_at": from,
+	}, bson.M{"$set": bson.M{
+		"next_run_at": next,
+		"last_run_at": from,
+	}})
+	if err != nil {
+		return false, fmt.Errorf("failed to advance report subscription: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
@@ -173,7 +173,11 @@ func (r *SalesRepository) Update(sale *Domain.Sale) error {
 			"payment_method": sale.PaymentMethod,
 			"payment_status": sale.PaymentStatus,
 			"notes":          sale.Notes,
+			"batches":        sale.Batches,
+			"components":     sale.Components,
+			"location_id":    sale.LocationID,
 			"status":         sale.Status,
+			"edits":          sale.Edits,
 			"updated_at":     sale.UpdatedAt,
 		},
 	}
@@ -210,6 +214,174 @@ func (r *SalesRepository) UpdateStatus(id string, status Domain.SaleStatus) erro
 	return nil
 }
 
+func (r *SalesRepository) Void(id string, voidedBy primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid sale ID: %w", err)
+	}
+
+	now := time.Now()
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":    objID,
+		"status": Domain.SaleStatusCompleted,
+	}, bson.M{"$set": bson.M{
+		"status":     Domain.SaleStatusVoided,
+		"voided_by":  voidedBy,
+		"voided_at":  now,
+		"updated_at": now,
+	}})
+	if err != nil {
+		return false, fmt.Errorf("failed to void sale: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+func (r *SalesRepository) FindStaffActivity(businessID string, userID *primitive.ObjectID, startDate, endDate time.Time) ([]Domain.Sale, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	dateRange := bson.M{
+		"$gte": startDate,
+		"$lte": endDate,
+	}
+	recorded := bson.M{"created_at": dateRange}
+	voided := bson.M{"voided_at": dateRange}
+	edited := bson.M{"edits.edited_at": dateRange}
+	if userID != nil {
+		recorded["created_by"] = *userID
+		voided["voided_by"] = *userID
+		edited = bson.M{"edits": bson.M{"$elemMatch": bson.M{
+			"edited_by": *userID,
+			"edited_at": dateRange,
+		}}}
+	}
+
+	query := bson.M{
+		"business_id": objBusinessID,
+		"$or":         bson.A{recorded, voided, edited},
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find staff activity: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var sales []Domain.Sale
+	if err := cursor.All(ctx, &sales); err != nil {
+		return nil, fmt.Errorf("failed to decode staff activity: %w", err)
+	}
+
+	return sales, nil
+}
+
+func (r *SalesRepository) SetCostOfGoods(id string, costOfGoods float64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid sale ID: %w", err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"cost_of_goods": costOfGoods,
+		},
+	}
+
+	_, err = r.collection.UpdateByID(ctx, objID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update sale cost of goods: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SalesRepository) SetComponents(id string, components []Domain.SaleComponent, costOfGoods float64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid sale ID: %w", err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"components":    components,
+			"cost_of_goods": costOfGoods,
+		},
+	}
+
+	_, err = r.collection.UpdateByID(ctx, objID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update sale components: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SalesRepository) GetComponentUsage(businessID string, productIDs []primitive.ObjectID, locationID *string, startDate, endDate time.Time) ([]Domain.ProductDemand, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	match := bson.M{
+		"business_id": objBusinessID,
+		"product_id":  bson.M{"$in": productIDs},
+		"status":      Domain.SaleStatusCompleted,
+		"created_at": bson.M{
+			"$gte": startDate,
+			"$lt":  endDate,
+		},
+	}
+	if locationID != nil && *locationID != "" {
+		objLocationID, err := primitive.ObjectIDFromHex(*locationID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid location ID: %w", err)
+		}
+		match["location_id"] = objLocationID
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$unwind": "$components"},
+		{"$group": bson.M{
+			"_id":      "$components.product_id",
+			"quantity": bson.M{"$sum": "$components.quantity"},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate component usage: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var usage []Domain.ProductDemand
+	if err := cursor.All(ctx, &usage); err != nil {
+		return nil, fmt.Errorf("failed to decode component usage: %w", err)
+	}
+
+	return usage, nil
+}
+
 func (r *SalesRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -315,8 +487,9 @@ func (r *SalesRepository) GetDailySales(businessID string, date time.Time) ([]Do
 		return nil, fmt.Errorf("invalid business ID: %w", err)
 	}
 
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	// The day runs midnight to midnight in the date's location
+	startOfDay := Domain.StartOfDay(date)
+	endOfDay := Domain.EndOfDay(date)
 
 	query := bson.M{
 		"business_id": objBusinessID,
@@ -0,0 +1,154 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ScheduledPriceRepository struct {
+	collection *mongo.Collection
+}
+
+func NewScheduledPriceRepository(db *mongo.Database) Domain.ScheduledPriceRepository {
+	return &ScheduledPriceRepository{
+		collection: db.Collection("scheduled_price_changes"),
+	}
+}
+
+func (r *ScheduledPriceRepository) CreateMany(changes []Domain.ScheduledPriceChange) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	documents := make([]interface{}, len(changes))
+	for i := range changes {
+		changes[i].ID = primitive.NewObjectID()
+		changes[i].Status = Domain.ScheduledPriceStatusPending
+		changes[i].CreatedAt = now
+		documents[i] = changes[i]
+	}
+
+	if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to schedule price changes: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ScheduledPriceRepository) FindByID(id string) (*Domain.ScheduledPriceChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduled price change ID: %w", err)
+	}
+
+	var change Domain.ScheduledPriceChange
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&change)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find scheduled price change: %w", err)
+	}
+
+	return &change, nil
+}
+
+func (r *ScheduledPriceRepository) FindByBusinessID(businessID string, status *Domain.ScheduledPriceStatus) ([]Domain.ScheduledPriceChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	filter := bson.M{"business_id": objBusinessID}
+	if status != nil {
+		filter["status"] = *status
+	}
+
+	opts := options.Find().SetSort(bson.M{"effective_at": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find scheduled price changes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var changes []Domain.ScheduledPriceChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduled price changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (r *ScheduledPriceRepository) FindDue(now time.Time, limit int) ([]Domain.ScheduledPriceChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":       Domain.ScheduledPriceStatusPending,
+		"effective_at": bson.M{"$lte": now},
+	}
+
+	opts := options.Find().SetSort(bson.M{"effective_at": 1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due price changes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var changes []Domain.ScheduledPriceChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode due price changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (r *ScheduledPriceRepository) UpdateStatus(id string, status Domain.ScheduledPriceStatus, from []Domain.ScheduledPriceStatus, errorMessage string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid scheduled price change ID: %w", err)
+	}
+
+	set := bson.M{"status": status}
+	switch status {
+	case Domain.ScheduledPriceStatusApplied:
+		set["applied_at"] = time.Now()
+	case Domain.ScheduledPriceStatusFailed:
+		set["error"] = errorMessage
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":    objID,
+		"status": bson.M{"$in": from},
+	}, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled price change: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("scheduled price change cannot be %s", status)
+	}
+
+	return nil
+}
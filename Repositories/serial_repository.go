@@ -0,0 +1,189 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SerialRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSerialRepository(db *mongo.Database) Domain.SerialRepository {
+	return &SerialRepository{
+		collection: db.Collection("serial_numbers"),
+	}
+}
+
+func (r *SerialRepository) Create(serial *Domain.SerialNumber) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if serial.ReceivedAt.IsZero() {
+		serial.ReceivedAt = time.Now()
+	}
+	serial.Status = Domain.SerialStatusInStock
+	serial.CreatedAt = time.Now()
+	serial.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, serial)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return Domain.ErrSerialExists
+		}
+		return fmt.Errorf("failed to create serial number: %w", err)
+	}
+
+	serial.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *SerialRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid serial number ID: %w", err)
+	}
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID}); err != nil {
+		return fmt.Errorf("failed to delete serial number: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SerialRepository) FindBySerial(businessID, serial string) (*Domain.SerialNumber, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	var found Domain.SerialNumber
+	err = r.collection.FindOne(ctx, bson.M{
+		"business_id": objBusinessID,
+		"serial":      serial,
+	}).Decode(&found)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find serial number: %w", err)
+	}
+
+	return &found, nil
+}
+
+func (r *SerialRepository) FindByProductID(productID string, status *Domain.SerialStatus) ([]Domain.SerialNumber, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	query := bson.M{"product_id": objProductID}
+	if status != nil {
+		query["status"] = *status
+	}
+
+	opts := options.Find().SetSort(bson.M{"received_at": -1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find serial numbers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var serials []Domain.SerialNumber
+	if err := cursor.All(ctx, &serials); err != nil {
+		return nil, fmt.Errorf("failed to decode serial numbers: %w", err)
+	}
+
+	return serials, nil
+}
+
+func (r *SerialRepository) CountInStock(productID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"product_id": objProductID,
+		"status":     Domain.SerialStatusInStock,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count serial numbers: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (r *SerialRepository) UpdateStatus(serial *Domain.SerialNumber, from Domain.SerialStatus, event Domain.SerialEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	serial.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":              serial.Status,
+			"sale_id":             serial.SaleID,
+			"customer_name":       serial.CustomerName,
+			"customer_phone":      serial.CustomerPhone,
+			"sold_at":             serial.SoldAt,
+			"warranty_expires_at": serial.WarrantyExpiresAt,
+			"updated_at":          serial.UpdatedAt,
+		},
+		"$push": bson.M{"events": event},
+	}
+
+	// Guard on the current status so a unit cannot be sold twice
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": serial.ID, "status": from}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update serial number: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("serial number %s is no longer %s", serial.Serial, from)
+	}
+
+	serial.Events = append(serial.Events, event)
+	return nil
+}
+
+func (r *SerialRepository) EnsureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "business_id", Value: 1}, {Key: "serial", Value: 1}},
+			Options: options.Index().SetName("business_serial_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}},
+			Options: options.Index().SetName("product_status"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create serial number indexes: %w", err)
+	}
+
+	return nil
+}
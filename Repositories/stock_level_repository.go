@@ -0,0 +1,183 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StockLevelRepository struct {
+	collection *mongo.Collection
+}
+
+func NewStockLevelRepository(db *mongo.Database) Domain.StockLevelRepository {
+	return &StockLevelRepository{
+		collection: db.Collection("stock_levels"),
+	}
+}
+
+func (r *StockLevelRepository) Find(productID, locationID string) (*Domain.StockLevel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter, err := stockLevelFilter(productID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var level Domain.StockLevel
+	err = r.collection.FindOne(ctx, filter).Decode(&level)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find stock level: %w", err)
+	}
+
+	return &level, nil
+}
+
+func (r *StockLevelRepository) FindByProductID(productID string) ([]Domain.StockLevel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"product_id": objProductID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stock levels: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var levels []Domain.StockLevel
+	if err := cursor.All(ctx, &levels); err != nil {
+		return nil, fmt.Errorf("failed to decode stock levels: %w", err)
+	}
+
+	return levels, nil
+}
+
+func (r *StockLevelRepository) FindByLocationID(locationID string) ([]Domain.StockLevel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objLocationID, err := primitive.ObjectIDFromHex(locationID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location ID: %w", err)
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"location_id": objLocationID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stock levels: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var levels []Domain.StockLevel
+	if err := cursor.All(ctx, &levels); err != nil {
+		return nil, fmt.Errorf("failed to decode stock levels: %w", err)
+	}
+
+	return levels, nil
+}
+
+func (r *StockLevelRepository) Adjust(businessID, productID, locationID string, delta float64) (*Domain.StockLevel, error) {
+	return r.adjust(businessID, productID, locationID, delta, false)
+}
+
+func (r *StockLevelRepository) ForceAdjust(businessID, productID, locationID string, delta float64) (*Domain.StockLevel, error) {
+	return r.adjust(businessID, productID, locationID, delta, true)
+}
+
+func (r *StockLevelRepository) adjust(businessID, productID, locationID string, delta float64, allowNegative bool) (*Domain.StockLevel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	filter, err := stockLevelFilter(productID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delta < 0 && !allowNegative {
+		// Never let concurrent movements take a location below zero
+		filter["quantity"] = bson.M{"$gte": -delta}
+	}
+
+	update := bson.M{
+		"$inc":         bson.M{"quantity": delta},
+		"$set":         bson.M{"updated_at": time.Now()},
+		"$setOnInsert": bson.M{"business_id": objBusinessID},
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetUpsert(delta >= 0 || allowNegative)
+
+	var level Domain.StockLevel
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&level)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("insufficient stock at location")
+		}
+		return nil, fmt.Errorf("failed to adjust stock level: %w", err)
+	}
+
+	return &level, nil
+}
+
+func (r *StockLevelRepository) SetMinStock(businessID, productID, locationID string, minStock float64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	filter, err := stockLevelFilter(productID, locationID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set":         bson.M{"min_stock": minStock, "updated_at": time.Now()},
+		"$setOnInsert": bson.M{"business_id": objBusinessID, "quantity": 0.0},
+	}
+
+	_, err = r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to set location minimum stock: %w", err)
+	}
+
+	return nil
+}
+
+func stockLevelFilter(productID, locationID string) (bson.M, error) {
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	objLocationID, err := primitive.ObjectIDFromHex(locationID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location ID: %w", err)
+	}
+
+	return bson.M{
+		"product_id":  objProductID,
+		"location_id": objLocationID,
+	}, nil
+}
@@ -0,0 +1,207 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StocktakeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewStocktakeRepository(db *mongo.Database) Domain.StocktakeRepository {
+	return &StocktakeRepository{
+		collection: db.Collection("stocktakes"),
+	}
+}
+
+func (r *StocktakeRepository) Create(stocktake *Domain.Stocktake) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stocktake.Status = Domain.StocktakeStatusOpen
+	stocktake.CreatedAt = time.Now()
+	stocktake.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, stocktake)
+	if err != nil {
+		return fmt.Errorf("failed to create stocktake: %w", err)
+	}
+
+	stocktake.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *StocktakeRepository) FindByID(id string) (*Domain.Stocktake, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stocktake ID: %w", err)
+	}
+
+	var stocktake Domain.Stocktake
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&stocktake)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find stocktake: %w", err)
+	}
+
+	return &stocktake, nil
+}
+
+func (r *StocktakeRepository) FindByBusinessID(businessID string, status *Domain.StocktakeStatus) ([]Domain.Stocktake, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	query := bson.M{"business_id": objBusinessID}
+	if status != nil {
+		query["status"] = *status
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stocktakes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var stocktakes []Domain.Stocktake
+	if err := cursor.All(ctx, &stocktakes); err != nil {
+		return nil, fmt.Errorf("failed to decode stocktakes: %w", err)
+	}
+
+	return stocktakes, nil
+}
+
+// RecordCount sets or increments the counted quantity of one item in a single
+// update, so several people can count the same session at once.
+func (r *StocktakeRepository) RecordCount(id, productID string, quantity float64, add bool, expected float64, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid stocktake ID: %w", err)
+	}
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"items.$.expected_quantity": expected,
+			"items.$.counted_by":        objUserID,
+			"items.$.counted_at":        now,
+			"updated_at":                now,
+		},
+	}
+
+	if add {
+		update["$inc"] = bson.M{"items.$.counted_quantity": quantity}
+	} else {
+		update["$set"].(bson.M)["items.$.counted_quantity"] = quantity
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":              objID,
+		"status":           Domain.StocktakeStatusOpen,
+		"items.product_id": objProductID,
+	}, update)
+	if err != nil {
+		return fmt.Errorf("failed to record count: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("stocktake is not open or product is not part of it")
+	}
+
+	return nil
+}
+
+func (r *StocktakeRepository) UpdateItems(id string, items []Domain.StocktakeItem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid stocktake ID: %w", err)
+	}
+
+	_, err = r.collection.UpdateByID(ctx, objID, bson.M{
+		"$set": bson.M{
+			"items":      items,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update stocktake items: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus closes an open stocktake. Only open sessions can change status,
+// which stops a session from being posted twice.
+func (r *StocktakeRepository) UpdateStatus(id string, status Domain.StocktakeStatus, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid stocktake ID: %w", err)
+	}
+
+	now := time.Now()
+	set := bson.M{
+		"status":     status,
+		"updated_at": now,
+	}
+
+	if status == Domain.StocktakeStatusPosted {
+		objUserID, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return fmt.Errorf("invalid user ID: %w", err)
+		}
+		set["posted_by"] = objUserID
+		set["posted_at"] = now
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":    objID,
+		"status": Domain.StocktakeStatusOpen,
+	}, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("failed to update stocktake status: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("stocktake is not open")
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SupplierRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSupplierRepository(db *mongo.Database) Domain.SupplierRepository {
+	return &SupplierRepository{
+		collection: db.Collection("suppliers"),
+	}
+}
+
+func (r *SupplierRepository) Create(supplier *Domain.Supplier) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	supplier.Status = Domain.SupplierStatusActive
+	supplier.CreatedAt = time.Now()
+	supplier.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, supplier)
+	if err != nil {
+		return fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	supplier.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *SupplierRepository) FindByID(id string) (*Domain.Supplier, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid supplier ID: %w", err)
+	}
+
+	var supplier Domain.Supplier
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&supplier)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find supplier: %w", err)
+	}
+
+	return &supplier, nil
+}
+
+func (r *SupplierRepository) FindByBusinessID(businessID string) ([]Domain.Supplier, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.M{"name": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"business_id": objBusinessID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find suppliers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var suppliers []Domain.Supplier
+	if err := cursor.All(ctx, &suppliers); err != nil {
+		return nil, fmt.Errorf("failed to decode suppliers: %w", err)
+	}
+
+	return suppliers, nil
+}
+
+func (r *SupplierRepository) Update(supplier *Domain.Supplier) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	supplier.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":           supplier.Name,
+			"contact_name":   supplier.ContactName,
+			"phone":          supplier.Phone,
+			"email":          supplier.Email,
+			"lead_time_days": supplier.LeadTimeDays,
+			"notes":          supplier.Notes,
+			"status":         supplier.Status,
+			"updated_at":     supplier.UpdatedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateByID(ctx, supplier.ID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update supplier: %w", err)
+	}
+
+	return nil
+}
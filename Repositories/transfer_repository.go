@@ -0,0 +1,159 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TransferRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTransferRepository(db *mongo.Database) Domain.TransferRepository {
+	return &TransferRepository{
+		collection: db.Collection("stock_transfers"),
+	}
+}
+
+func (r *TransferRepository) Create(transfer *Domain.StockTransfer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	transfer.Status = Domain.TransferStatusInTransit
+	transfer.DispatchedAt = time.Now()
+	transfer.CreatedAt = time.Now()
+	transfer.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, transfer)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	transfer.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *TransferRepository) FindByID(id string) (*Domain.StockTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer ID: %w", err)
+	}
+
+	var transfer Domain.StockTransfer
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&transfer)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find transfer: %w", err)
+	}
+
+	return &transfer, nil
+}
+
+func (r *TransferRepository) FindByBusinessID(businessID string, status *Domain.TransferStatus) ([]Domain.StockTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	query := bson.M{"business_id": objBusinessID}
+	if status != nil {
+		query["status"] = *status
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transfers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var transfers []Domain.StockTransfer
+	if err := cursor.All(ctx, &transfers); err != nil {
+		return nil, fmt.Errorf("failed to decode transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+func (r *TransferRepository) FindInTransitByProductID(productID string) ([]Domain.StockTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objProductID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"status":           Domain.TransferStatusInTransit,
+		"items.product_id": objProductID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transfers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var transfers []Domain.StockTransfer
+	if err := cursor.All(ctx, &transfers); err != nil {
+		return nil, fmt.Errorf("failed to decode transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// UpdateStatus completes or cancels a transfer. Only transfers still in
+// transit can change status, so goods are never received twice.
+func (r *TransferRepository) UpdateStatus(id string, status Domain.TransferStatus, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid transfer ID: %w", err)
+	}
+
+	now := time.Now()
+	set := bson.M{
+		"status":     status,
+		"updated_at": now,
+	}
+
+	if status == Domain.TransferStatusReceived {
+		objUserID, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return fmt.Errorf("invalid user ID: %w", err)
+		}
+		set["received_by"] = objUserID
+		set["received_at"] = now
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":    objID,
+		"status": Domain.TransferStatusInTransit,
+	}, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("failed to update transfer status: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("transfer is not in transit")
+	}
+
+	return nil
+}
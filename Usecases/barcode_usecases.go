@@ -0,0 +1,259 @@
+package Usecases
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+)
+
+type BarcodeUseCase interface {
+	GetProductByBarcode(businessID, code string) (*Domain.Product, error)
+	GenerateBarcode(productID, businessID string, format Domain.BarcodeFormat) (*Domain.Product, error)
+	GenerateMissingBarcodes(businessID string, format Domain.BarcodeFormat) (*Domain.GenerateBarcodesResult, error)
+	RenderLabels(businessID string, req Domain.LabelSheetRequest) ([]byte, string, error)
+}
+
+type barcodeUseCase struct {
+	inventoryRepo Domain.ProductRepository
+	businessRepo  Domain.BusinessRepository
+	labelService  Infrastructure.LabelService
+}
+
+func NewBarcodeUseCase(
+	inventoryRepo Domain.ProductRepository,
+	businessRepo Domain.BusinessRepository,
+	labelService Infrastructure.LabelService,
+) BarcodeUseCase {
+	return &barcodeUseCase{
+		inventoryRepo: inventoryRepo,
+		businessRepo:  businessRepo,
+		labelService:  labelService,
+	}
+}
+
+// maxLabelsPerSheet caps a single label request.
+const maxLabelsPerSheet = 1000
+
+func (uc *barcodeUseCase) GetProductByBarcode(businessID, code string) (*Domain.Product, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, fmt.Errorf("barcode is required")
+	}
+
+	product, err := uc.inventoryRepo.FindByBarcode(businessID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scanners often drop the leading zero of an EAN-13 printed as UPC-A
+	if product == nil && len(code) == 12 {
+		product, err = uc.inventoryRepo.FindByBarcode(businessID, "0"+code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if product == nil {
+		return nil, fmt.Errorf("no product with barcode %s", code)
+	}
+
+	if product.IsBundle() {
+		products := []Domain.Product{*product}
+		if err := applyBundleFigures(uc.inventoryRepo, products); err != nil {
+			return nil, err
+		}
+		product = &products[0]
+	}
+
+	return product, nil
+}
+
+// GenerateBarcode gives a product without a barcode an internal one.
+func (uc *barcodeUseCase) GenerateBarcode(productID, businessID string, format Domain.BarcodeFormat) (*Domain.Product, error) {
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	if product.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: product does not belong to this business")
+	}
+
+	if product.Barcode != "" {
+		return nil, fmt.Errorf("product already has barcode %s", product.Barcode)
+	}
+
+	barcode, err := uc.assignBarcode(product, format)
+	if err != nil {
+		return nil, err
+	}
+
+	product.Barcode = barcode
+	return product, nil
+}
+
+func (uc *barcodeUseCase) GenerateMissingBarcodes(businessID string, format Domain.BarcodeFormat) (*Domain.GenerateBarcodesResult, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Domain.GenerateBarcodesResult{Products: []string{}}
+	for i := range products {
+		if products[i].Barcode != "" {
+			continue
+		}
+
+		if _, err := uc.assignBarcode(&products[i], format); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", products[i].Name, err))
+			continue
+		}
+
+		result.Generated++
+		result.Products = append(result.Products, products[i].ID.Hex())
+	}
+
+	return result, nil
+}
+
+// assignBarcode generates internal codes until one is free, relying on the
+// unique index to catch a code taken in the meantime.
+func (uc *barcodeUseCase) assignBarcode(product *Domain.Product, format Domain.BarcodeFormat) (string, error) {
+	if format == "" {
+		format = Domain.BarcodeFormatEAN13
+	}
+	if format != Domain.BarcodeFormatEAN13 && format != Domain.BarcodeFormatCode128 {
+		return "", fmt.Errorf("invalid barcode format: %s", format)
+	}
+
+	businessID := product.BusinessID.Hex()
+	for attempt := 0; attempt < 10; attempt++ {
+		barcode, err := internalBarcode(format)
+		if err != nil {
+			return "", err
+		}
+
+		existing, err := uc.inventoryRepo.FindByBarcode(businessID, barcode)
+		if err != nil {
+			return "", err
+		}
+		if existing != nil {
+			continue
+		}
+
+		err = uc.inventoryRepo.SetBarcode(product.ID.Hex(), barcode)
+		if errors.Is(err, Domain.ErrBarcodeInUse) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return barcode, nil
+	}
+
+	return "", fmt.Errorf("could not find a free barcode")
+}
+
+// internalBarcode makes a random in-store code under the internal prefix.
+// Code 128 codes are kept at ten digits so they are never read as UPC-A.
+func internalBarcode(format Domain.BarcodeFormat) (string, error) {
+	length := 12
+	if format == Domain.BarcodeFormatCode128 {
+		length = 10
+	}
+
+	digits := Domain.InternalBarcodePrefix
+	for len(digits) < length {
+		digits += string(rune('0' + rand.IntN(10)))
+	}
+
+	if format == Domain.BarcodeFormatCode128 {
+		return digits, nil
+	}
+
+	check, err := Infrastructure.EAN13CheckDigit(digits)
+	if err != nil {
+		return "", err
+	}
+	return digits + string(check), nil
+}
+
+func (uc *barcodeUseCase) RenderLabels(businessID string, req Domain.LabelSheetRequest) ([]byte, string, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, "", fmt.Errorf("business not found")
+	}
+
+	if len(req.ProductIDs) == 0 {
+		return nil, "", fmt.Errorf("at least one product is required")
+	}
+
+	copies := req.Copies
+	if copies <= 0 {
+		copies = 1
+	}
+	if len(req.ProductIDs)*copies > maxLabelsPerSheet {
+		return nil, "", fmt.Errorf("at most %d labels can be printed at once", maxLabelsPerSheet)
+	}
+
+	labels := make([]Domain.Label, 0, len(req.ProductIDs)*copies)
+	for _, productID := range req.ProductIDs {
+		product, err := uc.inventoryRepo.FindByID(productID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find product: %w", err)
+		}
+		if product == nil || product.BusinessID.Hex() != businessID {
+			return nil, "", fmt.Errorf("product %s not found", productID)
+		}
+		if product.Barcode == "" {
+			return nil, "", fmt.Errorf("%s has no barcode; generate one first", product.Name)
+		}
+
+		label := Domain.Label{
+			Name:    product.Name,
+			Price:   strings.TrimSpace(fmt.Sprintf("%s %.2f", business.Currency, product.SellingPrice)),
+			Barcode: product.Barcode,
+		}
+		for i := 0; i < copies; i++ {
+			labels = append(labels, label)
+		}
+	}
+
+	timestamp := time.Now().Format("20060102_150405")
+
+	switch req.Format {
+	case "", Domain.LabelFormatPDF:
+		data, err := uc.labelService.RenderPDF(labels)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to render labels: %w", err)
+		}
+		return data, fmt.Sprintf("labels_%s.pdf", timestamp), nil
+	case Domain.LabelFormatPNG:
+		data, err := uc.labelService.RenderPNG(labels)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to render labels: %w", err)
+		}
+		return data, fmt.Sprintf("labels_%s.png", timestamp), nil
+	}
+
+	return nil, "", fmt.Errorf("invalid label format: %s", req.Format)
+}
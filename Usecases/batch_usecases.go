@@ -0,0 +1,425 @@
+package Usecases
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	Domain "ShopOps/Domain"
+)
+
+type BatchUseCase interface {
+	ReceiveBatch(productID, businessID, userID string, req Domain.ReceiveBatchRequest) (*Domain.StockBatch, error)
+	GetBatches(productID, businessID string, includeDepleted bool) ([]Domain.StockBatch, error)
+	GetNearExpiryReport(businessID string, days int) (*Domain.NearExpiryReport, error)
+	WriteOffExpired(businessID, userID string) (*Domain.ExpiryWriteOffResult, error)
+	// WriteOffAllExpired writes off the expired batches of every business
+	// on behalf of its owner. It runs in the background; a business that
+	// fails is logged and skipped.
+	WriteOffAllExpired() error
+}
+
+type batchUseCase struct {
+	batchRepo     Domain.BatchRepository
+	inventoryRepo Domain.ProductRepository
+	businessRepo  Domain.BusinessRepository
+	locationRepo  Domain.LocationRepository
+	costingUC     CostingUseCase
+}
+
+func NewBatchUseCase(
+	batchRepo Domain.BatchRepository,
+	inventoryRepo Domain.ProductRepository,
+	businessRepo Domain.BusinessRepository,
+	locationRepo Domain.LocationRepository,
+	costingUC CostingUseCase,
+) BatchUseCase {
+	return &batchUseCase{
+		batchRepo:     batchRepo,
+		inventoryRepo: inventoryRepo,
+		businessRepo:  businessRepo,
+		locationRepo:  locationRepo,
+		costingUC:     costingUC,
+	}
+}
+
+func (uc *batchUseCase) ReceiveBatch(productID, businessID, userID string, req Domain.ReceiveBatchRequest) (*Domain.StockBatch, error) {
+	product, err := uc.getProduct(productID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.IsBundle() {
+		return nil, fmt.Errorf("%s is a bundle and holds no stock of its own; receive its components instead", product.Name)
+	}
+
+	if req.LotNumber == "" {
+		return nil, fmt.Errorf("lot number is required")
+	}
+
+	if req.Quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be greater than 0")
+	}
+
+	receivedDate := time.Now()
+	if req.ReceivedDate != nil {
+		receivedDate = *req.ReceivedDate
+	}
+
+	if req.ExpiryDate != nil && req.ExpiryDate.Before(receivedDate) {
+		return nil, fmt.Errorf("expiry date cannot be before the received date")
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	// The lot is written off later from wherever it was received
+	location, err := resolveLocation(uc.locationRepo, businessID, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	var locationID *string
+	if location != nil {
+		locationHex := location.ID.Hex()
+		locationID = &locationHex
+	}
+
+	costPrice := req.CostPrice
+	if costPrice <= 0 {
+		costPrice = product.CostPrice
+	}
+
+	// Receiving a lot switches the product to batch tracking
+	enabledTracking := !product.TrackBatches
+	if enabledTracking {
+		product.TrackBatches = true
+		if err := uc.inventoryRepo.Update(product); err != nil {
+			return nil, fmt.Errorf("failed to enable batch tracking: %w", err)
+		}
+	}
+
+	batch := &Domain.StockBatch{
+		BusinessID:   product.BusinessID,
+		ProductID:    product.ID,
+		LotNumber:    req.LotNumber,
+		ExpiryDate:   req.ExpiryDate,
+		ReceivedDate: receivedDate,
+		Quantity:     req.Quantity,
+		Remaining:    req.Quantity,
+		CostPrice:    costPrice,
+		CreatedBy:    objUserID,
+	}
+	if location != nil {
+		batch.LocationID = &location.ID
+	}
+
+	if err := uc.batchRepo.Create(batch); err != nil {
+		return nil, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	referenceID := batch.ID.Hex()
+	if _, err := uc.costingUC.AdjustStock(
+		productID,
+		req.Quantity,
+		Domain.MovementTypePurchase,
+		fmt.Sprintf("Batch %s received", req.LotNumber),
+		&referenceID,
+		"batch",
+		userID,
+		costPrice,
+		locationID,
+	); err != nil {
+		// Without the stock the batch would hold units that do not exist
+		if deleteErr := uc.batchRepo.Delete(referenceID); deleteErr != nil {
+			fmt.Printf("Failed to delete batch %s: %v\n", req.LotNumber, deleteErr)
+		}
+		if enabledTracking {
+			product.TrackBatches = false
+			if updateErr := uc.inventoryRepo.Update(product); updateErr != nil {
+				fmt.Printf("Failed to disable batch tracking: %v\n", updateErr)
+			}
+		}
+		return nil, fmt.Errorf("failed to update stock for batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+func (uc *batchUseCase) GetBatches(productID, businessID string, includeDepleted bool) ([]Domain.StockBatch, error) {
+	if _, err := uc.getProduct(productID, businessID); err != nil {
+		return nil, err
+	}
+
+	return uc.batchRepo.FindByProductID(productID, includeDepleted)
+}
+
+func (uc *batchUseCase) GetNearExpiryReport(businessID string, days int) (*Domain.NearExpiryReport, error) {
+	if days <= 0 {
+		days = 30
+	}
+
+	now := time.Now()
+	batches, err := uc.batchRepo.FindExpiring(businessID, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Domain.NearExpiryReport{
+		GeneratedAt: now,
+		Days:        days,
+		Items:       []Domain.NearExpiryItem{},
+	}
+
+	productNames := make(map[string]string)
+	for _, batch := range batches {
+		productID := batch.ProductID.Hex()
+		name, ok := productNames[productID]
+		if !ok {
+			if product, err := uc.inventoryRepo.FindByID(productID); err == nil && product != nil {
+				name = product.Name
+			}
+			productNames[productID] = name
+		}
+
+		value := batch.Remaining * batch.CostPrice
+		report.Items = append(report.Items, Domain.NearExpiryItem{
+			BatchID:      batch.ID.Hex(),
+			ProductID:    productID,
+			ProductName:  name,
+			LotNumber:    batch.LotNumber,
+			ExpiryDate:   *batch.ExpiryDate,
+			DaysToExpiry: int(math.Floor(batch.ExpiryDate.Sub(now).Hours() / 24)),
+			Expired:      batch.IsExpired(now),
+			Remaining:    batch.Remaining,
+			Value:        value,
+		})
+
+		report.TotalBatches++
+		report.TotalQuantity += batch.Remaining
+		report.TotalValue += value
+	}
+
+	return report, nil
+}
+
+func (uc *batchUseCase) WriteOffExpired(businessID, userID string) (*Domain.ExpiryWriteOffResult, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	batches, err := uc.batchRepo.FindExpiring(businessID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Domain.ExpiryWriteOffResult{
+		Batches: []Domain.StockBatch{},
+	}
+
+	for _, batch := range batches {
+		batchID := batch.ID.Hex()
+		quantity := batch.Remaining
+
+		// Lots received before locations were recorded come off the default
+		var locationID *string
+		if batch.LocationID != nil {
+			locationHex := batch.LocationID.Hex()
+			locationID = &locationHex
+		}
+
+		if _, err := uc.costingUC.AdjustStock(
+			batch.ProductID.Hex(),
+			quantity,
+			Domain.MovementTypeDamage,
+			fmt.Sprintf("Expired batch %s written off", batch.LotNumber),
+			&batchID,
+			"batch",
+			userID,
+			0,
+			locationID,
+		); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("batch %s: %v", batch.LotNumber, err))
+			continue
+		}
+
+		if _, err := uc.batchRepo.AdjustRemaining(batchID, -quantity); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("batch %s: %v", batch.LotNumber, err))
+			continue
+		}
+
+		if err := uc.batchRepo.UpdateStatus(batchID, Domain.BatchStatusExpired); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("batch %s: %v", batch.LotNumber, err))
+			continue
+		}
+
+		batch.Remaining = 0
+		batch.Status = Domain.BatchStatusExpired
+
+		result.BatchesWrittenOff++
+		result.TotalQuantity += quantity
+		result.TotalValue += quantity * batch.CostPrice
+		result.Batches = append(result.Batches, batch)
+	}
+
+	return result, nil
+}
+
+func (uc *batchUseCase) WriteOffAllExpired() error {
+	businesses, err := uc.businessRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for _, business := range businesses {
+		result, err := uc.WriteOffExpired(business.ID.Hex(), business.UserID.Hex())
+		if err != nil {
+			fmt.Printf("Failed to write off expired batches for business %s: %v\n", business.ID.Hex(), err)
+			continue
+		}
+		for _, message := range result.Errors {
+			fmt.Printf("Failed to write off expired batch for business %s: %s\n", business.ID.Hex(), message)
+		}
+	}
+
+	return nil
+}
+
+func (uc *batchUseCase) getProduct(productID, businessID string) (*Domain.Product, error) {
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	if product.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: product does not belong to this business")
+	}
+
+	return product, nil
+}
+
+// allocateBatches takes quantity out of a product's batches. Without an
+// override, batches are consumed first-expiry, first-out and expired lots are
+// skipped. Stock received before batch tracking was enabled is not held in any
+// batch, so whatever the batches cannot cover is taken from that loose stock.
+// Beyond that, the block policy fails the sale; warn and allow sell the rest
+// unbatched, and warn returns the shortfall as a warning.
+func allocateBatches(batchRepo Domain.BatchRepository, product *Domain.Product, quantity float64, batchID *string, policy Domain.NegativeStockPolicy) ([]Domain.SaleBatchAllocation, string, error) {
+	now := time.Now()
+
+	if batchID != nil && *batchID != "" {
+		batch, err := batchRepo.FindByID(*batchID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find batch: %w", err)
+		}
+		if batch == nil || batch.ProductID != product.ID {
+			return nil, "", fmt.Errorf("batch not found for this product")
+		}
+		if batch.Status != Domain.BatchStatusActive {
+			return nil, "", fmt.Errorf("batch %s is %s", batch.LotNumber, batch.Status)
+		}
+		if batch.IsExpired(now) {
+			return nil, "", fmt.Errorf("batch %s expired on %s", batch.LotNumber, batch.ExpiryDate.Format("2006-01-02"))
+		}
+		if batch.Remaining < quantity {
+			return nil, "", fmt.Errorf("insufficient stock in batch %s. Available: %.2f, Requested: %.2f",
+				batch.LotNumber, batch.Remaining, quantity)
+		}
+
+		if _, err := batchRepo.AdjustRemaining(*batchID, -quantity); err != nil {
+			return nil, "", err
+		}
+
+		return []Domain.SaleBatchAllocation{{
+			BatchID:    batch.ID,
+			LotNumber:  batch.LotNumber,
+			ExpiryDate: batch.ExpiryDate,
+			Quantity:   quantity,
+		}}, "", nil
+	}
+
+	batches, err := batchRepo.FindAvailable(product.ID.Hex())
+	if err != nil {
+		return nil, "", err
+	}
+
+	var batched float64
+	for _, batch := range batches {
+		batched += batch.Remaining
+	}
+	unbatched := math.Max(product.Stock-batched, 0)
+
+	var allocations []Domain.SaleBatchAllocation
+	needed := quantity
+
+	for _, batch := range batches {
+		if needed <= 0 {
+			break
+		}
+		if batch.IsExpired(now) {
+			continue
+		}
+
+		take := math.Min(batch.Remaining, needed)
+		if _, err := batchRepo.AdjustRemaining(batch.ID.Hex(), -take); err != nil {
+			releaseBatches(batchRepo, allocations)
+			return nil, "", err
+		}
+
+		allocations = append(allocations, Domain.SaleBatchAllocation{
+			BatchID:    batch.ID,
+			LotNumber:  batch.LotNumber,
+			ExpiryDate: batch.ExpiryDate,
+			Quantity:   take,
+		})
+		needed -= take
+	}
+
+	var warning string
+	if needed > unbatched+1e-9 {
+		shortfall := fmt.Errorf("insufficient unexpired batch stock. Available: %.2f, Requested: %.2f",
+			quantity-needed+unbatched, quantity)
+		switch policy {
+		case Domain.NegativeStockAllow:
+		case Domain.NegativeStockWarn:
+			// A shortfall of the product's own stock was already warned about
+			// when the sale's stock was checked
+			if quantity > product.Stock {
+				break
+			}
+			warning = fmt.Sprintf("%s will go below zero: %v", product.Name, shortfall)
+		default:
+			releaseBatches(batchRepo, allocations)
+			return nil, "", shortfall
+		}
+	}
+
+	return allocations, warning, nil
+}
+
+// releaseBatches puts allocated quantities back into their batches.
+func releaseBatches(batchRepo Domain.BatchRepository, allocations []Domain.SaleBatchAllocation) {
+	for _, allocation := range allocations {
+		if _, err := batchRepo.AdjustRemaining(allocation.BatchID.Hex(), allocation.Quantity); err != nil {
+			fmt.Printf("Failed to restore batch %s: %v\n", allocation.LotNumber, err)
+		}
+	}
+}
+
+// retakeBatches deducts allocations again after they were released, used to
+// undo a release when a replacement allocation fails.
+func retakeBatches(batchRepo Domain.BatchRepository, allocations []Domain.SaleBatchAllocation) {
+	for _, allocation := range allocations {
+		if _, err := batchRepo.AdjustRemaining(allocation.BatchID.Hex(), -allocation.Quantity); err != nil {
+			fmt.Printf("Failed to reapply batch %s: %v\n", allocation.LotNumber, err)
+		}
+	}
+}
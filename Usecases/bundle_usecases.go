@@ -0,0 +1,120 @@
+package Usecases
+
+import (
+	"fmt"
+	"math"
+
+	Domain "ShopOps/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// componentUnitCost is what one unit of a component adds to a bundle's cost.
+func componentUnitCost(product *Domain.Product) float64 {
+	if product.AverageCost > 0 {
+		return product.AverageCost
+	}
+	return product.CostPrice
+}
+
+// resolveBundleComponents checks the component lines of a bundle and returns
+// them together with the bundle's rolled-up cost. Lines for the same product
+// are merged.
+func resolveBundleComponents(inventoryRepo Domain.ProductRepository, businessID string, bundleID *primitive.ObjectID, lines []Domain.BundleComponentRequest) ([]Domain.BundleComponent, float64, error) {
+	if len(lines) == 0 {
+		return nil, 0, fmt.Errorf("a bundle needs at least one component")
+	}
+
+	var components []Domain.BundleComponent
+	var cost float64
+	index := make(map[primitive.ObjectID]int)
+
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, 0, fmt.Errorf("component quantity must be greater than 0")
+		}
+
+		product, err := inventoryRepo.FindByID(line.ProductID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to find component: %w", err)
+		}
+		if product == nil || product.BusinessID.Hex() != businessID {
+			return nil, 0, fmt.Errorf("component %s not found", line.ProductID)
+		}
+		if bundleID != nil && product.ID == *bundleID {
+			return nil, 0, fmt.Errorf("a bundle cannot contain itself")
+		}
+		if product.IsBundle() {
+			return nil, 0, fmt.Errorf("%s is a bundle and cannot be a component", product.Name)
+		}
+		if product.TrackBatches {
+			return nil, 0, fmt.Errorf("%s is batch-tracked and cannot be a component", product.Name)
+		}
+		if product.TrackSerials {
+			return nil, 0, fmt.Errorf("%s is serial-tracked and cannot be a component", product.Name)
+		}
+
+		cost += componentUnitCost(product) * line.Quantity
+
+		if i, ok := index[product.ID]; ok {
+			components[i].Quantity += line.Quantity
+			continue
+		}
+		index[product.ID] = len(components)
+		components = append(components, Domain.BundleComponent{
+			ProductID: product.ID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	return components, cost, nil
+}
+
+// applyBundleFigures fills in the stock and cost of the bundles among
+// products from their components: stock is the number of whole bundles the
+// components can make, cost the sum of the component costs. Components that
+// are in products are not looked up again.
+func applyBundleFigures(inventoryRepo Domain.ProductRepository, products []Domain.Product) error {
+	known := make(map[primitive.ObjectID]*Domain.Product, len(products))
+	for i := range products {
+		known[products[i].ID] = &products[i]
+	}
+
+	for i := range products {
+		bundle := &products[i]
+		if !bundle.IsBundle() {
+			continue
+		}
+
+		stock := math.Inf(1)
+		var cost float64
+		for _, component := range bundle.Components {
+			product, ok := known[component.ProductID]
+			if !ok {
+				found, err := inventoryRepo.FindByID(component.ProductID.Hex())
+				if err != nil {
+					return fmt.Errorf("failed to find component: %w", err)
+				}
+				known[component.ProductID] = found
+				product = found
+			}
+
+			// A deleted component means the bundle cannot be made
+			if product == nil {
+				stock = 0
+				continue
+			}
+
+			cost += componentUnitCost(product) * component.Quantity
+			stock = math.Min(stock, math.Floor(product.Stock/component.Quantity))
+		}
+
+		if math.IsInf(stock, 1) || stock < 0 {
+			stock = 0
+		}
+		bundle.Stock = stock
+		bundle.CostPrice = cost
+	}
+
+	return nil
+}
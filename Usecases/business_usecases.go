@@ -2,6 +2,7 @@ package Usecases
 
 import (
 	"fmt"
+	"time"
 
 	Domain "ShopOps/Domain"
 )
@@ -17,14 +18,20 @@ type BusinessUseCase interface {
 }
 
 type businessUseCase struct {
-	businessRepo Domain.BusinessRepository
-	userRepo     Domain.UserRepository
+	businessRepo   Domain.BusinessRepository
+	userRepo       Domain.UserRepository
+	dailyStatsRepo Domain.DailyStatsRepository
 }
 
-func NewBusinessUseCase(businessRepo Domain.BusinessRepository, userRepo Domain.UserRepository) BusinessUseCase {
+func NewBusinessUseCase(
+	businessRepo Domain.BusinessRepository,
+	userRepo Domain.UserRepository,
+	dailyStatsRepo Domain.DailyStatsRepository,
+) BusinessUseCase {
 	return &businessUseCase{
-		businessRepo: businessRepo,
-		userRepo:     userRepo,
+		businessRepo:   businessRepo,
+		userRepo:       userRepo,
+		dailyStatsRepo: dailyStatsRepo,
 	}
 }
 
@@ -42,29 +49,50 @@ func (uc *businessUseCase) CreateBusiness(userID string, req Domain.CreateBusine
 	if req.Timezone == "" {
 		req.Timezone = "UTC"
 	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", req.Timezone)
+	}
 
 	// Set default currency if not provided
 	if req.Currency == "" {
 		req.Currency = "USD"
 	}
 
+	// Set default costing method if not provided
+	if req.CostingMethod == "" {
+		req.CostingMethod = Domain.CostingMethodFIFO
+	}
+	if !isValidCostingMethod(req.CostingMethod) {
+		return nil, fmt.Errorf("invalid costing method: %s", req.CostingMethod)
+	}
+
+	// Sales stop at zero stock unless the business opts in
+	if req.NegativeStock == "" {
+		req.NegativeStock = Domain.NegativeStockBlock
+	}
+	if !Domain.IsValidNegativeStockPolicy(req.NegativeStock) {
+		return nil, fmt.Errorf("invalid negative stock policy: %s", req.NegativeStock)
+	}
+
 	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
 	business := &Domain.Business{
-		UserID:       objUserID,
-		Name:         req.Name,
-		Description:  req.Description,
-		BusinessType: req.BusinessType,
-		Currency:     req.Currency,
-		Timezone:     req.Timezone,
-		Address:      req.Address,
-		City:         req.City,
-		Country:      req.Country,
-		Phone:        req.Phone,
-		Email:        req.Email,
+		UserID:        objUserID,
+		Name:          req.Name,
+		Description:   req.Description,
+		BusinessType:  req.BusinessType,
+		Currency:      req.Currency,
+		Timezone:      req.Timezone,
+		CostingMethod: req.CostingMethod,
+		NegativeStock: req.NegativeStock,
+		Address:       req.Address,
+		City:          req.City,
+		Country:       req.Country,
+		Phone:         req.Phone,
+		Email:         req.Email,
 	}
 
 	if err := uc.businessRepo.Create(business); err != nil {
@@ -89,6 +117,8 @@ func (uc *businessUseCase) UpdateBusiness(id, userID string, req Domain.UpdateBu
 		return nil, err
 	}
 
+	previousTimezone := business.Timezone
+
 	// Update fields
 	if req.Name != "" {
 		business.Name = req.Name
@@ -103,8 +133,23 @@ func (uc *businessUseCase) UpdateBusiness(id, userID string, req Domain.UpdateBu
 		business.Currency = req.Currency
 	}
 	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone: %s", req.Timezone)
+		}
 		business.Timezone = req.Timezone
 	}
+	if req.CostingMethod != "" {
+		if !isValidCostingMethod(req.CostingMethod) {
+			return nil, fmt.Errorf("invalid costing method: %s", req.CostingMethod)
+		}
+		business.CostingMethod = req.CostingMethod
+	}
+	if req.NegativeStock != "" {
+		if !Domain.IsValidNegativeStockPolicy(req.NegativeStock) {
+			return nil, fmt.Errorf("invalid negative stock policy: %s", req.NegativeStock)
+		}
+		business.NegativeStock = req.NegativeStock
+	}
 	if req.Address != "" {
 		business.Address = req.Address
 	}
@@ -125,6 +170,15 @@ func (uc *businessUseCase) UpdateBusiness(id, userID string, req Domain.UpdateBu
 		return nil, fmt.Errorf("failed to update business: %w", err)
 	}
 
+	// Every day's boundaries moved, so the daily rollups are rebuilt
+	if business.Timezone != previousTimezone {
+		go func(businessID string, loc *time.Location) {
+			if _, err := uc.dailyStatsRepo.Rebuild(businessID, loc); err != nil {
+				fmt.Printf("Failed to rebuild daily stats after timezone change: %v\n", err)
+			}
+		}(id, business.Location())
+	}
+
 	return business, nil
 }
 
@@ -164,3 +218,20 @@ func (uc *businessUseCase) ValidateBusinessAccess(businessID, userID string) (*D
 
 	return business, nil
 }
+
+func isValidCostingMethod(method Domain.CostingMethod) bool {
+	return method == Domain.CostingMethodFIFO || method == Domain.CostingMethodAverage
+}
+
+// businessLocation returns the timezone a business's days are counted in.
+func businessLocation(businessRepo Domain.BusinessRepository, businessID string) (*time.Location, error) {
+	business, err := businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	return business.Location(), nil
+}
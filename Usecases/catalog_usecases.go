@@ -0,0 +1,676 @@
+package Usecases
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+)
+
+type CatalogUseCase interface {
+	ImportCatalog(businessID, userID string, req Domain.CatalogImportRequest) (*Domain.CatalogImportJob, error)
+	GetImportJob(id, businessID string) (*Domain.CatalogImportJob, error)
+	GetImportJobs(businessID string) ([]Domain.CatalogImportJob, error)
+	ExportCatalog(businessID string, format Domain.CatalogFormat, templateOnly bool) ([]byte, string, error)
+}
+
+type catalogUseCase struct {
+	importJobRepo Domain.ImportJobRepository
+	inventoryRepo Domain.ProductRepository
+	supplierRepo  Domain.SupplierRepository
+	businessRepo  Domain.BusinessRepository
+	inventoryUC   InventoryUseCase
+	costingUC     CostingUseCase
+	exportService Infrastructure.ExportService
+}
+
+func NewCatalogUseCase(
+	importJobRepo Domain.ImportJobRepository,
+	inventoryRepo Domain.ProductRepository,
+	supplierRepo Domain.SupplierRepository,
+	businessRepo Domain.BusinessRepository,
+	inventoryUC InventoryUseCase,
+	costingUC CostingUseCase,
+	exportService Infrastructure.ExportService,
+) CatalogUseCase {
+	return &catalogUseCase{
+		importJobRepo: importJobRepo,
+		inventoryRepo: inventoryRepo,
+		supplierRepo:  supplierRepo,
+		businessRepo:  businessRepo,
+		inventoryUC:   inventoryUC,
+		costingUC:     costingUC,
+		exportService: exportService,
+	}
+}
+
+// importProgressInterval is how many rows are processed between progress
+// updates of a running import.
+const importProgressInterval = 25
+
+// ImportCatalog reads a catalog file and upserts its products. A dry run is
+// validated straight away and nothing is written; otherwise the import runs
+// in the background and the returned job can be polled for progress.
+func (uc *catalogUseCase) ImportCatalog(businessID, userID string, req Domain.CatalogImportRequest) (*Domain.CatalogImportJob, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	format := req.Format
+	if format == "" {
+		format = Domain.CatalogFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(req.FileName)), "."))
+	}
+
+	var rows [][]string
+	switch format {
+	case Domain.CatalogFormatCSV:
+		rows, err = Infrastructure.ReadCSV(req.Data)
+	case Domain.CatalogFormatXLSX:
+		rows, err = Infrastructure.ReadXLSX(req.Data)
+	default:
+		return nil, fmt.Errorf("unsupported file format %q: use csv or xlsx", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	columns, mapping, err := resolveCatalogColumns(rows[0], req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Domain.CatalogImportJob{
+		BusinessID: business.ID,
+		FileName:   req.FileName,
+		Format:     format,
+		DryRun:     req.DryRun,
+		Mapping:    mapping,
+		Errors:     []Domain.ImportRowError{},
+		CreatedBy:  objUserID,
+	}
+	for _, row := range rows[1:] {
+		if !isBlankRow(row) {
+			job.TotalRows++
+		}
+	}
+
+	if job.TotalRows == 0 {
+		return nil, fmt.Errorf("file has no product rows")
+	}
+
+	if req.DryRun {
+		now := time.Now()
+		job.Status = Domain.ImportJobStatusRunning
+		job.StartedAt = &now
+		if err := uc.processImport(job, businessID, userID, rows, columns); err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+
+	if err := uc.importJobRepo.Create(job); err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	go uc.runImport(*job, businessID, userID, rows, columns)
+
+	return job, nil
+}
+
+func (uc *catalogUseCase) GetImportJob(id, businessID string) (*Domain.CatalogImportJob, error) {
+	job, err := uc.importJobRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("import job not found")
+	}
+
+	if job.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: import job does not belong to this business")
+	}
+
+	return job, nil
+}
+
+func (uc *catalogUseCase) GetImportJobs(businessID string) ([]Domain.CatalogImportJob, error) {
+	return uc.importJobRepo.FindByBusinessID(businessID, 50)
+}
+
+// ExportCatalog writes the products of a business in the import template, so
+// an exported file can be edited and imported again.
+func (uc *catalogUseCase) ExportCatalog(businessID string, format Domain.CatalogFormat, templateOnly bool) ([]byte, string, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, "", fmt.Errorf("business not found")
+	}
+
+	header := make([]string, len(Domain.CatalogColumns))
+	for i, column := range Domain.CatalogColumns {
+		header[i] = column.Header
+	}
+	rows := [][]string{header}
+
+	if !templateOnly {
+		products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+		if err != nil {
+			return nil, "", err
+		}
+
+		suppliers, err := uc.supplierRepo.FindByBusinessID(businessID)
+		if err != nil {
+			return nil, "", err
+		}
+		supplierNames := make(map[string]string)
+		for _, supplier := range suppliers {
+			supplierNames[supplier.ID.Hex()] = supplier.Name
+		}
+
+		for _, product := range products {
+			rows = append(rows, catalogRow(product, supplierNames))
+		}
+	}
+
+	timestamp := time.Now()
+	name := "catalog"
+	if templateOnly {
+		name = "catalog_template"
+	}
+
+	switch format {
+	case "", Domain.CatalogFormatCSV:
+		data, err := uc.exportService.ExportToCSV(rows, Domain.ReportTypeCatalog)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to export to CSV: %w", err)
+		}
+		return data, fmt.Sprintf("%s_%s.csv", name, timestamp.Format("20060102_150405")), nil
+	case Domain.CatalogFormatXLSX:
+		data, err := uc.exportService.ExportToXLSX("Products", rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to export to XLSX: %w", err)
+		}
+		return data, fmt.Sprintf("%s_%s.xlsx", name, timestamp.Format("20060102_150405")), nil
+	}
+
+	return nil, "", fmt.Errorf("unsupported format %q: use csv or xlsx", format)
+}
+
+// runImport processes an import in the background, recording a failed job
+// rather than taking the server down if anything goes wrong.
+func (uc *catalogUseCase) runImport(job Domain.CatalogImportJob, businessID, userID string, rows [][]string, columns map[int]Domain.CatalogField) {
+	defer func() {
+		if r := recover(); r != nil {
+			uc.failImport(&job, fmt.Sprintf("import stopped unexpectedly: %v", r))
+		}
+	}()
+
+	now := time.Now()
+	job.Status = Domain.ImportJobStatusRunning
+	job.StartedAt = &now
+	if err := uc.importJobRepo.Update(&job); err != nil {
+		fmt.Printf("Failed to start import job %s: %v\n", job.ID.Hex(), err)
+	}
+
+	if err := uc.processImport(&job, businessID, userID, rows, columns); err != nil {
+		uc.failImport(&job, err.Error())
+	}
+}
+
+func (uc *catalogUseCase) failImport(job *Domain.CatalogImportJob, message string) {
+	now := time.Now()
+	job.Status = Domain.ImportJobStatusFailed
+	job.Error = message
+	job.CompletedAt = &now
+	if err := uc.importJobRepo.Update(job); err != nil {
+		fmt.Printf("Failed to record failure of import job %s: %v\n", job.ID.Hex(), err)
+	}
+}
+
+// processImport validates and, unless the job is a dry run, applies every row.
+// Existing products are matched by SKU, then by barcode. Empty cells leave the
+// existing value unchanged.
+func (uc *catalogUseCase) processImport(job *Domain.CatalogImportJob, businessID, userID string, rows [][]string, columns map[int]Domain.CatalogField) error {
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+	if err != nil {
+		return err
+	}
+
+	bySKU := make(map[string]*Domain.Product)
+	byBarcode := make(map[string]*Domain.Product)
+	for i := range products {
+		if products[i].SKU != "" {
+			bySKU[products[i].SKU] = &products[i]
+		}
+		if products[i].Barcode != "" {
+			byBarcode[products[i].Barcode] = &products[i]
+		}
+	}
+
+	suppliers, err := uc.supplierRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return err
+	}
+	suppliersByName := make(map[string]string)
+	for _, supplier := range suppliers {
+		suppliersByName[strings.ToLower(supplier.Name)] = supplier.ID.Hex()
+	}
+
+	headers := rows[0]
+	seenSKU := make(map[string]int)
+	seenBarcode := make(map[string]int)
+	jobID := job.ID.Hex()
+
+	for i, cells := range rows[1:] {
+		rowNumber := i + 2
+		if isBlankRow(cells) {
+			continue
+		}
+
+		values := make(map[Domain.CatalogField]string)
+		headerOf := make(map[Domain.CatalogField]string)
+		for index, field := range columns {
+			headerOf[field] = headers[index]
+			if index < len(cells) {
+				if value := strings.TrimSpace(cells[index]); value != "" {
+					values[field] = value
+				}
+			}
+		}
+
+		var rowErrors []Domain.ImportRowError
+		addError := func(field Domain.CatalogField, message string) {
+			rowErrors = append(rowErrors, Domain.ImportRowError{Row: rowNumber, Column: headerOf[field], Message: message})
+		}
+
+		var existing *Domain.Product
+		if sku := values[Domain.CatalogFieldSKU]; sku != "" {
+			existing = bySKU[sku]
+		}
+		if barcode := values[Domain.CatalogFieldBarcode]; existing == nil && barcode != "" {
+			existing = byBarcode[barcode]
+		}
+
+		if sku := values[Domain.CatalogFieldSKU]; sku != "" {
+			if first, ok := seenSKU[sku]; ok {
+				addError(Domain.CatalogFieldSKU, fmt.Sprintf("SKU %s already appears in row %d", sku, first))
+			}
+			seenSKU[sku] = rowNumber
+		}
+		if barcode := values[Domain.CatalogFieldBarcode]; barcode != "" {
+			if first, ok := seenBarcode[barcode]; ok {
+				addError(Domain.CatalogFieldBarcode, fmt.Sprintf("barcode %s already appears in row %d", barcode, first))
+			}
+			seenBarcode[barcode] = rowNumber
+			if other := byBarcode[barcode]; other != nil && existing != nil && other.ID != existing.ID {
+				addError(Domain.CatalogFieldBarcode, fmt.Sprintf("barcode %s belongs to %s", barcode, other.Name))
+			}
+		}
+
+		req := catalogRequest(existing)
+		for field, value := range values {
+			if err := setCatalogField(&req, field, value, suppliersByName); err != nil {
+				addError(field, err.Error())
+			}
+		}
+
+		if len(rowErrors) == 0 {
+			for _, problem := range validateCatalogRequest(req) {
+				addError(problem.field, problem.message)
+			}
+		}
+
+		if len(rowErrors) == 0 && !job.DryRun {
+			if existing != nil {
+				rowErrors = uc.updateFromCatalog(existing, businessID, userID, jobID, req, rowNumber)
+			} else {
+				req.LocationID = nil
+				product, err := uc.inventoryUC.CreateProduct(businessID, userID, req)
+				if err != nil {
+					rowErrors = append(rowErrors, Domain.ImportRowError{Row: rowNumber, Message: err.Error()})
+				} else {
+					// Later rows in the file may refer to the new product
+					if product.SKU != "" {
+						bySKU[product.SKU] = product
+					}
+					if product.Barcode != "" {
+						byBarcode[product.Barcode] = product
+					}
+				}
+			}
+		}
+
+		switch {
+		case len(rowErrors) > 0:
+			job.Failed++
+			job.Errors = append(job.Errors, rowErrors...)
+		case existing != nil:
+			job.Updated++
+		default:
+			job.Created++
+		}
+
+		job.ProcessedRows++
+		job.Progress = float64(job.ProcessedRows) / float64(job.TotalRows) * 100
+
+		if !job.DryRun && job.ProcessedRows%importProgressInterval == 0 {
+			if err := uc.importJobRepo.Update(job); err != nil {
+				fmt.Printf("Failed to update import job %s: %v\n", jobID, err)
+			}
+		}
+	}
+
+	now := time.Now()
+	job.Status = Domain.ImportJobStatusCompleted
+	job.CompletedAt = &now
+	if job.DryRun {
+		return nil
+	}
+
+	return uc.importJobRepo.Update(job)
+}
+
+// updateFromCatalog updates an existing product from an import row. A changed
+// stock figure is posted as an adjustment so the ledger explains it.
+func (uc *catalogUseCase) updateFromCatalog(existing *Domain.Product, businessID, userID, jobID string, req Domain.CreateProductRequest, rowNumber int) []Domain.ImportRowError {
+	if _, err := uc.inventoryUC.UpdateProduct(existing.ID.Hex(), businessID, userID, req); err != nil {
+		return []Domain.ImportRowError{{Row: rowNumber, Message: err.Error()}}
+	}
+
+	difference := req.Stock - existing.Stock
+	if difference == 0 {
+		return nil
+	}
+
+	if _, err := uc.costingUC.AdjustStock(
+		existing.ID.Hex(),
+		difference,
+		Domain.MovementTypeAdjust,
+		"Catalog import",
+		&jobID,
+		"catalog_import",
+		userID,
+		req.CostPrice,
+		nil,
+	); err != nil {
+		return []Domain.ImportRowError{{Row: rowNumber, Message: fmt.Sprintf("product updated but stock was not: %v", err)}}
+	}
+
+	existing.Stock = req.Stock
+	return nil
+}
+
+// resolveCatalogColumns works out which product field each column of the file
+// holds. The mapping takes precedence; other headers are matched against the
+// template headers and field names.
+func resolveCatalogColumns(headers []string, mapping map[string]Domain.CatalogField) (map[int]Domain.CatalogField, map[string]Domain.CatalogField, error) {
+	known := make(map[string]Domain.CatalogField)
+	for _, column := range Domain.CatalogColumns {
+		known[strings.ToLower(column.Header)] = column.Field
+		known[string(column.Field)] = column.Field
+	}
+
+	headerIndex := make(map[string]int)
+	for i, header := range headers {
+		headerIndex[strings.TrimSpace(header)] = i
+	}
+
+	columns := make(map[int]Domain.CatalogField)
+	resolved := make(map[string]Domain.CatalogField)
+	used := make(map[Domain.CatalogField]string)
+
+	assign := func(header string, index int, field Domain.CatalogField) error {
+		if other, ok := used[field]; ok {
+			return fmt.Errorf("columns %q and %q both map to %s", other, header, field)
+		}
+		used[field] = header
+		columns[index] = field
+		resolved[header] = field
+		return nil
+	}
+
+	for header, field := range mapping {
+		index, ok := headerIndex[strings.TrimSpace(header)]
+		if !ok {
+			return nil, nil, fmt.Errorf("mapped column %q is not in the file", header)
+		}
+		if _, ok := known[string(field)]; !ok {
+			return nil, nil, fmt.Errorf("unknown product field %q for column %q", field, header)
+		}
+		if err := assign(strings.TrimSpace(header), index, field); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for i, header := range headers {
+		header = strings.TrimSpace(header)
+		if _, mapped := resolved[header]; mapped {
+			continue
+		}
+		field, ok := known[strings.ToLower(header)]
+		if !ok {
+			continue
+		}
+		if _, taken := used[field]; taken {
+			continue
+		}
+		if err := assign(header, i, field); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	_, hasName := used[Domain.CatalogFieldName]
+	_, hasSKU := used[Domain.CatalogFieldSKU]
+	_, hasBarcode := used[Domain.CatalogFieldBarcode]
+	if !hasName && !hasSKU && !hasBarcode {
+		return nil, nil, fmt.Errorf("no name, SKU or barcode column found; map the file's columns to product fields")
+	}
+
+	return columns, resolved, nil
+}
+
+// catalogRequest starts an import row from the product it updates, so that
+// columns missing from the file keep their current values.
+func catalogRequest(product *Domain.Product) Domain.CreateProductRequest {
+	if product == nil {
+		return Domain.CreateProductRequest{}
+	}
+
+	req := Domain.CreateProductRequest{
+		Name:         product.Name,
+		Description:  product.Description,
+		SKU:          product.SKU,
+		Barcode:      product.Barcode,
+		Category:     product.Category,
+		Unit:         product.Unit,
+		CostPrice:    product.CostPrice,
+		SellingPrice: product.SellingPrice,
+		Stock:        product.Stock,
+		MinStock:     product.MinStock,
+		MaxStock:     product.MaxStock,
+		TrackBatches: product.TrackBatches,
+		LeadTimeDays: product.LeadTimeDays,
+	}
+	if product.SupplierID != nil {
+		supplierID := product.SupplierID.Hex()
+		req.SupplierID = &supplierID
+	}
+
+	return req
+}
+
+func setCatalogField(req *Domain.CreateProductRequest, field Domain.CatalogField, value string, suppliersByName map[string]string) error {
+	parseNumber := func(target *float64) error {
+		number, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*target = number
+		return nil
+	}
+
+	switch field {
+	case Domain.CatalogFieldName:
+		req.Name = value
+	case Domain.CatalogFieldSKU:
+		req.SKU = value
+	case Domain.CatalogFieldBarcode:
+		req.Barcode = value
+	case Domain.CatalogFieldDescription:
+		req.Description = value
+	case Domain.CatalogFieldCategory:
+		req.Category = value
+	case Domain.CatalogFieldUnit:
+		req.Unit = value
+	case Domain.CatalogFieldCostPrice:
+		return parseNumber(&req.CostPrice)
+	case Domain.CatalogFieldSellingPrice:
+		return parseNumber(&req.SellingPrice)
+	case Domain.CatalogFieldStock:
+		return parseNumber(&req.Stock)
+	case Domain.CatalogFieldMinStock:
+		return parseNumber(&req.MinStock)
+	case Domain.CatalogFieldMaxStock:
+		return parseNumber(&req.MaxStock)
+	case Domain.CatalogFieldLeadTimeDays:
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number of days", value)
+		}
+		req.LeadTimeDays = days
+	case Domain.CatalogFieldTrackBatches:
+		switch strings.ToLower(value) {
+		case "yes", "y", "true", "1":
+			req.TrackBatches = true
+		case "no", "n", "false", "0":
+			req.TrackBatches = false
+		default:
+			return fmt.Errorf("%q is not yes or no", value)
+		}
+	case Domain.CatalogFieldSupplier:
+		supplierID, ok := suppliersByName[strings.ToLower(value)]
+		if !ok {
+			return fmt.Errorf("supplier %q not found", value)
+		}
+		req.SupplierID = &supplierID
+	}
+
+	return nil
+}
+
+type catalogProblem struct {
+	field   Domain.CatalogField
+	message string
+}
+
+// validateCatalogRequest applies the product rules to an import row so a dry
+// run reports the same problems a real import would hit.
+func validateCatalogRequest(req Domain.CreateProductRequest) []catalogProblem {
+	var problems []catalogProblem
+
+	if req.Name == "" {
+		problems = append(problems, catalogProblem{Domain.CatalogFieldName, "name is required"})
+	}
+	if req.CostPrice <= 0 {
+		problems = append(problems, catalogProblem{Domain.CatalogFieldCostPrice, "cost price must be greater than 0"})
+	}
+	if req.SellingPrice <= 0 {
+		problems = append(problems, catalogProblem{Domain.CatalogFieldSellingPrice, "selling price must be greater than 0"})
+	} else if req.SellingPrice <= req.CostPrice {
+		problems = append(problems, catalogProblem{Domain.CatalogFieldSellingPrice, "selling price must be greater than cost price"})
+	}
+	if req.Stock < 0 {
+		problems = append(problems, catalogProblem{Domain.CatalogFieldStock, "stock cannot be negative"})
+	}
+	if req.MinStock < 0 || req.MaxStock < 0 {
+		problems = append(problems, catalogProblem{Domain.CatalogFieldMinStock, "stock limits cannot be negative"})
+	} else if req.MinStock > 0 && req.MaxStock > 0 && req.MinStock >= req.MaxStock {
+		problems = append(problems, catalogProblem{Domain.CatalogFieldMinStock, "minimum stock must be less than maximum stock"})
+	}
+	if req.LeadTimeDays < 0 {
+		problems = append(problems, catalogProblem{Domain.CatalogFieldLeadTimeDays, "lead time cannot be negative"})
+	}
+
+	return problems
+}
+
+// catalogRow lays out a product in the template's column order.
+func catalogRow(product Domain.Product, supplierNames map[string]string) []string {
+	formatNumber := func(value float64, blankZero bool) string {
+		if value == 0 && blankZero {
+			return ""
+		}
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	row := make([]string, len(Domain.CatalogColumns))
+	for i, column := range Domain.CatalogColumns {
+		switch column.Field {
+		case Domain.CatalogFieldName:
+			row[i] = product.Name
+		case Domain.CatalogFieldSKU:
+			row[i] = product.SKU
+		case Domain.CatalogFieldBarcode:
+			row[i] = product.Barcode
+		case Domain.CatalogFieldDescription:
+			row[i] = product.Description
+		case Domain.CatalogFieldCategory:
+			row[i] = product.Category
+		case Domain.CatalogFieldUnit:
+			row[i] = product.Unit
+		case Domain.CatalogFieldCostPrice:
+			row[i] = formatNumber(product.CostPrice, false)
+		case Domain.CatalogFieldSellingPrice:
+			row[i] = formatNumber(product.SellingPrice, false)
+		case Domain.CatalogFieldStock:
+			row[i] = formatNumber(product.Stock, false)
+		case Domain.CatalogFieldMinStock:
+			row[i] = formatNumber(product.MinStock, true)
+		case Domain.CatalogFieldMaxStock:
+			row[i] = formatNumber(product.MaxStock, true)
+		case Domain.CatalogFieldSupplier:
+			if product.SupplierID != nil {
+				row[i] = supplierNames[product.SupplierID.Hex()]
+			}
+		case Domain.CatalogFieldLeadTimeDays:
+			if product.LeadTimeDays > 0 {
+				row[i] = strconv.Itoa(product.LeadTimeDays)
+			}
+		case Domain.CatalogFieldTrackBatches:
+			row[i] = "no"
+			if product.TrackBatches {
+				row[i] = "yes"
+			}
+		}
+	}
+
+	return row
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,510 @@
+package Usecases
+
+import (
+	"fmt"
+	"strings"
+
+	Domain "ShopOps/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CategoryUseCase interface {
+	CreateCategory(businessID, userID string, req Domain.CreateCategoryRequest) (*Domain.Category, error)
+	// GetCategories returns the category tree of the business.
+	GetCategories(businessID string) ([]Domain.Category, error)
+	GetCategory(id, businessID string) (*Domain.Category, error)
+	UpdateCategory(id, businessID string, req Domain.UpdateCategoryRequest) (*Domain.Category, error)
+	ReorderCategories(businessID string, req Domain.ReorderCategoriesRequest) ([]Domain.Category, error)
+	MergeCategory(id, businessID string, req Domain.MergeCategoryRequest) (*Domain.Category, error)
+	DeleteCategory(id, businessID string) error
+	// MigrateCategories creates categories from the free-text category names
+	// of products that have no category ID yet and links the products.
+	MigrateCategories(businessID, userID string) (*Domain.CategoryMigrationResult, error)
+}
+
+type categoryUseCase struct {
+	categoryRepo  Domain.CategoryRepository
+	inventoryRepo Domain.ProductRepository
+	businessRepo  Domain.BusinessRepository
+}
+
+func NewCategoryUseCase(
+	categoryRepo Domain.CategoryRepository,
+	inventoryRepo Domain.ProductRepository,
+	businessRepo Domain.BusinessRepository,
+) CategoryUseCase {
+	return &categoryUseCase{
+		categoryRepo:  categoryRepo,
+		inventoryRepo: inventoryRepo,
+		businessRepo:  businessRepo,
+	}
+}
+
+func (uc *categoryUseCase) CreateCategory(businessID, userID string, req Domain.CreateCategoryRequest) (*Domain.Category, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("category name is required")
+	}
+	if err := uc.checkNameFree(businessID, name, nil); err != nil {
+		return nil, err
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	categories, err := uc.categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	category := &Domain.Category{
+		BusinessID: business.ID,
+		Name:       name,
+		Ancestors:  []primitive.ObjectID{},
+		CreatedBy:  objUserID,
+	}
+
+	if req.ParentID != "" {
+		parent, err := uc.getCategory(req.ParentID, businessID)
+		if err != nil {
+			return nil, err
+		}
+		category.ParentID = &parent.ID
+		category.Ancestors = append(append([]primitive.ObjectID{}, parent.Ancestors...), parent.ID)
+	}
+	category.SortOrder = nextSortOrder(categories, category.ParentID)
+
+	if err := uc.categoryRepo.Create(category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (uc *categoryUseCase) GetCategories(businessID string) ([]Domain.Category, error) {
+	categories, err := uc.categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCategoryTree(categories, nil), nil
+}
+
+func (uc *categoryUseCase) GetCategory(id, businessID string) (*Domain.Category, error) {
+	category, err := uc.getCategory(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+	category.Children = buildCategoryTree(categories, &category.ID)
+
+	return category, nil
+}
+
+func (uc *categoryUseCase) UpdateCategory(id, businessID string, req Domain.UpdateCategoryRequest) (*Domain.Category, error) {
+	category, err := uc.getCategory(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" && name != category.Name {
+		if err := uc.checkNameFree(businessID, name, &category.ID); err != nil {
+			return nil, err
+		}
+		category.Name = name
+
+		// Products keep the category name for display
+		if _, err := uc.inventoryRepo.MoveCategory(category.ID, category.ID, category.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ParentID == nil {
+		if err := uc.categoryRepo.Update(category); err != nil {
+			return nil, err
+		}
+		return category, nil
+	}
+
+	categories, err := uc.categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *Domain.Category
+	if *req.ParentID != "" {
+		parent, err = uc.getCategory(*req.ParentID, businessID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ID == category.ID || parent.HasAncestor(category.ID) {
+			return nil, fmt.Errorf("a category cannot be moved under itself")
+		}
+	}
+
+	if err := uc.reparent(categories, category, parent); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (uc *categoryUseCase) ReorderCategories(businessID string, req Domain.ReorderCategoriesRequest) ([]Domain.Category, error) {
+	if len(req.CategoryIDs) == 0 {
+		return nil, fmt.Errorf("category IDs are required")
+	}
+
+	var parentID *primitive.ObjectID
+	if req.ParentID != "" {
+		parent, err := uc.getCategory(req.ParentID, businessID)
+		if err != nil {
+			return nil, err
+		}
+		parentID = &parent.ID
+	}
+
+	for i, id := range req.CategoryIDs {
+		category, err := uc.getCategory(id, businessID)
+		if err != nil {
+			return nil, err
+		}
+		if !sameParent(category.ParentID, parentID) {
+			return nil, fmt.Errorf("%s is not a child of the given parent", category.Name)
+		}
+		if err := uc.categoryRepo.SetSortOrder(id, i); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.GetCategories(businessID)
+}
+
+func (uc *categoryUseCase) MergeCategory(id, businessID string, req Domain.MergeCategoryRequest) (*Domain.Category, error) {
+	source, err := uc.getCategory(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := uc.getCategory(req.TargetID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ID == source.ID || target.HasAncestor(source.ID) {
+		return nil, fmt.Errorf("a category cannot be merged into itself or one of its subcategories")
+	}
+
+	if _, err := uc.inventoryRepo.MoveCategory(source.ID, target.ID, target.Name); err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range categories {
+		child := &categories[i]
+		if child.ParentID == nil || *child.ParentID != source.ID {
+			continue
+		}
+		if err := uc.reparent(categories, child, target); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.categoryRepo.Delete(id); err != nil {
+		return nil, err
+	}
+
+	return uc.GetCategory(target.ID.Hex(), businessID)
+}
+
+func (uc *categoryUseCase) DeleteCategory(id, businessID string) error {
+	category, err := uc.getCategory(id, businessID)
+	if err != nil {
+		return err
+	}
+
+	categories, err := uc.categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return err
+	}
+	for _, other := range categories {
+		if other.ParentID != nil && *other.ParentID == category.ID {
+			return fmt.Errorf("cannot delete a category that has subcategories; merge it instead")
+		}
+	}
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{
+		CategoryIDs: []primitive.ObjectID{category.ID},
+		Limit:       1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(products) > 0 {
+		return fmt.Errorf("cannot delete a category that has products; merge it instead")
+	}
+
+	return uc.categoryRepo.Delete(id)
+}
+
+func (uc *categoryUseCase) MigrateCategories(businessID, userID string) (*Domain.CategoryMigrationResult, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := uc.categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Domain.CategoryMigrationResult{}
+	for i := range products {
+		product := &products[i]
+		if product.CategoryID != nil || strings.TrimSpace(product.Category) == "" {
+			continue
+		}
+
+		category, err := resolveCategory(uc.categoryRepo, businessID, userID, "", product.Category)
+		if err != nil {
+			return nil, err
+		}
+		product.CategoryID = &category.ID
+		product.Category = category.Name
+
+		// A bundle's stock is derived on read and never stored
+		if product.IsBundle() {
+			product.Stock = 0
+		}
+		if err := uc.inventoryRepo.Update(product); err != nil {
+			return nil, fmt.Errorf("failed to update product: %w", err)
+		}
+		result.ProductsUpdated++
+	}
+
+	after, err := uc.categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+	result.CategoriesCreated = len(after) - len(before)
+
+	return result, nil
+}
+
+func (uc *categoryUseCase) getCategory(id, businessID string) (*Domain.Category, error) {
+	category, err := uc.categoryRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, fmt.Errorf("category not found")
+	}
+	if category.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: category does not belong to this business")
+	}
+
+	return category, nil
+}
+
+func (uc *categoryUseCase) checkNameFree(businessID, name string, except *primitive.ObjectID) error {
+	existing, err := uc.categoryRepo.FindByName(businessID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && (except == nil || existing.ID != *except) {
+		return fmt.Errorf("category %s already exists", existing.Name)
+	}
+	return nil
+}
+
+// reparent moves a category under a new parent, or to the top level when
+// parent is nil, and rewrites the ancestors of everything below it.
+func (uc *categoryUseCase) reparent(categories []Domain.Category, category *Domain.Category, parent *Domain.Category) error {
+	oldPrefix := append(append([]primitive.ObjectID{}, category.Ancestors...), category.ID)
+
+	var parentID *primitive.ObjectID
+	ancestors := []primitive.ObjectID{}
+	if parent != nil {
+		parentID = &parent.ID
+		ancestors = append(append(ancestors, parent.Ancestors...), parent.ID)
+	}
+
+	if !sameParent(category.ParentID, parentID) {
+		category.SortOrder = nextSortOrder(categories, parentID)
+	}
+	category.ParentID = parentID
+	category.Ancestors = ancestors
+	if err := uc.categoryRepo.Update(category); err != nil {
+		return err
+	}
+
+	newPrefix := append(append([]primitive.ObjectID{}, ancestors...), category.ID)
+	for i := range categories {
+		descendant := &categories[i]
+		if !descendant.HasAncestor(category.ID) {
+			continue
+		}
+		descendant.Ancestors = append(append([]primitive.ObjectID{}, newPrefix...), descendant.Ancestors[len(oldPrefix):]...)
+		if err := uc.categoryRepo.Update(descendant); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveCategory finds the category a product is filed under, by ID or else
+// by name regardless of case. A name that matches no category creates a new
+// top-level one. It returns nil when neither is given.
+func resolveCategory(categoryRepo Domain.CategoryRepository, businessID, userID, categoryID, name string) (*Domain.Category, error) {
+	if categoryID != "" {
+		category, err := categoryRepo.FindByID(categoryID)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil || category.BusinessID.Hex() != businessID {
+			return nil, fmt.Errorf("category not found")
+		}
+		return category, nil
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil
+	}
+
+	category, err := categoryRepo.FindByName(businessID, name)
+	if err != nil {
+		return nil, err
+	}
+	if category != nil {
+		return category, nil
+	}
+
+	objBusinessID, err := Domain.PrimitiveObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	categories, err := categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	category = &Domain.Category{
+		BusinessID: objBusinessID,
+		Name:       name,
+		Ancestors:  []primitive.ObjectID{},
+		SortOrder:  nextSortOrder(categories, nil),
+		CreatedBy:  objUserID,
+	}
+	if err := categoryRepo.Create(category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// expandCategoryFilter turns the category of a product filter, given by ID or
+// name, into the IDs of that category and everything below it. A name that
+// matches no category is left as it is to match products not yet migrated.
+func expandCategoryFilter(categoryRepo Domain.CategoryRepository, businessID string, filters *Domain.ProductFilters) error {
+	if filters.Category == nil || *filters.Category == "" {
+		return nil
+	}
+
+	var category *Domain.Category
+	if _, err := primitive.ObjectIDFromHex(*filters.Category); err == nil {
+		found, err := categoryRepo.FindByID(*filters.Category)
+		if err != nil {
+			return err
+		}
+		if found != nil && found.BusinessID.Hex() == businessID {
+			category = found
+		}
+	}
+	if category == nil {
+		found, err := categoryRepo.FindByName(businessID, *filters.Category)
+		if err != nil {
+			return err
+		}
+		category = found
+	}
+	if category == nil {
+		return nil
+	}
+
+	categories, err := categoryRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return err
+	}
+
+	filters.CategoryIDs = []primitive.ObjectID{category.ID}
+	for _, other := range categories {
+		if other.HasAncestor(category.ID) {
+			filters.CategoryIDs = append(filters.CategoryIDs, other.ID)
+		}
+	}
+
+	return nil
+}
+
+// buildCategoryTree nests the categories below parentID, or the whole tree
+// when parentID is nil, in sort order.
+func buildCategoryTree(categories []Domain.Category, parentID *primitive.ObjectID) []Domain.Category {
+	var level []Domain.Category
+	for _, category := range Domain.OrderCategoryTree(categories) {
+		if !sameParent(category.ParentID, parentID) {
+			continue
+		}
+		category.Children = buildCategoryTree(categories, &category.ID)
+		level = append(level, category)
+	}
+	return level
+}
+
+func nextSortOrder(categories []Domain.Category, parentID *primitive.ObjectID) int {
+	next := 0
+	for _, category := range categories {
+		if sameParent(category.ParentID, parentID) && category.SortOrder >= next {
+			next = category.SortOrder + 1
+		}
+	}
+	return next
+}
+
+func sameParent(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
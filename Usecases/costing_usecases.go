@@ -0,0 +1,821 @@
+package Usecases
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	Domain "ShopOps/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CostingUseCase interface {
+	AdjustStock(productID string, quantity float64, movementType Domain.MovementType, reason string, referenceID *string, referenceType string, userID string, unitCost float64, locationID *string) (*Domain.StockMovement, error)
+	RecordOpeningStock(product *Domain.Product, locationID *string) error
+	GetCostLayers(productID, businessID string) ([]Domain.CostLayer, error)
+	RebuildProductCosts(productID, businessID string) (*Domain.CostRebuildResult, error)
+	RebuildBusinessCosts(businessID string) (*Domain.CostRebuildResult, error)
+	// GetStockValuation values the stock held at a point in time by replaying
+	// the stock movements made up to it with the business costing method.
+	GetStockValuation(businessID string, asOf time.Time) (*Domain.StockValuation, error)
+	// CheckLedgerIntegrity flags products whose stock disagrees with their
+	// stock movement history.
+	CheckLedgerIntegrity(businessID string) (*Domain.LedgerIntegrityReport, error)
+}
+
+type costingUseCase struct {
+	inventoryRepo     Domain.ProductRepository
+	costLayerRepo     Domain.CostLayerRepository
+	salesRepo         Domain.SaleRepository
+	businessRepo      Domain.BusinessRepository
+	locationRepo      Domain.LocationRepository
+	stockLevelRepo    Domain.StockLevelRepository
+	negativeStockRepo Domain.NegativeStockRepository
+	dailyStatsRepo    Domain.DailyStatsRepository
+}
+
+func NewCostingUseCase(
+	inventoryRepo Domain.ProductRepository,
+	costLayerRepo Domain.CostLayerRepository,
+	salesRepo Domain.SaleRepository,
+	businessRepo Domain.BusinessRepository,
+	locationRepo Domain.LocationRepository,
+	stockLevelRepo Domain.StockLevelRepository,
+	negativeStockRepo Domain.NegativeStockRepository,
+	dailyStatsRepo Domain.DailyStatsRepository,
+) CostingUseCase {
+	return &costingUseCase{
+		inventoryRepo:     inventoryRepo,
+		costLayerRepo:     costLayerRepo,
+		salesRepo:         salesRepo,
+		businessRepo:      businessRepo,
+		locationRepo:      locationRepo,
+		stockLevelRepo:    stockLevelRepo,
+		negativeStockRepo: negativeStockRepo,
+		dailyStatsRepo:    dailyStatsRepo,
+	}
+}
+
+// AdjustStock moves stock through the inventory repository and prices the
+// resulting movement. Stock coming in opens a cost layer and updates the
+// moving average; stock going out is costed with the business costing method.
+// A unitCost of 0 falls back to the product's cost. When the business has
+// stock locations, the movement is also applied to the stock level of the
+// given location, or of the default location when none is given. Sales may
+// take stock below zero when the negative stock policy of the product allows
+// it; the shortfall is recorded until later receipts cover it.
+func (uc *costingUseCase) AdjustStock(productID string, quantity float64, movementType Domain.MovementType, reason string, referenceID *string, referenceType string, userID string, unitCost float64, locationID *string) (*Domain.StockMovement, error) {
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	if product.IsBundle() {
+		return nil, fmt.Errorf("%s is a bundle and holds no stock of its own; adjust its components instead", product.Name)
+	}
+
+	businessID := product.BusinessID.Hex()
+	location, err := resolveLocation(uc.locationRepo, businessID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy Domain.NegativeStockPolicy
+	if movementType == Domain.MovementTypeSale {
+		business, err := uc.businessRepo.FindByID(businessID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find business: %w", err)
+		}
+		policy = Domain.EffectiveNegativeStockPolicy(business, product)
+	}
+	allowNegative := policy == Domain.NegativeStockWarn || policy == Domain.NegativeStockAllow
+
+	// Take stock out of the location first so a shortfall there stops the
+	// movement before the product total changes
+	var movementLocationID *string
+	delta := stockDelta(movementType, quantity)
+	if location != nil {
+		id := location.ID.Hex()
+		movementLocationID = &id
+
+		adjust := uc.stockLevelRepo.Adjust
+		if allowNegative {
+			adjust = uc.stockLevelRepo.ForceAdjust
+		}
+		if _, err := adjust(businessID, productID, id, delta); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, location.Name)
+		}
+	}
+
+	movement, err := uc.inventoryRepo.AdjustStock(productID, quantity, movementType, reason, referenceID, referenceType, userID, movementLocationID, allowNegative)
+	if err != nil {
+		if location != nil {
+			uc.stockLevelRepo.ForceAdjust(businessID, productID, *movementLocationID, -delta)
+		}
+		return nil, err
+	}
+
+	uc.trackNegativeStock(product, movement, policy)
+
+	var state *costState
+	if movement.New >= movement.Previous {
+		layers, err := uc.costLayerRepo.FindOpen(productID)
+		if err != nil {
+			return movement, fmt.Errorf("failed to load cost layers: %w", err)
+		}
+
+		state = newCostState(product, layers)
+		layer := state.receive(movement, unitCost)
+		if err := uc.costLayerRepo.Create(&layer); err != nil {
+			return movement, err
+		}
+	} else {
+		state, err = uc.issueStock(product, movement, uc.getCostingMethod(businessID))
+		if err != nil {
+			return movement, err
+		}
+	}
+
+	if err := uc.inventoryRepo.SetMovementCost(movement.ID.Hex(), movement.UnitCost, movement.TotalCost); err != nil {
+		return movement, err
+	}
+
+	if state.average != product.AverageCost {
+		if err := uc.inventoryRepo.UpdateAverageCost(productID, state.average); err != nil {
+			return movement, err
+		}
+	}
+
+	return movement, nil
+}
+
+// maxIssueAttempts bounds how often issueStock works out an issue again
+// after other movements took the layers it had picked.
+const maxIssueAttempts = 5
+
+// issueStock takes an outgoing movement out of the product's open cost layers
+// and prices it. Each layer is taken with a conditional decrement, so two
+// sales can't both take the same stock; when another movement got to a layer
+// first, what was taken is put back and the issue is worked out again from
+// the layers as they now stand.
+func (uc *costingUseCase) issueStock(product *Domain.Product, movement *Domain.StockMovement, method Domain.CostingMethod) (*costState, error) {
+	productID := product.ID.Hex()
+
+	for attempt := 0; attempt < maxIssueAttempts; attempt++ {
+		layers, err := uc.costLayerRepo.FindOpen(productID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cost layers: %w", err)
+		}
+
+		held := make([]float64, len(layers))
+		for i := range layers {
+			held[i] = layers[i].Remaining
+		}
+
+		state := newCostState(product, layers)
+		changed := state.issue(movement, method)
+
+		var taken []int
+		missed := false
+		for _, i := range changed {
+			ok, err := uc.costLayerRepo.Consume(layers[i].ID.Hex(), held[i]-layers[i].Remaining)
+			if err == nil && ok {
+				taken = append(taken, i)
+				continue
+			}
+
+			for _, j := range taken {
+				if _, undoErr := uc.costLayerRepo.Consume(layers[j].ID.Hex(), layers[j].Remaining-held[j]); undoErr != nil {
+					fmt.Printf("Failed to put back cost layer %s: %v\n", layers[j].ID.Hex(), undoErr)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			missed = true
+			break
+		}
+
+		if !missed {
+			return state, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to cost stock of %s: its cost layers kept changing", product.Name)
+}
+
+// trackNegativeStock records a movement that took a product below zero, and
+// matches stock coming in while the product is below zero against its open
+// shortfalls, oldest first.
+func (uc *costingUseCase) trackNegativeStock(product *Domain.Product, movement *Domain.StockMovement, policy Domain.NegativeStockPolicy) {
+	switch {
+	case movement.New < movement.Previous && movement.New < 0:
+		event := &Domain.NegativeStockEvent{
+			BusinessID:    movement.BusinessID,
+			ProductID:     movement.ProductID,
+			ProductName:   product.Name,
+			LocationID:    movement.LocationID,
+			MovementID:    movement.ID,
+			ReferenceID:   movement.ReferenceID,
+			ReferenceType: movement.ReferenceType,
+			Policy:        policy,
+			Shortfall:     math.Min(movement.Previous-movement.New, -movement.New),
+			CreatedBy:     movement.CreatedBy,
+			CreatedAt:     movement.CreatedAt,
+		}
+		if err := uc.negativeStockRepo.Create(event); err != nil {
+			fmt.Printf("Failed to record negative stock of %s: %v\n", product.Name, err)
+		}
+
+	case movement.New > movement.Previous && movement.Previous < 0:
+		available := math.Min(movement.New, 0) - movement.Previous
+
+		events, err := uc.negativeStockRepo.FindOpenByProductID(movement.ProductID.Hex())
+		if err != nil {
+			fmt.Printf("Failed to load negative stock of %s: %v\n", product.Name, err)
+			return
+		}
+
+		for i := range events {
+			if available <= ledgerTolerance {
+				break
+			}
+
+			outstanding := events[i].Outstanding()
+			take := math.Min(outstanding, available)
+			cover := Domain.NegativeStockCover{
+				MovementID:    movement.ID,
+				Type:          movement.Type,
+				ReferenceID:   movement.ReferenceID,
+				ReferenceType: movement.ReferenceType,
+				Reason:        movement.Reason,
+				Quantity:      take,
+				At:            movement.CreatedAt,
+			}
+			if err := uc.negativeStockRepo.AddCover(events[i].ID.Hex(), cover, outstanding-take <= ledgerTolerance); err != nil {
+				fmt.Printf("Failed to record negative stock cover of %s: %v\n", product.Name, err)
+			}
+			available -= take
+		}
+	}
+}
+
+// RecordOpeningStock opens the first cost layer for stock entered when the
+// product was created and places it at its location.
+func (uc *costingUseCase) RecordOpeningStock(product *Domain.Product, locationID *string) error {
+	if product.Stock <= 0 {
+		return nil
+	}
+
+	businessID := product.BusinessID.Hex()
+	location, err := resolveLocation(uc.locationRepo, businessID, locationID)
+	if err != nil {
+		return err
+	}
+	if location != nil {
+		if _, err := uc.stockLevelRepo.Adjust(businessID, product.ID.Hex(), location.ID.Hex(), product.Stock); err != nil {
+			return err
+		}
+	}
+
+	layer := &Domain.CostLayer{
+		BusinessID: product.BusinessID,
+		ProductID:  product.ID,
+		ReceivedAt: product.CreatedAt,
+		Quantity:   product.Stock,
+		Remaining:  product.Stock,
+		UnitCost:   product.CostPrice,
+	}
+
+	return uc.costLayerRepo.Create(layer)
+}
+
+func (uc *costingUseCase) GetCostLayers(productID, businessID string) ([]Domain.CostLayer, error) {
+	if _, err := uc.getProduct(productID, businessID); err != nil {
+		return nil, err
+	}
+
+	return uc.costLayerRepo.FindOpen(productID)
+}
+
+// RebuildProductCosts replays the product's stock movement history from the
+// start, recreating its cost layers and average cost and re-pricing every
+// movement and the cost of goods of the sales they belong to.
+func (uc *costingUseCase) RebuildProductCosts(productID, businessID string) (*Domain.CostRebuildResult, error) {
+	product, err := uc.getProduct(productID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Domain.CostRebuildResult{}
+	if err := uc.rebuildProduct(product, uc.getCostingMethod(businessID), result); err != nil {
+		return nil, err
+	}
+
+	uc.rebuildDailyStats(businessID, result)
+
+	return result, nil
+}
+
+func (uc *costingUseCase) RebuildBusinessCosts(businessID string) (*Domain.CostRebuildResult, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	method := costingMethodOf(business)
+	result := &Domain.CostRebuildResult{}
+
+	for i := range products {
+		if products[i].IsBundle() {
+			continue
+		}
+		if err := uc.rebuildProduct(&products[i], method, result); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", products[i].Name, err))
+		}
+	}
+
+	uc.rebuildDailyStats(businessID, result)
+
+	return result, nil
+}
+
+// rebuildDailyStats brings the business's daily rollups in line with the
+// cost of goods a rebuild re-priced, which may span its whole history.
+func (uc *costingUseCase) rebuildDailyStats(businessID string, result *Domain.CostRebuildResult) {
+	if result.SalesUpdated == 0 {
+		return
+	}
+
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err == nil {
+		_, err = uc.dailyStatsRepo.Rebuild(businessID, loc)
+	}
+	if err != nil {
+		fmt.Printf("Failed to rebuild daily stats after cost rebuild: %v\n", err)
+	}
+}
+
+func (uc *costingUseCase) rebuildProduct(product *Domain.Product, method Domain.CostingMethod, result *Domain.CostRebuildResult) error {
+	productID := product.ID.Hex()
+
+	history, err := uc.inventoryRepo.GetStockHistory(productID, 0)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.costLayerRepo.DeleteByProductID(productID); err != nil {
+		return err
+	}
+
+	// History comes newest first
+	state := &costState{costPrice: product.CostPrice}
+	saleCosts := make(map[string]float64)
+
+	for i := len(history) - 1; i >= 0; i-- {
+		movement := &history[i]
+
+		if movement.New >= movement.Previous {
+			state.receive(movement, movement.UnitCost)
+		} else {
+			state.issue(movement, method)
+		}
+
+		if err := uc.inventoryRepo.SetMovementCost(movement.ID.Hex(), movement.UnitCost, movement.TotalCost); err != nil {
+			return err
+		}
+
+		if movement.ReferenceID != nil && movement.ReferenceType == "sale" {
+			saleID := movement.ReferenceID.Hex()
+			switch movement.Type {
+			case Domain.MovementTypeSale:
+				saleCosts[saleID] += movement.TotalCost
+			case Domain.MovementTypeReturn:
+				saleCosts[saleID] -= movement.TotalCost
+			}
+		}
+
+		result.MovementsProcessed++
+	}
+
+	for i := range state.layers {
+		if state.layers[i].Remaining <= 0 {
+			continue
+		}
+		if err := uc.costLayerRepo.Create(&state.layers[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.inventoryRepo.UpdateAverageCost(productID, state.average); err != nil {
+		return err
+	}
+
+	for saleID, cost := range saleCosts {
+		sale, err := uc.salesRepo.FindByID(saleID)
+		if err != nil || sale == nil {
+			continue
+		}
+
+		// Voided sales have had their goods returned, keep their last cost
+		if sale.Status != Domain.SaleStatusCompleted {
+			continue
+		}
+
+		// A bundle sale is costed from all its components, so only this
+		// product's line changes
+		if len(sale.Components) > 0 {
+			var total float64
+			for j := range sale.Components {
+				if sale.Components[j].ProductID == product.ID {
+					sale.Components[j].CostOfGoods = math.Max(cost, 0)
+				}
+				total += sale.Components[j].CostOfGoods
+			}
+			if err := uc.salesRepo.SetComponents(saleID, sale.Components, total); err != nil {
+				return err
+			}
+			result.SalesUpdated++
+			continue
+		}
+
+		if err := uc.salesRepo.SetCostOfGoods(saleID, math.Max(cost, 0)); err != nil {
+			return err
+		}
+		result.SalesUpdated++
+	}
+
+	result.ProductsProcessed++
+	return nil
+}
+
+func (uc *costingUseCase) GetStockValuation(businessID string, asOf time.Time) (*Domain.StockValuation, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+	if asOf.After(time.Now()) {
+		return nil, fmt.Errorf("valuation date cannot be in the future")
+	}
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := uc.inventoryRepo.GetMovementsUntil(businessID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	byProduct := make(map[primitive.ObjectID][]Domain.StockMovement)
+	for _, movement := range movements {
+		byProduct[movement.ProductID] = append(byProduct[movement.ProductID], movement)
+	}
+
+	method := costingMethodOf(business)
+	valuation := &Domain.StockValuation{
+		AsOf:          asOf,
+		CostingMethod: method,
+		Items:         []Domain.StockValuationItem{},
+	}
+
+	for i := range products {
+		product := &products[i]
+		history := byProduct[product.ID]
+		if product.IsBundle() || len(history) == 0 {
+			continue
+		}
+
+		// Replay from a blank state so no cost from after the date leaks in
+		state := &costState{costPrice: product.CostPrice}
+		for j := range history {
+			movement := history[j]
+			if movement.New >= movement.Previous {
+				state.receive(&movement, movement.UnitCost)
+			} else {
+				state.issue(&movement, method)
+			}
+		}
+
+		last := history[len(history)-1]
+		quantity := last.New
+		value := state.value(quantity, method)
+
+		item := Domain.StockValuationItem{
+			ProductID:      product.ID,
+			ProductName:    product.Name,
+			SKU:            product.SKU,
+			Category:       product.Category,
+			Quantity:       quantity,
+			Value:          value,
+			LastMovementAt: &last.CreatedAt,
+		}
+		if quantity > 0 {
+			item.UnitCost = value / quantity
+		}
+
+		valuation.Items = append(valuation.Items, item)
+		valuation.TotalProducts++
+		valuation.TotalQuantity += quantity
+		valuation.TotalValue += value
+	}
+
+	return valuation, nil
+}
+
+func (uc *costingUseCase) CheckLedgerIntegrity(businessID string) (*Domain.LedgerIntegrityReport, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	now := time.Now()
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := uc.inventoryRepo.GetMovementsUntil(businessID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	byProduct := make(map[primitive.ObjectID][]Domain.StockMovement)
+	for _, movement := range movements {
+		byProduct[movement.ProductID] = append(byProduct[movement.ProductID], movement)
+	}
+
+	report := &Domain.LedgerIntegrityReport{
+		CheckedAt:        now,
+		MovementsChecked: len(movements),
+		Discrepancies:    []Domain.LedgerDiscrepancy{},
+	}
+
+	for _, product := range products {
+		// A bundle holds no stock of its own
+		if product.IsBundle() {
+			continue
+		}
+		report.ProductsChecked++
+
+		if discrepancy := checkProductLedger(product, byProduct[product.ID]); discrepancy != nil {
+			report.Discrepancies = append(report.Discrepancies, *discrepancy)
+		}
+	}
+
+	return report, nil
+}
+
+// ledgerTolerance absorbs floating point noise when comparing quantities.
+const ledgerTolerance = 1e-6
+
+// checkProductLedger walks a product's movements, oldest first, and returns
+// what is wrong with them, or nil when the ledger is sound.
+func checkProductLedger(product Domain.Product, history []Domain.StockMovement) *Domain.LedgerDiscrepancy {
+	discrepancy := &Domain.LedgerDiscrepancy{
+		ProductID:    product.ID,
+		ProductName:  product.Name,
+		CurrentStock: product.Stock,
+	}
+
+	broken := func(movement Domain.StockMovement, problem string) {
+		discrepancy.BrokenLinks++
+		if discrepancy.FirstBreakAt == nil {
+			at := movement.CreatedAt
+			discrepancy.FirstBreakAt = &at
+		}
+		discrepancy.Problems = append(discrepancy.Problems, problem)
+	}
+
+	for i, movement := range history {
+		if i > 0 && math.Abs(movement.Previous-history[i-1].New) > ledgerTolerance {
+			broken(movement, fmt.Sprintf("%s movement on %s starts at %.2f but the one before ended at %.2f",
+				movement.Type, movement.CreatedAt.Format(time.RFC3339), movement.Previous, history[i-1].New))
+		}
+		if math.Abs(movement.New-movement.Previous-signedQuantity(movement)) > ledgerTolerance {
+			broken(movement, fmt.Sprintf("%s movement on %s of %.2f moves stock from %.2f to %.2f",
+				movement.Type, movement.CreatedAt.Format(time.RFC3339), movement.Quantity, movement.Previous, movement.New))
+		}
+	}
+
+	if len(history) > 0 {
+		discrepancy.LedgerStock = history[len(history)-1].New
+	}
+	discrepancy.Difference = product.Stock - discrepancy.LedgerStock
+
+	if math.Abs(discrepancy.Difference) > ledgerTolerance {
+		if len(history) == 0 {
+			discrepancy.Problems = append(discrepancy.Problems, "product holds stock but has no movement history")
+		} else {
+			discrepancy.Problems = append(discrepancy.Problems, fmt.Sprintf("current stock %.2f differs from the ledger's %.2f",
+				product.Stock, discrepancy.LedgerStock))
+		}
+	}
+
+	if len(discrepancy.Problems) == 0 {
+		return nil
+	}
+	return discrepancy
+}
+
+// signedQuantity is the change a movement makes to stock: purchases, returns
+// and adjustments add their quantity, everything else takes it away.
+func signedQuantity(movement Domain.StockMovement) float64 {
+	switch movement.Type {
+	case Domain.MovementTypePurchase, Domain.MovementTypeReturn, Domain.MovementTypeAdjust:
+		return movement.Quantity
+	default:
+		return -movement.Quantity
+	}
+}
+
+func (uc *costingUseCase) getProduct(productID, businessID string) (*Domain.Product, error) {
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	if product.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: product does not belong to this business")
+	}
+
+	return product, nil
+}
+
+func (uc *costingUseCase) getCostingMethod(businessID string) Domain.CostingMethod {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil || business == nil {
+		return Domain.CostingMethodFIFO
+	}
+
+	return costingMethodOf(business)
+}
+
+func costingMethodOf(business *Domain.Business) Domain.CostingMethod {
+	if business.CostingMethod == Domain.CostingMethodAverage {
+		return Domain.CostingMethodAverage
+	}
+
+	return Domain.CostingMethodFIFO
+}
+
+// costState holds the open cost layers and moving average of one product
+// while movements are applied to it.
+type costState struct {
+	layers    []Domain.CostLayer
+	average   float64
+	costPrice float64
+}
+
+func newCostState(product *Domain.Product, layers []Domain.CostLayer) *costState {
+	average := product.AverageCost
+	if average <= 0 {
+		average = product.CostPrice
+	}
+
+	return &costState{
+		layers:    layers,
+		average:   average,
+		costPrice: product.CostPrice,
+	}
+}
+
+// receive adds incoming stock as a new layer and returns it. The movement is
+// priced at unitCost, or at the product cost when none is given.
+func (s *costState) receive(movement *Domain.StockMovement, unitCost float64) Domain.CostLayer {
+	quantity := movement.New - movement.Previous
+
+	if unitCost <= 0 {
+		if movement.Type != Domain.MovementTypePurchase && s.average > 0 {
+			unitCost = s.average
+		} else {
+			unitCost = s.costPrice
+		}
+	}
+
+	// Stock below zero carries no cost, so only count what is actually held
+	held := math.Max(movement.Previous, 0)
+	if held+quantity > 0 {
+		s.average = (held*s.average + quantity*unitCost) / (held + quantity)
+	}
+	movementID := movement.ID
+	layer := Domain.CostLayer{
+		BusinessID: movement.BusinessID,
+		ProductID:  movement.ProductID,
+		MovementID: &movementID,
+		ReceivedAt: movement.CreatedAt,
+		Quantity:   quantity,
+		Remaining:  math.Max(movement.New, 0) - held, // Stock sold below zero is already gone
+		UnitCost:   unitCost,
+	}
+	s.layers = append(s.layers, layer)
+
+	movement.UnitCost = unitCost
+	movement.TotalCost = quantity * unitCost
+
+	return layer
+}
+
+// value prices a quantity of stock from the state: with the open layers for
+// FIFO, falling back to the average for stock they do not cover, or wholly at
+// the moving average.
+func (s *costState) value(quantity float64, method Domain.CostingMethod) float64 {
+	if quantity <= 0 {
+		return 0
+	}
+
+	fallback := s.average
+	if fallback <= 0 {
+		fallback = s.costPrice
+	}
+	if method == Domain.CostingMethodAverage {
+		return quantity * fallback
+	}
+
+	var value, covered float64
+	for _, layer := range s.layers {
+		if layer.Remaining <= 0 {
+			continue
+		}
+		take := math.Min(layer.Remaining, quantity-covered)
+		value += take * layer.UnitCost
+		covered += take
+		if covered >= quantity {
+			break
+		}
+	}
+	if covered < quantity {
+		value += (quantity - covered) * fallback
+	}
+
+	return value
+}
+
+// issue takes outgoing stock out of the oldest layers and prices the movement
+// with the given method. It returns the indexes of the layers it changed.
+func (s *costState) issue(movement *Domain.StockMovement, method Domain.CostingMethod) []int {
+	quantity := movement.Previous - movement.New
+
+	var changed []int
+	var fifoCost float64
+	needed := quantity
+
+	for i := range s.layers {
+		if needed <= 0 {
+			break
+		}
+		if s.layers[i].Remaining <= 0 {
+			continue
+		}
+
+		take := math.Min(s.layers[i].Remaining, needed)
+		s.layers[i].Remaining -= take
+		fifoCost += take * s.layers[i].UnitCost
+		needed -= take
+		changed = append(changed, i)
+	}
+
+	// Stock with no layer behind it (e.g. recorded before costing existed)
+	// is priced at the current average
+	fallback := s.average
+	if fallback <= 0 {
+		fallback = s.costPrice
+	}
+	if needed > 0 {
+		fifoCost += needed * fallback
+	}
+
+	total := fifoCost
+	if method == Domain.CostingMethodAverage {
+		total = quantity * fallback
+	}
+
+	movement.TotalCost = total
+	if quantity > 0 {
+		movement.UnitCost = total / quantity
+	}
+
+	return changed
+}
@@ -0,0 +1,165 @@
+package Usecases
+
+import (
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+)
+
+// DailyStatsUseCase rebuilds the daily rollups that dashboards and summaries
+// read, for backfills and after changes that move every day's boundaries.
+type DailyStatsUseCase interface {
+	RebuildBusiness(businessID string) (int, error)
+	// RebuildAll rebuilds every business's rollups and returns the number of
+	// businesses rebuilt. A business that fails is logged and skipped.
+	RebuildAll() (int, error)
+	// BackfillMissing rebuilds the rollups of every business whose rollups do
+	// not reach back to its first sale or expense, such as one that traded
+	// before rollups were kept.
+	BackfillMissing() error
+}
+
+type dailyStatsUseCase struct {
+	dailyStatsRepo Domain.DailyStatsRepository
+	businessRepo   Domain.BusinessRepository
+}
+
+func NewDailyStatsUseCase(
+	dailyStatsRepo Domain.DailyStatsRepository,
+	businessRepo Domain.BusinessRepository,
+) DailyStatsUseCase {
+	return &dailyStatsUseCase{
+		dailyStatsRepo: dailyStatsRepo,
+		businessRepo:   businessRepo,
+	}
+}
+
+func (uc *dailyStatsUseCase) RebuildBusiness(businessID string) (int, error) {
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return 0, err
+	}
+
+	return uc.dailyStatsRepo.Rebuild(businessID, loc)
+}
+
+func (uc *dailyStatsUseCase) RebuildAll() (int, error) {
+	businesses, err := uc.businessRepo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	rebuilt := 0
+	for _, business := range businesses {
+		if _, err := uc.dailyStatsRepo.Rebuild(business.ID.Hex(), business.Location()); err != nil {
+			fmt.Printf("Failed to rebuild daily stats for business %s: %v\n", business.ID.Hex(), err)
+			continue
+		}
+		rebuilt++
+	}
+
+	return rebuilt, nil
+}
+
+func (uc *dailyStatsUseCase) BackfillMissing() error {
+	businesses, err := uc.businessRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for _, business := range businesses {
+		businessID := business.ID.Hex()
+		covered, err := uc.dailyStatsRepo.CoversHistory(businessID)
+		if err != nil {
+			fmt.Printf("Failed to check daily stats for business %s: %v\n", businessID, err)
+			continue
+		}
+		if covered {
+			continue
+		}
+
+		days, err := uc.dailyStatsRepo.Rebuild(businessID, business.Location())
+		if err != nil {
+			fmt.Printf("Failed to rebuild daily stats for business %s: %v\n", businessID, err)
+			continue
+		}
+		fmt.Printf("Rebuilt %d days of stats for business %s\n", days, businessID)
+	}
+
+	return nil
+}
+
+// refreshDailyStats recomputes the rollups of the business's days that the
+// given times fall on, in its timezone. Failures are logged rather than
+// returned: the write that touched the day has already been saved, and a
+// backfill repairs the rollup.
+func refreshDailyStats(dailyStatsRepo Domain.DailyStatsRepository, businessRepo Domain.BusinessRepository, businessID string, times ...time.Time) {
+	loc, err := businessLocation(businessRepo, businessID)
+	if err != nil {
+		fmt.Printf("Failed to refresh daily stats: %v\n", err)
+		return
+	}
+
+	refreshed := make(map[string]bool)
+	for _, t := range times {
+		if t.IsZero() {
+			continue
+		}
+		day := Domain.StartOfDay(t.In(loc))
+		date := day.Format("2006-01-02")
+		if refreshed[date] {
+			continue
+		}
+		refreshed[date] = true
+
+		if err := dailyStatsRepo.Refresh(businessID, day); err != nil {
+			fmt.Printf("Failed to refresh daily stats for %s: %v\n", date, err)
+		}
+	}
+}
+
+// sumDailyStats totals the rollups of the calendar days from startDate's to
+// endDate's, both read in their own location.
+func sumDailyStats(dailyStatsRepo Domain.DailyStatsRepository, businessID string, startDate, endDate time.Time) (Domain.DailyBusinessStats, error) {
+	days, err := dailyStatsRepo.FindRange(businessID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if err != nil {
+		return Domain.DailyBusinessStats{}, err
+	}
+
+	return Domain.SumDailyStats(days), nil
+}
+
+// profitFromDailyStats builds a profit report from the rollups of its days,
+// with the same figures as ReportRepository.GenerateProfitReport.
+func profitFromDailyStats(total Domain.DailyBusinessStats, startDate, endDate time.Time) *Domain.ProfitReport {
+	// Stock purchases become cost of goods sold as the stock is sold, so
+	// only the remaining expenses are deducted from gross profit
+	stockPurchases := total.StockPurchases()
+	operatingExpenses := total.Expenses - stockPurchases
+
+	revenue := total.Revenue
+	grossProfit := revenue - total.CostOfGoods
+	netProfit := grossProfit - operatingExpenses
+
+	grossMargin := 0.0
+	profitMargin := 0.0
+	if revenue > 0 {
+		grossMargin = (grossProfit / revenue) * 100
+		profitMargin = (netProfit / revenue) * 100
+	}
+
+	return &Domain.ProfitReport{
+		Period:            fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")),
+		TotalSales:        revenue,
+		TotalExpenses:     total.Expenses,
+		Revenue:           revenue,
+		CostOfGoodsSold:   total.CostOfGoods,
+		GrossProfit:       grossProfit,
+		GrossMargin:       grossMargin,
+		OperatingExpenses: operatingExpenses,
+		StockPurchases:    stockPurchases,
+		NetProfit:         netProfit,
+		ProfitMargin:      profitMargin,
+	}
+}
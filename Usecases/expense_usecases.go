@@ -19,17 +19,20 @@ type ExpenseUseCase interface {
 }
 
 type expenseUseCase struct {
-	expenseRepo  Domain.ExpenseRepository
-	businessRepo Domain.BusinessRepository
+	expenseRepo    Domain.ExpenseRepository
+	businessRepo   Domain.BusinessRepository
+	dailyStatsRepo Domain.DailyStatsRepository
 }
 
 func NewExpenseUseCase(
 	expenseRepo Domain.ExpenseRepository,
 	businessRepo Domain.BusinessRepository,
+	dailyStatsRepo Domain.DailyStatsRepository,
 ) ExpenseUseCase {
 	return &expenseUseCase{
-		expenseRepo:  expenseRepo,
-		businessRepo: businessRepo,
+		expenseRepo:    expenseRepo,
+		businessRepo:   businessRepo,
+		dailyStatsRepo: dailyStatsRepo,
 	}
 }
 
@@ -76,6 +79,8 @@ func (uc *expenseUseCase) CreateExpense(businessID, userID string, req Domain.Cr
 		return nil, fmt.Errorf("failed to create expense: %w", err)
 	}
 
+	refreshDailyStats(uc.dailyStatsRepo, uc.businessRepo, businessID, expense.Date)
+
 	return expense, nil
 }
 
@@ -116,6 +121,8 @@ func (uc *expenseUseCase) UpdateExpense(id, businessID, userID string, req Domai
 		return nil, fmt.Errorf("invalid expense category: %s", req.Category)
 	}
 
+	previousDate := expense.Date
+
 	// Update expense fields
 	if req.Category != "" {
 		expense.Category = req.Category
@@ -134,6 +141,8 @@ func (uc *expenseUseCase) UpdateExpense(id, businessID, userID string, req Domai
 		return nil, fmt.Errorf("failed to update expense: %w", err)
 	}
 
+	refreshDailyStats(uc.dailyStatsRepo, uc.businessRepo, businessID, previousDate, expense.Date)
+
 	return expense, nil
 }
 
@@ -149,29 +158,42 @@ func (uc *expenseUseCase) VoidExpense(id, businessID, userID string) error {
 	}
 
 	// Update expense status
-	return uc.expenseRepo.UpdateStatus(id, Domain.ExpenseStatusVoided)
+	if err := uc.expenseRepo.UpdateStatus(id, Domain.ExpenseStatusVoided); err != nil {
+		return err
+	}
+
+	refreshDailyStats(uc.dailyStatsRepo, uc.businessRepo, businessID, expense.Date)
+
+	return nil
 }
 
 func (uc *expenseUseCase) GetExpenseSummary(businessID string, period string) ([]Domain.ExpenseSummary, error) {
-	now := time.Now()
-	var startDate, endDate time.Time
-
-	switch period {
-	case "today":
-		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		endDate = startDate.Add(24 * time.Hour)
-	case "week":
-		startDate = now.AddDate(0, 0, -7)
-		endDate = now
-	case "month":
-		startDate = now.AddDate(0, 0, -30)
-		endDate = now
-	default:
-		startDate = now.AddDate(0, 0, -30) // Default to last 30 days
-		endDate = now
-	}
-
-	return uc.expenseRepo.GetSummaryByCategory(businessID, startDate, endDate)
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The summary covers the whole days of the period, read from the rollups
+	startDate, endDate := summaryRange(period, time.Now().In(loc))
+	total, err := sumDailyStats(uc.dailyStatsRepo, businessID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense summary: %w", err)
+	}
+
+	summaries := make([]Domain.ExpenseSummary, 0, len(total.ExpenseCategories))
+	for _, category := range total.ExpenseCategories {
+		summary := Domain.ExpenseSummary{
+			Category:    category.Category,
+			TotalAmount: category.Amount,
+			Count:       category.Count,
+		}
+		if total.Expenses > 0 {
+			summary.Percentage = (category.Amount / total.Expenses) * 100
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
 }
 
 func (uc *expenseUseCase) GetExpenseTotal(businessID string, startDate, endDate time.Time) (float64, error) {
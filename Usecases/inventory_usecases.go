@@ -18,17 +18,29 @@ type InventoryUseCase interface {
 }
 
 type inventoryUseCase struct {
-	inventoryRepo Domain.ProductRepository
-	businessRepo  Domain.BusinessRepository
+	inventoryRepo    Domain.ProductRepository
+	businessRepo     Domain.BusinessRepository
+	supplierRepo     Domain.SupplierRepository
+	categoryRepo     Domain.CategoryRepository
+	priceHistoryRepo Domain.PriceHistoryRepository
+	costingUC        CostingUseCase
 }
 
 func NewInventoryUseCase(
 	inventoryRepo Domain.ProductRepository,
 	businessRepo Domain.BusinessRepository,
+	supplierRepo Domain.SupplierRepository,
+	categoryRepo Domain.CategoryRepository,
+	priceHistoryRepo Domain.PriceHistoryRepository,
+	costingUC CostingUseCase,
 ) InventoryUseCase {
 	return &inventoryUseCase{
-		inventoryRepo: inventoryRepo,
-		businessRepo:  businessRepo,
+		inventoryRepo:    inventoryRepo,
+		businessRepo:     businessRepo,
+		supplierRepo:     supplierRepo,
+		categoryRepo:     categoryRepo,
+		priceHistoryRepo: priceHistoryRepo,
+		costingUC:        costingUC,
 	}
 }
 
@@ -42,6 +54,31 @@ func (uc *inventoryUseCase) CreateProduct(businessID, userID string, req Domain.
 		return nil, fmt.Errorf("business not found")
 	}
 
+	// A bundle's cost rolls up from its components
+	var components []Domain.BundleComponent
+	switch req.Type {
+	case "", Domain.ProductTypeStandard:
+		if len(req.Components) > 0 {
+			return nil, fmt.Errorf("only bundles have components")
+		}
+	case Domain.ProductTypeBundle:
+		if req.Stock != 0 {
+			return nil, fmt.Errorf("bundles hold no stock of their own; stock their components instead")
+		}
+		if req.TrackBatches {
+			return nil, fmt.Errorf("bundles cannot be batch-tracked")
+		}
+		if req.TrackSerials {
+			return nil, fmt.Errorf("bundles cannot be serial-tracked")
+		}
+		components, req.CostPrice, err = resolveBundleComponents(uc.inventoryRepo, businessID, nil, req.Components)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid product type: %s", req.Type)
+	}
+
 	// Validate selling price > cost price
 	if req.SellingPrice <= req.CostPrice {
 		return nil, fmt.Errorf("selling price must be greater than cost price")
@@ -52,6 +89,36 @@ func (uc *inventoryUseCase) CreateProduct(businessID, userID string, req Domain.
 		return nil, fmt.Errorf("minimum stock must be less than maximum stock")
 	}
 
+	if req.LeadTimeDays < 0 {
+		return nil, fmt.Errorf("lead time cannot be negative")
+	}
+
+	if req.WarrantyMonths < 0 {
+		return nil, fmt.Errorf("warranty cannot be negative")
+	}
+
+	if req.NegativeStock == Domain.NegativeStockInherit {
+		req.NegativeStock = ""
+	}
+	if req.NegativeStock != "" && !Domain.IsValidNegativeStockPolicy(req.NegativeStock) {
+		return nil, fmt.Errorf("invalid negative stock policy: %s", req.NegativeStock)
+	}
+
+	// Each serialised unit enters stock with its serial number
+	if req.TrackSerials && req.Stock != 0 {
+		return nil, fmt.Errorf("serial-tracked products start without stock; receive their serial numbers instead")
+	}
+
+	supplier, err := findSupplier(uc.supplierRepo, businessID, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := resolveCategory(uc.categoryRepo, businessID, userID, req.CategoryID, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
 	objBusinessID, err := Domain.PrimitiveObjectIDFromHex(businessID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid business ID: %w", err)
@@ -63,25 +130,50 @@ func (uc *inventoryUseCase) CreateProduct(businessID, userID string, req Domain.
 	}
 
 	product := &Domain.Product{
-		BusinessID:   objBusinessID,
-		Name:         req.Name,
-		Description:  req.Description,
-		SKU:          req.SKU,
-		Barcode:      req.Barcode,
-		Category:     req.Category,
-		Unit:         req.Unit,
-		CostPrice:    req.CostPrice,
-		SellingPrice: req.SellingPrice,
-		Stock:        req.Stock,
-		MinStock:     req.MinStock,
-		MaxStock:     req.MaxStock,
-		CreatedBy:    objUserID,
+		BusinessID:     objBusinessID,
+		Name:           req.Name,
+		Type:           req.Type,
+		Description:    req.Description,
+		SKU:            req.SKU,
+		Barcode:        req.Barcode,
+		Unit:           req.Unit,
+		CostPrice:      req.CostPrice,
+		SellingPrice:   req.SellingPrice,
+		Stock:          req.Stock,
+		MinStock:       req.MinStock,
+		MaxStock:       req.MaxStock,
+		TrackBatches:   req.TrackBatches,
+		TrackSerials:   req.TrackSerials,
+		WarrantyMonths: req.WarrantyMonths,
+		NegativeStock:  req.NegativeStock,
+		LeadTimeDays:   req.LeadTimeDays,
+		Components:     components,
+		CreatedBy:      objUserID,
+	}
+	if supplier != nil {
+		product.SupplierID = &supplier.ID
+	}
+	if category != nil {
+		product.CategoryID = &category.ID
+		product.Category = category.Name
 	}
 
 	if err := uc.inventoryRepo.Create(product); err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
 
+	if err := uc.costingUC.RecordOpeningStock(product, req.LocationID); err != nil {
+		fmt.Printf("Failed to record opening stock cost: %v\n", err)
+	}
+
+	if product.IsBundle() {
+		products := []Domain.Product{*product}
+		if err := applyBundleFigures(uc.inventoryRepo, products); err != nil {
+			return nil, err
+		}
+		product = &products[0]
+	}
+
 	return product, nil
 }
 
@@ -99,11 +191,32 @@ func (uc *inventoryUseCase) GetProductByID(id, businessID string) (*Domain.Produ
 		return nil, fmt.Errorf("access denied: product does not belong to this business")
 	}
 
+	if product.IsBundle() {
+		products := []Domain.Product{*product}
+		if err := applyBundleFigures(uc.inventoryRepo, products); err != nil {
+			return nil, err
+		}
+		product = &products[0]
+	}
+
 	return product, nil
 }
 
 func (uc *inventoryUseCase) GetProducts(businessID string, filters Domain.ProductFilters) ([]Domain.Product, error) {
-	return uc.inventoryRepo.FindByBusinessID(businessID, filters)
+	if err := expandCategoryFilter(uc.categoryRepo, businessID, &filters); err != nil {
+		return nil, err
+	}
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyBundleFigures(uc.inventoryRepo, products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
 }
 
 func (uc *inventoryUseCase) UpdateProduct(id, businessID, userID string, req Domain.CreateProductRequest) (*Domain.Product, error) {
@@ -111,6 +224,7 @@ func (uc *inventoryUseCase) UpdateProduct(id, businessID, userID string, req Dom
 	if err != nil {
 		return nil, err
 	}
+	oldCost, oldSelling := product.CostPrice, product.SellingPrice
 
 	// Validate selling price > cost price
 	if req.SellingPrice > 0 && req.CostPrice > 0 && req.SellingPrice <= req.CostPrice {
@@ -122,6 +236,35 @@ func (uc *inventoryUseCase) UpdateProduct(id, businessID, userID string, req Dom
 		return nil, fmt.Errorf("minimum stock must be less than maximum stock")
 	}
 
+	if req.Type != "" && req.Type != product.Type && !(req.Type == Domain.ProductTypeStandard && product.Type == "") {
+		return nil, fmt.Errorf("product type cannot be changed")
+	}
+	if req.WarrantyMonths < 0 {
+		return nil, fmt.Errorf("warranty cannot be negative")
+	}
+
+	if product.IsBundle() {
+		if req.TrackBatches {
+			return nil, fmt.Errorf("bundles cannot be batch-tracked")
+		}
+		if req.TrackSerials {
+			return nil, fmt.Errorf("bundles cannot be serial-tracked")
+		}
+		if req.Components != nil {
+			product.Components, product.CostPrice, err = resolveBundleComponents(uc.inventoryRepo, businessID, &product.ID, req.Components)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// The cost comes from the components, never from the request
+		req.CostPrice = 0
+		if req.SellingPrice > 0 && req.SellingPrice <= product.CostPrice {
+			return nil, fmt.Errorf("selling price must be greater than the bundle cost of %.2f", product.CostPrice)
+		}
+	} else if len(req.Components) > 0 {
+		return nil, fmt.Errorf("only bundles have components")
+	}
+
 	// Update product fields
 	if req.Name != "" {
 		product.Name = req.Name
@@ -135,8 +278,13 @@ func (uc *inventoryUseCase) UpdateProduct(id, businessID, userID string, req Dom
 	if req.Barcode != "" {
 		product.Barcode = req.Barcode
 	}
-	if req.Category != "" {
-		product.Category = req.Category
+	if req.CategoryID != "" || req.Category != "" {
+		category, err := resolveCategory(uc.categoryRepo, businessID, userID, req.CategoryID, req.Category)
+		if err != nil {
+			return nil, err
+		}
+		product.CategoryID = &category.ID
+		product.Category = category.Name
 	}
 	if req.Unit != "" {
 		product.Unit = req.Unit
@@ -153,14 +301,56 @@ func (uc *inventoryUseCase) UpdateProduct(id, businessID, userID string, req Dom
 	if req.MaxStock >= 0 {
 		product.MaxStock = req.MaxStock
 	}
+	if req.TrackBatches {
+		product.TrackBatches = true
+	}
+	if req.TrackSerials {
+		product.TrackSerials = true
+	}
+	if req.WarrantyMonths > 0 {
+		product.WarrantyMonths = req.WarrantyMonths
+	}
+	switch {
+	case req.NegativeStock == Domain.NegativeStockInherit:
+		product.NegativeStock = ""
+	case Domain.IsValidNegativeStockPolicy(req.NegativeStock):
+		product.NegativeStock = req.NegativeStock
+	case req.NegativeStock != "":
+		return nil, fmt.Errorf("invalid negative stock policy: %s", req.NegativeStock)
+	}
+	if req.SupplierID != nil {
+		supplier, err := findSupplier(uc.supplierRepo, businessID, req.SupplierID)
+		if err != nil {
+			return nil, err
+		}
+		// An empty supplier ID unassigns the supplier
+		product.SupplierID = nil
+		if supplier != nil {
+			product.SupplierID = &supplier.ID
+		}
+	}
+	if req.LeadTimeDays > 0 {
+		product.LeadTimeDays = req.LeadTimeDays
+	}
 
 	// Stock should only be updated via AdjustStock method
 	// product.Stock = req.Stock
 
+	// A bundle's stock is derived on read and never stored
+	stock := product.Stock
+	if product.IsBundle() {
+		product.Stock = 0
+	}
+
 	if err := uc.inventoryRepo.Update(product); err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
+	if objUserID, err := Domain.PrimitiveObjectIDFromHex(userID); err == nil {
+		recordPriceChange(uc.priceHistoryRepo, product, oldCost, oldSelling, objUserID, Domain.PriceChangeSourceManual, nil)
+	}
+
+	product.Stock = stock
 	return product, nil
 }
 
@@ -170,8 +360,8 @@ func (uc *inventoryUseCase) DeleteProduct(id, businessID, userID string) error {
 		return err
 	}
 
-	// Check if product has stock
-	if product.Stock > 0 {
+	// Check if product has stock; a bundle's stock belongs to its components
+	if !product.IsBundle() && product.Stock > 0 {
 		return fmt.Errorf("cannot delete product with remaining stock. Current stock: %.2f", product.Stock)
 	}
 
@@ -180,11 +370,16 @@ func (uc *inventoryUseCase) DeleteProduct(id, businessID, userID string) error {
 
 func (uc *inventoryUseCase) AdjustStock(id, businessID, userID string, req Domain.AdjustStockRequest) error {
 	// First, get the product to verify it belongs to business
-	_, err := uc.GetProductByID(id, businessID)
+	product, err := uc.GetProductByID(id, businessID)
 	if err != nil {
 		return err
 	}
 
+	// Serialised stock moves with its serial numbers
+	if product.TrackSerials {
+		return fmt.Errorf("stock of serial-tracked products changes through their serial numbers; receive or write off serials instead")
+	}
+
 	// Validate movement type
 	if !uc.isValidMovementType(req.Type) {
 		return fmt.Errorf("invalid movement type: %s", req.Type)
@@ -200,8 +395,13 @@ func (uc *inventoryUseCase) AdjustStock(id, businessID, userID string, req Domai
 		return fmt.Errorf("quantity must be greater than 0")
 	}
 
-	// Call repository method
-	return uc.inventoryRepo.AdjustStock(
+	// Validate unit cost
+	if req.UnitCost < 0 {
+		return fmt.Errorf("unit cost cannot be negative")
+	}
+
+	// Move stock and price the movement
+	_, err = uc.costingUC.AdjustStock(
 		id,
 		req.Quantity,
 		req.Type,
@@ -209,7 +409,10 @@ func (uc *inventoryUseCase) AdjustStock(id, businessID, userID string, req Domai
 		nil, // referenceID
 		"",  // referenceType
 		userID,
+		req.UnitCost,
+		req.LocationID,
 	)
+	return err
 }
 
 func (uc *inventoryUseCase) GetLowStock(businessID string, threshold float64) ([]Domain.Product, error) {
@@ -0,0 +1,386 @@
+package Usecases
+
+import (
+	"fmt"
+
+	Domain "ShopOps/Domain"
+)
+
+type LocationUseCase interface {
+	CreateLocation(businessID string, req Domain.CreateLocationRequest) (*Domain.Location, error)
+	GetLocation(id, businessID string) (*Domain.Location, error)
+	GetLocations(businessID string) ([]Domain.Location, error)
+	UpdateLocation(id, businessID string, req Domain.UpdateLocationRequest) (*Domain.Location, error)
+	GetLocationStock(id, businessID string) (*Domain.LocationStockReport, error)
+	SetMinStock(locationID, productID, businessID string, req Domain.SetLocationMinStockRequest) error
+	GetProductStock(productID, businessID string) (*Domain.ProductLocationStock, error)
+}
+
+type locationUseCase struct {
+	locationRepo   Domain.LocationRepository
+	stockLevelRepo Domain.StockLevelRepository
+	transferRepo   Domain.TransferRepository
+	inventoryRepo  Domain.ProductRepository
+	businessRepo   Domain.BusinessRepository
+}
+
+func NewLocationUseCase(
+	locationRepo Domain.LocationRepository,
+	stockLevelRepo Domain.StockLevelRepository,
+	transferRepo Domain.TransferRepository,
+	inventoryRepo Domain.ProductRepository,
+	businessRepo Domain.BusinessRepository,
+) LocationUseCase {
+	return &locationUseCase{
+		locationRepo:   locationRepo,
+		stockLevelRepo: stockLevelRepo,
+		transferRepo:   transferRepo,
+		inventoryRepo:  inventoryRepo,
+		businessRepo:   businessRepo,
+	}
+}
+
+// CreateLocation adds a stock location. The first location of a business
+// becomes its default and takes over all stock held so far, so that stock
+// levels always add up to the product totals.
+func (uc *locationUseCase) CreateLocation(businessID string, req Domain.CreateLocationRequest) (*Domain.Location, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("location name is required")
+	}
+
+	if req.Type == "" {
+		req.Type = Domain.LocationTypeStore
+	}
+	if !isValidLocationType(req.Type) {
+		return nil, fmt.Errorf("invalid location type: %s", req.Type)
+	}
+
+	existing, err := uc.locationRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+	first := len(existing) == 0
+
+	if req.IsDefault && !first {
+		if err := uc.locationRepo.ClearDefault(businessID); err != nil {
+			return nil, err
+		}
+	}
+
+	location := &Domain.Location{
+		BusinessID: business.ID,
+		Name:       req.Name,
+		Code:       req.Code,
+		Type:       req.Type,
+		Address:    req.Address,
+		IsDefault:  req.IsDefault || first,
+	}
+
+	if err := uc.locationRepo.Create(location); err != nil {
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+
+	if first {
+		products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range products {
+			if product.Stock <= 0 {
+				continue
+			}
+			if _, err := uc.stockLevelRepo.Adjust(businessID, product.ID.Hex(), location.ID.Hex(), product.Stock); err != nil {
+				return nil, fmt.Errorf("failed to assign stock of %s: %w", product.Name, err)
+			}
+		}
+	}
+
+	return location, nil
+}
+
+func (uc *locationUseCase) GetLocation(id, businessID string) (*Domain.Location, error) {
+	location, err := uc.locationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		return nil, fmt.Errorf("location not found")
+	}
+
+	if location.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: location does not belong to this business")
+	}
+
+	return location, nil
+}
+
+func (uc *locationUseCase) GetLocations(businessID string) ([]Domain.Location, error) {
+	return uc.locationRepo.FindByBusinessID(businessID)
+}
+
+func (uc *locationUseCase) UpdateLocation(id, businessID string, req Domain.UpdateLocationRequest) (*Domain.Location, error) {
+	location, err := uc.GetLocation(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		location.Name = req.Name
+	}
+	if req.Code != "" {
+		location.Code = req.Code
+	}
+	if req.Type != "" {
+		if !isValidLocationType(req.Type) {
+			return nil, fmt.Errorf("invalid location type: %s", req.Type)
+		}
+		location.Type = req.Type
+	}
+	if req.Address != "" {
+		location.Address = req.Address
+	}
+	if req.Status != "" {
+		if req.Status != Domain.LocationStatusActive && req.Status != Domain.LocationStatusInactive {
+			return nil, fmt.Errorf("invalid location status: %s", req.Status)
+		}
+		location.Status = req.Status
+	}
+
+	if req.IsDefault != nil {
+		if !*req.IsDefault && location.IsDefault {
+			return nil, fmt.Errorf("make another location the default instead")
+		}
+		if *req.IsDefault && !location.IsDefault {
+			if err := uc.locationRepo.ClearDefault(businessID); err != nil {
+				return nil, err
+			}
+			location.IsDefault = true
+		}
+	}
+
+	if location.IsDefault && location.Status == Domain.LocationStatusInactive {
+		return nil, fmt.Errorf("the default location cannot be deactivated")
+	}
+
+	if err := uc.locationRepo.Update(location); err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return location, nil
+}
+
+func (uc *locationUseCase) GetLocationStock(id, businessID string) (*Domain.LocationStockReport, error) {
+	location, err := uc.GetLocation(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := uc.stockLevelRepo.FindByLocationID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Domain.LocationStockReport{
+		LocationID:    location.ID.Hex(),
+		LocationName:  location.Name,
+		Items:         []Domain.LocationStockItem{},
+		LowStockItems: []Domain.LowStockItem{},
+	}
+
+	for _, level := range levels {
+		product, err := uc.inventoryRepo.FindByID(level.ProductID.Hex())
+		if err != nil || product == nil || product.Status != Domain.ProductStatusActive {
+			continue
+		}
+
+		minStock := level.MinStock
+		if minStock <= 0 {
+			minStock = product.MinStock
+		}
+
+		item := Domain.LocationStockItem{
+			ProductID:   product.ID.Hex(),
+			ProductName: product.Name,
+			SKU:         product.SKU,
+			Quantity:    level.Quantity,
+			MinStock:    minStock,
+			Value:       level.Quantity * product.CostPrice,
+			LowStock:    minStock > 0 && level.Quantity < minStock,
+		}
+
+		report.Items = append(report.Items, item)
+		report.TotalProducts++
+		report.TotalStock += item.Quantity
+		report.TotalValue += item.Value
+
+		if item.LowStock {
+			report.LowStockItems = append(report.LowStockItems, Domain.LowStockItem{
+				ProductID:   item.ProductID,
+				ProductName: item.ProductName,
+				Current:     item.Quantity,
+				Minimum:     minStock,
+				Difference:  minStock - item.Quantity,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+func (uc *locationUseCase) SetMinStock(locationID, productID, businessID string, req Domain.SetLocationMinStockRequest) error {
+	if _, err := uc.GetLocation(locationID, businessID); err != nil {
+		return err
+	}
+
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return fmt.Errorf("product not found")
+	}
+	if product.BusinessID.Hex() != businessID {
+		return fmt.Errorf("access denied: product does not belong to this business")
+	}
+
+	if req.MinStock < 0 {
+		return fmt.Errorf("minimum stock cannot be negative")
+	}
+
+	return uc.stockLevelRepo.SetMinStock(businessID, productID, locationID, req.MinStock)
+}
+
+func (uc *locationUseCase) GetProductStock(productID, businessID string) (*Domain.ProductLocationStock, error) {
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	if product.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: product does not belong to this business")
+	}
+
+	levels, err := uc.stockLevelRepo.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := uc.locationRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[string]float64)
+	for _, level := range levels {
+		quantities[level.LocationID.Hex()] = level.Quantity
+	}
+
+	result := &Domain.ProductLocationStock{
+		ProductID:   product.ID.Hex(),
+		ProductName: product.Name,
+		TotalStock:  product.Stock,
+		Locations:   []Domain.LocationStockBalance{},
+	}
+
+	for _, location := range locations {
+		result.Locations = append(result.Locations, Domain.LocationStockBalance{
+			LocationID:   location.ID.Hex(),
+			LocationName: location.Name,
+			Quantity:     quantities[location.ID.Hex()],
+		})
+	}
+
+	transfers, err := uc.transferRepo.FindInTransitByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, transfer := range transfers {
+		for _, item := range transfer.Items {
+			if item.ProductID == product.ID {
+				result.InTransit += item.Quantity
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func isValidLocationType(locationType Domain.LocationType) bool {
+	switch locationType {
+	case Domain.LocationTypeStore, Domain.LocationTypeOutlet, Domain.LocationTypeWarehouse:
+		return true
+	}
+	return false
+}
+
+// resolveLocation returns the active location stock should move at: the given
+// one, or the business default when none is given. It returns nil when the
+// business does not use locations.
+func resolveLocation(locationRepo Domain.LocationRepository, businessID string, locationID *string) (*Domain.Location, error) {
+	if locationID == nil || *locationID == "" {
+		return locationRepo.FindDefault(businessID)
+	}
+
+	location, err := locationRepo.FindByID(*locationID)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil || location.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("location not found")
+	}
+	if location.Status != Domain.LocationStatusActive {
+		return nil, fmt.Errorf("location %s is %s", location.Name, location.Status)
+	}
+
+	return location, nil
+}
+
+// checkLocationStock fails when a location holds less of a product than the
+// quantity requested.
+func checkLocationStock(stockLevelRepo Domain.StockLevelRepository, location *Domain.Location, productID string, quantity float64) error {
+	level, err := stockLevelRepo.Find(productID, location.ID.Hex())
+	if err != nil {
+		return err
+	}
+
+	var available float64
+	if level != nil {
+		available = level.Quantity
+	}
+
+	if available < quantity {
+		return fmt.Errorf("insufficient stock at %s. Available: %.2f, Requested: %.2f",
+			location.Name, available, quantity)
+	}
+
+	return nil
+}
+
+// locationIDOf returns the hex ID of a location, or nil when there is none.
+func locationIDOf(location *Domain.Location) *string {
+	if location == nil {
+		return nil
+	}
+	id := location.ID.Hex()
+	return &id
+}
+
+// stockDelta is the signed change a movement makes to stock on hand.
+func stockDelta(movementType Domain.MovementType, quantity float64) float64 {
+	switch movementType {
+	case Domain.MovementTypeSale, Domain.MovementTypeDamage, Domain.MovementTypeTheft:
+		return -quantity
+	}
+	return quantity
+}
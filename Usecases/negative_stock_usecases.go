@@ -0,0 +1,75 @@
+package Usecases
+
+import (
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+)
+
+type NegativeStockUseCase interface {
+	// GetNegativeStockReport lists the sales that took products below zero,
+	// newest first, each with the receipts that have since covered it.
+	GetNegativeStockReport(businessID string, filters Domain.NegativeStockFilters) (*Domain.NegativeStockReport, error)
+}
+
+type negativeStockUseCase struct {
+	negativeStockRepo Domain.NegativeStockRepository
+	businessRepo      Domain.BusinessRepository
+}
+
+func NewNegativeStockUseCase(
+	negativeStockRepo Domain.NegativeStockRepository,
+	businessRepo Domain.BusinessRepository,
+) NegativeStockUseCase {
+	return &negativeStockUseCase{
+		negativeStockRepo: negativeStockRepo,
+		businessRepo:      businessRepo,
+	}
+}
+
+func (uc *negativeStockUseCase) GetNegativeStockReport(businessID string, filters Domain.NegativeStockFilters) (*Domain.NegativeStockReport, error) {
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dates are calendar days in the business's timezone
+	if filters.StartDate != nil {
+		startDate := Domain.DateIn(*filters.StartDate, loc)
+		filters.StartDate = &startDate
+	}
+	if filters.EndDate != nil {
+		endDate := Domain.EndOfDay(Domain.DateIn(*filters.EndDate, loc))
+		filters.EndDate = &endDate
+	}
+
+	if filters.Status != nil &&
+		*filters.Status != Domain.NegativeStockStatusOpen &&
+		*filters.Status != Domain.NegativeStockStatusCovered {
+		return nil, fmt.Errorf("invalid status: %s", *filters.Status)
+	}
+
+	events, err := uc.negativeStockRepo.FindByBusinessID(businessID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Domain.NegativeStockReport{
+		GeneratedAt: time.Now(),
+		Events:      []Domain.NegativeStockEvent{},
+	}
+
+	for _, event := range events {
+		if event.Status == Domain.NegativeStockStatusCovered {
+			report.CoveredEvents++
+		} else {
+			report.OpenEvents++
+		}
+		report.TotalShortfall += event.Shortfall
+		report.TotalOutstanding += event.Outstanding()
+		report.Events = append(report.Events, event)
+	}
+
+	return report, nil
+}
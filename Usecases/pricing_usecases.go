@@ -0,0 +1,374 @@
+package Usecases
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	Domain "ShopOps/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PricingUseCase interface {
+	GetPriceHistory(productID, businessID string, limit int) ([]Domain.PriceChange, error)
+	GetPriceChanges(businessID string, startDate, endDate time.Time) ([]Domain.PriceChange, error)
+	GetPricesAt(businessID string, at time.Time) ([]Domain.ProductPrice, error)
+	SchedulePriceChanges(businessID, userID string, req Domain.SchedulePriceChangeRequest) ([]Domain.ScheduledPriceChange, error)
+	GetScheduledPriceChanges(businessID string, status *Domain.ScheduledPriceStatus) ([]Domain.ScheduledPriceChange, error)
+	CancelScheduledPriceChange(id, businessID string) error
+	// ApplyDuePriceChanges applies every scheduled change whose effective
+	// time has passed. It is run by a background job.
+	ApplyDuePriceChanges() error
+}
+
+type pricingUseCase struct {
+	inventoryRepo      Domain.ProductRepository
+	businessRepo       Domain.BusinessRepository
+	categoryRepo       Domain.CategoryRepository
+	priceHistoryRepo   Domain.PriceHistoryRepository
+	scheduledPriceRepo Domain.ScheduledPriceRepository
+}
+
+func NewPricingUseCase(
+	inventoryRepo Domain.ProductRepository,
+	businessRepo Domain.BusinessRepository,
+	categoryRepo Domain.CategoryRepository,
+	priceHistoryRepo Domain.PriceHistoryRepository,
+	scheduledPriceRepo Domain.ScheduledPriceRepository,
+) PricingUseCase {
+	return &pricingUseCase{
+		inventoryRepo:      inventoryRepo,
+		businessRepo:       businessRepo,
+		categoryRepo:       categoryRepo,
+		priceHistoryRepo:   priceHistoryRepo,
+		scheduledPriceRepo: scheduledPriceRepo,
+	}
+}
+
+// duePriceChangesPerRun caps how many scheduled changes one job run applies;
+// the rest wait for the next run.
+const duePriceChangesPerRun = 500
+
+func (uc *pricingUseCase) GetPriceHistory(productID, businessID string, limit int) ([]Domain.PriceChange, error) {
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	if product.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: product does not belong to this business")
+	}
+
+	return uc.priceHistoryRepo.FindByProductID(productID, limit)
+}
+
+// GetPriceChanges lists the price changes between two calendar dates, counted
+// in the business's timezone. A zero end date means now and a zero start date
+// 30 days before the end.
+func (uc *pricingUseCase) GetPriceChanges(businessID string, startDate, endDate time.Time) ([]Domain.PriceChange, error) {
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if endDate.IsZero() {
+		endDate = time.Now().In(loc)
+	} else {
+		endDate = Domain.EndOfDay(Domain.DateIn(endDate, loc))
+	}
+	if startDate.IsZero() {
+		startDate = Domain.AddDays(endDate, -30)
+	} else {
+		startDate = Domain.DateIn(startDate, loc)
+	}
+
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must be after start date")
+	}
+
+	return uc.priceHistoryRepo.FindByBusinessID(businessID, startDate, endDate)
+}
+
+// GetPricesAt returns the price list in effect at a point in time: the prices
+// set by the last change before it, or the prices the first later change
+// replaced, or the current prices for products whose prices never changed.
+func (uc *pricingUseCase) GetPricesAt(businessID string, at time.Time) ([]Domain.ProductPrice, error) {
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := uc.priceHistoryRepo.FindLatestBefore(businessID, at)
+	if err != nil {
+		return nil, err
+	}
+	after, err := uc.priceHistoryRepo.FindFirstAfter(businessID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[primitive.ObjectID]Domain.PriceChange, len(before))
+	for _, change := range before {
+		latest[change.ProductID] = change
+	}
+	next := make(map[primitive.ObjectID]Domain.PriceChange, len(after))
+	for _, change := range after {
+		next[change.ProductID] = change
+	}
+
+	prices := make([]Domain.ProductPrice, 0, len(products))
+	for _, product := range products {
+		// Products created later had no price yet
+		if product.CreatedAt.After(at) {
+			continue
+		}
+
+		price := Domain.ProductPrice{
+			ProductID:    product.ID,
+			ProductName:  product.Name,
+			SKU:          product.SKU,
+			Category:     product.Category,
+			CostPrice:    product.CostPrice,
+			SellingPrice: product.SellingPrice,
+		}
+		if change, ok := latest[product.ID]; ok {
+			price.CostPrice = change.NewCostPrice
+			price.SellingPrice = change.NewSellingPrice
+		} else if change, ok := next[product.ID]; ok {
+			price.CostPrice = change.OldCostPrice
+			price.SellingPrice = change.OldSellingPrice
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
+
+func (uc *pricingUseCase) SchedulePriceChanges(businessID, userID string, req Domain.SchedulePriceChangeRequest) ([]Domain.ScheduledPriceChange, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	if req.EffectiveAt.IsZero() {
+		return nil, fmt.Errorf("effective time is required")
+	}
+	if !req.EffectiveAt.After(time.Now()) {
+		return nil, fmt.Errorf("effective time must be in the future")
+	}
+
+	if req.PercentChange != nil {
+		if req.SellingPrice != nil || req.CostPrice != nil {
+			return nil, fmt.Errorf("give either new prices or a percentage change, not both")
+		}
+		if *req.PercentChange <= -100 || *req.PercentChange == 0 {
+			return nil, fmt.Errorf("percentage change must be above -100 and not 0")
+		}
+	} else {
+		if req.SellingPrice == nil && req.CostPrice == nil {
+			return nil, fmt.Errorf("a new selling price, cost price or percentage change is required")
+		}
+		if (req.SellingPrice != nil && *req.SellingPrice <= 0) || (req.CostPrice != nil && *req.CostPrice <= 0) {
+			return nil, fmt.Errorf("prices must be greater than 0")
+		}
+		if req.SellingPrice != nil && req.CostPrice != nil && *req.SellingPrice <= *req.CostPrice {
+			return nil, fmt.Errorf("selling price must be greater than cost price")
+		}
+	}
+
+	products, err := uc.selectProducts(businessID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	batchID := primitive.NewObjectID()
+	changes := make([]Domain.ScheduledPriceChange, 0, len(products))
+	for _, product := range products {
+		if product.IsBundle() && req.CostPrice != nil {
+			return nil, fmt.Errorf("%s is a bundle; its cost comes from its components", product.Name)
+		}
+
+		changes = append(changes, Domain.ScheduledPriceChange{
+			BusinessID:    business.ID,
+			ProductID:     product.ID,
+			ProductName:   product.Name,
+			BatchID:       batchID,
+			CostPrice:     req.CostPrice,
+			SellingPrice:  req.SellingPrice,
+			PercentChange: req.PercentChange,
+			EffectiveAt:   req.EffectiveAt,
+			Notes:         req.Notes,
+			CreatedBy:     objUserID,
+		})
+	}
+
+	if err := uc.scheduledPriceRepo.CreateMany(changes); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// selectProducts returns the products a scheduled change covers: the listed
+// ones, or every active product of a category.
+func (uc *pricingUseCase) selectProducts(businessID string, req Domain.SchedulePriceChangeRequest) ([]Domain.Product, error) {
+	if len(req.ProductIDs) > 0 && req.Category != "" {
+		return nil, fmt.Errorf("give either products or a category, not both")
+	}
+
+	if req.Category != "" {
+		status := Domain.ProductStatusActive
+		filters := Domain.ProductFilters{
+			Category: &req.Category,
+			Status:   &status,
+		}
+		if err := expandCategoryFilter(uc.categoryRepo, businessID, &filters); err != nil {
+			return nil, err
+		}
+
+		products, err := uc.inventoryRepo.FindByBusinessID(businessID, filters)
+		if err != nil {
+			return nil, err
+		}
+		if len(products) == 0 {
+			return nil, fmt.Errorf("no active products in category %s", req.Category)
+		}
+		return products, nil
+	}
+
+	if len(req.ProductIDs) == 0 {
+		return nil, fmt.Errorf("products or a category are required")
+	}
+
+	products := make([]Domain.Product, 0, len(req.ProductIDs))
+	for _, productID := range req.ProductIDs {
+		product, err := uc.inventoryRepo.FindByID(productID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product: %w", err)
+		}
+		if product == nil || product.BusinessID.Hex() != businessID {
+			return nil, fmt.Errorf("product %s not found", productID)
+		}
+		products = append(products, *product)
+	}
+
+	return products, nil
+}
+
+func (uc *pricingUseCase) GetScheduledPriceChanges(businessID string, status *Domain.ScheduledPriceStatus) ([]Domain.ScheduledPriceChange, error) {
+	return uc.scheduledPriceRepo.FindByBusinessID(businessID, status)
+}
+
+func (uc *pricingUseCase) CancelScheduledPriceChange(id, businessID string) error {
+	change, err := uc.scheduledPriceRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if change == nil {
+		return fmt.Errorf("scheduled price change not found")
+	}
+	if change.BusinessID.Hex() != businessID {
+		return fmt.Errorf("access denied: scheduled price change does not belong to this business")
+	}
+
+	return uc.scheduledPriceRepo.UpdateStatus(id, Domain.ScheduledPriceStatusCancelled,
+		[]Domain.ScheduledPriceStatus{Domain.ScheduledPriceStatusPending}, "")
+}
+
+func (uc *pricingUseCase) ApplyDuePriceChanges() error {
+	due, err := uc.scheduledPriceRepo.FindDue(time.Now(), duePriceChangesPerRun)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range due {
+		// Claim the change first so two servers never apply it twice
+		if err := uc.scheduledPriceRepo.UpdateStatus(change.ID.Hex(), Domain.ScheduledPriceStatusApplied,
+			[]Domain.ScheduledPriceStatus{Domain.ScheduledPriceStatusPending}, ""); err != nil {
+			continue
+		}
+
+		if err := uc.applyPriceChange(change); err != nil {
+			if err := uc.scheduledPriceRepo.UpdateStatus(change.ID.Hex(), Domain.ScheduledPriceStatusFailed,
+				[]Domain.ScheduledPriceStatus{Domain.ScheduledPriceStatusApplied}, err.Error()); err != nil {
+				fmt.Printf("Failed to record failed price change: %v\n", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (uc *pricingUseCase) applyPriceChange(change Domain.ScheduledPriceChange) error {
+	product, err := uc.inventoryRepo.FindByID(change.ProductID.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return fmt.Errorf("product no longer exists")
+	}
+
+	oldCost, oldSelling := product.CostPrice, product.SellingPrice
+
+	if change.PercentChange != nil {
+		product.SellingPrice = math.Round(product.SellingPrice*(1+*change.PercentChange/100)*100) / 100
+	}
+	if change.SellingPrice != nil {
+		product.SellingPrice = *change.SellingPrice
+	}
+	if change.CostPrice != nil {
+		if product.IsBundle() {
+			return fmt.Errorf("a bundle's cost comes from its components")
+		}
+		product.CostPrice = *change.CostPrice
+	}
+
+	if product.SellingPrice <= product.CostPrice {
+		return fmt.Errorf("selling price %.2f would not be above cost price %.2f", product.SellingPrice, product.CostPrice)
+	}
+
+	if err := uc.inventoryRepo.Update(product); err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
+	}
+
+	scheduleID := change.ID
+	recordPriceChange(uc.priceHistoryRepo, product, oldCost, oldSelling, change.CreatedBy, Domain.PriceChangeSourceScheduled, &scheduleID)
+	return nil
+}
+
+// recordPriceChange adds a price change to the product's history when either
+// of its prices differs from the old ones.
+func recordPriceChange(priceHistoryRepo Domain.PriceHistoryRepository, product *Domain.Product, oldCost, oldSelling float64, changedBy primitive.ObjectID, source Domain.PriceChangeSource, scheduleID *primitive.ObjectID) {
+	if product.CostPrice == oldCost && product.SellingPrice == oldSelling {
+		return
+	}
+
+	change := &Domain.PriceChange{
+		BusinessID:      product.BusinessID,
+		ProductID:       product.ID,
+		ProductName:     product.Name,
+		OldCostPrice:    oldCost,
+		NewCostPrice:    product.CostPrice,
+		OldSellingPrice: oldSelling,
+		NewSellingPrice: product.SellingPrice,
+		Source:          source,
+		ScheduleID:      scheduleID,
+		ChangedBy:       changedBy,
+	}
+
+	if err := priceHistoryRepo.Create(change); err != nil {
+		fmt.Printf("Failed to record price change: %v\n", err)
+	}
+}
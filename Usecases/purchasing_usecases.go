@@ -0,0 +1,497 @@
+package Usecases
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PurchasingUseCase interface {
+	GetReorderSuggestions(businessID string, opts Domain.ReorderOptions) (*Domain.ReorderSuggestionsReport, error)
+	CreateDraftOrders(businessID, userID string, req Domain.CreateDraftOrdersRequest) ([]Domain.PurchaseOrder, error)
+	GetPurchaseOrder(id, businessID string) (*Domain.PurchaseOrder, error)
+	GetPurchaseOrders(businessID string, status *Domain.PurchaseOrderStatus) ([]Domain.PurchaseOrder, error)
+	MarkOrdered(id, businessID, userID string) (*Domain.PurchaseOrder, error)
+	ReceivePurchaseOrder(id, businessID, userID string, req Domain.ReceivePurchaseOrderRequest) (*Domain.PurchaseOrder, error)
+	CancelPurchaseOrder(id, businessID, userID string) (*Domain.PurchaseOrder, error)
+}
+
+type purchasingUseCase struct {
+	purchaseOrderRepo Domain.PurchaseOrderRepository
+	supplierRepo      Domain.SupplierRepository
+	inventoryRepo     Domain.ProductRepository
+	businessRepo      Domain.BusinessRepository
+	locationRepo      Domain.LocationRepository
+	serialRepo        Domain.SerialRepository
+	costingUC         CostingUseCase
+}
+
+func NewPurchasingUseCase(
+	purchaseOrderRepo Domain.PurchaseOrderRepository,
+	supplierRepo Domain.SupplierRepository,
+	inventoryRepo Domain.ProductRepository,
+	businessRepo Domain.BusinessRepository,
+	locationRepo Domain.LocationRepository,
+	serialRepo Domain.SerialRepository,
+	costingUC CostingUseCase,
+) PurchasingUseCase {
+	return &purchasingUseCase{
+		purchaseOrderRepo: purchaseOrderRepo,
+		supplierRepo:      supplierRepo,
+		inventoryRepo:     inventoryRepo,
+		businessRepo:      businessRepo,
+		locationRepo:      locationRepo,
+		serialRepo:        serialRepo,
+		costingUC:         costingUC,
+	}
+}
+
+// openOrderStatuses are the purchase order statuses whose goods are still to
+// arrive.
+var openOrderStatuses = []Domain.PurchaseOrderStatus{
+	Domain.PurchaseOrderStatusDraft,
+	Domain.PurchaseOrderStatusOrdered,
+}
+
+// GetReorderSuggestions works out, for each active product, the average daily
+// demand over the lookback period and how many days the stock will last. A
+// product is suggested once its stock plus open orders falls to the demand
+// expected over its lead time and safety days, or to its minimum stock. The
+// suggested quantity tops it up to MaxStock, or to the reorder point plus the
+// cover days when no maximum is set.
+func (uc *purchasingUseCase) GetReorderSuggestions(businessID string, opts Domain.ReorderOptions) (*Domain.ReorderSuggestionsReport, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	if opts.LookbackDays < 0 || opts.SafetyDays < 0 || opts.CoverDays < 0 {
+		return nil, fmt.Errorf("days cannot be negative")
+	}
+	if opts.LookbackDays == 0 {
+		opts.LookbackDays = 30
+	}
+	if opts.SafetyDays == 0 {
+		opts.SafetyDays = 7
+	}
+	if opts.CoverDays == 0 {
+		opts.CoverDays = 30
+	}
+
+	if opts.SupplierID != "" {
+		if _, err := findSupplier(uc.supplierRepo, businessID, &opts.SupplierID); err != nil {
+			return nil, err
+		}
+	}
+
+	status := Domain.ProductStatusActive
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{Status: &status})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	demand, err := uc.inventoryRepo.GetDemand(businessID, now.AddDate(0, 0, -opts.LookbackDays))
+	if err != nil {
+		return nil, err
+	}
+	sold := make(map[string]float64)
+	for _, d := range demand {
+		sold[d.ProductID.Hex()] = d.Quantity
+	}
+
+	suppliers, err := uc.supplierRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+	suppliersByID := make(map[string]Domain.Supplier)
+	for _, supplier := range suppliers {
+		suppliersByID[supplier.ID.Hex()] = supplier
+	}
+
+	orders, err := uc.purchaseOrderRepo.FindByBusinessID(businessID, openOrderStatuses)
+	if err != nil {
+		return nil, err
+	}
+	onOrder := make(map[string]float64)
+	for _, order := range orders {
+		for _, item := range order.Items {
+			onOrder[item.ProductID.Hex()] += item.Quantity
+		}
+	}
+
+	report := &Domain.ReorderSuggestionsReport{
+		LookbackDays: opts.LookbackDays,
+		SafetyDays:   opts.SafetyDays,
+		GeneratedAt:  now,
+		Suppliers:    []Domain.SupplierReorderGroup{},
+	}
+	groups := make(map[string]*Domain.SupplierReorderGroup)
+
+	for _, product := range products {
+		// Bundles are restocked by ordering their components
+		if product.IsBundle() {
+			continue
+		}
+
+		supplierID := ""
+		if product.SupplierID != nil {
+			supplierID = product.SupplierID.Hex()
+		}
+		if opts.SupplierID != "" && supplierID != opts.SupplierID {
+			continue
+		}
+		supplier, hasSupplier := suppliersByID[supplierID]
+
+		productID := product.ID.Hex()
+		soldQuantity := math.Max(sold[productID], 0)
+		dailyDemand := soldQuantity / float64(opts.LookbackDays)
+
+		leadTime := product.LeadTimeDays
+		if leadTime <= 0 && hasSupplier {
+			leadTime = supplier.LeadTimeDays
+		}
+		if leadTime <= 0 {
+			leadTime = Domain.DefaultLeadTimeDays
+		}
+
+		reorderPoint := math.Max(dailyDemand*float64(leadTime+opts.SafetyDays), product.MinStock)
+		position := product.Stock + onOrder[productID]
+		if reorderPoint <= 0 || position > reorderPoint {
+			continue
+		}
+
+		target := product.MaxStock
+		if target <= 0 {
+			target = reorderPoint + dailyDemand*float64(opts.CoverDays)
+		}
+		quantity := math.Ceil(target - position)
+		if quantity <= 0 {
+			continue
+		}
+
+		suggestion := Domain.ReorderSuggestion{
+			ProductID:          productID,
+			ProductName:        product.Name,
+			SKU:                product.SKU,
+			Unit:               product.Unit,
+			CurrentStock:       product.Stock,
+			OnOrder:            onOrder[productID],
+			MinStock:           product.MinStock,
+			MaxStock:           product.MaxStock,
+			SoldInPeriod:       soldQuantity,
+			AverageDailyDemand: dailyDemand,
+			LeadTimeDays:       leadTime,
+			ReorderPoint:       reorderPoint,
+			SuggestedQuantity:  quantity,
+			UnitCost:           product.CostPrice,
+			EstimatedCost:      quantity * product.CostPrice,
+		}
+		if dailyDemand > 0 {
+			cover := product.Stock / dailyDemand
+			suggestion.DaysOfCover = &cover
+		}
+
+		group, ok := groups[supplierID]
+		if !ok {
+			group = &Domain.SupplierReorderGroup{SupplierName: "No supplier"}
+			if hasSupplier {
+				group.SupplierID = supplierID
+				group.SupplierName = supplier.Name
+			}
+			groups[supplierID] = group
+		}
+
+		group.Items = append(group.Items, suggestion)
+		group.EstimatedCost += suggestion.EstimatedCost
+		report.TotalItems++
+		report.EstimatedCost += suggestion.EstimatedCost
+	}
+
+	for _, group := range groups {
+		// Most urgent first; products that have not sold come last
+		sort.SliceStable(group.Items, func(i, j int) bool {
+			a, b := group.Items[i].DaysOfCover, group.Items[j].DaysOfCover
+			if a == nil || b == nil {
+				return b == nil && a != nil
+			}
+			return *a < *b
+		})
+		report.Suppliers = append(report.Suppliers, *group)
+	}
+
+	// Named suppliers alphabetically, products without a supplier last
+	sort.Slice(report.Suppliers, func(i, j int) bool {
+		a, b := report.Suppliers[i], report.Suppliers[j]
+		if (a.SupplierID == "") != (b.SupplierID == "") {
+			return b.SupplierID == ""
+		}
+		return a.SupplierName < b.SupplierName
+	})
+
+	return report, nil
+}
+
+// CreateDraftOrders drafts one purchase order per supplier from the current
+// reorder suggestions.
+func (uc *purchasingUseCase) CreateDraftOrders(businessID, userID string, req Domain.CreateDraftOrdersRequest) ([]Domain.PurchaseOrder, error) {
+	report, err := uc.GetReorderSuggestions(businessID, Domain.ReorderOptions{
+		LookbackDays: req.LookbackDays,
+		SafetyDays:   req.SafetyDays,
+		CoverDays:    req.CoverDays,
+		SupplierID:   req.SupplierID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objBusinessID, err := Domain.PrimitiveObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	selected := make(map[string]bool)
+	for _, productID := range req.ProductIDs {
+		selected[productID] = true
+	}
+
+	orders := []Domain.PurchaseOrder{}
+	for _, group := range report.Suppliers {
+		order := Domain.PurchaseOrder{
+			BusinessID:   objBusinessID,
+			SupplierName: group.SupplierName,
+			Notes:        req.Notes,
+			CreatedBy:    objUserID,
+		}
+		if group.SupplierID != "" {
+			supplierID, err := Domain.PrimitiveObjectIDFromHex(group.SupplierID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid supplier ID: %w", err)
+			}
+			order.SupplierID = &supplierID
+		}
+
+		for _, suggestion := range group.Items {
+			if len(selected) > 0 && !selected[suggestion.ProductID] {
+				continue
+			}
+
+			productID, err := Domain.PrimitiveObjectIDFromHex(suggestion.ProductID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid product ID: %w", err)
+			}
+
+			order.Items = append(order.Items, Domain.PurchaseOrderItem{
+				ProductID:   productID,
+				ProductName: suggestion.ProductName,
+				SKU:         suggestion.SKU,
+				Quantity:    suggestion.SuggestedQuantity,
+				UnitCost:    suggestion.UnitCost,
+				TotalCost:   suggestion.EstimatedCost,
+			})
+			order.TotalCost += suggestion.EstimatedCost
+		}
+
+		if len(order.Items) == 0 {
+			continue
+		}
+
+		if err := uc.purchaseOrderRepo.Create(&order); err != nil {
+			return nil, fmt.Errorf("failed to create purchase order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if len(orders) == 0 {
+		return nil, fmt.Errorf("no products need reordering")
+	}
+
+	return orders, nil
+}
+
+func (uc *purchasingUseCase) GetPurchaseOrder(id, businessID string) (*Domain.PurchaseOrder, error) {
+	order, err := uc.purchaseOrderRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, fmt.Errorf("purchase order not found")
+	}
+
+	if order.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: purchase order does not belong to this business")
+	}
+
+	return order, nil
+}
+
+func (uc *purchasingUseCase) GetPurchaseOrders(businessID string, status *Domain.PurchaseOrderStatus) ([]Domain.PurchaseOrder, error) {
+	var statuses []Domain.PurchaseOrderStatus
+	if status != nil {
+		statuses = []Domain.PurchaseOrderStatus{*status}
+	}
+	return uc.purchaseOrderRepo.FindByBusinessID(businessID, statuses)
+}
+
+func (uc *purchasingUseCase) MarkOrdered(id, businessID, userID string) (*Domain.PurchaseOrder, error) {
+	if _, err := uc.GetPurchaseOrder(id, businessID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.purchaseOrderRepo.UpdateStatus(id, Domain.PurchaseOrderStatusOrdered,
+		[]Domain.PurchaseOrderStatus{Domain.PurchaseOrderStatusDraft}, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetPurchaseOrder(id, businessID)
+}
+
+// ReceivePurchaseOrder books the ordered goods into stock at their order cost,
+// recording the serial numbers of serialised products.
+func (uc *purchasingUseCase) ReceivePurchaseOrder(id, businessID, userID string, req Domain.ReceivePurchaseOrderRequest) (*Domain.PurchaseOrder, error) {
+	order, err := uc.GetPurchaseOrder(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := resolveLocation(uc.locationRepo, businessID, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	serials, err := uc.orderSerials(order, req.Serials)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.purchaseOrderRepo.UpdateStatus(id, Domain.PurchaseOrderStatusReceived, openOrderStatuses, userID); err != nil {
+		return nil, err
+	}
+
+	reason := fmt.Sprintf("Purchase order from %s", order.SupplierName)
+	for _, item := range order.Items {
+		var received []Domain.SerialNumber
+		if product, ok := serials.products[item.ProductID]; ok {
+			received, err = receiveSerials(uc.serialRepo, product, serials.numbers[item.ProductID], item.UnitCost,
+				&order.ID, "purchase_order", "Received on purchase order", objUserID)
+			if err != nil {
+				fmt.Printf("Failed to record serial numbers of %s on purchase order %s: %v\n", item.ProductName, id, err)
+			}
+		}
+
+		if _, err := uc.costingUC.AdjustStock(
+			item.ProductID.Hex(),
+			item.Quantity,
+			Domain.MovementTypePurchase,
+			reason,
+			&id,
+			"purchase_order",
+			userID,
+			item.UnitCost,
+			locationIDOf(location),
+		); err != nil {
+			deleteSerials(uc.serialRepo, received)
+			fmt.Printf("Failed to receive %s on purchase order %s: %v\n", item.ProductName, id, err)
+		}
+	}
+
+	return uc.GetPurchaseOrder(id, businessID)
+}
+
+// receivedSerials holds the serialised products of a purchase order and the
+// serial numbers received for each.
+type receivedSerials struct {
+	products map[primitive.ObjectID]*Domain.Product
+	numbers  map[primitive.ObjectID][]string
+}
+
+// orderSerials checks that every serialised product on the order comes with
+// one new serial number per unit, and that serials are only given for
+// serialised products on the order.
+func (uc *purchasingUseCase) orderSerials(order *Domain.PurchaseOrder, given map[string][]string) (*receivedSerials, error) {
+	serials := &receivedSerials{
+		products: make(map[primitive.ObjectID]*Domain.Product),
+		numbers:  make(map[primitive.ObjectID][]string),
+	}
+
+	onOrder := make(map[string]bool, len(order.Items))
+	seen := make(map[string]bool)
+	for _, item := range order.Items {
+		productID := item.ProductID.Hex()
+		onOrder[productID] = true
+
+		product, err := uc.inventoryRepo.FindByID(productID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product: %w", err)
+		}
+		if product == nil || !product.TrackSerials {
+			if len(given[productID]) > 0 {
+				return nil, fmt.Errorf("%s is not serial-tracked", item.ProductName)
+			}
+			continue
+		}
+
+		numbers, err := normalizeSerials(given[productID])
+		if err != nil {
+			return nil, fmt.Errorf("%s is serial-tracked: %w", item.ProductName, err)
+		}
+		if float64(len(numbers)) != item.Quantity {
+			return nil, fmt.Errorf("%d serial numbers given for %.0f units of %s", len(numbers), item.Quantity, item.ProductName)
+		}
+
+		for _, number := range numbers {
+			if seen[number] {
+				return nil, fmt.Errorf("serial number %s is listed more than once", number)
+			}
+			seen[number] = true
+
+			existing, err := uc.serialRepo.FindBySerial(order.BusinessID.Hex(), number)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, fmt.Errorf("serial number %s is already recorded for %s", number, existing.ProductName)
+			}
+		}
+
+		serials.products[item.ProductID] = product
+		serials.numbers[item.ProductID] = numbers
+	}
+
+	for productID := range given {
+		if !onOrder[productID] {
+			return nil, fmt.Errorf("serial numbers given for product %s, which is not on the order", productID)
+		}
+	}
+
+	return serials, nil
+}
+
+func (uc *purchasingUseCase) CancelPurchaseOrder(id, businessID, userID string) (*Domain.PurchaseOrder, error) {
+	if _, err := uc.GetPurchaseOrder(id, businessID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.purchaseOrderRepo.UpdateStatus(id, Domain.PurchaseOrderStatusCancelled, openOrderStatuses, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetPurchaseOrder(id, businessID)
+}
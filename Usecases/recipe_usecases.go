@@ -0,0 +1,385 @@
+package Usecases
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	Domain "ShopOps/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RecipeUseCase interface {
+	SetRecipe(productID, businessID, userID string, req Domain.SetRecipeRequest) (*Domain.Recipe, error)
+	GetRecipe(productID, businessID string) (*Domain.Recipe, error)
+	GetRecipes(businessID string) ([]Domain.Recipe, error)
+	DeleteRecipe(productID, businessID string) error
+	GetUsageReport(businessID, stocktakeID string, startDate *time.Time) (*Domain.UsageReport, error)
+}
+
+type recipeUseCase struct {
+	recipeRepo       Domain.RecipeRepository
+	inventoryRepo    Domain.ProductRepository
+	salesRepo        Domain.SaleRepository
+	stocktakeRepo    Domain.StocktakeRepository
+	priceHistoryRepo Domain.PriceHistoryRepository
+}
+
+func NewRecipeUseCase(
+	recipeRepo Domain.RecipeRepository,
+	inventoryRepo Domain.ProductRepository,
+	salesRepo Domain.SaleRepository,
+	stocktakeRepo Domain.StocktakeRepository,
+	priceHistoryRepo Domain.PriceHistoryRepository,
+) RecipeUseCase {
+	return &recipeUseCase{
+		recipeRepo:       recipeRepo,
+		inventoryRepo:    inventoryRepo,
+		salesRepo:        salesRepo,
+		stocktakeRepo:    stocktakeRepo,
+		priceHistoryRepo: priceHistoryRepo,
+	}
+}
+
+// defaultUsagePeriodDays is how far back the usage report looks when there is
+// no earlier stocktake to start from.
+const defaultUsagePeriodDays = 30
+
+// SetRecipe creates or replaces the recipe of a menu item and updates the
+// item's cost price to the cost of its ingredients.
+func (uc *recipeUseCase) SetRecipe(productID, businessID, userID string, req Domain.SetRecipeRequest) (*Domain.Recipe, error) {
+	product, err := uc.getProduct(productID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if product.IsBundle() {
+		return nil, fmt.Errorf("bundles cannot have a recipe")
+	}
+
+	if len(req.Ingredients) == 0 {
+		return nil, fmt.Errorf("a recipe needs at least one ingredient")
+	}
+
+	// Recipes are one level deep: an ingredient cannot be a menu item too
+	recipes, err := uc.recipeRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range recipes {
+		for _, ingredient := range other.Ingredients {
+			if ingredient.ProductID == product.ID {
+				return nil, fmt.Errorf("%s is an ingredient of another recipe and cannot have one", product.Name)
+			}
+		}
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	recipe := &Domain.Recipe{
+		BusinessID: product.BusinessID,
+		ProductID:  product.ID,
+		Notes:      req.Notes,
+		CreatedBy:  objUserID,
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range req.Ingredients {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("ingredient quantity must be greater than 0")
+		}
+		if line.WasteFactor < 0 || line.WasteFactor >= 1 {
+			return nil, fmt.Errorf("waste factor must be between 0 and 1")
+		}
+		if seen[line.ProductID] {
+			return nil, fmt.Errorf("each ingredient can only be listed once")
+		}
+		seen[line.ProductID] = true
+
+		ingredient, err := uc.inventoryRepo.FindByID(line.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find ingredient: %w", err)
+		}
+		if ingredient == nil || ingredient.BusinessID != product.BusinessID {
+			return nil, fmt.Errorf("ingredient %s not found", line.ProductID)
+		}
+		if ingredient.ID == product.ID {
+			return nil, fmt.Errorf("a product cannot be its own ingredient")
+		}
+		if ingredient.IsBundle() {
+			return nil, fmt.Errorf("%s is a bundle and cannot be an ingredient", ingredient.Name)
+		}
+		if ingredient.TrackBatches {
+			return nil, fmt.Errorf("%s is batch-tracked and cannot be an ingredient", ingredient.Name)
+		}
+		if ingredient.TrackSerials {
+			return nil, fmt.Errorf("%s is serial-tracked and cannot be an ingredient", ingredient.Name)
+		}
+
+		// Ingredients are taken straight from stock, so they cannot have a
+		// recipe of their own
+		nested, err := uc.recipeRepo.FindByProductID(line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if nested != nil {
+			return nil, fmt.Errorf("%s has a recipe and cannot be an ingredient", ingredient.Name)
+		}
+
+		recipe.Ingredients = append(recipe.Ingredients, Domain.RecipeIngredient{
+			ProductID:   ingredient.ID,
+			Quantity:    line.Quantity,
+			WasteFactor: line.WasteFactor,
+		})
+		recipe.Cost += componentUnitCost(ingredient) * recipe.Ingredients[len(recipe.Ingredients)-1].Usage()
+	}
+
+	if err := uc.recipeRepo.Save(recipe); err != nil {
+		return nil, err
+	}
+
+	if recipe.Cost > 0 && recipe.Cost != product.CostPrice {
+		oldCost := product.CostPrice
+		product.CostPrice = recipe.Cost
+		if err := uc.inventoryRepo.Update(product); err != nil {
+			return nil, fmt.Errorf("failed to update product cost: %w", err)
+		}
+		recordPriceChange(uc.priceHistoryRepo, product, oldCost, product.SellingPrice, objUserID, Domain.PriceChangeSourceRecipe, nil)
+	}
+
+	return recipe, nil
+}
+
+func (uc *recipeUseCase) GetRecipe(productID, businessID string) (*Domain.Recipe, error) {
+	if _, err := uc.getProduct(productID, businessID); err != nil {
+		return nil, err
+	}
+
+	recipe, err := uc.recipeRepo.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if recipe == nil {
+		return nil, fmt.Errorf("product has no recipe")
+	}
+
+	if err := uc.costRecipe(recipe); err != nil {
+		return nil, err
+	}
+
+	return recipe, nil
+}
+
+func (uc *recipeUseCase) GetRecipes(businessID string) ([]Domain.Recipe, error) {
+	recipes, err := uc.recipeRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recipes {
+		if err := uc.costRecipe(&recipes[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return recipes, nil
+}
+
+func (uc *recipeUseCase) DeleteRecipe(productID, businessID string) error {
+	if _, err := uc.getProduct(productID, businessID); err != nil {
+		return err
+	}
+
+	return uc.recipeRepo.Delete(productID)
+}
+
+// GetUsageReport compares the ingredient usage back-flushed by sales with
+// the usage a stocktake reveals. The stocktake's expected quantities already
+// include the back-flushed usage, so whatever the count is short of them was
+// used on top of the recipes. The period runs from the previous stocktake,
+// or startDate, to when the stocktake was opened.
+func (uc *recipeUseCase) GetUsageReport(businessID, stocktakeID string, startDate *time.Time) (*Domain.UsageReport, error) {
+	stocktake, err := uc.stocktakeRepo.FindByID(stocktakeID)
+	if err != nil {
+		return nil, err
+	}
+	if stocktake == nil {
+		return nil, fmt.Errorf("stocktake not found")
+	}
+	if stocktake.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: stocktake does not belong to this business")
+	}
+	if stocktake.Status == Domain.StocktakeStatusCancelled {
+		return nil, fmt.Errorf("stocktake is cancelled")
+	}
+
+	endDate := stocktake.CreatedAt
+	start, err := uc.usagePeriodStart(stocktake, startDate)
+	if err != nil {
+		return nil, err
+	}
+	if !start.Before(endDate) {
+		return nil, fmt.Errorf("start date must be before the stocktake was opened")
+	}
+
+	recipes, err := uc.recipeRepo.FindByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return nil, fmt.Errorf("no recipes to report on")
+	}
+
+	menuItems := make([]primitive.ObjectID, 0, len(recipes))
+	ingredients := make(map[primitive.ObjectID]bool)
+	for _, recipe := range recipes {
+		menuItems = append(menuItems, recipe.ProductID)
+		for _, ingredient := range recipe.Ingredients {
+			ingredients[ingredient.ProductID] = true
+		}
+	}
+
+	var locationID *string
+	if stocktake.LocationID != nil {
+		id := stocktake.LocationID.Hex()
+		locationID = &id
+	}
+
+	usage, err := uc.salesRepo.GetComponentUsage(businessID, menuItems, locationID, start, endDate)
+	if err != nil {
+		return nil, err
+	}
+	theoretical := make(map[primitive.ObjectID]float64)
+	for _, u := range usage {
+		theoretical[u.ProductID] = u.Quantity
+	}
+
+	report := &Domain.UsageReport{
+		StocktakeID:   stocktake.ID,
+		StocktakeName: stocktake.Name,
+		StartDate:     start,
+		EndDate:       endDate,
+		Items:         []Domain.IngredientUsage{},
+		GeneratedAt:   time.Now(),
+	}
+
+	counted := make(map[primitive.ObjectID]bool)
+	for _, item := range stocktake.Items {
+		if !ingredients[item.ProductID] {
+			continue
+		}
+		counted[item.ProductID] = true
+
+		if item.CountedQuantity == nil {
+			report.Uncounted = append(report.Uncounted, item.ProductName)
+			continue
+		}
+
+		line := Domain.IngredientUsage{
+			ProductID:        item.ProductID,
+			ProductName:      item.ProductName,
+			Unit:             item.Unit,
+			TheoreticalUsage: theoretical[item.ProductID],
+			UnitCost:         item.UnitCost,
+		}
+		line.ActualUsage = line.TheoreticalUsage + item.ExpectedQuantity - *item.CountedQuantity
+		line.Variance = line.ActualUsage - line.TheoreticalUsage
+		line.VarianceValue = line.Variance * line.UnitCost
+		if line.TheoreticalUsage > 0 {
+			line.VariancePercent = math.Round(line.Variance/line.TheoreticalUsage*10000) / 100
+		}
+
+		report.Items = append(report.Items, line)
+		report.TotalTheoreticalValue += line.TheoreticalUsage * line.UnitCost
+		report.TotalActualValue += line.ActualUsage * line.UnitCost
+		report.TotalVarianceValue += line.VarianceValue
+	}
+
+	// Ingredients the stocktake did not cover at all
+	for productID := range ingredients {
+		if counted[productID] {
+			continue
+		}
+		product, err := uc.inventoryRepo.FindByID(productID.Hex())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find ingredient: %w", err)
+		}
+		if product != nil {
+			report.Uncounted = append(report.Uncounted, product.Name)
+		}
+	}
+	sort.Strings(report.Uncounted)
+
+	// Largest losses first
+	sort.Slice(report.Items, func(i, j int) bool {
+		return report.Items[i].VarianceValue > report.Items[j].VarianceValue
+	})
+
+	return report, nil
+}
+
+// usagePeriodStart picks where the usage period begins: the given date, the
+// most recent earlier stocktake of the same scope, or a default period.
+func (uc *recipeUseCase) usagePeriodStart(stocktake *Domain.Stocktake, startDate *time.Time) (time.Time, error) {
+	if startDate != nil {
+		return *startDate, nil
+	}
+
+	posted := Domain.StocktakeStatusPosted
+	previous, err := uc.stocktakeRepo.FindByBusinessID(stocktake.BusinessID.Hex(), &posted)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var start time.Time
+	for _, candidate := range previous {
+		if candidate.ID == stocktake.ID || !candidate.CreatedAt.Before(stocktake.CreatedAt) {
+			continue
+		}
+		sameScope := (candidate.LocationID == nil && stocktake.LocationID == nil) ||
+			(candidate.LocationID != nil && stocktake.LocationID != nil && *candidate.LocationID == *stocktake.LocationID)
+		if sameScope && candidate.CreatedAt.After(start) {
+			start = candidate.CreatedAt
+		}
+	}
+
+	if start.IsZero() {
+		start = stocktake.CreatedAt.AddDate(0, 0, -defaultUsagePeriodDays)
+	}
+	return start, nil
+}
+
+// costRecipe fills in the current ingredient cost of one unit.
+func (uc *recipeUseCase) costRecipe(recipe *Domain.Recipe) error {
+	recipe.Cost = 0
+	for _, ingredient := range recipe.Ingredients {
+		product, err := uc.inventoryRepo.FindByID(ingredient.ProductID.Hex())
+		if err != nil {
+			return fmt.Errorf("failed to find ingredient: %w", err)
+		}
+		if product != nil {
+			recipe.Cost += componentUnitCost(product) * ingredient.Usage()
+		}
+	}
+	return nil
+}
+
+func (uc *recipeUseCase) getProduct(productID, businessID string) (*Domain.Product, error) {
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	if product.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: product does not belong to this business")
+	}
+
+	return product, nil
+}
@@ -0,0 +1,293 @@
+package Usecases
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	Domain "ShopOps/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultReconciliationDays is how many days a reconciliation report covers
+// when no start date is given.
+const defaultReconciliationDays = 7
+
+// ReconciliationUseCase sets the takings of each payment method against the
+// settlements the business owner enters from deposits and statements.
+type ReconciliationUseCase interface {
+	GetPaymentReconciliation(businessID string, req Domain.PaymentReconciliationRequest) (*Domain.PaymentReconciliationReport, error)
+	RecordSettlement(businessID, userID string, req Domain.RecordSettlementRequest) (*Domain.PaymentSettlement, error)
+}
+
+type reconciliationUseCase struct {
+	reportRepo     Domain.ReportRepository
+	settlementRepo Domain.PaymentSettlementRepository
+	businessRepo   Domain.BusinessRepository
+}
+
+func NewReconciliationUseCase(
+	reportRepo Domain.ReportRepository,
+	settlementRepo Domain.PaymentSettlementRepository,
+	businessRepo Domain.BusinessRepository,
+) ReconciliationUseCase {
+	return &reconciliationUseCase{
+		reportRepo:     reportRepo,
+		settlementRepo: settlementRepo,
+		businessRepo:   businessRepo,
+	}
+}
+
+func (uc *reconciliationUseCase) GetPaymentReconciliation(businessID string, req Domain.PaymentReconciliationRequest) (*Domain.PaymentReconciliationReport, error) {
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := resolveDayPeriod(req.StartDate, req.EndDate, defaultReconciliationDays, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.reportRepo.GetPaymentTotals(businessID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate payment totals: %w", err)
+	}
+	settlements, err := uc.settlementRepo.FindRange(businessID,
+		period.StartDate.Format("2006-01-02"), period.EndDate.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]map[Domain.PaymentMethod]*Domain.PaymentReconciliationLine)
+	line := func(date string, method Domain.PaymentMethod) *Domain.PaymentReconciliationLine {
+		if days[date] == nil {
+			days[date] = make(map[Domain.PaymentMethod]*Domain.PaymentReconciliationLine)
+		}
+		if l, ok := days[date][method]; ok {
+			return l
+		}
+		l := &Domain.PaymentReconciliationLine{
+			PaymentMethod: method,
+			Statuses:      []Domain.PaymentStatusTotal{},
+		}
+		days[date][method] = l
+		return l
+	}
+
+	for _, total := range totals {
+		l := line(total.Date, total.PaymentMethod)
+		l.Transactions += total.Transactions
+		l.Amount += total.Amount
+		// Only paid sales should have reached the till or the account
+		if total.PaymentStatus == Domain.PaymentStatusPaid {
+			l.Expected += total.Amount
+		}
+		l.Statuses = append(l.Statuses, Domain.PaymentStatusTotal{
+			PaymentStatus: total.PaymentStatus,
+			Transactions:  total.Transactions,
+			Amount:        total.Amount,
+		})
+	}
+	for i := range settlements {
+		settlement := &settlements[i]
+		l := line(settlement.Date, settlement.PaymentMethod)
+		l.Settlement = settlement
+		actual := settlement.Actual
+		l.Actual = &actual
+	}
+
+	report := &Domain.PaymentReconciliationReport{
+		Period:   period,
+		Timezone: loc.String(),
+		Methods:  []Domain.PaymentMethodSummary{},
+		Days:     make([]Domain.PaymentReconciliationDay, 0, len(days)),
+	}
+	summaries := make(map[Domain.PaymentMethod]*Domain.PaymentMethodSummary)
+
+	for date, methods := range days {
+		day := Domain.PaymentReconciliationDay{
+			Date:    date,
+			Methods: make([]Domain.PaymentReconciliationLine, 0, len(methods)),
+		}
+
+		for method, l := range methods {
+			// Variances are against the takings as they stand now, so a sale
+			// voided or edited after the settlement was entered shows up
+			if l.Actual != nil {
+				variance := *l.Actual - l.Expected
+				l.Variance = &variance
+			}
+			l.Status = reconciliationStatus(l)
+
+			summary, ok := summaries[method]
+			if !ok {
+				summary = &Domain.PaymentMethodSummary{
+					PaymentMethod: method,
+					Statuses:      []Domain.PaymentStatusTotal{},
+				}
+				summaries[method] = summary
+			}
+			summary.Transactions += l.Transactions
+			summary.Amount += l.Amount
+			summary.Expected += l.Expected
+			summary.Statuses = mergeStatusTotals(summary.Statuses, l.Statuses)
+			if l.Actual != nil {
+				summary.Actual += *l.Actual
+				summary.Variance += *l.Variance
+			}
+
+			if Domain.IsSettledPaymentMethod(method) {
+				report.Expected += l.Expected
+			}
+			if l.Actual != nil {
+				report.Actual += *l.Actual
+				report.Variance += *l.Variance
+			}
+			switch l.Status {
+			case Domain.ReconciliationUnreconciled:
+				report.Unreconciled++
+			case Domain.ReconciliationDiscrepancy:
+				report.Discrepancies++
+			}
+
+			day.Methods = append(day.Methods, *l)
+		}
+
+		sort.Slice(day.Methods, func(i, j int) bool {
+			return paymentMethodBefore(day.Methods[i].PaymentMethod, day.Methods[j].PaymentMethod)
+		})
+		report.Days = append(report.Days, day)
+	}
+
+	sort.Slice(report.Days, func(i, j int) bool {
+		return report.Days[i].Date < report.Days[j].Date
+	})
+
+	for _, summary := range summaries {
+		report.Methods = append(report.Methods, *summary)
+	}
+	sort.Slice(report.Methods, func(i, j int) bool {
+		return paymentMethodBefore(report.Methods[i].PaymentMethod, report.Methods[j].PaymentMethod)
+	})
+
+	return report, nil
+}
+
+func (uc *reconciliationUseCase) RecordSettlement(businessID, userID string, req Domain.RecordSettlementRequest) (*Domain.PaymentSettlement, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+	if business.UserID.Hex() != userID {
+		return nil, fmt.Errorf("access denied: only the business owner can record settlements")
+	}
+
+	objUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if !Domain.IsSettledPaymentMethod(req.PaymentMethod) {
+		return nil, fmt.Errorf("payment method must be cash, card, mobile or bank")
+	}
+	if req.Actual == nil {
+		return nil, fmt.Errorf("actual amount is required")
+	}
+	if *req.Actual < 0 {
+		return nil, fmt.Errorf("actual amount cannot be negative")
+	}
+
+	loc := business.Location()
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("date must be YYYY-MM-DD")
+	}
+	dayStart := Domain.DateIn(date, loc)
+	if dayStart.After(time.Now().In(loc)) {
+		return nil, fmt.Errorf("cannot record a settlement for a future date")
+	}
+
+	totals, err := uc.reportRepo.GetPaymentTotals(businessID, dayStart, Domain.EndOfDay(dayStart))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate payment totals: %w", err)
+	}
+
+	expected := 0.0
+	for _, total := range totals {
+		if total.PaymentMethod == req.PaymentMethod && total.PaymentStatus == Domain.PaymentStatusPaid {
+			expected += total.Amount
+		}
+	}
+
+	settlement := &Domain.PaymentSettlement{
+		BusinessID:    business.ID,
+		Date:          req.Date,
+		PaymentMethod: req.PaymentMethod,
+		Expected:      expected,
+		Actual:        *req.Actual,
+		Variance:      *req.Actual - expected,
+		Status:        Domain.ReconciliationStatusOf(expected, req.Actual),
+		Reference:     req.Reference,
+		Notes:         req.Notes,
+		RecordedBy:    objUserID,
+	}
+
+	if err := uc.settlementRepo.Save(settlement); err != nil {
+		return nil, err
+	}
+
+	return settlement, nil
+}
+
+// reconciliationStatus works out where a day's method stands. Credit and
+// other methods are never settled, and a method with no paid takings and no
+// settlement has nothing to reconcile.
+func reconciliationStatus(line *Domain.PaymentReconciliationLine) Domain.ReconciliationStatus {
+	if !Domain.IsSettledPaymentMethod(line.PaymentMethod) {
+		return Domain.ReconciliationNotRequired
+	}
+	if line.Actual == nil && line.Expected < Domain.ReconciliationTolerance {
+		return Domain.ReconciliationNotRequired
+	}
+	return Domain.ReconciliationStatusOf(line.Expected, line.Actual)
+}
+
+func mergeStatusTotals(into, from []Domain.PaymentStatusTotal) []Domain.PaymentStatusTotal {
+	for _, total := range from {
+		merged := false
+		for i := range into {
+			if into[i].PaymentStatus == total.PaymentStatus {
+				into[i].Transactions += total.Transactions
+				into[i].Amount += total.Amount
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			into = append(into, total)
+		}
+	}
+	return into
+}
+
+// paymentMethodBefore orders the settled methods first, in the order they
+// are listed, and the rest by name.
+func paymentMethodBefore(a, b Domain.PaymentMethod) bool {
+	rank := func(method Domain.PaymentMethod) int {
+		for i, settled := range Domain.SettledPaymentMethods {
+			if method == settled {
+				return i
+			}
+		}
+		return len(Domain.SettledPaymentMethods)
+	}
+	if rank(a) != rank(b) {
+		return rank(a) < rank(b)
+	}
+	return a < b
+}
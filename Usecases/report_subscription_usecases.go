@@ -0,0 +1,503 @@
+package Usecases
+
+import (
+	"fmt"
+	"time"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+)
+
+type ReportSubscriptionUseCase interface {
+	CreateSubscription(businessID, userID string, req Domain.CreateReportSubscriptionRequest) (*Domain.ReportSubscription, error)
+	GetSubscriptions(businessID, userID string) ([]Domain.ReportSubscription, error)
+	UpdateSubscription(id, businessID, userID string, req Domain.UpdateReportSubscriptionRequest) (*Domain.ReportSubscription, error)
+	DeleteSubscription(id, businessID, userID string) error
+	// SendNow sends a subscription's report for the last full period straight
+	// away, outside its schedule.
+	SendNow(id, businessID, userID string) (*Domain.ReportDelivery, error)
+	GetDeliveries(businessID, userID string, filters Domain.ReportDeliveryFilters) ([]Domain.ReportDelivery, error)
+	// RetryDelivery makes one more attempt at a failed delivery.
+	RetryDelivery(id, businessID, userID string) (*Domain.ReportDelivery, error)
+	// DeliverDueReports queues a delivery for every subscription whose time
+	// has come and attempts every delivery that is due, retrying failures
+	// with a growing delay. Run it on a schedule.
+	DeliverDueReports() error
+}
+
+// dueReportsPerRun caps the subscriptions and deliveries handled by one run
+// of DeliverDueReports; the rest wait for the next run.
+const dueReportsPerRun = 50
+
+// reportDeliveryLease is how long an attempt holds a delivery before another
+// run may take it over.
+const reportDeliveryLease = 10 * time.Minute
+
+// reportRetryDelay is the wait before the next attempt after the given number
+// of failed attempts: 5, 15, then 45 minutes.
+func reportRetryDelay(attempts int) time.Duration {
+	delay := 5 * time.Minute
+	for i := 1; i < attempts; i++ {
+		delay *= 3
+	}
+	return delay
+}
+
+var reportTitles = map[Domain.ReportType]string{
+	Domain.ReportTypeSales:     "sales",
+	Domain.ReportTypeExpenses:  "expenses",
+	Domain.ReportTypeProfit:    "profit and loss",
+	Domain.ReportTypeInventory: "inventory",
+}
+
+var reportAttachmentTypes = map[string]string{
+	"pdf":  "application/pdf",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"csv":  "text/csv",
+}
+
+type reportSubscriptionUseCase struct {
+	reportUC         ReportUseCase
+	subscriptionRepo Domain.ReportSubscriptionRepository
+	deliveryRepo     Domain.ReportDeliveryRepository
+	businessRepo     Domain.BusinessRepository
+	userRepo         Domain.UserRepository
+	mailer           Infrastructure.Mailer
+}
+
+func NewReportSubscriptionUseCase(
+	reportUC ReportUseCase,
+	subscriptionRepo Domain.ReportSubscriptionRepository,
+	deliveryRepo Domain.ReportDeliveryRepository,
+	businessRepo Domain.BusinessRepository,
+	userRepo Domain.UserRepository,
+	mailer Infrastructure.Mailer,
+) ReportSubscriptionUseCase {
+	return &reportSubscriptionUseCase{
+		reportUC:         reportUC,
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		businessRepo:     businessRepo,
+		userRepo:         userRepo,
+		mailer:           mailer,
+	}
+}
+
+func (uc *reportSubscriptionUseCase) CreateSubscription(businessID, userID string, req Domain.CreateReportSubscriptionRequest) (*Domain.ReportSubscription, error) {
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	objBusinessID, err := Domain.PrimitiveObjectIDFromHex(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business ID: %w", err)
+	}
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	email := req.Email
+	if email == "" {
+		user, err := uc.userRepo.FindByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		if user == nil {
+			return nil, fmt.Errorf("user not found")
+		}
+		email = user.Email
+	}
+	if email == "" {
+		return nil, fmt.Errorf("an email address is required: add one to your profile or the subscription")
+	}
+
+	subscription := &Domain.ReportSubscription{
+		BusinessID: objBusinessID,
+		UserID:     objUserID,
+		Email:      email,
+		ReportType: req.ReportType,
+		Period:     req.Period,
+		Format:     req.Format,
+		Schedule:   req.Schedule,
+		Weekday:    int(time.Monday),
+		DayOfMonth: 1,
+		Hour:       7,
+		Active:     true,
+	}
+	if subscription.Period == "" {
+		subscription.Period = Domain.PeriodType(req.Schedule)
+	}
+	if subscription.Format == "" {
+		subscription.Format = "pdf"
+	}
+	if req.Weekday != nil {
+		subscription.Weekday = *req.Weekday
+	}
+	if req.DayOfMonth != nil {
+		subscription.DayOfMonth = *req.DayOfMonth
+	}
+	if req.Hour != nil {
+		subscription.Hour = *req.Hour
+	}
+
+	if err := validateReportSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	subscription.NextRunAt = subscription.NextRun(time.Now(), loc)
+
+	if err := uc.subscriptionRepo.Create(subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (uc *reportSubscriptionUseCase) GetSubscriptions(businessID, userID string) ([]Domain.ReportSubscription, error) {
+	subscriptions, err := uc.subscriptionRepo.FindByUserID(businessID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if subscriptions == nil {
+		subscriptions = []Domain.ReportSubscription{}
+	}
+	return subscriptions, nil
+}
+
+func (uc *reportSubscriptionUseCase) UpdateSubscription(id, businessID, userID string, req Domain.UpdateReportSubscriptionRequest) (*Domain.ReportSubscription, error) {
+	subscription, err := uc.ownSubscription(id, businessID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Email != nil {
+		subscription.Email = *req.Email
+	}
+	if req.ReportType != nil {
+		subscription.ReportType = *req.ReportType
+	}
+	if req.Period != nil {
+		subscription.Period = *req.Period
+	}
+	if req.Format != nil {
+		subscription.Format = *req.Format
+	}
+	if req.Schedule != nil {
+		subscription.Schedule = *req.Schedule
+	}
+	if req.Weekday != nil {
+		subscription.Weekday = *req.Weekday
+	}
+	if req.DayOfMonth != nil {
+		subscription.DayOfMonth = *req.DayOfMonth
+	}
+	if req.Hour != nil {
+		subscription.Hour = *req.Hour
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if subscription.Email == "" {
+		return nil, fmt.Errorf("an email address is required")
+	}
+	if err := validateReportSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	// The schedule may have changed, and a paused subscription does not catch
+	// up on the runs it missed
+	subscription.NextRunAt = subscription.NextRun(time.Now(), loc)
+
+	if err := uc.subscriptionRepo.Update(subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (uc *reportSubscriptionUseCase) DeleteSubscription(id, businessID, userID string) error {
+	if _, err := uc.ownSubscription(id, businessID, userID); err != nil {
+		return err
+	}
+
+	// Deliveries stay as history
+	return uc.subscriptionRepo.Delete(id)
+}
+
+func (uc *reportSubscriptionUseCase) SendNow(id, businessID, userID string) (*Domain.ReportDelivery, error) {
+	subscription, err := uc.ownSubscription(id, businessID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	delivery, err := uc.queueDelivery(subscription, now, now.Add(reportDeliveryLease), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.attempt(delivery)
+	return delivery, nil
+}
+
+func (uc *reportSubscriptionUseCase) GetDeliveries(businessID, userID string, filters Domain.ReportDeliveryFilters) ([]Domain.ReportDelivery, error) {
+	if filters.Status != nil &&
+		*filters.Status != Domain.ReportDeliveryPending &&
+		*filters.Status != Domain.ReportDeliverySent &&
+		*filters.Status != Domain.ReportDeliveryFailed {
+		return nil, fmt.Errorf("invalid status: %s", *filters.Status)
+	}
+	if filters.Limit <= 0 || filters.Limit > 200 {
+		filters.Limit = 50
+	}
+
+	deliveries, err := uc.deliveryRepo.FindByUserID(businessID, userID, filters)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []Domain.ReportDelivery{}
+	}
+	return deliveries, nil
+}
+
+func (uc *reportSubscriptionUseCase) RetryDelivery(id, businessID, userID string) (*Domain.ReportDelivery, error) {
+	delivery, err := uc.deliveryRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, fmt.Errorf("report delivery not found")
+	}
+	if delivery.BusinessID.Hex() != businessID || delivery.UserID.Hex() != userID {
+		return nil, fmt.Errorf("access denied: report delivery belongs to another user")
+	}
+	if delivery.Status != Domain.ReportDeliveryFailed {
+		return nil, fmt.Errorf("only failed deliveries can be retried")
+	}
+
+	uc.attempt(delivery)
+	return delivery, nil
+}
+
+func (uc *reportSubscriptionUseCase) DeliverDueReports() error {
+	now := time.Now()
+
+	subscriptions, err := uc.subscriptionRepo.FindDue(now, dueReportsPerRun)
+	if err != nil {
+		return err
+	}
+
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+
+		loc, err := businessLocation(uc.businessRepo, subscription.BusinessID.Hex())
+		if err != nil {
+			fmt.Printf("Failed to schedule report subscription %s: %v\n", subscription.ID.Hex(), err)
+			continue
+		}
+
+		// Claim the run first so two servers never send it twice. Runs missed
+		// while the server was down are skipped rather than sent in a burst.
+		runAt := subscription.NextRunAt
+		claimed, err := uc.subscriptionRepo.AdvanceRun(subscription.ID.Hex(), runAt, subscription.NextRun(now, loc))
+		if err != nil || !claimed {
+			continue
+		}
+
+		if _, err := uc.queueDelivery(subscription, runAt, now, loc); err != nil {
+			fmt.Printf("Failed to queue report delivery: %v\n", err)
+		}
+	}
+
+	deliveries, err := uc.deliveryRepo.FindDue(now, dueReportsPerRun)
+	if err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		claimed, err := uc.deliveryRepo.Claim(delivery.ID.Hex(), *delivery.NextAttemptAt, now.Add(reportDeliveryLease))
+		if err != nil || !claimed {
+			continue
+		}
+
+		uc.attempt(delivery)
+	}
+
+	return nil
+}
+
+// queueDelivery records a pending delivery of the report a subscription sends
+// at runAt, to be attempted from attemptAt.
+func (uc *reportSubscriptionUseCase) queueDelivery(subscription *Domain.ReportSubscription, runAt, attemptAt time.Time, loc *time.Location) (*Domain.ReportDelivery, error) {
+	start, end := subscription.CoveredPeriod(runAt, loc)
+
+	delivery := &Domain.ReportDelivery{
+		SubscriptionID: subscription.ID,
+		BusinessID:     subscription.BusinessID,
+		UserID:         subscription.UserID,
+		Email:          subscription.Email,
+		ReportType:     subscription.ReportType,
+		Format:         subscription.Format,
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		Status:         Domain.ReportDeliveryPending,
+		NextAttemptAt:  &attemptAt,
+	}
+
+	if err := uc.deliveryRepo.Create(delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// attempt generates and emails a delivery's report and records the outcome,
+// scheduling another attempt after a failure until the attempts run out.
+func (uc *reportSubscriptionUseCase) attempt(delivery *Domain.ReportDelivery) {
+	delivery.Attempts++
+
+	filename, err := uc.send(delivery)
+	now := time.Now()
+	if err == nil {
+		delivery.Status = Domain.ReportDeliverySent
+		delivery.Filename = filename
+		delivery.SentAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= Domain.MaxReportDeliveryAttempts {
+			delivery.Status = Domain.ReportDeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			next := now.Add(reportRetryDelay(delivery.Attempts))
+			delivery.Status = Domain.ReportDeliveryPending
+			delivery.NextAttemptAt = &next
+		}
+	}
+
+	if err := uc.deliveryRepo.Update(delivery); err != nil {
+		fmt.Printf("Failed to record report delivery: %v\n", err)
+	}
+}
+
+func (uc *reportSubscriptionUseCase) send(delivery *Domain.ReportDelivery) (string, error) {
+	business, err := uc.businessRepo.FindByID(delivery.BusinessID.Hex())
+	if err != nil {
+		return "", fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return "", fmt.Errorf("business not found")
+	}
+	loc := business.Location()
+
+	// The period's dates are read as calendar days in the business's timezone
+	start, end := delivery.PeriodStart.In(loc), delivery.PeriodEnd.In(loc)
+	format := delivery.Format
+
+	data, filename, err := uc.reportUC.ExportReport(Domain.ReportRequest{
+		BusinessID: business.ID.Hex(),
+		Type:       delivery.ReportType,
+		Period:     Domain.PeriodTypeCustom,
+		StartDate:  &start,
+		EndDate:    &end,
+		Format:     &format,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	covering := "for " + start.Format("2 Jan 2006")
+	if !end.Equal(start) {
+		covering += " to " + end.Format("2 Jan 2006")
+	}
+	if delivery.ReportType == Domain.ReportTypeInventory {
+		// Inventory reports show stock as it stands
+		covering = "as of " + time.Now().In(loc).Format("2 Jan 2006")
+	}
+	report := reportTitles[delivery.ReportType]
+
+	err = uc.mailer.Send(Infrastructure.MailMessage{
+		To:      []string{delivery.Email},
+		Subject: fmt.Sprintf("%s: %s report %s", business.Name, report, covering),
+		Body: fmt.Sprintf("Attached is the %s report of %s %s.\n\n"+
+			"You receive this email because of a report subscription in ShopOps. "+
+			"Change or cancel it under Reports > Subscriptions.\n", report, business.Name, covering),
+		Attachments: []Infrastructure.MailAttachment{{
+			Filename:    filename,
+			ContentType: reportAttachmentTypes[format],
+			Data:        data,
+		}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return filename, nil
+}
+
+// ownSubscription finds a subscription the user holds in the business.
+func (uc *reportSubscriptionUseCase) ownSubscription(id, businessID, userID string) (*Domain.ReportSubscription, error) {
+	subscription, err := uc.subscriptionRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, fmt.Errorf("report subscription not found")
+	}
+	if subscription.BusinessID.Hex() != businessID || subscription.UserID.Hex() != userID {
+		return nil, fmt.Errorf("access denied: report subscription belongs to another user")
+	}
+	return subscription, nil
+}
+
+func validateReportSubscription(subscription *Domain.ReportSubscription) error {
+	if _, ok := reportTitles[subscription.ReportType]; !ok {
+		return fmt.Errorf("report type must be sales, expenses, profit or inventory")
+	}
+
+	switch subscription.Period {
+	case Domain.PeriodTypeDaily, Domain.PeriodTypeWeekly, Domain.PeriodTypeMonthly, Domain.PeriodTypeYearly:
+	default:
+		return fmt.Errorf("period must be daily, weekly, monthly or yearly")
+	}
+
+	if _, ok := reportAttachmentTypes[subscription.Format]; !ok {
+		return fmt.Errorf("format must be pdf, xlsx or csv")
+	}
+
+	switch subscription.Schedule {
+	case Domain.ReportScheduleDaily, Domain.ReportScheduleWeekly, Domain.ReportScheduleMonthly:
+	default:
+		return fmt.Errorf("schedule must be daily, weekly or monthly")
+	}
+
+	if subscription.Weekday < int(time.Sunday) || subscription.Weekday > int(time.Saturday) {
+		return fmt.Errorf("weekday must be between 0 (Sunday) and 6 (Saturday)")
+	}
+	// Every month has days 1 to 28
+	if subscription.DayOfMonth < 1 || subscription.DayOfMonth > 28 {
+		return fmt.Errorf("day of month must be between 1 and 28")
+	}
+	if subscription.Hour < 0 || subscription.Hour > 23 {
+		return fmt.Errorf("hour must be between 0 and 23")
+	}
+
+	return nil
+}
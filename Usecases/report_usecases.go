@@ -2,10 +2,14 @@ package Usecases
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	Domain "ShopOps/Domain"
 	Infrastructure "ShopOps/Infrastructure"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ReportUseCase interface {
@@ -14,36 +18,59 @@ type ReportUseCase interface {
 	ExportReport(req Domain.ReportRequest) ([]byte, string, error)
 	GetProfitSummary(businessID string, period Domain.PeriodType, startDate, endDate *time.Time) (*Domain.ProfitReport, error)
 	GetProfitTrends(businessID string, period Domain.PeriodType, weeks int) ([]Domain.ProfitTrend, error)
-	ComparePeriods(businessID string, period1, period2 Domain.ReportRequest) (interface{}, error)
+	// ComparePeriods sets a period's figures against an earlier period's, with
+	// the categories and products behind the change in sales.
+	ComparePeriods(businessID string, req Domain.PeriodComparisonRequest) (*Domain.PeriodComparison, error)
+	GetAgingReport(businessID string, req Domain.AgingReportRequest) (*Domain.AgingReport, error)
+	ExportAgingReport(businessID string, req Domain.AgingReportRequest, format string) ([]byte, string, error)
+	GetSalesHeatmap(businessID string, req Domain.SalesHeatmapRequest) (*Domain.SalesHeatmap, error)
+	ExportSalesHeatmap(businessID string, req Domain.SalesHeatmapRequest, format string) ([]byte, string, error)
+	GetABCAnalysis(businessID string, req Domain.ABCReportRequest) (*Domain.ABCReport, error)
+	ExportABCAnalysis(businessID string, req Domain.ABCReportRequest, format string) ([]byte, string, error)
 }
 
 type reportUseCase struct {
-	reportRepo    Domain.ReportRepository
-	businessRepo  Domain.BusinessRepository
-	exportService Infrastructure.ExportService
+	reportRepo     Domain.ReportRepository
+	businessRepo   Domain.BusinessRepository
+	inventoryRepo  Domain.ProductRepository
+	categoryRepo   Domain.CategoryRepository
+	salesRepo      Domain.SaleRepository
+	expenseRepo    Domain.ExpenseRepository
+	dailyStatsRepo Domain.DailyStatsRepository
+	exportService  Infrastructure.ExportService
 }
 
 func NewReportUseCase(
 	reportRepo Domain.ReportRepository,
 	businessRepo Domain.BusinessRepository,
+	inventoryRepo Domain.ProductRepository,
+	categoryRepo Domain.CategoryRepository,
+	salesRepo Domain.SaleRepository,
+	expenseRepo Domain.ExpenseRepository,
+	dailyStatsRepo Domain.DailyStatsRepository,
 	exportService Infrastructure.ExportService,
 ) ReportUseCase {
 	return &reportUseCase{
-		reportRepo:    reportRepo,
-		businessRepo:  businessRepo,
-		exportService: exportService,
+		reportRepo:     reportRepo,
+		businessRepo:   businessRepo,
+		inventoryRepo:  inventoryRepo,
+		categoryRepo:   categoryRepo,
+		salesRepo:      salesRepo,
+		expenseRepo:    expenseRepo,
+		dailyStatsRepo: dailyStatsRepo,
+		exportService:  exportService,
 	}
 }
 
 func (uc *reportUseCase) GenerateReport(req Domain.ReportRequest) (interface{}, error) {
 	// Validate business exists
-	_, err := uc.businessRepo.FindByID(req.BusinessID)
+	loc, err := businessLocation(uc.businessRepo, req.BusinessID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find business: %w", err)
+		return nil, err
 	}
 
 	// Set default dates based on period
-	startDate, endDate := uc.getDateRange(req.Period, req.StartDate, req.EndDate)
+	startDate, endDate := uc.getDateRange(req.Period, req.StartDate, req.EndDate, loc)
 
 	switch req.Type {
 	case Domain.ReportTypeSales:
@@ -53,7 +80,9 @@ func (uc *reportUseCase) GenerateReport(req Domain.ReportRequest) (interface{},
 	case Domain.ReportTypeProfit:
 		return uc.reportRepo.GenerateProfitReport(req.BusinessID, startDate, endDate)
 	case Domain.ReportTypeInventory:
-		return uc.reportRepo.GenerateInventoryReport(req.BusinessID)
+		return uc.reportRepo.GenerateInventoryReport(req.BusinessID, req.LocationID)
+	case Domain.ReportTypeAging:
+		return uc.GetAgingReport(req.BusinessID, Domain.AgingReportRequest{})
 	default:
 		return nil, fmt.Errorf("invalid report type: %s", req.Type)
 	}
@@ -61,12 +90,12 @@ func (uc *reportUseCase) GenerateReport(req Domain.ReportRequest) (interface{},
 
 func (uc *reportUseCase) GetDashboardData(businessID string) (*Domain.DashboardData, error) {
 	// Validate business exists
-	_, err := uc.businessRepo.FindByID(businessID)
+	loc, err := businessLocation(uc.businessRepo, businessID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find business: %w", err)
+		return nil, err
 	}
 
-	return uc.reportRepo.GetDashboardData(businessID)
+	return uc.reportRepo.GetDashboardData(businessID, time.Now().In(loc))
 }
 
 func (uc *reportUseCase) ExportReport(req Domain.ReportRequest) ([]byte, string, error) {
@@ -79,7 +108,38 @@ func (uc *reportUseCase) ExportReport(req Domain.ReportRequest) ([]byte, string,
 	var data []byte
 	var filename string
 
-	if req.Format != nil && *req.Format == "csv" {
+	if req.Format != nil && *req.Format == "xlsx" {
+		business, err := uc.businessRepo.FindByID(req.BusinessID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find business: %w", err)
+		}
+
+		records, err := uc.reportRecords(req, business)
+		if err != nil {
+			return nil, "", err
+		}
+
+		data, err = uc.exportService.ExportReportToXLSX(report, req.Type, business, records)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to export to XLSX: %w", err)
+		}
+		filename = fmt.Sprintf("%s_%s.xlsx",
+			string(req.Type),
+			time.Now().Format("20060102_150405"))
+	} else if req.Format != nil && *req.Format == "pdf" {
+		business, err := uc.businessRepo.FindByID(req.BusinessID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find business: %w", err)
+		}
+
+		data, err = uc.exportService.ExportToPDF(report, req.Type, business)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to export to PDF: %w", err)
+		}
+		filename = fmt.Sprintf("%s_%s.pdf",
+			string(req.Type),
+			time.Now().Format("20060102_150405"))
+	} else if req.Format != nil && *req.Format == "csv" {
 		// Export to CSV
 		data, err = uc.exportService.ExportToCSV(report, req.Type)
 		if err != nil {
@@ -100,14 +160,106 @@ func (uc *reportUseCase) ExportReport(req Domain.ReportRequest) ([]byte, string,
 	return data, filename, nil
 }
 
+// exportReport renders a report as CSV or, by default, JSON, and names the
+// file after the report type and the time of export.
+func exportReport(exportService Infrastructure.ExportService, report interface{}, reportType Domain.ReportType, format string) ([]byte, string, error) {
+	timestamp := time.Now()
+
+	if format == "csv" {
+		data, err := exportService.ExportToCSV(report, reportType)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to export to CSV: %w", err)
+		}
+		return data, Infrastructure.GenerateFilename(reportType, timestamp), nil
+	}
+
+	data, err := exportService.ExportToJSON(report)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to export to JSON: %w", err)
+	}
+
+	return data, fmt.Sprintf("%s_%s.json",
+		string(reportType),
+		timestamp.Format("20060102_150405")), nil
+}
+
+// reportRecords loads the completed sales and active expenses of a report's
+// period, oldest first, for the transaction sheets of its workbook.
+func (uc *reportUseCase) reportRecords(req Domain.ReportRequest, business *Domain.Business) (Infrastructure.ReportRecords, error) {
+	records := Infrastructure.ReportRecords{ProductNames: make(map[string]string)}
+
+	var withSales, withExpenses bool
+	switch req.Type {
+	case Domain.ReportTypeSales:
+		withSales = true
+	case Domain.ReportTypeExpenses:
+		withExpenses = true
+	case Domain.ReportTypeProfit:
+		withSales, withExpenses = true, true
+	default:
+		return records, nil
+	}
+
+	startDate, endDate := uc.getDateRange(req.Period, req.StartDate, req.EndDate, business.Location())
+
+	if withSales {
+		status := Domain.SaleStatusCompleted
+		sales, err := uc.salesRepo.FindByBusinessID(req.BusinessID, Domain.SaleFilters{
+			StartDate: &startDate,
+			EndDate:   &endDate,
+			Status:    &status,
+		})
+		if err != nil {
+			return records, fmt.Errorf("failed to get sales: %w", err)
+		}
+		sort.SliceStable(sales, func(i, j int) bool {
+			return sales[i].CreatedAt.Before(sales[j].CreatedAt)
+		})
+		records.Sales = sales
+
+		products, err := uc.inventoryRepo.FindByBusinessID(req.BusinessID, Domain.ProductFilters{})
+		if err != nil {
+			return records, fmt.Errorf("failed to get products: %w", err)
+		}
+		for _, product := range products {
+			records.ProductNames[product.ID.Hex()] = product.Name
+		}
+	}
+
+	if withExpenses {
+		status := Domain.ExpenseStatusActive
+		expenses, err := uc.expenseRepo.FindByBusinessID(req.BusinessID, Domain.ExpenseFilters{
+			StartDate: &startDate,
+			EndDate:   &endDate,
+			Status:    &status,
+		})
+		if err != nil {
+			return records, fmt.Errorf("failed to get expenses: %w", err)
+		}
+		sort.SliceStable(expenses, func(i, j int) bool {
+			return expenses[i].Date.Before(expenses[j].Date)
+		})
+		records.Expenses = expenses
+	}
+
+	return records, nil
+}
+
 func (uc *reportUseCase) GetProfitSummary(businessID string, period Domain.PeriodType, startDate, endDate *time.Time) (*Domain.ProfitReport, error) {
-	if startDate == nil || endDate == nil {
-		startDateVal, endDateVal := uc.getDateRange(period, nil, nil)
-		startDate = &startDateVal
-		endDate = &endDateVal
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
 	}
 
-	return uc.reportRepo.GenerateProfitReport(businessID, *startDate, *endDate)
+	startDateVal, endDateVal := uc.getDateRange(period, startDate, endDate, loc)
+
+	// The summary covers the whole days of the period, read from the rollups
+	total, err := sumDailyStats(uc.dailyStatsRepo, businessID, startDateVal, endDateVal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profit summary: %w", err)
+	}
+
+	return profitFromDailyStats(total, startDateVal, endDateVal), nil
 }
 
 func (uc *reportUseCase) GetProfitTrends(businessID string, period Domain.PeriodType, weeks int) ([]Domain.ProfitTrend, error) {
@@ -115,34 +267,44 @@ func (uc *reportUseCase) GetProfitTrends(businessID string, period Domain.Period
 		weeks = 12 // Default to 12 weeks
 	}
 
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
 	var trends []Domain.ProfitTrend
-	now := time.Now()
+	now := time.Now().In(loc)
 
 	for i := 0; i < weeks; i++ {
-		endDate := now.AddDate(0, 0, -i*7)
+		// Each period ends with a whole day in the business's timezone
+		endDate := now
+		if i > 0 {
+			endDate = Domain.EndOfDay(Domain.AddDays(now, -i*7))
+		}
 		var startDate time.Time
 
 		switch period {
 		case Domain.PeriodTypeWeekly:
-			startDate = endDate.AddDate(0, 0, -7)
+			startDate = Domain.LastDays(endDate, 7)
 		case Domain.PeriodTypeMonthly:
-			startDate = endDate.AddDate(0, 0, -30)
+			startDate = Domain.LastDays(endDate, 30)
 		default:
-			startDate = endDate.AddDate(0, 0, -7) // Default to weekly
+			startDate = Domain.LastDays(endDate, 7) // Default to weekly
 		}
 
-		report, err := uc.reportRepo.GenerateProfitReport(businessID, startDate, endDate)
+		total, err := sumDailyStats(uc.dailyStatsRepo, businessID, startDate, endDate)
 		if err != nil {
 			// Skip this period if there's an error
 			continue
 		}
+		report := profitFromDailyStats(total, startDate, endDate)
 
 		periodLabel := uc.getPeriodLabel(startDate, endDate, period)
 
 		trends = append(trends, Domain.ProfitTrend{
 			Period:   periodLabel,
 			Sales:    report.TotalSales,
-			Expenses: report.TotalExpenses,
+			Expenses: report.CostOfGoodsSold + report.OperatingExpenses,
 			Profit:   report.NetProfit,
 		})
 	}
@@ -150,61 +312,904 @@ func (uc *reportUseCase) GetProfitTrends(businessID string, period Domain.Period
 	return trends, nil
 }
 
-func (uc *reportUseCase) ComparePeriods(businessID string, period1, period2 Domain.ReportRequest) (interface{}, error) {
-	// Generate reports for both periods
-	report1, err := uc.GenerateReport(period1)
+func (uc *reportUseCase) ComparePeriods(businessID string, req Domain.PeriodComparisonRequest) (*Domain.PeriodComparison, error) {
+	loc, err := businessLocation(uc.businessRepo, businessID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate report for period 1: %w", err)
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = Domain.DefaultComparisonLimit
 	}
 
-	report2, err := uc.GenerateReport(period2)
+	comparison, err := resolveComparisonPeriods(req, time.Now().In(loc))
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate report for period 2: %w", err)
+		return nil, err
 	}
 
-	// Create comparison result
-	comparison := map[string]interface{}{
-		"period1": report1,
-		"period2": report2,
+	current, err := uc.periodFigures(businessID, comparison.Current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate report for current period: %w", err)
 	}
+	previous, err := uc.periodFigures(businessID, comparison.Previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate report for previous period: %w", err)
+	}
+
+	comparison.Sales = Domain.NewMetricChange(current.sales.TotalAmount, previous.sales.TotalAmount)
+	comparison.Expenses = Domain.NewMetricChange(
+		current.profit.CostOfGoodsSold+current.profit.OperatingExpenses,
+		previous.profit.CostOfGoodsSold+previous.profit.OperatingExpenses,
+	)
+	comparison.Profit = Domain.NewMetricChange(current.profit.NetProfit, previous.profit.NetProfit)
+	comparison.Transactions = Domain.NewMetricChange(
+		float64(current.sales.TotalTransactions),
+		float64(previous.sales.TotalTransactions),
+	)
+	comparison.AverageBasket = Domain.NewMetricChange(current.sales.AverageSale, previous.sales.AverageSale)
+
+	totalChange := comparison.Sales.Change
+	comparison.Categories = compareCategories(current.sales.Categories, previous.sales.Categories, totalChange)
+	comparison.Products = compareProducts(current.products, previous.products, totalChange, limit)
 
 	return comparison, nil
 }
 
-func (uc *reportUseCase) getDateRange(period Domain.PeriodType, customStart, customEnd *time.Time) (time.Time, time.Time) {
+// periodFigures holds the reports a comparison draws on for one period.
+type periodFigures struct {
+	sales    *Domain.SalesReport
+	profit   *Domain.ProfitReport
+	products []Domain.TopProduct
+}
+
+func (uc *reportUseCase) periodFigures(businessID string, period Domain.ComparisonPeriod) (*periodFigures, error) {
+	sales, err := uc.reportRepo.GenerateSalesReport(businessID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	profit, err := uc.reportRepo.GenerateProfitReport(businessID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	products, err := uc.reportRepo.GetProductSales(businessID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &periodFigures{sales: sales, profit: profit, products: products}, nil
+}
+
+// resolveComparisonPeriods works out the two periods of a comparison, with day
+// boundaries in now's location. Presets that run to date compare against the
+// same stretch of the earlier period, so a Wednesday morning is set against
+// last Monday to last Wednesday morning.
+func resolveComparisonPeriods(req Domain.PeriodComparisonRequest, now time.Time) (*Domain.PeriodComparison, error) {
+	loc := now.Location()
+
+	preset := req.Preset
+	if preset == "" {
+		preset = Domain.ComparisonMonthOverMonth
+		if req.CurrentStart != nil || req.CurrentEnd != nil || req.PreviousStart != nil || req.PreviousEnd != nil {
+			preset = Domain.ComparisonCustom
+		}
+	}
+
+	var currentStart, currentEnd, previousStart, previousEnd time.Time
+
+	switch preset {
+	case Domain.ComparisonWeekOverWeek:
+		// Weeks start on Monday
+		currentStart = Domain.AddDays(now, -((int(now.Weekday()) + 6) % 7))
+		currentEnd = now
+		previousStart = Domain.AddDays(currentStart, -7)
+		previousEnd = now.AddDate(0, 0, -7)
+	case Domain.ComparisonMonthOverMonth:
+		currentStart = Domain.AddDays(now, 1-now.Day())
+		currentEnd = now
+		previousStart = Domain.StartOfDay(shiftMonths(currentStart, -1))
+		previousEnd = shiftMonths(now, -1)
+	case Domain.ComparisonYearOverYear:
+		if req.CurrentStart != nil && req.CurrentEnd != nil {
+			currentStart = Domain.DateIn(*req.CurrentStart, loc)
+			currentEnd = Domain.EndOfDay(Domain.DateIn(*req.CurrentEnd, loc))
+		} else {
+			currentStart = Domain.AddDays(now, 1-now.Day())
+			currentEnd = now
+		}
+		previousStart = Domain.StartOfDay(shiftMonths(currentStart, -12))
+		previousEnd = shiftMonths(currentEnd, -12)
+	case Domain.ComparisonCustom:
+		if req.CurrentStart == nil || req.CurrentEnd == nil {
+			return nil, fmt.Errorf("custom comparison needs current start and end dates")
+		}
+		if (req.PreviousStart == nil) != (req.PreviousEnd == nil) {
+			return nil, fmt.Errorf("custom comparison needs both previous dates or neither")
+		}
+		currentStart = Domain.DateIn(*req.CurrentStart, loc)
+		currentEnd = Domain.EndOfDay(Domain.DateIn(*req.CurrentEnd, loc))
+		if currentEnd.Before(currentStart) {
+			return nil, fmt.Errorf("end date must be after start date")
+		}
+
+		if req.PreviousStart != nil {
+			previousStart = Domain.DateIn(*req.PreviousStart, loc)
+			previousEnd = Domain.EndOfDay(Domain.DateIn(*req.PreviousEnd, loc))
+			break
+		}
+
+		previous := precedingPeriod(currentStart, currentEnd)
+		previousStart, previousEnd = previous.StartDate, previous.EndDate
+	default:
+		return nil, fmt.Errorf("invalid comparison preset: %s", preset)
+	}
+
+	if currentEnd.Before(currentStart) || previousEnd.Before(previousStart) {
+		return nil, fmt.Errorf("end date must be after start date")
+	}
+
+	return &Domain.PeriodComparison{
+		Preset:   preset,
+		Current:  comparisonPeriod(currentStart, currentEnd),
+		Previous: comparisonPeriod(previousStart, previousEnd),
+	}, nil
+}
+
+// precedingPeriod returns the whole days just before a range of whole days,
+// as many calendar days as it covers. DST changes keep days from all being 24
+// hours long, so the days are counted rather than the hours.
+func precedingPeriod(startDay, endDate time.Time) Domain.ComparisonPeriod {
+	days := 1
+	for day := startDay; day.Before(Domain.StartOfDay(endDate)); day = Domain.AddDays(day, 1) {
+		days++
+	}
+
+	return comparisonPeriod(Domain.AddDays(startDay, -days), Domain.EndOfDay(Domain.AddDays(startDay, -1)))
+}
+
+func comparisonPeriod(startDate, endDate time.Time) Domain.ComparisonPeriod {
+	return Domain.ComparisonPeriod{
+		Label:     fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")),
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+}
+
+// maxReportDays is the longest range, in calendar days, a report over
+// requested dates covers.
+const maxReportDays = 366
+
+// resolveDayPeriod works out the whole days a report covers, with day
+// boundaries in now's location. The range ends today unless an end date is
+// given, and starts defaultDays before its end, both ends included, unless a
+// start date is.
+func resolveDayPeriod(start, end *time.Time, defaultDays int, now time.Time) (Domain.ComparisonPeriod, error) {
+	loc := now.Location()
+
+	endDay := Domain.StartOfDay(now)
+	if end != nil {
+		endDay = Domain.DateIn(*end, loc)
+	}
+	startDay := Domain.AddDays(endDay, 1-defaultDays)
+	if start != nil {
+		startDay = Domain.DateIn(*start, loc)
+	}
+
+	if endDay.Before(startDay) {
+		return Domain.ComparisonPeriod{}, fmt.Errorf("end date must be after start date")
+	}
+	if Domain.AddDays(startDay, maxReportDays-1).Before(endDay) {
+		return Domain.ComparisonPeriod{}, fmt.Errorf("range cannot be longer than %d days", maxReportDays)
+	}
+
+	return comparisonPeriod(startDay, Domain.EndOfDay(endDay)), nil
+}
+
+// shiftMonths moves t by whole months, keeping the time of day and clamping
+// the day to the length of the target month, so March 31 less a month is
+// February 28 or 29.
+func shiftMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, 12, 0, 0, 0, t.Location())
+	if last := time.Date(first.Year(), first.Month()+1, 0, 12, 0, 0, 0, t.Location()).Day(); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// contribution returns a change as a percentage of the total change, or nil
+// when the total did not change.
+func contribution(change, totalChange float64) *float64 {
+	if totalChange == 0 {
+		return nil
+	}
+	percent := change / math.Abs(totalChange) * 100
+	return &percent
+}
+
+// compareCategories matches each period's category sales, keeping the tree
+// order of the current period and appending categories only sold before.
+func compareCategories(current, previous []Domain.CategorySales, totalChange float64) []Domain.CategoryContribution {
+	previousByID := make(map[string]Domain.CategorySales, len(previous))
+	for _, category := range previous {
+		previousByID[category.CategoryID] = category
+	}
+
+	contributions := []Domain.CategoryContribution{}
+	seen := make(map[string]bool, len(current))
+	add := func(category Domain.CategorySales, currentRevenue, previousRevenue float64) {
+		change := currentRevenue - previousRevenue
+		contributions = append(contributions, Domain.CategoryContribution{
+			CategoryID:    category.CategoryID,
+			Name:          category.Name,
+			ParentID:      category.ParentID,
+			Depth:         category.Depth,
+			Current:       currentRevenue,
+			Previous:      previousRevenue,
+			Change:        change,
+			ChangePercent: Domain.PercentChange(currentRevenue, previousRevenue),
+			Contribution:  contribution(change, totalChange),
+		})
+	}
+
+	for _, category := range current {
+		seen[category.CategoryID] = true
+		add(category, category.Revenue, previousByID[category.CategoryID].Revenue)
+	}
+	for _, category := range previous {
+		if !seen[category.CategoryID] {
+			add(category, 0, category.Revenue)
+		}
+	}
+
+	return contributions
+}
+
+// compareProducts matches each period's product sales and returns the
+// products whose sales moved most, either way.
+func compareProducts(current, previous []Domain.TopProduct, totalChange float64, limit int) []Domain.ProductContribution {
+	byID := make(map[string]*Domain.ProductContribution)
+	var order []string
+	entry := func(product Domain.TopProduct) *Domain.ProductContribution {
+		if existing, ok := byID[product.ProductID]; ok {
+			return existing
+		}
+		item := &Domain.ProductContribution{
+			ProductID:   product.ProductID,
+			ProductName: product.ProductName,
+		}
+		byID[product.ProductID] = item
+		order = append(order, product.ProductID)
+		return item
+	}
+
+	for _, product := range current {
+		item := entry(product)
+		item.CurrentQuantity = product.Quantity
+		item.Current = product.TotalAmount
+	}
+	for _, product := range previous {
+		item := entry(product)
+		item.PreviousQuantity = product.Quantity
+		item.Previous = product.TotalAmount
+	}
+
+	contributions := make([]Domain.ProductContribution, 0, len(order))
+	for _, id := range order {
+		item := byID[id]
+		item.Change = item.Current - item.Previous
+		item.ChangePercent = Domain.PercentChange(item.Current, item.Previous)
+		item.Contribution = contribution(item.Change, totalChange)
+		contributions = append(contributions, *item)
+	}
+
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return math.Abs(contributions[i].Change) > math.Abs(contributions[j].Change)
+	})
+	if len(contributions) > limit {
+		contributions = contributions[:limit]
+	}
+
+	return contributions
+}
+
+func (uc *reportUseCase) GetAgingReport(businessID string, req Domain.AgingReportRequest) (*Domain.AgingReport, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	deadStockDays := req.DeadStockDays
+	if deadStockDays <= 0 {
+		deadStockDays = Domain.DefaultDeadStockDays
+	}
+
+	limits := req.Buckets
+	if len(limits) == 0 {
+		limits = Domain.DefaultAgingBuckets
+	}
+	for i, limit := range limits {
+		if limit <= 0 || (i > 0 && limit <= limits[i-1]) {
+			return nil, fmt.Errorf("aging buckets must be positive and ascending")
+		}
+	}
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, Domain.ProductFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	sold, err := uc.reportRepo.GetLastSaleDates(businessID)
+	if err != nil {
+		return nil, err
+	}
+	received, err := uc.reportRepo.GetLastReceiptDates(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSold := make(map[string]time.Time, len(sold))
+	for _, activity := range sold {
+		lastSold[activity.ProductID.Hex()] = activity.LastAt
+	}
+	lastReceived := make(map[string]time.Time, len(received))
+	for _, activity := range received {
+		lastReceived[activity.ProductID.Hex()] = activity.LastAt
+	}
+
 	now := time.Now()
+	report := &Domain.AgingReport{
+		AsOf:          now,
+		DeadStockDays: deadStockDays,
+		SalesAging:    newAgingBuckets(limits),
+		ReceiptAging:  newAgingBuckets(limits),
+		Items:         []Domain.AgingItem{},
+	}
+
+	for _, product := range products {
+		// A bundle's stock is held by its components
+		if product.IsBundle() || product.Stock <= 0 {
+			continue
+		}
+
+		item := Domain.AgingItem{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			SKU:         product.SKU,
+			Category:    product.Category,
+			Stock:       product.Stock,
+			UnitCost:    product.CostPrice,
+			Value:       product.Stock * product.CostPrice,
+		}
+
+		soldFrom := product.CreatedAt
+		if at, ok := lastSold[product.ID.Hex()]; ok {
+			item.LastSoldAt = &at
+			soldFrom = at
+		}
+		receivedFrom := product.CreatedAt
+		if at, ok := lastReceived[product.ID.Hex()]; ok {
+			item.LastReceivedAt = &at
+			receivedFrom = at
+		}
+
+		item.DaysSinceSold = daysBetween(soldFrom, now)
+		item.DaysSinceReceived = daysBetween(receivedFrom, now)
+		item.SalesBucket = addToAgingBucket(report.SalesAging, item.DaysSinceSold, item)
+		item.ReceiptBucket = addToAgingBucket(report.ReceiptAging, item.DaysSinceReceived, item)
+		item.DeadStock = item.DaysSinceSold >= deadStockDays
+
+		report.TotalProducts++
+		report.TotalStock += item.Stock
+		report.TotalValue += item.Value
+		if item.DeadStock {
+			report.DeadStockCount++
+			report.DeadStockValue += item.Value
+		}
+
+		report.Items = append(report.Items, item)
+	}
+
+	// Longest unsold first, the likeliest candidates for a discount
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		if report.Items[i].DaysSinceSold != report.Items[j].DaysSinceSold {
+			return report.Items[i].DaysSinceSold > report.Items[j].DaysSinceSold
+		}
+		return report.Items[i].Value > report.Items[j].Value
+	})
+
+	return report, nil
+}
+
+func (uc *reportUseCase) ExportAgingReport(businessID string, req Domain.AgingReportRequest, format string) ([]byte, string, error) {
+	report, err := uc.GetAgingReport(businessID, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return exportReport(uc.exportService, report, Domain.ReportTypeAging, format)
+}
+
+// newAgingBuckets lays out buckets from the ascending upper day limits, e.g.
+// 30, 90 gives 0-30, 31-90 and 90+.
+func newAgingBuckets(limits []int) []Domain.AgingBucket {
+	buckets := make([]Domain.AgingBucket, 0, len(limits)+1)
+	min := 0
+	for _, limit := range limits {
+		max := limit
+		buckets = append(buckets, Domain.AgingBucket{
+			Label:   fmt.Sprintf("%d-%d", min, max),
+			MinDays: min,
+			MaxDays: &max,
+		})
+		min = limit + 1
+	}
+	buckets = append(buckets, Domain.AgingBucket{
+		Label:   fmt.Sprintf("%d+", limits[len(limits)-1]),
+		MinDays: min,
+	})
+
+	return buckets
+}
+
+// addToAgingBucket adds an item to the bucket its age in days falls in and
+// returns the bucket label.
+func addToAgingBucket(buckets []Domain.AgingBucket, days int, item Domain.AgingItem) string {
+	for i := range buckets {
+		if buckets[i].MaxDays != nil && days > *buckets[i].MaxDays {
+			continue
+		}
+		buckets[i].Products++
+		buckets[i].Stock += item.Stock
+		buckets[i].Value += item.Value
+		return buckets[i].Label
+	}
+	return ""
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(math.Max(0, math.Floor(to.Sub(from).Hours()/24)))
+}
+
+// GetSalesHeatmap counts the business's completed sales by day of the week
+// and hour of the day in its timezone, against the range of the same number
+// of days just before.
+func (uc *reportUseCase) GetSalesHeatmap(businessID string, req Domain.SalesHeatmapRequest) (*Domain.SalesHeatmap, error) {
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, previous, err := resolveHeatmapRanges(req, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := uc.heatmapFilters(businessID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	currentCounts, err := uc.reportRepo.GetSalesHeatmap(businessID, current.StartDate, current.EndDate, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sales heatmap: %w", err)
+	}
+	previousCounts, err := uc.reportRepo.GetSalesHeatmap(businessID, previous.StartDate, previous.EndDate, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sales heatmap for previous range: %w", err)
+	}
+
+	heatmap := &Domain.SalesHeatmap{
+		Timezone:      loc.String(),
+		Current:       current,
+		Previous:      previous,
+		ProductID:     req.ProductID,
+		Category:      req.Category,
+		PaymentMethod: req.PaymentMethod,
+		Cells:         make([]Domain.HeatmapCell, 0, 7*24),
+		Weekdays:      make([]Domain.HeatmapTotal, 7),
+		Hours:         make([]Domain.HeatmapTotal, 24),
+	}
+
+	// Rows run from Monday to Sunday
+	cells := make(map[[2]int]*Domain.HeatmapCell, 7*24)
+	for row := 0; row < 7; row++ {
+		weekday := time.Weekday((row + 1) % 7)
+		heatmap.Weekdays[row].Label = weekday.String()
+		for hour := 0; hour < 24; hour++ {
+			heatmap.Cells = append(heatmap.Cells, Domain.HeatmapCell{
+				Weekday:     int(weekday),
+				WeekdayName: weekday.String(),
+				Hour:        hour,
+			})
+		}
+	}
+	for i := range heatmap.Cells {
+		cells[[2]int{heatmap.Cells[i].Weekday, heatmap.Cells[i].Hour}] = &heatmap.Cells[i]
+	}
+	for hour := range heatmap.Hours {
+		heatmap.Hours[hour].Label = fmt.Sprintf("%02d:00", hour)
+	}
+
+	for _, count := range currentCounts {
+		if cell, ok := cells[[2]int{count.Weekday, count.Hour}]; ok {
+			cell.Transactions += count.Transactions
+			cell.Revenue += count.Revenue
+		}
+	}
+	for _, count := range previousCounts {
+		if cell, ok := cells[[2]int{count.Weekday, count.Hour}]; ok {
+			cell.PreviousTransactions += count.Transactions
+			cell.PreviousRevenue += count.Revenue
+		}
+	}
+
+	var transactions, previousTransactions int
+	var revenue, previousRevenue float64
+	for i := range heatmap.Cells {
+		cell := &heatmap.Cells[i]
+		cell.RevenueChangePercent = Domain.PercentChange(cell.Revenue, cell.PreviousRevenue)
+
+		addHeatmapTotal(&heatmap.Weekdays[(cell.Weekday+6)%7], cell)
+		addHeatmapTotal(&heatmap.Hours[cell.Hour], cell)
+
+		transactions += cell.Transactions
+		previousTransactions += cell.PreviousTransactions
+		revenue += cell.Revenue
+		previousRevenue += cell.PreviousRevenue
+
+		if cell.Transactions > 0 && (heatmap.Peak == nil || cell.Revenue > heatmap.Peak.Revenue) {
+			heatmap.Peak = cell
+		}
+	}
+	for i := range heatmap.Weekdays {
+		heatmap.Weekdays[i].RevenueChangePercent = Domain.PercentChange(heatmap.Weekdays[i].Revenue, heatmap.Weekdays[i].PreviousRevenue)
+	}
+	for i := range heatmap.Hours {
+		heatmap.Hours[i].RevenueChangePercent = Domain.PercentChange(heatmap.Hours[i].Revenue, heatmap.Hours[i].PreviousRevenue)
+	}
+	if heatmap.Peak != nil {
+		peak := *heatmap.Peak
+		heatmap.Peak = &peak
+	}
+
+	heatmap.Transactions = Domain.NewMetricChange(float64(transactions), float64(previousTransactions))
+	heatmap.Revenue = Domain.NewMetricChange(revenue, previousRevenue)
+
+	return heatmap, nil
+}
+
+func (uc *reportUseCase) ExportSalesHeatmap(businessID string, req Domain.SalesHeatmapRequest, format string) ([]byte, string, error) {
+	heatmap, err := uc.GetSalesHeatmap(businessID, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return exportReport(uc.exportService, heatmap, Domain.ReportTypeHeatmap, format)
+}
+
+// heatmapFilters resolves the product and category of a heatmap request into
+// the products whose sales it counts.
+func (uc *reportUseCase) heatmapFilters(businessID string, req Domain.SalesHeatmapRequest) (Domain.SalesHeatmapFilters, error) {
+	filters := Domain.SalesHeatmapFilters{PaymentMethod: req.PaymentMethod}
+
+	if req.PaymentMethod != nil && !Domain.IsValidPaymentMethod(*req.PaymentMethod) {
+		return filters, fmt.Errorf("invalid payment method: %s", *req.PaymentMethod)
+	}
+
+	var productID *primitive.ObjectID
+	if req.ProductID != "" {
+		objProductID, err := primitive.ObjectIDFromHex(req.ProductID)
+		if err != nil {
+			return filters, fmt.Errorf("invalid product ID: %w", err)
+		}
+		productID = &objProductID
+	}
+
+	if req.Category == "" {
+		if productID != nil {
+			filters.ProductIDs = []primitive.ObjectID{*productID}
+		}
+		return filters, nil
+	}
+
+	productFilters := Domain.ProductFilters{Category: &req.Category}
+	if err := expandCategoryFilter(uc.categoryRepo, businessID, &productFilters); err != nil {
+		return filters, fmt.Errorf("failed to resolve category: %w", err)
+	}
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, productFilters)
+	if err != nil {
+		return filters, fmt.Errorf("failed to find products: %w", err)
+	}
+
+	// A product outside the category matches no sales
+	filters.ProductIDs = []primitive.ObjectID{}
+	for _, product := range products {
+		if productID == nil || product.ID == *productID {
+			filters.ProductIDs = append(filters.ProductIDs, product.ID)
+		}
+	}
+
+	return filters, nil
+}
+
+// resolveHeatmapRanges works out the whole days a heatmap covers, in now's
+// location, and the range of the same number of days just before them.
+func resolveHeatmapRanges(req Domain.SalesHeatmapRequest, now time.Time) (Domain.ComparisonPeriod, Domain.ComparisonPeriod, error) {
+	current, err := resolveDayPeriod(req.StartDate, req.EndDate, Domain.DefaultHeatmapDays, now)
+	if err != nil {
+		return Domain.ComparisonPeriod{}, Domain.ComparisonPeriod{}, err
+	}
+
+	return current, precedingPeriod(current.StartDate, current.EndDate), nil
+}
+
+func addHeatmapTotal(total *Domain.HeatmapTotal, cell *Domain.HeatmapCell) {
+	total.Transactions += cell.Transactions
+	total.Revenue += cell.Revenue
+	total.PreviousTransactions += cell.PreviousTransactions
+	total.PreviousRevenue += cell.PreviousRevenue
+}
+
+// GetABCAnalysis ranks the business's whole catalogue by revenue, gross
+// profit and quantity sold over a range, and classifies each product A, B or
+// C by its cumulative share of each total.
+func (uc *reportUseCase) GetABCAnalysis(businessID string, req Domain.ABCReportRequest) (*Domain.ABCReport, error) {
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	metric := req.Metric
+	if metric == "" {
+		metric = Domain.ABCMetricRevenue
+	}
+	if !Domain.IsValidABCMetric(metric) {
+		return nil, fmt.Errorf("invalid metric: %s", metric)
+	}
+
+	thresholds, err := abcThresholds(req)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := resolveDayPeriod(req.StartDate, req.EndDate, Domain.DefaultABCDays, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	productFilters := Domain.ProductFilters{}
+	if req.Category != "" {
+		productFilters.Category = &req.Category
+		if err := expandCategoryFilter(uc.categoryRepo, businessID, &productFilters); err != nil {
+			return nil, fmt.Errorf("failed to resolve category: %w", err)
+		}
+	}
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, productFilters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products: %w", err)
+	}
+
+	sales, err := uc.reportRepo.GetProductSales(businessID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate product sales: %w", err)
+	}
+	sold := make(map[string]Domain.TopProduct, len(sales))
+	for _, sale := range sales {
+		sold[sale.ProductID] = sale
+	}
+
+	items := make([]Domain.ABCItem, 0, len(products))
+	listed := make(map[string]bool, len(products))
+	for _, product := range products {
+		id := product.ID.Hex()
+		listed[id] = true
+
+		sale, ok := sold[id]
+		// Discontinued products that no longer sell have no class to earn
+		if !ok && product.Status == Domain.ProductStatusDiscontinued {
+			continue
+		}
+		items = append(items, newABCItem(id, product.Name, product.SKU, product.Category, sale))
+	}
+
+	// Products deleted since they sold still count towards the totals, but
+	// can't be placed in a category
+	if req.Category == "" {
+		for _, sale := range sales {
+			if listed[sale.ProductID] {
+				continue
+			}
+			name := sale.ProductName
+			if name == "" {
+				name = "Deleted product"
+			}
+			items = append(items, newABCItem(sale.ProductID, name, "", "", sale))
+		}
+	}
+
+	report := &Domain.ABCReport{
+		Period:        period,
+		Metric:        metric,
+		Category:      req.Category,
+		Thresholds:    thresholds,
+		TotalProducts: len(items),
+		Summaries:     make([]Domain.ABCMetricSummary, 0, len(Domain.ABCMetrics)),
+	}
+	for _, item := range items {
+		report.Revenue += item.Revenue
+		report.CostOfGoods += item.CostOfGoods
+		report.GrossProfit += item.GrossProfit
+		report.Quantity += item.Quantity
+		if item.Quantity == 0 && item.Revenue == 0 {
+			report.ProductsNotSold++
+		}
+	}
+
+	for _, m := range Domain.ABCMetrics {
+		report.Summaries = append(report.Summaries, classifyABC(items, m, thresholds))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Ranking(metric).Rank < items[j].Ranking(metric).Rank
+	})
+	report.Items = items
+
+	return report, nil
+}
+
+func (uc *reportUseCase) ExportABCAnalysis(businessID string, req Domain.ABCReportRequest, format string) ([]byte, string, error) {
+	report, err := uc.GetABCAnalysis(businessID, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return exportReport(uc.exportService, report, Domain.ReportTypeABC, format)
+}
+
+func newABCItem(productID, name, sku, category string, sale Domain.TopProduct) Domain.ABCItem {
+	item := Domain.ABCItem{
+		ProductID:   productID,
+		ProductName: name,
+		SKU:         sku,
+		Category:    category,
+		Quantity:    sale.Quantity,
+		Revenue:     sale.TotalAmount,
+		CostOfGoods: sale.CostOfGoods,
+		GrossProfit: sale.TotalAmount - sale.CostOfGoods,
+	}
+	if item.Revenue > 0 {
+		item.GrossMargin = (item.GrossProfit / item.Revenue) * 100
+	}
+	return item
+}
+
+// abcThresholds fills in the default class shares and checks they fit in
+// the whole.
+func abcThresholds(req Domain.ABCReportRequest) (Domain.ABCThresholds, error) {
+	thresholds := Domain.ABCThresholds{A: Domain.DefaultABCClassA, B: Domain.DefaultABCClassB}
+	if req.ClassA != nil {
+		thresholds.A = *req.ClassA
+	}
+	if req.ClassB != nil {
+		thresholds.B = *req.ClassB
+	}
+
+	if thresholds.A <= 0 || thresholds.B < 0 || thresholds.A+thresholds.B > 100 {
+		return thresholds, fmt.Errorf("class A share must be above 0, class B share cannot be negative, and together they cannot exceed 100")
+	}
+	thresholds.C = 100 - thresholds.A - thresholds.B
+
+	return thresholds, nil
+}
+
+// classifyABC ranks the items by the metric, largest first, and classes each
+// by the share of the total taken by the items ranked above it, so the
+// product that crosses a threshold falls in the class it completes. Items
+// with nothing to contribute are always class C.
+func classifyABC(items []Domain.ABCItem, metric Domain.ABCMetric, thresholds Domain.ABCThresholds) Domain.ABCMetricSummary {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Value(metric) != items[j].Value(metric) {
+			return items[i].Value(metric) > items[j].Value(metric)
+		}
+		return items[i].ProductName < items[j].ProductName
+	})
+
+	summary := Domain.ABCMetricSummary{
+		Metric: metric,
+		Classes: []Domain.ABCClassSummary{
+			{Class: Domain.ABCClassA},
+			{Class: Domain.ABCClassB},
+			{Class: Domain.ABCClassC},
+		},
+	}
+	for i := range items {
+		summary.Total += math.Max(0, items[i].Value(metric))
+	}
+
+	// Leave room for rounding in the running share
+	const epsilon = 1e-9
+
+	cumulative := 0.0
+	for i := range items {
+		ranking := items[i].Ranking(metric)
+		ranking.Rank = i + 1
+
+		value := math.Max(0, items[i].Value(metric))
+		class := 2
+		if value > 0 {
+			switch {
+			case cumulative < thresholds.A-epsilon:
+				class = 0
+			case cumulative < thresholds.A+thresholds.B-epsilon:
+				class = 1
+			}
+			ranking.Share = (value / summary.Total) * 100
+		}
+		cumulative += ranking.Share
+		ranking.CumulativeShare = cumulative
+		ranking.Class = summary.Classes[class].Class
+
+		summary.Classes[class].Products++
+		summary.Classes[class].Value += value
+		summary.Classes[class].Share += ranking.Share
+	}
+
+	if len(items) > 0 {
+		for i := range summary.Classes {
+			summary.Classes[i].ProductShare = (float64(summary.Classes[i].Products) / float64(len(items))) * 100
+		}
+	}
+
+	return summary
+}
+
+// getDateRange resolves a period to a range whose day boundaries fall at
+// midnight in loc. Custom dates are calendar days in loc, the end day
+// included in full.
+func (uc *reportUseCase) getDateRange(period Domain.PeriodType, customStart, customEnd *time.Time, loc *time.Location) (time.Time, time.Time) {
+	return dateRange(period, customStart, customEnd, time.Now().In(loc))
+}
+
+// dateRange works out the range a report period covers, with day boundaries
+// in now's location.
+func dateRange(period Domain.PeriodType, customStart, customEnd *time.Time, now time.Time) (time.Time, time.Time) {
+	loc := now.Location()
 
 	// Use custom dates if provided
 	if customStart != nil && customEnd != nil {
-		return *customStart, *customEnd
+		return Domain.DateIn(*customStart, loc), Domain.EndOfDay(Domain.DateIn(*customEnd, loc))
 	}
 
 	var startDate, endDate time.Time
 
 	switch period {
 	case Domain.PeriodTypeDaily:
-		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		endDate = startDate.Add(24 * time.Hour)
+		startDate = Domain.StartOfDay(now)
+		endDate = Domain.EndOfDay(now)
 	case Domain.PeriodTypeWeekly:
 		// Last 7 days
 		endDate = now
-		startDate = now.AddDate(0, 0, -7)
+		startDate = Domain.LastDays(now, 7)
 	case Domain.PeriodTypeMonthly:
 		// Last 30 days
 		endDate = now
-		startDate = now.AddDate(0, 0, -30)
+		startDate = Domain.LastDays(now, 30)
 	case Domain.PeriodTypeYearly:
 		// Last 365 days
 		endDate = now
-		startDate = now.AddDate(0, 0, -365)
+		startDate = Domain.LastDays(now, 365)
 	case Domain.PeriodTypeCustom:
 		// Default to last 30 days
 		endDate = now
-		startDate = now.AddDate(0, 0, -30)
+		startDate = Domain.LastDays(now, 30)
 	default:
 		// Default to last 30 days
 		endDate = now
-		startDate = now.AddDate(0, 0, -30)
+		startDate = Domain.LastDays(now, 30)
 	}
 
 	return startDate, endDate
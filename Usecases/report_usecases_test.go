@@ -0,0 +1,321 @@
+package Usecases
+
+import (
+	"testing"
+	"time"
+
+	Domain "ShopOps/Domain"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestDateRangeCustomDates(t *testing.T) {
+	santiago := mustLoadLocation(t, "America/Santiago")
+	now := time.Date(2024, 9, 20, 10, 0, 0, 0, santiago)
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "single day in the DST gap",
+			start:     time.Date(2024, 9, 8, 0, 0, 0, 0, time.UTC),
+			end:       time.Date(2024, 9, 8, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 8, 23, 59, 59, 999999999, santiago),
+		},
+		{
+			name:      "ending on the day before the gap",
+			start:     time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+			end:       time.Date(2024, 9, 7, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, 9, 1, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 7, 23, 59, 59, 999999999, santiago),
+		},
+		{
+			name:      "over the repeated hour",
+			start:     time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC),
+			end:       time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, 4, 6, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 4, 7, 23, 59, 59, 999999999, santiago),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := dateRange(Domain.PeriodTypeCustom, &tt.start, &tt.end, now)
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("start = %v, want %v", start, tt.wantStart)
+			}
+			if !end.Equal(tt.wantEnd) {
+				t.Errorf("end = %v, want %v", end, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestDateRangePeriods(t *testing.T) {
+	addisAbaba := mustLoadLocation(t, "Africa/Addis_Ababa")
+	santiago := mustLoadLocation(t, "America/Santiago")
+
+	tests := []struct {
+		name      string
+		period    Domain.PeriodType
+		now       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "daily late in the evening",
+			period:    Domain.PeriodTypeDaily,
+			now:       time.Date(2024, 3, 10, 23, 45, 0, 0, addisAbaba),
+			wantStart: time.Date(2024, 3, 10, 0, 0, 0, 0, addisAbaba),
+			wantEnd:   time.Date(2024, 3, 10, 23, 59, 59, 999999999, addisAbaba),
+		},
+		{
+			name:      "daily just after midnight",
+			period:    Domain.PeriodTypeDaily,
+			now:       time.Date(2024, 3, 11, 0, 5, 0, 0, addisAbaba),
+			wantStart: time.Date(2024, 3, 11, 0, 0, 0, 0, addisAbaba),
+			wantEnd:   time.Date(2024, 3, 11, 23, 59, 59, 999999999, addisAbaba),
+		},
+		{
+			name:      "daily on the DST gap",
+			period:    Domain.PeriodTypeDaily,
+			now:       time.Date(2024, 9, 8, 9, 0, 0, 0, santiago),
+			wantStart: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 8, 23, 59, 59, 999999999, santiago),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := dateRange(tt.period, nil, nil, tt.now)
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("start = %v, want %v", start, tt.wantStart)
+			}
+			if !end.Equal(tt.wantEnd) {
+				t.Errorf("end = %v, want %v", end, tt.wantEnd)
+			}
+		})
+	}
+}
+
+// calendarDays counts the calendar days from start's to end's, both included.
+func calendarDays(start, end time.Time) int {
+	days := 0
+	for day := Domain.StartOfDay(start); !day.After(end); day = Domain.AddDays(day, 1) {
+		days++
+	}
+	return days
+}
+
+// Rolling periods run from midnight in the business's timezone up to now,
+// over as many calendar days as they are named for, today included.
+func TestDateRangeRollingPeriods(t *testing.T) {
+	addisAbaba := mustLoadLocation(t, "Africa/Addis_Ababa")
+	santiago := mustLoadLocation(t, "America/Santiago")
+
+	periods := map[Domain.PeriodType]int{
+		Domain.PeriodTypeWeekly:  7,
+		Domain.PeriodTypeMonthly: 30,
+		Domain.PeriodTypeYearly:  365,
+		Domain.PeriodTypeCustom:  30,
+		"":                       30,
+	}
+	nows := []time.Time{
+		time.Date(2024, 3, 10, 23, 45, 0, 0, addisAbaba),
+		time.Date(2024, 9, 14, 23, 30, 0, 0, santiago), // A week after the gap
+		time.Date(2024, 10, 7, 0, 15, 0, 0, santiago),  // A month after it
+	}
+
+	for period, wantDays := range periods {
+		for _, now := range nows {
+			start, end := dateRange(period, nil, nil, now)
+			if !end.Equal(now) {
+				t.Errorf("%q at %v: end = %v, want now", period, now, end)
+			}
+			if !start.Equal(Domain.StartOfDay(start)) {
+				t.Errorf("%q at %v: start %v is not the start of a day", period, now, start)
+			}
+			if start.Location() != now.Location() {
+				t.Errorf("%q at %v: start is in %v", period, now, start.Location())
+			}
+			if days := calendarDays(start, end); days != wantDays {
+				t.Errorf("%q at %v: covers %d days, want %d", period, now, days, wantDays)
+			}
+		}
+	}
+}
+
+func TestResolveDayPeriod(t *testing.T) {
+	santiago := mustLoadLocation(t, "America/Santiago")
+	now := time.Date(2024, 9, 14, 23, 30, 0, 0, santiago)
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	tests := []struct {
+		name      string
+		start     *time.Time
+		end       *time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "default days up to today",
+			wantStart: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 14, 23, 59, 59, 999999999, santiago),
+		},
+		{
+			name:      "default days up to the end date",
+			end:       date(2024, 9, 10),
+			wantStart: time.Date(2024, 9, 4, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 10, 23, 59, 59, 999999999, santiago),
+		},
+		{
+			name:      "start date up to today",
+			start:     date(2024, 9, 1),
+			wantStart: time.Date(2024, 9, 1, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 14, 23, 59, 59, 999999999, santiago),
+		},
+		{
+			name:      "longest range",
+			start:     date(2024, 1, 1),
+			end:       date(2024, 12, 31),
+			wantStart: time.Date(2024, 1, 1, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 12, 31, 23, 59, 59, 999999999, santiago),
+		},
+		{
+			name:    "a day too long",
+			start:   date(2023, 12, 31),
+			end:     date(2024, 12, 31),
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			start:   date(2024, 9, 10),
+			end:     date(2024, 9, 9),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, err := resolveDayPeriod(tt.start, tt.end, 7, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %s, want an error", period.Label)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !period.StartDate.Equal(tt.wantStart) {
+				t.Errorf("start = %v, want %v", period.StartDate, tt.wantStart)
+			}
+			if !period.EndDate.Equal(tt.wantEnd) {
+				t.Errorf("end = %v, want %v", period.EndDate, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestResolveComparisonPeriodsCustomDates(t *testing.T) {
+	santiago := mustLoadLocation(t, "America/Santiago")
+	now := time.Date(2024, 9, 20, 10, 0, 0, 0, santiago)
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	tests := []struct {
+		name         string
+		req          Domain.PeriodComparisonRequest
+		wantPreset   Domain.ComparisonPreset
+		wantPrevious Domain.ComparisonPeriod
+		wantErr      bool
+	}{
+		{
+			name:       "current dates alone compare with the days before",
+			req:        Domain.PeriodComparisonRequest{CurrentStart: date(2024, 9, 9), CurrentEnd: date(2024, 9, 15)},
+			wantPreset: Domain.ComparisonCustom,
+			wantPrevious: Domain.ComparisonPeriod{
+				StartDate: time.Date(2024, 9, 2, 0, 0, 0, 0, santiago),
+				EndDate:   time.Date(2024, 9, 8, 23, 59, 59, 999999999, santiago),
+			},
+		},
+		{
+			name:       "the days before run over the DST gap",
+			req:        Domain.PeriodComparisonRequest{Preset: Domain.ComparisonCustom, CurrentStart: date(2024, 9, 9), CurrentEnd: date(2024, 9, 9)},
+			wantPreset: Domain.ComparisonCustom,
+			wantPrevious: Domain.ComparisonPeriod{
+				StartDate: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+				EndDate:   time.Date(2024, 9, 8, 23, 59, 59, 999999999, santiago),
+			},
+		},
+		{
+			name: "previous dates given",
+			req: Domain.PeriodComparisonRequest{
+				CurrentStart: date(2024, 9, 9), CurrentEnd: date(2024, 9, 15),
+				PreviousStart: date(2023, 9, 11), PreviousEnd: date(2023, 9, 17),
+			},
+			wantPreset: Domain.ComparisonCustom,
+			wantPrevious: Domain.ComparisonPeriod{
+				StartDate: time.Date(2023, 9, 11, 0, 0, 0, 0, santiago),
+				EndDate:   time.Date(2023, 9, 17, 23, 59, 59, 999999999, santiago),
+			},
+		},
+		{
+			name:    "only a current start",
+			req:     Domain.PeriodComparisonRequest{CurrentStart: date(2024, 9, 9)},
+			wantErr: true,
+		},
+		{
+			name:    "only a previous start",
+			req:     Domain.PeriodComparisonRequest{CurrentStart: date(2024, 9, 9), CurrentEnd: date(2024, 9, 15), PreviousStart: date(2024, 9, 1)},
+			wantErr: true,
+		},
+		{
+			name:    "current end before start",
+			req:     Domain.PeriodComparisonRequest{CurrentStart: date(2024, 9, 15), CurrentEnd: date(2024, 9, 9)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison, err := resolveComparisonPeriods(tt.req, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %s against %s, want an error", comparison.Current.Label, comparison.Previous.Label)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if comparison.Preset != tt.wantPreset {
+				t.Errorf("preset = %s, want %s", comparison.Preset, tt.wantPreset)
+			}
+			if !comparison.Previous.StartDate.Equal(tt.wantPrevious.StartDate) {
+				t.Errorf("previous start = %v, want %v", comparison.Previous.StartDate, tt.wantPrevious.StartDate)
+			}
+			if !comparison.Previous.EndDate.Equal(tt.wantPrevious.EndDate) {
+				t.Errorf("previous end = %v, want %v", comparison.Previous.EndDate, tt.wantPrevious.EndDate)
+			}
+		})
+	}
+}
@@ -20,20 +20,41 @@ type SalesUseCase interface {
 }
 
 type salesUseCase struct {
-	salesRepo     Domain.SaleRepository
-	businessRepo  Domain.BusinessRepository
-	inventoryRepo Domain.ProductRepository
+	salesRepo      Domain.SaleRepository
+	businessRepo   Domain.BusinessRepository
+	inventoryRepo  Domain.ProductRepository
+	batchRepo      Domain.BatchRepository
+	serialRepo     Domain.SerialRepository
+	locationRepo   Domain.LocationRepository
+	stockLevelRepo Domain.StockLevelRepository
+	recipeRepo     Domain.RecipeRepository
+	dailyStatsRepo Domain.DailyStatsRepository
+	costingUC      CostingUseCase
 }
 
 func NewSalesUseCase(
 	salesRepo Domain.SaleRepository,
 	businessRepo Domain.BusinessRepository,
 	inventoryRepo Domain.ProductRepository,
+	batchRepo Domain.BatchRepository,
+	serialRepo Domain.SerialRepository,
+	locationRepo Domain.LocationRepository,
+	stockLevelRepo Domain.StockLevelRepository,
+	recipeRepo Domain.RecipeRepository,
+	dailyStatsRepo Domain.DailyStatsRepository,
+	costingUC CostingUseCase,
 ) SalesUseCase {
 	return &salesUseCase{
-		salesRepo:     salesRepo,
-		businessRepo:  businessRepo,
-		inventoryRepo: inventoryRepo,
+		salesRepo:      salesRepo,
+		businessRepo:   businessRepo,
+		inventoryRepo:  inventoryRepo,
+		batchRepo:      batchRepo,
+		serialRepo:     serialRepo,
+		locationRepo:   locationRepo,
+		stockLevelRepo: stockLevelRepo,
+		recipeRepo:     recipeRepo,
+		dailyStatsRepo: dailyStatsRepo,
+		costingUC:      costingUC,
 	}
 }
 
@@ -47,15 +68,23 @@ func (uc *salesUseCase) CreateSale(businessID, userID string, req Domain.CreateS
 		return nil, fmt.Errorf("business not found")
 	}
 
+	// Goods leave from the chosen location, or the default one
+	location, err := resolveLocation(uc.locationRepo, businessID, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate product if specified
 	var productID *primitive.ObjectID
+	var product *Domain.Product
+	var serials, warnings []string
 	if req.ProductID != nil {
 		objProductID, err := primitive.ObjectIDFromHex(*req.ProductID)
 		if err != nil {
 			return nil, fmt.Errorf("invalid product ID: %w", err)
 		}
 
-		product, err := uc.inventoryRepo.FindByID(*req.ProductID)
+		product, err = uc.inventoryRepo.FindByID(*req.ProductID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find product: %w", err)
 		}
@@ -64,12 +93,19 @@ func (uc *salesUseCase) CreateSale(businessID, userID string, req Domain.CreateS
 		}
 
 		// Check if sufficient stock
-		if product.Stock < req.Quantity {
-			return nil, fmt.Errorf("insufficient stock. Available: %.2f, Requested: %.2f",
-				product.Stock, req.Quantity)
+		warnings, err = uc.checkSaleStock(business, product, req.Quantity, location, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		serials, err = checkSaleSerials(uc.serialRepo, product, req.Quantity, req.Serials, nil)
+		if err != nil {
+			return nil, err
 		}
 
 		productID = &objProductID
+	} else if len(req.Serials) > 0 {
+		return nil, fmt.Errorf("serial numbers can only be given for a product sale")
 	}
 
 	objBusinessID, err := primitive.ObjectIDFromHex(businessID)
@@ -94,30 +130,49 @@ func (uc *salesUseCase) CreateSale(businessID, userID string, req Domain.CreateS
 		Tax:           req.Tax,
 		PaymentMethod: req.PaymentMethod,
 		Notes:         req.Notes,
+		Serials:       serials,
+		Warnings:      warnings,
 		CreatedBy:     objUserID,
 	}
+	if location != nil {
+		sale.LocationID = &location.ID
+	}
+
+	// Pick batches (FEFO unless a batch was chosen) for batch-tracked products
+	if product != nil && product.TrackBatches {
+		allocations, warning, err := allocateBatches(uc.batchRepo, product, req.Quantity, req.BatchID,
+			Domain.EffectiveNegativeStockPolicy(business, product))
+		if err != nil {
+			return nil, err
+		}
+		sale.Batches = allocations
+		if warning != "" {
+			sale.Warnings = append(sale.Warnings, warning)
+		}
+	}
+
+	// Serials record the sale, so it takes its ID before it is saved
+	if len(sale.Serials) > 0 {
+		sale.ID = primitive.NewObjectID()
+		if err := sellSerials(uc.serialRepo, product, sale, sale.Serials, objUserID); err != nil {
+			releaseBatches(uc.batchRepo, sale.Batches)
+			return nil, err
+		}
+	}
 
 	if err := uc.salesRepo.Create(sale); err != nil {
+		releaseBatches(uc.batchRepo, sale.Batches)
+		returnSerials(uc.serialRepo, businessID, sale.Serials, &sale.ID, "Sale not completed", objUserID)
 		return nil, fmt.Errorf("failed to create sale: %w", err)
 	}
 
 	// Update inventory if product was specified
-	if productID != nil {
-		referenceID := sale.ID.Hex()
-		if err := uc.inventoryRepo.AdjustStock(
-			productID.Hex(), // This should work now
-			req.Quantity,
-			Domain.MovementTypeSale,
-			"Sale transaction",
-			&referenceID,
-			"sale",
-			userID,
-		); err != nil {
-			// Rollback sale creation? For now, just log error
-			fmt.Printf("Failed to update inventory for sale: %v\n", err)
-		}
+	if product != nil {
+		uc.deductStock(sale, product, "Sale transaction", userID)
 	}
 
+	refreshDailyStats(uc.dailyStatsRepo, uc.businessRepo, businessID, sale.CreatedAt)
+
 	return sale, nil
 }
 func (uc *salesUseCase) GetSaleByID(id, businessID string) (*Domain.Sale, error) {
@@ -152,14 +207,28 @@ func (uc *salesUseCase) UpdateSale(id, businessID, userID string, req Domain.Cre
 		return nil, fmt.Errorf("cannot update sale with status: %s", sale.Status)
 	}
 
-	// Get previous product and quantity for inventory adjustment
-	var previousProductID *string
-	var previousQuantity float64
-	if sale.ProductID != nil {
-		// FIX: Dereference the pointer before calling Hex()
-		productIDStr := sale.ProductID.Hex()
-		previousProductID = &productIDStr
-		previousQuantity = sale.Quantity
+	// Keep the sale as it was for the inventory adjustment
+	previous := *sale
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if req.LocationID != nil && *req.LocationID != "" {
+		location, err := resolveLocation(uc.locationRepo, businessID, req.LocationID)
+		if err != nil {
+			return nil, err
+		}
+		sale.LocationID = &location.ID
+	}
+
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
 	}
 
 	// Update sale fields
@@ -180,41 +249,99 @@ func (uc *salesUseCase) UpdateSale(id, businessID, userID string, req Domain.Cre
 	sale.PaymentMethod = req.PaymentMethod
 	sale.Notes = req.Notes
 
-	if err := uc.salesRepo.Update(sale); err != nil {
-		return nil, fmt.Errorf("failed to update sale: %w", err)
+	// Look the product up before anything is released, so a failure here
+	// leaves the stored sale's allocations as they are
+	var product *Domain.Product
+	if sale.ProductID != nil {
+		product, err = uc.inventoryRepo.FindByID(sale.ProductID.Hex())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product: %w", err)
+		}
+	} else if len(req.Serials) > 0 {
+		return nil, fmt.Errorf("serial numbers can only be given for a product sale")
 	}
 
-	// Handle inventory adjustments if product changed
-	if previousProductID != nil {
-		// Restore previous product stock
-		referenceID := sale.ID.Hex()
-		uc.inventoryRepo.AdjustStock(
-			*previousProductID,
-			previousQuantity,
-			Domain.MovementTypeReturn,
-			"Sale update - restoring stock",
-			&referenceID,
-			"sale",
-			userID,
-		)
+	// The goods the sale took go back before the new quantity is deducted, so
+	// they count towards the stock available to it
+	sale.Warnings = nil
+	if product != nil {
+		location, err := resolveLocation(uc.locationRepo, businessID, saleLocationID(sale))
+		if err != nil {
+			return nil, err
+		}
+		sale.Warnings, err = uc.checkSaleStock(business, product, sale.Quantity, location, &previous)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if sale.ProductID != nil {
-		// Deduct new product stock
-		referenceID := sale.ID.Hex()
-		if err := uc.inventoryRepo.AdjustStock(
-			sale.ProductID.Hex(), // FIX: This should work now
-			sale.Quantity,
-			Domain.MovementTypeSale,
-			"Sale update - new sale",
-			&referenceID,
-			"sale",
-			userID,
-		); err != nil {
-			fmt.Printf("Failed to update inventory for sale update: %v\n", err)
+	// Return the previous batch allocations and pick batches again
+	previousBatches := sale.Batches
+	releaseBatches(uc.batchRepo, previousBatches)
+	sale.Batches = nil
+
+	// Bundle components and serials are taken again below
+	sale.Components = nil
+	sale.Serials = nil
+
+	if product != nil {
+		// Serials already sold on this sale stay available to it
+		sale.Serials, err = checkSaleSerials(uc.serialRepo, product, sale.Quantity, req.Serials, &sale.ID)
+		if err != nil {
+			retakeBatches(uc.batchRepo, previousBatches)
+			return nil, err
+		}
+	}
+	if product != nil && product.TrackBatches {
+		// The old quantity is still deducted from product stock at this point
+		if previous.ProductID != nil && *previous.ProductID == *sale.ProductID {
+			product.Stock += previous.Quantity
+		}
+		allocations, warning, err := allocateBatches(uc.batchRepo, product, sale.Quantity, req.BatchID,
+			Domain.EffectiveNegativeStockPolicy(business, product))
+		if err != nil {
+			retakeBatches(uc.batchRepo, previousBatches)
+			return nil, err
+		}
+		sale.Batches = allocations
+		if warning != "" {
+			sale.Warnings = append(sale.Warnings, warning)
 		}
 	}
 
+	// Return the units dropped from the sale and sell the ones added to it
+	removed, added := diffSerials(previous.Serials, sale.Serials)
+	returnSerials(uc.serialRepo, businessID, removed, &sale.ID, "Removed from sale", objUserID)
+	if err := sellSerials(uc.serialRepo, product, sale, added, objUserID); err != nil {
+		releaseBatches(uc.batchRepo, sale.Batches)
+		retakeBatches(uc.batchRepo, previousBatches)
+		uc.resellSerials(&previous, removed, objUserID)
+		return nil, err
+	}
+
+	sale.Edits = append(sale.Edits, Domain.SaleEdit{
+		EditedBy: objUserID,
+		EditedAt: time.Now(),
+	})
+
+	if err := uc.salesRepo.Update(sale); err != nil {
+		// Put the batches and serials back as the stored sale still has them
+		releaseBatches(uc.batchRepo, sale.Batches)
+		retakeBatches(uc.batchRepo, previousBatches)
+		returnSerials(uc.serialRepo, businessID, added, &sale.ID, "Sale update not completed", objUserID)
+		uc.resellSerials(&previous, removed, objUserID)
+		return nil, fmt.Errorf("failed to update sale: %w", err)
+	}
+
+	// Restore previous product stock, then deduct the new one
+	uc.restoreStock(&previous, "Sale update - restoring stock", userID)
+
+	if product != nil {
+		uc.deductStock(sale, product, "Sale update - new sale", userID)
+	}
+
+	refreshDailyStats(uc.dailyStatsRepo, uc.businessRepo, businessID, previous.CreatedAt, sale.CreatedAt)
+
 	return sale, nil
 }
 func (uc *salesUseCase) VoidSale(id, businessID, userID string) error {
@@ -228,56 +355,348 @@ func (uc *salesUseCase) VoidSale(id, businessID, userID string) error {
 		return fmt.Errorf("sale cannot be voided with status: %s", sale.Status)
 	}
 
-	// Update sale status
-	if err := uc.salesRepo.UpdateStatus(id, Domain.SaleStatusVoided); err != nil {
-		return fmt.Errorf("failed to void sale: %w", err)
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
 	}
 
+	// Update sale status, recording who voided it
+	voided, err := uc.salesRepo.Void(id, objUserID)
+	if err != nil {
+		return err
+	}
+	if !voided {
+		return fmt.Errorf("sale has already been voided")
+	}
+
+	// Put sold quantities back into their batches
+	releaseBatches(uc.batchRepo, sale.Batches)
+
+	// Serialised units come back into stock
+	returnSerials(uc.serialRepo, businessID, sale.Serials, &sale.ID, "Sale voided", objUserID)
+
 	// Restore inventory if product was sold
-	if sale.ProductID != nil {
-		referenceID := sale.ID.Hex()
-		if err := uc.inventoryRepo.AdjustStock(
-			sale.ProductID.Hex(),
+	uc.restoreStock(sale, "Sale voided - restoring stock", userID)
+
+	refreshDailyStats(uc.dailyStatsRepo, uc.businessRepo, businessID, sale.CreatedAt)
+
+	return nil
+}
+
+// resellSerials marks serials of a sale as sold again after they were
+// returned, used to undo a return when the replacement serials fail.
+func (uc *salesUseCase) resellSerials(sale *Domain.Sale, serials []string, userID primitive.ObjectID) {
+	if len(serials) == 0 || sale.ProductID == nil {
+		return
+	}
+
+	product, err := uc.inventoryRepo.FindByID(sale.ProductID.Hex())
+	if err != nil || product == nil {
+		fmt.Printf("Failed to find product to resell serial numbers: %v\n", err)
+		return
+	}
+
+	if err := sellSerials(uc.serialRepo, product, sale, serials, userID); err != nil {
+		fmt.Printf("Failed to resell serial numbers of sale %s: %v\n", sale.ID.Hex(), err)
+	}
+}
+
+// saleComponents returns the stock a sale of the product takes out in place
+// of the product's own: the components of a bundle, or the ingredients of a
+// recipe. It returns nil for products sold from their own stock.
+func (uc *salesUseCase) saleComponents(product *Domain.Product, quantity float64) ([]Domain.SaleComponent, error) {
+	if product.IsBundle() {
+		components := make([]Domain.SaleComponent, 0, len(product.Components))
+		for _, line := range product.Components {
+			components = append(components, Domain.SaleComponent{
+				ProductID: line.ProductID,
+				Quantity:  line.Quantity * quantity,
+			})
+		}
+		return components, nil
+	}
+
+	recipe, err := uc.recipeRepo.FindByProductID(product.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if recipe == nil {
+		return nil, nil
+	}
+
+	components := make([]Domain.SaleComponent, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		components = append(components, Domain.SaleComponent{
+			ProductID: ingredient.ProductID,
+			Quantity:  ingredient.Usage() * quantity,
+		})
+	}
+	return components, nil
+}
+
+// checkSaleStock makes sure there is enough stock of the product, or of each
+// component or ingredient it is made of, to sell the quantity. A shortfall
+// fails the sale under the block policy; under the warn policy it is returned
+// as a warning instead, and under the allow policy it is ignored. When a sale
+// is edited, previous is the stored sale: the goods it took go back to stock
+// before the new quantity is taken, so they count as available.
+func (uc *salesUseCase) checkSaleStock(business *Domain.Business, product *Domain.Product, quantity float64, location *Domain.Location, previous *Domain.Sale) ([]string, error) {
+	components, err := uc.saleComponents(product, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[primitive.ObjectID]float64)
+	heldHere := make(map[primitive.ObjectID]float64)
+	if previous != nil {
+		held = saleGoods(previous)
+		// A sale without a location took its goods from the default one
+		if location != nil && (previous.LocationID == nil && location.IsDefault ||
+			previous.LocationID != nil && *previous.LocationID == location.ID) {
+			heldHere = held
+		}
+	}
+
+	var warnings []string
+	check := func(item *Domain.Product, shortfall error) error {
+		if shortfall == nil {
+			return nil
+		}
+		switch Domain.EffectiveNegativeStockPolicy(business, item) {
+		case Domain.NegativeStockAllow:
+			return nil
+		case Domain.NegativeStockWarn:
+			warnings = append(warnings, fmt.Sprintf("%s will go below zero: %v", item.Name, shortfall))
+			return nil
+		}
+		return shortfall
+	}
+
+	if components == nil {
+		var shortfall error
+		if stock := product.Stock + held[product.ID]; stock < quantity {
+			shortfall = fmt.Errorf("insufficient stock. Available: %.2f, Requested: %.2f",
+				stock, quantity)
+		} else if location != nil {
+			shortfall = checkLocationStock(uc.stockLevelRepo, location, product.ID.Hex(), quantity-heldHere[product.ID])
+		}
+		if err := check(product, shortfall); err != nil {
+			return nil, err
+		}
+		return warnings, nil
+	}
+
+	for _, line := range components {
+		component, err := uc.inventoryRepo.FindByID(line.ProductID.Hex())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find component: %w", err)
+		}
+		if component == nil {
+			return nil, fmt.Errorf("a component of %s no longer exists", product.Name)
+		}
+
+		var shortfall error
+		if stock := component.Stock + held[line.ProductID]; stock < line.Quantity {
+			shortfall = fmt.Errorf("insufficient stock of %s. Available: %.2f, Requested: %.2f",
+				component.Name, stock, line.Quantity)
+		} else if location != nil {
+			shortfall = checkLocationStock(uc.stockLevelRepo, location, line.ProductID.Hex(), line.Quantity-heldHere[line.ProductID])
+		}
+		if err := check(component, shortfall); err != nil {
+			return nil, err
+		}
+	}
+
+	return warnings, nil
+}
+
+// deductStock takes the goods of a sale out of stock and records their cost:
+// the product itself, or the components or ingredients it is made of.
+func (uc *salesUseCase) deductStock(sale *Domain.Sale, product *Domain.Product, reason, userID string) {
+	referenceID := sale.ID.Hex()
+
+	lines, err := uc.saleComponents(product, sale.Quantity)
+	if err != nil {
+		fmt.Printf("Failed to load components for sale: %v\n", err)
+		return
+	}
+
+	if lines == nil {
+		movement, err := uc.costingUC.AdjustStock(
+			product.ID.Hex(),
 			sale.Quantity,
+			Domain.MovementTypeSale,
+			reason,
+			&referenceID,
+			"sale",
+			userID,
+			0,
+			saleLocationID(sale),
+		)
+		if err != nil {
+			// Rollback sale creation? For now, just log error
+			fmt.Printf("Failed to update inventory for sale: %v\n", err)
+		}
+		uc.recordCostOfGoods(sale, movement)
+		return
+	}
+
+	var components []Domain.SaleComponent
+	var costOfGoods float64
+	for _, component := range lines {
+		movement, err := uc.costingUC.AdjustStock(
+			component.ProductID.Hex(),
+			component.Quantity,
+			Domain.MovementTypeSale,
+			reason,
+			&referenceID,
+			"sale",
+			userID,
+			0,
+			saleLocationID(sale),
+		)
+		if err != nil {
+			fmt.Printf("Failed to update inventory of sale component: %v\n", err)
+			continue
+		}
+		if movement != nil {
+			component.CostOfGoods = movement.TotalCost
+		}
+
+		components = append(components, component)
+		costOfGoods += component.CostOfGoods
+	}
+
+	sale.Components = components
+	sale.CostOfGoods = costOfGoods
+	if err := uc.salesRepo.SetComponents(sale.ID.Hex(), components, costOfGoods); err != nil {
+		fmt.Printf("Failed to record components for sale: %v\n", err)
+	}
+}
+
+// restoreStock puts the goods of a sale back into stock at the cost they were
+// sold at.
+func (uc *salesUseCase) restoreStock(sale *Domain.Sale, reason, userID string) {
+	referenceID := sale.ID.Hex()
+
+	returnGoods := func(productID string, quantity, costOfGoods float64) {
+		var unitCost float64
+		if quantity > 0 {
+			unitCost = costOfGoods / quantity
+		}
+
+		if _, err := uc.costingUC.AdjustStock(
+			productID,
+			quantity,
 			Domain.MovementTypeReturn,
-			"Sale voided - restoring stock",
+			reason,
 			&referenceID,
 			"sale",
 			userID,
+			unitCost,
+			saleLocationID(sale),
 		); err != nil {
-			fmt.Printf("Failed to restore inventory for voided sale: %v\n", err)
+			fmt.Printf("Failed to restore inventory for sale: %v\n", err)
 		}
 	}
 
-	return nil
+	if len(sale.Components) > 0 {
+		for _, component := range sale.Components {
+			returnGoods(component.ProductID.Hex(), component.Quantity, component.CostOfGoods)
+		}
+		return
+	}
+
+	if sale.ProductID != nil {
+		returnGoods(sale.ProductID.Hex(), sale.Quantity, sale.CostOfGoods)
+	}
+}
+
+// saleGoods returns the quantity of each product a sale took out of stock.
+func saleGoods(sale *Domain.Sale) map[primitive.ObjectID]float64 {
+	goods := make(map[primitive.ObjectID]float64)
+	if len(sale.Components) > 0 {
+		for _, component := range sale.Components {
+			goods[component.ProductID] += component.Quantity
+		}
+		return goods
+	}
+	if sale.ProductID != nil {
+		goods[*sale.ProductID] += sale.Quantity
+	}
+	return goods
+}
+
+// saleLocationID returns the location a sale's goods left from, if any.
+func saleLocationID(sale *Domain.Sale) *string {
+	if sale.LocationID == nil {
+		return nil
+	}
+	id := sale.LocationID.Hex()
+	return &id
+}
+
+// recordCostOfGoods stores the cost of the stock movement that fulfilled a sale.
+func (uc *salesUseCase) recordCostOfGoods(sale *Domain.Sale, movement *Domain.StockMovement) {
+	if movement == nil {
+		return
+	}
+
+	sale.CostOfGoods = movement.TotalCost
+	if err := uc.salesRepo.SetCostOfGoods(sale.ID.Hex(), sale.CostOfGoods); err != nil {
+		fmt.Printf("Failed to record cost of goods for sale: %v\n", err)
+	}
 }
 
 func (uc *salesUseCase) GetSalesSummary(businessID string, period string) (*Domain.SaleSummary, error) {
-	now := time.Now()
-	var startDate, endDate time.Time
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The summary covers the whole days of the period, read from the rollups
+	startDate, endDate := summaryRange(period, time.Now().In(loc))
+	total, err := sumDailyStats(uc.dailyStatsRepo, businessID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales summary: %w", err)
+	}
+
+	return &Domain.SaleSummary{
+		Date:             startDate,
+		TotalSales:       total.ItemsSold,
+		TotalAmount:      total.Revenue,
+		TotalDiscount:    total.Discounts,
+		TotalTax:         total.Tax,
+		TransactionCount: total.Transactions,
+	}, nil
+}
 
+// summaryRange resolves a summary period to a range ending now, with day
+// boundaries in now's location.
+func summaryRange(period string, now time.Time) (time.Time, time.Time) {
 	switch period {
 	case "today":
-		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		endDate = startDate.Add(24 * time.Hour)
+		return Domain.StartOfDay(now), Domain.EndOfDay(now)
 	case "week":
-		startDate = now.AddDate(0, 0, -7)
-		endDate = now
+		return Domain.LastDays(now, 7), now
 	case "month":
-		startDate = now.AddDate(0, 0, -30)
-		endDate = now
+		return Domain.LastDays(now, 30), now
 	default:
-		startDate = now.AddDate(0, 0, -30) // Default to last 30 days
-		endDate = now
+		return Domain.LastDays(now, 30), now // Default to last 30 days
 	}
-
-	return uc.salesRepo.GetSummary(businessID, startDate, endDate)
 }
 
 func (uc *salesUseCase) GetSalesStats(businessID string, period string) (*Domain.SaleStats, error) {
 	return uc.salesRepo.GetStats(businessID, period)
 }
 
+// GetDailySales returns the completed sales of a calendar date, counted in
+// the business's timezone.
 func (uc *salesUseCase) GetDailySales(businessID string, date time.Time) ([]Domain.Sale, error) {
-	return uc.salesRepo.GetDailySales(businessID, date)
+	loc, err := businessLocation(uc.businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.salesRepo.GetDailySales(businessID, Domain.DateIn(date, loc))
 }
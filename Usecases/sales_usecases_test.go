@@ -0,0 +1,75 @@
+package Usecases
+
+import (
+	"testing"
+	"time"
+
+	Domain "ShopOps/Domain"
+)
+
+func TestSummaryRangeToday(t *testing.T) {
+	addisAbaba := mustLoadLocation(t, "Africa/Addis_Ababa")
+	santiago := mustLoadLocation(t, "America/Santiago")
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "late in the evening",
+			now:       time.Date(2024, 3, 10, 23, 59, 0, 0, addisAbaba),
+			wantStart: time.Date(2024, 3, 10, 0, 0, 0, 0, addisAbaba),
+			wantEnd:   time.Date(2024, 3, 10, 23, 59, 59, 999999999, addisAbaba),
+		},
+		{
+			name:      "evening before in UTC",
+			now:       time.Date(2024, 3, 10, 21, 30, 0, 0, time.UTC).In(addisAbaba),
+			wantStart: time.Date(2024, 3, 11, 0, 0, 0, 0, addisAbaba),
+			wantEnd:   time.Date(2024, 3, 11, 23, 59, 59, 999999999, addisAbaba),
+		},
+		{
+			name:      "DST gap",
+			now:       time.Date(2024, 9, 8, 12, 0, 0, 0, santiago),
+			wantStart: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 9, 8, 23, 59, 59, 999999999, santiago),
+		},
+		{
+			name:      "repeated hour",
+			now:       time.Date(2024, 4, 6, 23, 30, 0, 0, santiago),
+			wantStart: time.Date(2024, 4, 6, 0, 0, 0, 0, santiago),
+			wantEnd:   time.Date(2024, 4, 7, 0, 0, 0, 0, santiago).Add(-time.Nanosecond),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := summaryRange("today", tt.now)
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("start = %v, want %v", start, tt.wantStart)
+			}
+			if !end.Equal(tt.wantEnd) {
+				t.Errorf("end = %v, want %v", end, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestSummaryRangeRollingPeriods(t *testing.T) {
+	santiago := mustLoadLocation(t, "America/Santiago")
+	now := time.Date(2024, 9, 14, 23, 30, 0, 0, santiago)
+
+	for period, wantDays := range map[string]int{"today": 1, "week": 7, "month": 30, "": 30} {
+		start, end := summaryRange(period, now)
+		if period != "today" && !end.Equal(now) {
+			t.Errorf("%q: end = %v, want now", period, end)
+		}
+		if !start.Equal(Domain.StartOfDay(start)) {
+			t.Errorf("%q: start %v is not the start of a day", period, start)
+		}
+		if days := calendarDays(start, end); days != wantDays {
+			t.Errorf("%q: covers %d days, want %d", period, days, wantDays)
+		}
+	}
+}
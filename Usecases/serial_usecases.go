@@ -0,0 +1,469 @@
+package Usecases
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	Domain "ShopOps/Domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SerialUseCase interface {
+	ReceiveSerials(productID, businessID, userID string, req Domain.ReceiveSerialsRequest) ([]Domain.SerialNumber, error)
+	GetProductSerials(productID, businessID string, status *Domain.SerialStatus) (*Domain.ProductSerials, error)
+	LookupSerial(businessID, serial string) (*Domain.SerialNumber, error)
+	WriteOffSerial(businessID, userID, serial string, req Domain.WriteOffSerialRequest) (*Domain.SerialNumber, error)
+}
+
+type serialUseCase struct {
+	serialRepo    Domain.SerialRepository
+	inventoryRepo Domain.ProductRepository
+	businessRepo  Domain.BusinessRepository
+	locationRepo  Domain.LocationRepository
+	costingUC     CostingUseCase
+}
+
+func NewSerialUseCase(
+	serialRepo Domain.SerialRepository,
+	inventoryRepo Domain.ProductRepository,
+	businessRepo Domain.BusinessRepository,
+	locationRepo Domain.LocationRepository,
+	costingUC CostingUseCase,
+) SerialUseCase {
+	return &serialUseCase{
+		serialRepo:    serialRepo,
+		inventoryRepo: inventoryRepo,
+		businessRepo:  businessRepo,
+		locationRepo:  locationRepo,
+		costingUC:     costingUC,
+	}
+}
+
+// ReceiveSerials books serialised units into stock, one per serial number.
+// Existing serials are registered against stock already on hand instead.
+func (uc *serialUseCase) ReceiveSerials(productID, businessID, userID string, req Domain.ReceiveSerialsRequest) ([]Domain.SerialNumber, error) {
+	product, err := uc.getProduct(productID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !product.TrackSerials {
+		return nil, fmt.Errorf("%s is not serial-tracked", product.Name)
+	}
+
+	serials, err := normalizeSerials(req.Serials)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := resolveLocation(uc.locationRepo, businessID, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if req.Existing {
+		inStock, err := uc.serialRepo.CountInStock(productID)
+		if err != nil {
+			return nil, err
+		}
+		untracked := product.Stock - float64(inStock)
+		if float64(len(serials)) > untracked {
+			return nil, fmt.Errorf("only %.0f units of %s are in stock without a serial number", math.Max(untracked, 0), product.Name)
+		}
+	}
+
+	costPrice := req.CostPrice
+	if costPrice <= 0 {
+		costPrice = product.CostPrice
+	}
+
+	note := "Received"
+	if req.Existing {
+		note = "Registered from existing stock"
+	}
+
+	received, err := receiveSerials(uc.serialRepo, product, serials, costPrice, nil, "", note, objUserID)
+	if err != nil {
+		deleteSerials(uc.serialRepo, received)
+		return nil, err
+	}
+
+	if req.Existing {
+		return received, nil
+	}
+
+	if _, err := uc.costingUC.AdjustStock(
+		productID,
+		float64(len(received)),
+		Domain.MovementTypePurchase,
+		fmt.Sprintf("%d serialised units received", len(received)),
+		nil,
+		"serial",
+		userID,
+		costPrice,
+		locationIDOf(location),
+	); err != nil {
+		// Without the stock the serials would be in stock with nothing behind them
+		deleteSerials(uc.serialRepo, received)
+		return nil, fmt.Errorf("failed to update stock for serial numbers: %w", err)
+	}
+
+	return received, nil
+}
+
+func (uc *serialUseCase) GetProductSerials(productID, businessID string, status *Domain.SerialStatus) (*Domain.ProductSerials, error) {
+	product, err := uc.getProduct(productID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	serials, err := uc.serialRepo.FindByProductID(productID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	inStock, err := uc.serialRepo.CountInStock(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if serials == nil {
+		serials = []Domain.SerialNumber{}
+	}
+
+	return &Domain.ProductSerials{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Stock:       product.Stock,
+		InStock:     inStock,
+		Untracked:   product.Stock - float64(inStock),
+		Serials:     serials,
+	}, nil
+}
+
+func (uc *serialUseCase) LookupSerial(businessID, serial string) (*Domain.SerialNumber, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	found, err := uc.serialRepo.FindBySerial(businessID, normalizeSerial(serial))
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("serial number not found")
+	}
+
+	return found, nil
+}
+
+// WriteOffSerial takes a damaged or lost unit out of stock.
+func (uc *serialUseCase) WriteOffSerial(businessID, userID, serial string, req Domain.WriteOffSerialRequest) (*Domain.SerialNumber, error) {
+	if req.Reason == "" {
+		return nil, fmt.Errorf("reason is required to write off a serial number")
+	}
+
+	found, err := uc.LookupSerial(businessID, serial)
+	if err != nil {
+		return nil, err
+	}
+	if found.Status != Domain.SerialStatusInStock {
+		return nil, fmt.Errorf("serial number %s is %s", found.Serial, found.Status)
+	}
+
+	location, err := resolveLocation(uc.locationRepo, businessID, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	found.Status = Domain.SerialStatusWrittenOff
+	if err := uc.serialRepo.UpdateStatus(found, Domain.SerialStatusInStock, Domain.SerialEvent{
+		Type:   Domain.SerialEventWrittenOff,
+		At:     time.Now(),
+		Note:   req.Reason,
+		UserID: objUserID,
+	}); err != nil {
+		return nil, err
+	}
+
+	referenceID := found.ID.Hex()
+	if _, err := uc.costingUC.AdjustStock(
+		found.ProductID.Hex(),
+		1,
+		Domain.MovementTypeDamage,
+		fmt.Sprintf("Serial %s written off: %s", found.Serial, req.Reason),
+		&referenceID,
+		"serial",
+		userID,
+		0,
+		locationIDOf(location),
+	); err != nil {
+		return nil, fmt.Errorf("failed to update stock for serial number: %w", err)
+	}
+
+	return found, nil
+}
+
+func (uc *serialUseCase) getProduct(productID, businessID string) (*Domain.Product, error) {
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	if product.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: product does not belong to this business")
+	}
+	return product, nil
+}
+
+// normalizeSerial trims a serial number and upper-cases it so lookups do not
+// depend on how it was typed or scanned.
+func normalizeSerial(serial string) string {
+	return strings.ToUpper(strings.TrimSpace(serial))
+}
+
+// normalizeSerials normalises a list of serial numbers, rejecting blanks and
+// repeats.
+func normalizeSerials(serials []string) ([]string, error) {
+	if len(serials) == 0 {
+		return nil, fmt.Errorf("at least one serial number is required")
+	}
+
+	seen := make(map[string]bool, len(serials))
+	normalized := make([]string, 0, len(serials))
+	for _, serial := range serials {
+		serial = normalizeSerial(serial)
+		if serial == "" {
+			return nil, fmt.Errorf("serial numbers cannot be blank")
+		}
+		if seen[serial] {
+			return nil, fmt.Errorf("serial number %s is listed more than once", serial)
+		}
+		seen[serial] = true
+		normalized = append(normalized, serial)
+	}
+
+	return normalized, nil
+}
+
+// receiveSerials records new in-stock serial numbers of a product. It checks
+// every serial before creating any so a single duplicate rejects the lot.
+func receiveSerials(
+	serialRepo Domain.SerialRepository,
+	product *Domain.Product,
+	serials []string,
+	costPrice float64,
+	referenceID *primitive.ObjectID,
+	referenceType, note string,
+	userID primitive.ObjectID,
+) ([]Domain.SerialNumber, error) {
+	for _, serial := range serials {
+		existing, err := serialRepo.FindBySerial(product.BusinessID.Hex(), serial)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("serial number %s is already recorded for %s", serial, existing.ProductName)
+		}
+	}
+
+	now := time.Now()
+	received := make([]Domain.SerialNumber, 0, len(serials))
+	for _, serial := range serials {
+		record := Domain.SerialNumber{
+			BusinessID:  product.BusinessID,
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Serial:      serial,
+			CostPrice:   costPrice,
+			ReceivedAt:  now,
+			Events: []Domain.SerialEvent{{
+				Type:          Domain.SerialEventReceived,
+				At:            now,
+				ReferenceID:   referenceID,
+				ReferenceType: referenceType,
+				Note:          note,
+				UserID:        userID,
+			}},
+			CreatedBy: userID,
+		}
+		if err := serialRepo.Create(&record); err != nil {
+			if errors.Is(err, Domain.ErrSerialExists) {
+				return received, fmt.Errorf("serial number %s is already recorded", serial)
+			}
+			return received, err
+		}
+		received = append(received, record)
+	}
+
+	return received, nil
+}
+
+// deleteSerials removes serials whose receipt did not complete.
+func deleteSerials(serialRepo Domain.SerialRepository, serials []Domain.SerialNumber) {
+	for _, serial := range serials {
+		if err := serialRepo.Delete(serial.ID.Hex()); err != nil {
+			fmt.Printf("Failed to delete serial number %s: %v\n", serial.Serial, err)
+		}
+	}
+}
+
+// checkSaleSerials makes sure a sale of a serialised product names one
+// in-stock serial of the product per unit, and returns them normalised.
+// Serials already sold on the given sale also count as available.
+func checkSaleSerials(serialRepo Domain.SerialRepository, product *Domain.Product, quantity float64, serials []string, saleID *primitive.ObjectID) ([]string, error) {
+	if !product.TrackSerials {
+		if len(serials) > 0 {
+			return nil, fmt.Errorf("%s is not serial-tracked", product.Name)
+		}
+		return nil, nil
+	}
+
+	if quantity != math.Trunc(quantity) {
+		return nil, fmt.Errorf("serialised products are sold in whole units")
+	}
+
+	normalized, err := normalizeSerials(serials)
+	if err != nil {
+		return nil, fmt.Errorf("%s is serial-tracked: %w", product.Name, err)
+	}
+	if float64(len(normalized)) != quantity {
+		return nil, fmt.Errorf("%d serial numbers given for a quantity of %.0f", len(normalized), quantity)
+	}
+
+	for _, serial := range normalized {
+		found, err := serialRepo.FindBySerial(product.BusinessID.Hex(), serial)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil || found.ProductID != product.ID {
+			return nil, fmt.Errorf("serial number %s not found for %s", serial, product.Name)
+		}
+		if found.Status == Domain.SerialStatusSold && saleID != nil && found.SaleID != nil && *found.SaleID == *saleID {
+			continue
+		}
+		if found.Status != Domain.SerialStatusInStock {
+			return nil, fmt.Errorf("serial number %s is %s", serial, found.Status)
+		}
+	}
+
+	return normalized, nil
+}
+
+// sellSerials marks serials as sold to the customer of a sale and starts
+// their warranty. Serials already marked are put back when one fails.
+func sellSerials(serialRepo Domain.SerialRepository, product *Domain.Product, sale *Domain.Sale, serials []string, userID primitive.ObjectID) error {
+	if len(serials) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var warrantyExpiresAt *time.Time
+	if product.WarrantyMonths > 0 {
+		expires := now.AddDate(0, product.WarrantyMonths, 0)
+		warrantyExpiresAt = &expires
+	}
+
+	for i, serial := range serials {
+		found, err := serialRepo.FindBySerial(sale.BusinessID.Hex(), serial)
+		if err == nil && found == nil {
+			err = fmt.Errorf("serial number %s not found", serial)
+		}
+		if err == nil {
+			found.Status = Domain.SerialStatusSold
+			found.SaleID = &sale.ID
+			found.CustomerName = sale.CustomerName
+			found.CustomerPhone = sale.CustomerPhone
+			found.SoldAt = &now
+			found.WarrantyExpiresAt = warrantyExpiresAt
+			err = serialRepo.UpdateStatus(found, Domain.SerialStatusInStock, Domain.SerialEvent{
+				Type:          Domain.SerialEventSold,
+				At:            now,
+				ReferenceID:   &sale.ID,
+				ReferenceType: "sale",
+				CustomerName:  sale.CustomerName,
+				CustomerPhone: sale.CustomerPhone,
+				UserID:        userID,
+			})
+		}
+		if err != nil {
+			returnSerials(serialRepo, sale.BusinessID.Hex(), serials[:i], &sale.ID, "Sale not completed", userID)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// returnSerials puts the units of a sale back into stock, keeping the sale in
+// their history.
+func returnSerials(serialRepo Domain.SerialRepository, businessID string, serials []string, saleID *primitive.ObjectID, note string, userID primitive.ObjectID) {
+	for _, serial := range serials {
+		found, err := serialRepo.FindBySerial(businessID, serial)
+		if err != nil || found == nil {
+			fmt.Printf("Failed to find serial number %s to return: %v\n", serial, err)
+			continue
+		}
+
+		event := Domain.SerialEvent{
+			Type:          Domain.SerialEventReturned,
+			At:            time.Now(),
+			ReferenceID:   saleID,
+			ReferenceType: "sale",
+			CustomerName:  found.CustomerName,
+			CustomerPhone: found.CustomerPhone,
+			Note:          note,
+			UserID:        userID,
+		}
+
+		found.Status = Domain.SerialStatusInStock
+		found.SaleID = nil
+		found.CustomerName = ""
+		found.CustomerPhone = ""
+		found.SoldAt = nil
+		found.WarrantyExpiresAt = nil
+		if err := serialRepo.UpdateStatus(found, Domain.SerialStatusSold, event); err != nil {
+			fmt.Printf("Failed to return serial number %s: %v\n", serial, err)
+		}
+	}
+}
+
+// diffSerials returns the serials dropped from a list and those added to it.
+func diffSerials(before, after []string) (removed, added []string) {
+	inBefore := make(map[string]bool, len(before))
+	for _, serial := range before {
+		inBefore[serial] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, serial := range after {
+		inAfter[serial] = true
+		if !inBefore[serial] {
+			added = append(added, serial)
+		}
+	}
+	for _, serial := range before {
+		if !inAfter[serial] {
+			removed = append(removed, serial)
+		}
+	}
+	return removed, added
+}
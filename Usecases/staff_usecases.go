@@ -0,0 +1,250 @@
+package Usecases
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	Domain "ShopOps/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultStaffReportDays is how many days a staff report covers when no
+// start date is given.
+const defaultStaffReportDays = 30
+
+// StaffReportUseCase reports what each user of a business did at the till.
+// The reports are for the business owner only.
+type StaffReportUseCase interface {
+	GetStaffPerformance(businessID, userID string, req Domain.StaffReportRequest) (*Domain.StaffPerformanceReport, error)
+	GetStaffTransactions(businessID, userID, staffID string, req Domain.StaffReportRequest) (*Domain.StaffTransactions, error)
+}
+
+type staffReportUseCase struct {
+	salesRepo    Domain.SaleRepository
+	businessRepo Domain.BusinessRepository
+	userRepo     Domain.UserRepository
+}
+
+func NewStaffReportUseCase(
+	salesRepo Domain.SaleRepository,
+	businessRepo Domain.BusinessRepository,
+	userRepo Domain.UserRepository,
+) StaffReportUseCase {
+	return &staffReportUseCase{
+		salesRepo:    salesRepo,
+		businessRepo: businessRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (uc *staffReportUseCase) GetStaffPerformance(businessID, userID string, req Domain.StaffReportRequest) (*Domain.StaffPerformanceReport, error) {
+	business, err := uc.ownBusiness(businessID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := resolveDayPeriod(req.StartDate, req.EndDate, defaultStaffReportDays, time.Now().In(business.Location()))
+	if err != nil {
+		return nil, err
+	}
+
+	sales, err := uc.salesRepo.FindStaffActivity(businessID, nil, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	staff := make(map[primitive.ObjectID]*Domain.StaffPerformance)
+	member := func(id primitive.ObjectID) *Domain.StaffPerformance {
+		if performance, ok := staff[id]; ok {
+			return performance
+		}
+		performance := &Domain.StaffPerformance{UserID: id}
+		staff[id] = performance
+		return performance
+	}
+
+	for _, sale := range sales {
+		seller := sale.CreatedBy
+
+		if inPeriod(sale.CreatedAt, period) {
+			performance := member(seller)
+			switch sale.Status {
+			case Domain.SaleStatusCompleted:
+				performance.Sales++
+				performance.Revenue += sale.FinalAmount
+				performance.ItemsSold += sale.Quantity
+				performance.Discounts += sale.Discount
+			case Domain.SaleStatusRefunded:
+				performance.Refunds++
+				performance.RefundedAmount += sale.FinalAmount
+			}
+		}
+
+		if sale.VoidedBy != nil && sale.VoidedAt != nil && inPeriod(*sale.VoidedAt, period) {
+			performance := member(*sale.VoidedBy)
+			performance.Voids++
+			performance.VoidedAmount += sale.FinalAmount
+			if *sale.VoidedBy != seller {
+				performance.VoidsOfOthers++
+				member(seller).SalesVoidedByOthers++
+			}
+		}
+
+		editedByOthers := false
+		for _, edit := range sale.Edits {
+			if !inPeriod(edit.EditedAt, period) {
+				continue
+			}
+			performance := member(edit.EditedBy)
+			performance.Edits++
+			if edit.EditedBy != seller {
+				performance.EditsOfOthers++
+				editedByOthers = true
+			}
+		}
+		if editedByOthers {
+			member(seller).SalesEditedByOthers++
+		}
+	}
+
+	report := &Domain.StaffPerformanceReport{
+		Period: period,
+		Staff:  make([]Domain.StaffPerformance, 0, len(staff)),
+	}
+	users := make(map[primitive.ObjectID]*Domain.User)
+	for id, performance := range staff {
+		if performance.Sales > 0 {
+			performance.AverageBasket = performance.Revenue / float64(performance.Sales)
+		}
+		performance.Name, performance.Role = uc.userName(users, id)
+		report.Staff = append(report.Staff, *performance)
+	}
+
+	sort.Slice(report.Staff, func(i, j int) bool {
+		if report.Staff[i].Revenue != report.Staff[j].Revenue {
+			return report.Staff[i].Revenue > report.Staff[j].Revenue
+		}
+		return report.Staff[i].Name < report.Staff[j].Name
+	})
+
+	return report, nil
+}
+
+func (uc *staffReportUseCase) GetStaffTransactions(businessID, userID, staffID string, req Domain.StaffReportRequest) (*Domain.StaffTransactions, error) {
+	business, err := uc.ownBusiness(businessID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	objStaffID, err := primitive.ObjectIDFromHex(staffID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	period, err := resolveDayPeriod(req.StartDate, req.EndDate, defaultStaffReportDays, time.Now().In(business.Location()))
+	if err != nil {
+		return nil, err
+	}
+
+	sales, err := uc.salesRepo.FindStaffActivity(businessID, &objStaffID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[primitive.ObjectID]*Domain.User)
+	name, _ := uc.userName(users, objStaffID)
+
+	result := &Domain.StaffTransactions{
+		UserID:       objStaffID,
+		Name:         name,
+		Period:       period,
+		Transactions: make([]Domain.StaffTransaction, 0, len(sales)),
+	}
+
+	for _, sale := range sales {
+		transaction := Domain.StaffTransaction{
+			Sale:    sale,
+			Actions: []Domain.StaffAction{},
+		}
+		transaction.SellerName, _ = uc.userName(users, sale.CreatedBy)
+
+		if sale.CreatedBy == objStaffID && inPeriod(sale.CreatedAt, period) {
+			transaction.Actions = append(transaction.Actions, Domain.StaffActionSold)
+		}
+		if sale.VoidedBy != nil && *sale.VoidedBy == objStaffID && sale.VoidedAt != nil && inPeriod(*sale.VoidedAt, period) {
+			transaction.Actions = append(transaction.Actions, Domain.StaffActionVoided)
+		}
+		for _, edit := range sale.Edits {
+			if edit.EditedBy == objStaffID && inPeriod(edit.EditedAt, period) {
+				transaction.Actions = append(transaction.Actions, Domain.StaffActionEdited)
+				break
+			}
+		}
+
+		// Corrections by anyone but the seller stand out
+		if sale.VoidedBy != nil {
+			transaction.VoidedByName, _ = uc.userName(users, *sale.VoidedBy)
+			if *sale.VoidedBy != sale.CreatedBy {
+				transaction.Highlighted = true
+				transaction.Notes = append(transaction.Notes,
+					fmt.Sprintf("Voided by %s, not the seller %s", transaction.VoidedByName, transaction.SellerName))
+			}
+		}
+		editors := make(map[primitive.ObjectID]bool)
+		for _, edit := range sale.Edits {
+			if edit.EditedBy == sale.CreatedBy || editors[edit.EditedBy] {
+				continue
+			}
+			editors[edit.EditedBy] = true
+			editor, _ := uc.userName(users, edit.EditedBy)
+			transaction.EditorNames = append(transaction.EditorNames, editor)
+			transaction.Highlighted = true
+			transaction.Notes = append(transaction.Notes,
+				fmt.Sprintf("Edited by %s, not the seller %s", editor, transaction.SellerName))
+		}
+
+		result.Transactions = append(result.Transactions, transaction)
+	}
+
+	return result, nil
+}
+
+// ownBusiness returns the business when the user owns it.
+func (uc *staffReportUseCase) ownBusiness(businessID, userID string) (*Domain.Business, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+	if business.UserID.Hex() != userID {
+		return nil, fmt.Errorf("access denied: only the business owner can view staff performance")
+	}
+
+	return business, nil
+}
+
+// userName looks each user up once per report, and names users that no
+// longer exist by their ID.
+func (uc *staffReportUseCase) userName(users map[primitive.ObjectID]*Domain.User, id primitive.ObjectID) (string, Domain.UserRole) {
+	user, ok := users[id]
+	if !ok {
+		found, err := uc.userRepo.FindByID(id.Hex())
+		if err != nil {
+			fmt.Printf("Failed to find user for staff report: %v\n", err)
+		}
+		user = found
+		users[id] = user
+	}
+	if user == nil {
+		return "Unknown user " + id.Hex(), ""
+	}
+	return user.Name, user.Role
+}
+
+func inPeriod(t time.Time, period Domain.ComparisonPeriod) bool {
+	return !t.Before(period.StartDate) && !t.After(period.EndDate)
+}
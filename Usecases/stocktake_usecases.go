@@ -0,0 +1,397 @@
+package Usecases
+
+import (
+	"fmt"
+
+	Domain "ShopOps/Domain"
+	Infrastructure "ShopOps/Infrastructure"
+)
+
+type StocktakeUseCase interface {
+	CreateStocktake(businessID, userID string, req Domain.CreateStocktakeRequest) (*Domain.Stocktake, error)
+	GetStocktake(id, businessID string) (*Domain.Stocktake, error)
+	GetStocktakes(businessID string, status *Domain.StocktakeStatus) ([]Domain.Stocktake, error)
+	RecordCounts(id, businessID, userID string, req Domain.RecordStocktakeCountsRequest) (*Domain.Stocktake, error)
+	PostStocktake(id, businessID, userID string) (*Domain.PostStocktakeResult, error)
+	CancelStocktake(id, businessID, userID string) error
+	ExportStocktake(id, businessID, format string) ([]byte, string, error)
+}
+
+type stocktakeUseCase struct {
+	stocktakeRepo  Domain.StocktakeRepository
+	inventoryRepo  Domain.ProductRepository
+	businessRepo   Domain.BusinessRepository
+	categoryRepo   Domain.CategoryRepository
+	locationRepo   Domain.LocationRepository
+	stockLevelRepo Domain.StockLevelRepository
+	costingUC      CostingUseCase
+	exportService  Infrastructure.ExportService
+}
+
+func NewStocktakeUseCase(
+	stocktakeRepo Domain.StocktakeRepository,
+	inventoryRepo Domain.ProductRepository,
+	businessRepo Domain.BusinessRepository,
+	categoryRepo Domain.CategoryRepository,
+	locationRepo Domain.LocationRepository,
+	stockLevelRepo Domain.StockLevelRepository,
+	costingUC CostingUseCase,
+	exportService Infrastructure.ExportService,
+) StocktakeUseCase {
+	return &stocktakeUseCase{
+		stocktakeRepo:  stocktakeRepo,
+		inventoryRepo:  inventoryRepo,
+		businessRepo:   businessRepo,
+		categoryRepo:   categoryRepo,
+		locationRepo:   locationRepo,
+		stockLevelRepo: stockLevelRepo,
+		costingUC:      costingUC,
+		exportService:  exportService,
+	}
+}
+
+func (uc *stocktakeUseCase) CreateStocktake(businessID, userID string, req Domain.CreateStocktakeRequest) (*Domain.Stocktake, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("stocktake name is required")
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	// Snapshot the expected quantities of the products being counted
+	status := Domain.ProductStatusActive
+	filters := Domain.ProductFilters{Status: &status}
+	if req.Category != "" {
+		filters.Category = &req.Category
+		if err := expandCategoryFilter(uc.categoryRepo, businessID, &filters); err != nil {
+			return nil, err
+		}
+	}
+
+	products, err := uc.inventoryRepo.FindByBusinessID(businessID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(products) == 0 {
+		return nil, fmt.Errorf("no active products to count")
+	}
+
+	// Counting a single location compares against the stock held there
+	var location *Domain.Location
+	var levels map[string]float64
+	if req.LocationID != "" {
+		location, err = resolveLocation(uc.locationRepo, businessID, &req.LocationID)
+		if err != nil {
+			return nil, err
+		}
+
+		locationLevels, err := uc.stockLevelRepo.FindByLocationID(req.LocationID)
+		if err != nil {
+			return nil, err
+		}
+
+		levels = make(map[string]float64)
+		for _, level := range locationLevels {
+			levels[level.ProductID.Hex()] = level.Quantity
+		}
+	}
+
+	items := make([]Domain.StocktakeItem, 0, len(products))
+	for _, product := range products {
+		// Bundles are counted through their components
+		if product.IsBundle() {
+			continue
+		}
+
+		unitCost := product.AverageCost
+		if unitCost <= 0 {
+			unitCost = product.CostPrice
+		}
+
+		expected := product.Stock
+		if location != nil {
+			expected = levels[product.ID.Hex()]
+		}
+
+		items = append(items, Domain.StocktakeItem{
+			ProductID:        product.ID,
+			ProductName:      product.Name,
+			SKU:              product.SKU,
+			Barcode:          product.Barcode,
+			Unit:             product.Unit,
+			ExpectedQuantity: expected,
+			UnitCost:         unitCost,
+		})
+	}
+
+	stocktake := &Domain.Stocktake{
+		BusinessID: business.ID,
+		Name:       req.Name,
+		Category:   req.Category,
+		Notes:      req.Notes,
+		Items:      items,
+		CreatedBy:  objUserID,
+	}
+	if location != nil {
+		stocktake.LocationID = &location.ID
+	}
+
+	if err := uc.stocktakeRepo.Create(stocktake); err != nil {
+		return nil, fmt.Errorf("failed to create stocktake: %w", err)
+	}
+
+	stocktake.CalculateVariances()
+	return stocktake, nil
+}
+
+func (uc *stocktakeUseCase) GetStocktake(id, businessID string) (*Domain.Stocktake, error) {
+	stocktake, err := uc.stocktakeRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if stocktake == nil {
+		return nil, fmt.Errorf("stocktake not found")
+	}
+
+	if stocktake.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: stocktake does not belong to this business")
+	}
+
+	stocktake.CalculateVariances()
+	return stocktake, nil
+}
+
+func (uc *stocktakeUseCase) GetStocktakes(businessID string, status *Domain.StocktakeStatus) ([]Domain.Stocktake, error) {
+	stocktakes, err := uc.stocktakeRepo.FindByBusinessID(businessID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stocktakes {
+		stocktakes[i].CalculateVariances()
+	}
+
+	return stocktakes, nil
+}
+
+func (uc *stocktakeUseCase) RecordCounts(id, businessID, userID string, req Domain.RecordStocktakeCountsRequest) (*Domain.Stocktake, error) {
+	stocktake, err := uc.GetStocktake(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stocktake.Status != Domain.StocktakeStatusOpen {
+		return nil, fmt.Errorf("stocktake is %s", stocktake.Status)
+	}
+
+	if len(req.Counts) == 0 {
+		return nil, fmt.Errorf("at least one count is required")
+	}
+
+	for _, count := range req.Counts {
+		productID, err := resolveStocktakeProduct(stocktake, count)
+		if err != nil {
+			return nil, err
+		}
+
+		mode := count.Mode
+		if mode == "" {
+			mode = Domain.StocktakeCountModeAdd
+		}
+
+		quantity := count.Quantity
+		switch mode {
+		case Domain.StocktakeCountModeAdd:
+			// A bare scan counts one unit
+			if quantity == 0 {
+				quantity = 1
+			}
+		case Domain.StocktakeCountModeSet:
+			if quantity < 0 {
+				return nil, fmt.Errorf("counted quantity cannot be negative")
+			}
+		default:
+			return nil, fmt.Errorf("invalid count mode: %s", mode)
+		}
+
+		// Compare the count with the stock held now, not when the session
+		// was opened, as sales and receipts since then are already in it
+		expected, err := uc.heldQuantity(stocktake, productID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := uc.stocktakeRepo.RecordCount(id, productID, quantity, mode == Domain.StocktakeCountModeAdd, expected, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.GetStocktake(id, businessID)
+}
+
+// PostStocktake closes the session and adjusts stock by each counted item's
+// variance. Each variance is against the stock held when the item was
+// counted, so stock sold or received while counting moves only once.
+// Batch-tracked and serial-tracked stock is not adjusted; its variances are
+// returned as errors to be settled through the batches or serials.
+func (uc *stocktakeUseCase) PostStocktake(id, businessID, userID string) (*Domain.PostStocktakeResult, error) {
+	stocktake, err := uc.GetStocktake(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stocktake.Status != Domain.StocktakeStatusOpen {
+		return nil, fmt.Errorf("stocktake is %s", stocktake.Status)
+	}
+
+	if stocktake.Summary.CountedItems == 0 {
+		return nil, fmt.Errorf("no items have been counted")
+	}
+
+	if err := uc.stocktakeRepo.UpdateStatus(id, Domain.StocktakeStatusPosted, userID); err != nil {
+		return nil, err
+	}
+
+	result := &Domain.PostStocktakeResult{}
+	reason := fmt.Sprintf("Stocktake %s", stocktake.Name)
+
+	var locationID *string
+	if stocktake.LocationID != nil {
+		locationHex := stocktake.LocationID.Hex()
+		locationID = &locationHex
+	}
+
+	for i := range stocktake.Items {
+		item := &stocktake.Items[i]
+		if item.CountedQuantity == nil || item.Variance == 0 {
+			continue
+		}
+
+		product, err := uc.inventoryRepo.FindByID(item.ProductID.Hex())
+		if err != nil || product == nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: product not found", item.ProductName))
+			continue
+		}
+		if product.TrackBatches {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: batch-tracked stock is adjusted through its batches; receive or write off batches instead", item.ProductName))
+			continue
+		}
+		if product.TrackSerials {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: serial-tracked stock changes through its serial numbers; receive or write off serials instead", item.ProductName))
+			continue
+		}
+
+		movement, err := uc.costingUC.AdjustStock(
+			item.ProductID.Hex(),
+			item.Variance,
+			Domain.MovementTypeAdjust,
+			reason,
+			&id,
+			"stocktake",
+			userID,
+			item.UnitCost,
+			locationID,
+		)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", item.ProductName, err))
+			continue
+		}
+
+		// Record the value the ledger actually posted
+		if movement != nil && movement.TotalCost > 0 {
+			if item.Variance < 0 {
+				item.VarianceValue = -movement.TotalCost
+			} else {
+				item.VarianceValue = movement.TotalCost
+			}
+		}
+
+		result.AdjustmentsMade++
+	}
+
+	if err := uc.stocktakeRepo.UpdateItems(id, stocktake.Items); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	posted, err := uc.GetStocktake(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Stocktake = posted
+	result.NetVarianceValue = posted.Summary.NetVarianceValue
+	return result, nil
+}
+
+func (uc *stocktakeUseCase) CancelStocktake(id, businessID, userID string) error {
+	if _, err := uc.GetStocktake(id, businessID); err != nil {
+		return err
+	}
+
+	return uc.stocktakeRepo.UpdateStatus(id, Domain.StocktakeStatusCancelled, userID)
+}
+
+func (uc *stocktakeUseCase) ExportStocktake(id, businessID, format string) ([]byte, string, error) {
+	stocktake, err := uc.GetStocktake(id, businessID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return exportReport(uc.exportService, stocktake, Domain.ReportTypeStocktake, format)
+}
+
+// heldQuantity returns the stock of a product the stocktake counts: its
+// level at the counted location, or its stock across the business.
+func (uc *stocktakeUseCase) heldQuantity(stocktake *Domain.Stocktake, productID string) (float64, error) {
+	if stocktake.LocationID != nil {
+		level, err := uc.stockLevelRepo.Find(productID, stocktake.LocationID.Hex())
+		if err != nil {
+			return 0, err
+		}
+		if level == nil {
+			return 0, nil
+		}
+		return level.Quantity, nil
+	}
+
+	product, err := uc.inventoryRepo.FindByID(productID)
+	if err != nil {
+		return 0, err
+	}
+	if product == nil {
+		return 0, fmt.Errorf("product not found")
+	}
+	return product.Stock, nil
+}
+
+// resolveStocktakeProduct finds the item a count refers to, by product ID or
+// by the barcode snapshotted when the session was opened.
+func resolveStocktakeProduct(stocktake *Domain.Stocktake, count Domain.StocktakeCount) (string, error) {
+	for _, item := range stocktake.Items {
+		if count.ProductID != "" && item.ProductID.Hex() == count.ProductID {
+			return count.ProductID, nil
+		}
+		if count.ProductID == "" && count.Barcode != "" && item.Barcode == count.Barcode {
+			return item.ProductID.Hex(), nil
+		}
+	}
+
+	if count.ProductID == "" && count.Barcode == "" {
+		return "", fmt.Errorf("product ID or barcode is required")
+	}
+	if count.ProductID != "" {
+		return "", fmt.Errorf("product %s is not part of this stocktake", count.ProductID)
+	}
+	return "", fmt.Errorf("no product with barcode %s in this stocktake", count.Barcode)
+}
@@ -0,0 +1,182 @@
+package Usecases
+
+import (
+	"testing"
+
+	Domain "ShopOps/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeStocktakeRepo struct {
+	stocktake *Domain.Stocktake
+}
+
+func (r *fakeStocktakeRepo) Create(stocktake *Domain.Stocktake) error {
+	stocktake.ID = primitive.NewObjectID()
+	stocktake.Status = Domain.StocktakeStatusOpen
+	r.stocktake = stocktake
+	return nil
+}
+
+func (r *fakeStocktakeRepo) FindByID(id string) (*Domain.Stocktake, error) {
+	if r.stocktake == nil || r.stocktake.ID.Hex() != id {
+		return nil, nil
+	}
+	copied := *r.stocktake
+	copied.Items = append([]Domain.StocktakeItem(nil), r.stocktake.Items...)
+	return &copied, nil
+}
+
+func (r *fakeStocktakeRepo) FindByBusinessID(businessID string, status *Domain.StocktakeStatus) ([]Domain.Stocktake, error) {
+	return nil, nil
+}
+
+func (r *fakeStocktakeRepo) RecordCount(id, productID string, quantity float64, add bool, expected float64, userID string) error {
+	for i := range r.stocktake.Items {
+		item := &r.stocktake.Items[i]
+		if item.ProductID.Hex() != productID {
+			continue
+		}
+		counted := quantity
+		if add && item.CountedQuantity != nil {
+			counted += *item.CountedQuantity
+		}
+		item.CountedQuantity = &counted
+		item.ExpectedQuantity = expected
+	}
+	return nil
+}
+
+func (r *fakeStocktakeRepo) UpdateItems(id string, items []Domain.StocktakeItem) error {
+	r.stocktake.Items = items
+	return nil
+}
+
+func (r *fakeStocktakeRepo) UpdateStatus(id string, status Domain.StocktakeStatus, userID string) error {
+	r.stocktake.Status = status
+	return nil
+}
+
+// fakeProductRepo holds products in memory; the methods stocktakes do not
+// use are left to the embedded nil interface.
+type fakeProductRepo struct {
+	Domain.ProductRepository
+	products map[string]*Domain.Product
+}
+
+func (r *fakeProductRepo) FindByID(id string) (*Domain.Product, error) {
+	product, ok := r.products[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *product
+	return &copied, nil
+}
+
+func (r *fakeProductRepo) FindByBusinessID(businessID string, filters Domain.ProductFilters) ([]Domain.Product, error) {
+	var products []Domain.Product
+	for _, product := range r.products {
+		products = append(products, *product)
+	}
+	return products, nil
+}
+
+type fakeBusinessRepo struct {
+	Domain.BusinessRepository
+	business *Domain.Business
+}
+
+func (r *fakeBusinessRepo) FindByID(id string) (*Domain.Business, error) {
+	return r.business, nil
+}
+
+// fakeCostingUseCase moves product stock the way the ledger would.
+type fakeCostingUseCase struct {
+	CostingUseCase
+	products    *fakeProductRepo
+	adjustments map[string]float64
+}
+
+func (uc *fakeCostingUseCase) AdjustStock(productID string, quantity float64, movementType Domain.MovementType, reason string, referenceID *string, referenceType string, userID string, unitCost float64, locationID *string) (*Domain.StockMovement, error) {
+	uc.products.products[productID].Stock += quantity
+	if movementType == Domain.MovementTypeAdjust {
+		uc.adjustments[productID] += quantity
+	}
+	return &Domain.StockMovement{Quantity: quantity}, nil
+}
+
+func TestStocktakeSaleWhileOpen(t *testing.T) {
+	businessID := primitive.NewObjectID()
+	userID := primitive.NewObjectID().Hex()
+	sold := &Domain.Product{ID: primitive.NewObjectID(), BusinessID: businessID, Name: "Sold while counting", Stock: 10, CostPrice: 2}
+	short := &Domain.Product{ID: primitive.NewObjectID(), BusinessID: businessID, Name: "Short on the shelf", Stock: 10, CostPrice: 2}
+	received := &Domain.Product{ID: primitive.NewObjectID(), BusinessID: businessID, Name: "Received while counting", Stock: 4, CostPrice: 2}
+	batched := &Domain.Product{ID: primitive.NewObjectID(), BusinessID: businessID, Name: "Batch tracked", Stock: 5, CostPrice: 2, TrackBatches: true}
+
+	products := &fakeProductRepo{products: map[string]*Domain.Product{
+		sold.ID.Hex():     sold,
+		short.ID.Hex():    short,
+		received.ID.Hex(): received,
+		batched.ID.Hex():  batched,
+	}}
+	costing := &fakeCostingUseCase{products: products, adjustments: make(map[string]float64)}
+	stocktakes := &fakeStocktakeRepo{}
+	uc := NewStocktakeUseCase(stocktakes, products, &fakeBusinessRepo{business: &Domain.Business{ID: businessID}},
+		nil, nil, nil, costing, nil)
+
+	stocktake, err := uc.CreateStocktake(businessID.Hex(), userID, Domain.CreateStocktakeRequest{Name: "Month end"})
+	if err != nil {
+		t.Fatalf("CreateStocktake: %v", err)
+	}
+	id := stocktake.ID.Hex()
+
+	// Sales and a receipt go through after the snapshot, before the count
+	costing.AdjustStock(sold.ID.Hex(), -3, Domain.MovementTypeSale, "Sale", nil, "sale", userID, 0, nil)
+	costing.AdjustStock(short.ID.Hex(), -3, Domain.MovementTypeSale, "Sale", nil, "sale", userID, 0, nil)
+	costing.AdjustStock(received.ID.Hex(), 6, Domain.MovementTypePurchase, "Delivery", nil, "purchase", userID, 2, nil)
+
+	counts := []Domain.StocktakeCount{
+		{ProductID: sold.ID.Hex(), Quantity: 7, Mode: Domain.StocktakeCountModeSet},
+		{ProductID: short.ID.Hex(), Quantity: 5, Mode: Domain.StocktakeCountModeSet},
+		{ProductID: received.ID.Hex(), Quantity: 10, Mode: Domain.StocktakeCountModeSet},
+		{ProductID: batched.ID.Hex(), Quantity: 4, Mode: Domain.StocktakeCountModeSet},
+	}
+	if _, err := uc.RecordCounts(id, businessID.Hex(), userID, Domain.RecordStocktakeCountsRequest{Counts: counts}); err != nil {
+		t.Fatalf("RecordCounts: %v", err)
+	}
+
+	// A sale after its count is not part of what was on the shelf
+	costing.AdjustStock(received.ID.Hex(), -1, Domain.MovementTypeSale, "Sale", nil, "sale", userID, 0, nil)
+
+	result, err := uc.PostStocktake(id, businessID.Hex(), userID)
+	if err != nil {
+		t.Fatalf("PostStocktake: %v", err)
+	}
+
+	tests := []struct {
+		product        *Domain.Product
+		wantAdjustment float64
+		wantStock      float64
+	}{
+		{sold, 0, 7},
+		{short, -2, 5},
+		{received, 0, 9},
+		{batched, 0, 5},
+	}
+	for _, tt := range tests {
+		if got := costing.adjustments[tt.product.ID.Hex()]; got != tt.wantAdjustment {
+			t.Errorf("%s: adjusted by %v, want %v", tt.product.Name, got, tt.wantAdjustment)
+		}
+		if got := products.products[tt.product.ID.Hex()].Stock; got != tt.wantStock {
+			t.Errorf("%s: stock %v, want %v", tt.product.Name, got, tt.wantStock)
+		}
+	}
+
+	if result.AdjustmentsMade != 1 {
+		t.Errorf("AdjustmentsMade = %d, want 1", result.AdjustmentsMade)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors = %v, want one for the batch-tracked product", result.Errors)
+	}
+}
@@ -0,0 +1,141 @@
+package Usecases
+
+import (
+	"fmt"
+
+	Domain "ShopOps/Domain"
+)
+
+type SupplierUseCase interface {
+	CreateSupplier(businessID string, req Domain.CreateSupplierRequest) (*Domain.Supplier, error)
+	GetSupplier(id, businessID string) (*Domain.Supplier, error)
+	GetSuppliers(businessID string) ([]Domain.Supplier, error)
+	UpdateSupplier(id, businessID string, req Domain.UpdateSupplierRequest) (*Domain.Supplier, error)
+}
+
+type supplierUseCase struct {
+	supplierRepo Domain.SupplierRepository
+	businessRepo Domain.BusinessRepository
+}
+
+func NewSupplierUseCase(
+	supplierRepo Domain.SupplierRepository,
+	businessRepo Domain.BusinessRepository,
+) SupplierUseCase {
+	return &supplierUseCase{
+		supplierRepo: supplierRepo,
+		businessRepo: businessRepo,
+	}
+}
+
+func (uc *supplierUseCase) CreateSupplier(businessID string, req Domain.CreateSupplierRequest) (*Domain.Supplier, error) {
+	business, err := uc.businessRepo.FindByID(businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business: %w", err)
+	}
+	if business == nil {
+		return nil, fmt.Errorf("business not found")
+	}
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("supplier name is required")
+	}
+	if req.LeadTimeDays < 0 {
+		return nil, fmt.Errorf("lead time cannot be negative")
+	}
+
+	supplier := &Domain.Supplier{
+		BusinessID:   business.ID,
+		Name:         req.Name,
+		ContactName:  req.ContactName,
+		Phone:        req.Phone,
+		Email:        req.Email,
+		LeadTimeDays: req.LeadTimeDays,
+		Notes:        req.Notes,
+	}
+
+	if err := uc.supplierRepo.Create(supplier); err != nil {
+		return nil, fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	return supplier, nil
+}
+
+func (uc *supplierUseCase) GetSupplier(id, businessID string) (*Domain.Supplier, error) {
+	supplier, err := uc.supplierRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if supplier == nil {
+		return nil, fmt.Errorf("supplier not found")
+	}
+
+	if supplier.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: supplier does not belong to this business")
+	}
+
+	return supplier, nil
+}
+
+func (uc *supplierUseCase) GetSuppliers(businessID string) ([]Domain.Supplier, error) {
+	return uc.supplierRepo.FindByBusinessID(businessID)
+}
+
+func (uc *supplierUseCase) UpdateSupplier(id, businessID string, req Domain.UpdateSupplierRequest) (*Domain.Supplier, error) {
+	supplier, err := uc.GetSupplier(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		supplier.Name = req.Name
+	}
+	if req.ContactName != "" {
+		supplier.ContactName = req.ContactName
+	}
+	if req.Phone != "" {
+		supplier.Phone = req.Phone
+	}
+	if req.Email != "" {
+		supplier.Email = req.Email
+	}
+	if req.LeadTimeDays != nil {
+		if *req.LeadTimeDays < 0 {
+			return nil, fmt.Errorf("lead time cannot be negative")
+		}
+		supplier.LeadTimeDays = *req.LeadTimeDays
+	}
+	if req.Notes != "" {
+		supplier.Notes = req.Notes
+	}
+	if req.Status != "" {
+		if req.Status != Domain.SupplierStatusActive && req.Status != Domain.SupplierStatusInactive {
+			return nil, fmt.Errorf("invalid supplier status: %s", req.Status)
+		}
+		supplier.Status = req.Status
+	}
+
+	if err := uc.supplierRepo.Update(supplier); err != nil {
+		return nil, fmt.Errorf("failed to update supplier: %w", err)
+	}
+
+	return supplier, nil
+}
+
+// findSupplier returns the supplier a product is assigned to, or nil when no
+// supplier ID is given.
+func findSupplier(supplierRepo Domain.SupplierRepository, businessID string, supplierID *string) (*Domain.Supplier, error) {
+	if supplierID == nil || *supplierID == "" {
+		return nil, nil
+	}
+
+	supplier, err := supplierRepo.FindByID(*supplierID)
+	if err != nil {
+		return nil, err
+	}
+	if supplier == nil || supplier.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("supplier not found")
+	}
+
+	return supplier, nil
+}
@@ -16,12 +16,13 @@ type SyncUseCase interface {
 }
 
 type syncUseCase struct {
-	syncService   Infrastructure.SyncService
-	businessRepo  Domain.BusinessRepository
-	salesRepo     Domain.SaleRepository
-	expenseRepo   Domain.ExpenseRepository
-	inventoryRepo Domain.ProductRepository
-	syncRepo      Domain.SyncRepository
+	syncService    Infrastructure.SyncService
+	businessRepo   Domain.BusinessRepository
+	salesRepo      Domain.SaleRepository
+	expenseRepo    Domain.ExpenseRepository
+	inventoryRepo  Domain.ProductRepository
+	syncRepo       Domain.SyncRepository
+	dailyStatsRepo Domain.DailyStatsRepository
 }
 
 func NewSyncUseCase(
@@ -31,14 +32,16 @@ func NewSyncUseCase(
 	expenseRepo Domain.ExpenseRepository,
 	inventoryRepo Domain.ProductRepository,
 	syncRepo Domain.SyncRepository,
+	dailyStatsRepo Domain.DailyStatsRepository,
 ) SyncUseCase {
 	return &syncUseCase{
-		syncService:   syncService,
-		businessRepo:  businessRepo,
-		salesRepo:     salesRepo,
-		expenseRepo:   expenseRepo,
-		inventoryRepo: inventoryRepo,
-		syncRepo:      syncRepo,
+		syncService:    syncService,
+		businessRepo:   businessRepo,
+		salesRepo:      salesRepo,
+		expenseRepo:    expenseRepo,
+		inventoryRepo:  inventoryRepo,
+		syncRepo:       syncRepo,
+		dailyStatsRepo: dailyStatsRepo,
 	}
 }
 
@@ -48,15 +51,43 @@ func (uc *syncUseCase) ProcessBatch(batch Domain.SyncBatch) (*Domain.SyncRespons
 		return nil, fmt.Errorf("batch validation failed: %w", err)
 	}
 
+	// Days the synced sales and expenses fall on before the batch, so
+	// records moved or voided by it leave their old day's rollup too
+	days := uc.syncedDays(batch)
+
 	// Process batch using sync service
 	response, err := uc.syncService.ProcessBatch(batch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process batch: %w", err)
 	}
 
+	days = append(days, uc.syncedDays(batch)...)
+	refreshDailyStats(uc.dailyStatsRepo, uc.businessRepo, batch.BusinessID, days...)
+
 	return response, nil
 }
 
+// syncedDays returns the times of the stored sales and expenses that the
+// batch's items refer to.
+func (uc *syncUseCase) syncedDays(batch Domain.SyncBatch) []time.Time {
+	var days []time.Time
+	for _, item := range batch.Items {
+		switch item.EntityType {
+		case "sale":
+			sale, err := uc.salesRepo.FindByLocalID(batch.BusinessID, item.LocalID)
+			if err == nil && sale != nil {
+				days = append(days, sale.CreatedAt)
+			}
+		case "expense":
+			expense, err := uc.expenseRepo.FindByLocalID(batch.BusinessID, item.LocalID)
+			if err == nil && expense != nil {
+				days = append(days, expense.Date)
+			}
+		}
+	}
+	return days
+}
+
 func (uc *syncUseCase) GetSyncStatus(businessID string) (*Domain.SyncStatus, error) {
 	// Validate business exists
 	_, err := uc.businessRepo.FindByID(businessID)
@@ -0,0 +1,176 @@
+package Usecases
+
+import (
+	"fmt"
+
+	Domain "ShopOps/Domain"
+)
+
+type TransferUseCase interface {
+	CreateTransfer(businessID, userID string, req Domain.CreateTransferRequest) (*Domain.StockTransfer, error)
+	GetTransfer(id, businessID string) (*Domain.StockTransfer, error)
+	GetTransfers(businessID string, status *Domain.TransferStatus) ([]Domain.StockTransfer, error)
+	ReceiveTransfer(id, businessID, userID string) (*Domain.StockTransfer, error)
+	CancelTransfer(id, businessID, userID string) (*Domain.StockTransfer, error)
+}
+
+type transferUseCase struct {
+	transferRepo   Domain.TransferRepository
+	locationRepo   Domain.LocationRepository
+	stockLevelRepo Domain.StockLevelRepository
+	inventoryRepo  Domain.ProductRepository
+}
+
+func NewTransferUseCase(
+	transferRepo Domain.TransferRepository,
+	locationRepo Domain.LocationRepository,
+	stockLevelRepo Domain.StockLevelRepository,
+	inventoryRepo Domain.ProductRepository,
+) TransferUseCase {
+	return &transferUseCase{
+		transferRepo:   transferRepo,
+		locationRepo:   locationRepo,
+		stockLevelRepo: stockLevelRepo,
+		inventoryRepo:  inventoryRepo,
+	}
+}
+
+// CreateTransfer dispatches goods from one location to another. The goods
+// leave the source straight away and stay in transit until received. Product
+// totals do not change because the goods never leave the business.
+func (uc *transferUseCase) CreateTransfer(businessID, userID string, req Domain.CreateTransferRequest) (*Domain.StockTransfer, error) {
+	if req.FromLocationID == "" || req.ToLocationID == "" {
+		return nil, fmt.Errorf("source and destination locations are required")
+	}
+	if req.FromLocationID == req.ToLocationID {
+		return nil, fmt.Errorf("source and destination must be different locations")
+	}
+
+	from, err := resolveLocation(uc.locationRepo, businessID, &req.FromLocationID)
+	if err != nil {
+		return nil, fmt.Errorf("source %w", err)
+	}
+	to, err := resolveLocation(uc.locationRepo, businessID, &req.ToLocationID)
+	if err != nil {
+		return nil, fmt.Errorf("destination %w", err)
+	}
+
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("at least one item is required")
+	}
+
+	objUserID, err := Domain.PrimitiveObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	items := make([]Domain.TransferItem, 0, len(req.Items))
+	for _, itemReq := range req.Items {
+		if itemReq.Quantity <= 0 {
+			return nil, fmt.Errorf("quantity must be greater than 0")
+		}
+
+		product, err := uc.inventoryRepo.FindByID(itemReq.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product: %w", err)
+		}
+		if product == nil {
+			return nil, fmt.Errorf("product not found")
+		}
+		if product.BusinessID.Hex() != businessID {
+			return nil, fmt.Errorf("access denied: product does not belong to this business")
+		}
+
+		items = append(items, Domain.TransferItem{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Quantity:    itemReq.Quantity,
+		})
+	}
+
+	// Take the goods out of the source, putting back what was taken if any
+	// item is short
+	fromID := from.ID.Hex()
+	for i, item := range items {
+		if _, err := uc.stockLevelRepo.Adjust(businessID, item.ProductID.Hex(), fromID, -item.Quantity); err != nil {
+			uc.addItems(businessID, fromID, items[:i])
+			return nil, fmt.Errorf("insufficient stock of %s at %s", item.ProductName, from.Name)
+		}
+	}
+
+	transfer := &Domain.StockTransfer{
+		BusinessID:     from.BusinessID,
+		FromLocationID: from.ID,
+		ToLocationID:   to.ID,
+		Items:          items,
+		Notes:          req.Notes,
+		CreatedBy:      objUserID,
+	}
+
+	if err := uc.transferRepo.Create(transfer); err != nil {
+		uc.addItems(businessID, fromID, items)
+		return nil, fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+func (uc *transferUseCase) GetTransfer(id, businessID string) (*Domain.StockTransfer, error) {
+	transfer, err := uc.transferRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, fmt.Errorf("transfer not found")
+	}
+
+	if transfer.BusinessID.Hex() != businessID {
+		return nil, fmt.Errorf("access denied: transfer does not belong to this business")
+	}
+
+	return transfer, nil
+}
+
+func (uc *transferUseCase) GetTransfers(businessID string, status *Domain.TransferStatus) ([]Domain.StockTransfer, error) {
+	return uc.transferRepo.FindByBusinessID(businessID, status)
+}
+
+func (uc *transferUseCase) ReceiveTransfer(id, businessID, userID string) (*Domain.StockTransfer, error) {
+	transfer, err := uc.GetTransfer(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.transferRepo.UpdateStatus(id, Domain.TransferStatusReceived, userID); err != nil {
+		return nil, err
+	}
+
+	uc.addItems(businessID, transfer.ToLocationID.Hex(), transfer.Items)
+
+	return uc.GetTransfer(id, businessID)
+}
+
+// CancelTransfer returns goods still in transit to the source location.
+func (uc *transferUseCase) CancelTransfer(id, businessID, userID string) (*Domain.StockTransfer, error) {
+	transfer, err := uc.GetTransfer(id, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.transferRepo.UpdateStatus(id, Domain.TransferStatusCancelled, userID); err != nil {
+		return nil, err
+	}
+
+	uc.addItems(businessID, transfer.FromLocationID.Hex(), transfer.Items)
+
+	return uc.GetTransfer(id, businessID)
+}
+
+// addItems puts transfer items into a location.
+func (uc *transferUseCase) addItems(businessID, locationID string, items []Domain.TransferItem) {
+	for _, item := range items {
+		if _, err := uc.stockLevelRepo.Adjust(businessID, item.ProductID.Hex(), locationID, item.Quantity); err != nil {
+			fmt.Printf("Failed to move %s at location %s: %v\n", item.ProductName, locationID, err)
+		}
+	}
+}
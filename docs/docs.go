@@ -806,21 +806,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/inventory/products": {
-            "get": {
+        "/api/v1/businesses/{businessId}/inventory/barcodes/generate": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get products with filtering and search",
+                "description": "Give every product without a barcode an internal one",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "barcodes"
                 ],
-                "summary": "List all products",
+                "summary": "Generate missing barcodes",
                 "parameters": [
                     {
                         "type": "string",
@@ -830,50 +833,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Product category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Product status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter low stock items",
-                        "name": "low_stock",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search in name, SKU, barcode",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit results",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset results",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Barcode format",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.GenerateBarcodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Domain.Product"
-                            }
+                            "$ref": "#/definitions/Domain.GenerateBarcodesResult"
                         }
                     },
                     "400": {
@@ -891,24 +863,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/batches/near-expiry": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new product with stock information",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List batches with stock that expire within the given number of days, including already expired ones",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "inventory"
                 ],
-                "summary": "Add new product",
+                "summary": "Get near-expiry report",
                 "parameters": [
                     {
                         "type": "string",
@@ -918,20 +889,17 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Product details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/Domain.CreateProductRequest"
-                        }
+                        "type": "integer",
+                        "description": "Days ahead to look (default 30)",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.Product"
+                            "$ref": "#/definitions/Domain.NearExpiryReport"
                         }
                     },
                     "400": {
@@ -951,21 +919,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/inventory/products/low-stock": {
-            "get": {
+        "/api/v1/businesses/{businessId}/inventory/batches/write-off-expired": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get products with stock below minimum threshold",
+                "description": "Remove the remaining stock of every expired batch, recording a damage movement for each. This also runs automatically every hour for every business",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "inventory"
                 ],
-                "summary": "Get products below threshold",
+                "summary": "Write off expired batches",
                 "parameters": [
                     {
                         "type": "string",
@@ -973,23 +941,13 @@ const docTemplate = `{
                         "name": "businessId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "number",
-                        "format": "float64",
-                        "description": "Custom threshold (optional)",
-                        "name": "threshold",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Domain.Product"
-                            }
+                            "$ref": "#/definitions/Domain.ExpiryWriteOffResult"
                         }
                     },
                     "400": {
@@ -1009,21 +967,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/inventory/products/{productId}": {
+        "/api/v1/businesses/{businessId}/inventory/catalog/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific product",
+                "description": "Download all products in the import template, ready to edit and import again",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "inventory"
+                    "catalog"
                 ],
-                "summary": "Get product details",
+                "summary": "Export product catalog",
                 "parameters": [
                     {
                         "type": "string",
@@ -1034,17 +993,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
-                        "in": "path",
-                        "required": true
+                        "description": "Format: csv (default), xlsx",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Catalog file",
                         "schema": {
-                            "$ref": "#/definitions/Domain.Product"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1060,30 +1018,28 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/catalog/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete a product (mark as discontinued)",
+                "description": "Upload a CSV or XLSX catalog. Rows are upserted by SKU, then barcode, and opening stock is booked as stock movements. A dry run returns the validation report with row-level errors straight away; otherwise the import runs in the background and the returned job can be polled",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "catalog"
                 ],
-                "summary": "Delete product",
+                "summary": "Import product catalog",
                 "parameters": [
                     {
                         "type": "string",
@@ -1093,19 +1049,36 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Catalog file (.csv or .xlsx)",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping file headers to product fields, e.g. {\\",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, without importing",
+                        "name": "dry_run",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Dry run report",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/Domain.CatalogImportJob"
+                        }
+                    },
+                    "202": {
+                        "description": "Import job started",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CatalogImportJob"
                         }
                     },
                     "400": {
@@ -1121,33 +1094,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/catalog/imports": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update product information and pricing",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the most recent catalog import jobs, newest first, without their row errors",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "catalog"
                 ],
-                "summary": "Update product",
+                "summary": "List catalog imports",
                 "parameters": [
                     {
                         "type": "string",
@@ -1155,29 +1120,16 @@ const docTemplate = `{
                         "name": "businessId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Product update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/Domain.CreateProductRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.Product"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.CatalogImportJob"
+                            }
                         }
                     },
                     "400": {
@@ -1193,35 +1145,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/inventory/products/{productId}/adjust": {
-            "post": {
+        "/api/v1/businesses/{businessId}/inventory/catalog/imports/{jobId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Manually adjust product stock with movement type and reason",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Poll the progress of a catalog import, with its row-level errors",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "catalog"
                 ],
-                "summary": "Manually adjust stock",
+                "summary": "Get catalog import",
                 "parameters": [
                     {
                         "type": "string",
@@ -1232,27 +1174,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "Import job ID",
+                        "name": "jobId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Stock adjustment details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/Domain.AdjustStockRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/Domain.CatalogImportJob"
                         }
                     },
                     "400": {
@@ -1279,21 +1211,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/inventory/products/{productId}/history": {
+        "/api/v1/businesses/{businessId}/inventory/catalog/template": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get history of stock changes for a product",
+                "description": "Download an empty catalog file with the template headers",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "inventory"
+                    "catalog"
                 ],
-                "summary": "Get stock movement history",
+                "summary": "Download catalog template",
                 "parameters": [
                     {
                         "type": "string",
@@ -1304,26 +1237,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit results (default 50)",
-                        "name": "limit",
+                        "description": "Format: csv (default), xlsx",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Template file",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Domain.StockMovement"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1339,32 +1262,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/reports/dashboard": {
+        "/api/v1/businesses/{businessId}/inventory/categories": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get key metrics for dashboard display (today's data)",
+                "description": "Get the category tree of the business, each category with its subcategories in order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
                 ],
-                "summary": "Get dashboard overview",
+                "summary": "Get category tree",
                 "parameters": [
                     {
                         "type": "string",
@@ -1378,7 +1294,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.DashboardData"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Category"
+                            }
                         }
                     },
                     "400": {
@@ -1396,23 +1315,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/businesses/{businessId}/reports/expenses": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate expense report with optional period and category filtering",
+                "description": "Add a product category, at the top level or below a parent. Names are unique within the business regardless of case",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
                 ],
-                "summary": "Get expenses report",
+                "summary": "Create category",
                 "parameters": [
                     {
                         "type": "string",
@@ -1422,35 +1342,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Period: daily, weekly, monthly, yearly, custom",
-                        "name": "period",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD) for custom period",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD) for custom period",
-                        "name": "end_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by category",
-                        "name": "category",
-                        "in": "query"
+                        "description": "Category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/Domain.ExpensesReport"
+                            "$ref": "#/definitions/Domain.Category"
                         }
                     },
                     "400": {
@@ -1470,21 +1375,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/reports/export": {
-            "get": {
+        "/api/v1/businesses/{businessId}/inventory/categories/migrate": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Export report data to CSV format",
+                "description": "Create categories from the category names of products that have no category ID yet, matching names regardless of case, and link the products to them",
                 "produces": [
-                    "text/csv"
+                    "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
                 ],
-                "summary": "Generate CSV export",
+                "summary": "Migrate free-text categories",
                 "parameters": [
                     {
                         "type": "string",
@@ -1492,38 +1397,13 @@ const docTemplate = `{
                         "name": "businessId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Report type: sales, expenses, profit, inventory",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Period: daily, weekly, monthly, yearly, custom",
-                        "name": "period",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD) for custom period",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD) for custom period",
-                        "name": "end_date",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "CSV file",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/Domain.CategoryMigrationResult"
                         }
                     },
                     "400": {
@@ -1543,21 +1423,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/reports/inventory": {
-            "get": {
+        "/api/v1/businesses/{businessId}/inventory/categories/reorder": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate inventory report with low stock alerts",
+                "description": "Set the order of the subcategories of a parent, or of the top-level categories when parent_id is empty",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
                 ],
-                "summary": "Get inventory status report",
+                "summary": "Reorder categories",
                 "parameters": [
                     {
                         "type": "string",
@@ -1565,13 +1448,25 @@ const docTemplate = `{
                         "name": "businessId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Category IDs in their new order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReorderCategoriesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.InventoryReport"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Category"
+                            }
                         }
                     },
                     "400": {
@@ -1591,21 +1486,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/reports/profit": {
+        "/api/v1/businesses/{businessId}/inventory/categories/{categoryId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate profit/loss report with optional period filtering",
+                "description": "Get a category with the subcategories below it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
                 ],
-                "summary": "Get profit report",
+                "summary": "Get category",
                 "parameters": [
                     {
                         "type": "string",
@@ -1616,28 +1511,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Period: daily, weekly, monthly, yearly, custom",
-                        "name": "period",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD) for custom period",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD) for custom period",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.ProfitReport"
+                            "$ref": "#/definitions/Domain.Category"
                         }
                     },
                     "400": {
@@ -1653,25 +1537,30 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/businesses/{businessId}/reports/profit/summary": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get profit summary with custom date range",
+                "description": "Delete a category that has no products or subcategories; merge it into another category otherwise",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
                 ],
-                "summary": "Get profit summary for period",
+                "summary": "Delete category",
                 "parameters": [
                     {
                         "type": "string",
@@ -1682,28 +1571,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Period: daily, weekly, monthly, yearly, custom",
-                        "name": "period",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD) for custom period",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD) for custom period",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.ProfitReport"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -1721,23 +1600,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/businesses/{businessId}/reports/profit/trends": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get profit trends for multiple periods",
+                "description": "Rename a category or move it, with everything below it, under another parent. An empty parent_id moves it to the top level",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
                 ],
-                "summary": "Get profit trends over time",
+                "summary": "Update category",
                 "parameters": [
                     {
                         "type": "string",
@@ -1748,25 +1628,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Period: daily, weekly, monthly",
-                        "name": "period",
-                        "in": "query"
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of weeks to analyze (default 12)",
-                        "name": "weeks",
-                        "in": "query"
+                        "description": "Changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.UpdateCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Domain.ProfitTrend"
-                            }
+                            "$ref": "#/definitions/Domain.Category"
                         }
                     },
                     "400": {
@@ -1786,21 +1667,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/reports/sales": {
-            "get": {
+        "/api/v1/businesses/{businessId}/inventory/categories/{categoryId}/merge": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate sales report with optional period filtering",
+                "description": "Fold a category into another: its products and subcategories move to the target and it is deleted",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
                 ],
-                "summary": "Get sales report",
+                "summary": "Merge category",
                 "parameters": [
                     {
                         "type": "string",
@@ -1811,28 +1695,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Period: daily, weekly, monthly, yearly, custom",
-                        "name": "period",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD) for custom period",
-                        "name": "start_date",
-                        "in": "query"
+                        "description": "Category to merge away",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD) for custom period",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Target category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.MergeCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.SalesReport"
+                            "$ref": "#/definitions/Domain.Category"
                         }
                     },
                     "400": {
@@ -1852,21 +1734,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/sales": {
-            "get": {
+        "/api/v1/businesses/{businessId}/inventory/costs/rebuild": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get sales transactions with filtering and pagination",
+                "description": "Rebuild cost layers, average costs and cost of goods sold for every product, e.g. after changing the costing method",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales"
+                    "inventory"
                 ],
-                "summary": "List all sales",
+                "summary": "Rebuild business costs",
                 "parameters": [
                     {
                         "type": "string",
@@ -1874,58 +1756,13 @@ const docTemplate = `{
                         "name": "businessId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sale status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Payment method",
-                        "name": "payment_method",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Payment status",
-                        "name": "payment_status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit results",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset results",
-                        "name": "offset",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Domain.Sale"
-                            }
+                            "$ref": "#/definitions/Domain.CostRebuildResult"
                         }
                     },
                     "400": {
@@ -1943,24 +1780,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/labels": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Record a sales transaction with optional product association",
+                "description": "Render a printable sheet of labels with name, price and barcode for the selected products, as an A4 PDF (24 labels per page) or a PNG",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/pdf",
+                    "image/png"
                 ],
                 "tags": [
-                    "sales"
+                    "barcodes"
                 ],
-                "summary": "Record a new sale",
+                "summary": "Print barcode labels",
                 "parameters": [
                     {
                         "type": "string",
@@ -1970,20 +1810,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Sale details",
+                        "description": "Products and copies",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Domain.CreateSaleRequest"
+                            "$ref": "#/definitions/Domain.LabelSheetRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Label sheet",
                         "schema": {
-                            "$ref": "#/definitions/Domain.Sale"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1999,32 +1839,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/sales/stats": {
+        "/api/v1/businesses/{businessId}/inventory/ledger/integrity": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get sales statistics and analytics",
+                "description": "Walk every product's stock movement history and flag products whose current stock disagrees with it, or whose movements do not chain from one to the next",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales"
+                    "inventory"
                 ],
-                "summary": "Get sales statistics",
+                "summary": "Check stock ledger integrity",
                 "parameters": [
                     {
                         "type": "string",
@@ -2032,19 +1865,13 @@ const docTemplate = `{
                         "name": "businessId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Period: today, week, month",
-                        "name": "period",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.SaleStats"
+                            "$ref": "#/definitions/Domain.LedgerIntegrityReport"
                         }
                     },
                     "400": {
@@ -2064,21 +1891,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/sales/summary": {
+        "/api/v1/businesses/{businessId}/inventory/locations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get aggregated sales data for a period (daily/weekly/monthly)",
+                "description": "Get all stock locations of a business, default first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales"
+                    "locations"
                 ],
-                "summary": "Get sales summary",
+                "summary": "List stock locations",
                 "parameters": [
                     {
                         "type": "string",
@@ -2086,19 +1913,16 @@ const docTemplate = `{
                         "name": "businessId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Period: today, week, month",
-                        "name": "period",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.SaleSummary"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Location"
+                            }
                         }
                     },
                     "400": {
@@ -2116,23 +1940,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/businesses/{businessId}/sales/{saleId}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific sale",
+                "description": "Add a location that holds stock, such as a back store or an outlet. The first location becomes the default and takes over all existing stock",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales"
+                    "locations"
                 ],
-                "summary": "Get sale details",
+                "summary": "Create stock location",
                 "parameters": [
                     {
                         "type": "string",
@@ -2142,18 +1967,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Sale ID",
-                        "name": "saleId",
-                        "in": "path",
-                        "required": true
+                        "description": "Location details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateLocationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/Domain.Sale"
+                            "$ref": "#/definitions/Domain.Location"
                         }
                     },
                     "400": {
@@ -2169,30 +1996,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/locations/{locationId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Void a completed sale transaction",
+                "description": "Get a stock location by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales"
+                    "locations"
                 ],
-                "summary": "Void/soft delete sale",
+                "summary": "Get stock location",
                 "parameters": [
                     {
                         "type": "string",
@@ -2203,8 +2025,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Sale ID",
-                        "name": "saleId",
+                        "description": "Location ID",
+                        "name": "locationId",
                         "in": "path",
                         "required": true
                     }
@@ -2213,8 +2035,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/Domain.Location"
                         }
                     },
                     "400": {
@@ -2246,7 +2067,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update sale details (before sync)",
+                "description": "Update a location's details, make it the default or deactivate it",
                 "consumes": [
                     "application/json"
                 ],
@@ -2254,9 +2075,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sales"
+                    "locations"
                 ],
-                "summary": "Update sale",
+                "summary": "Update stock location",
                 "parameters": [
                     {
                         "type": "string",
@@ -2267,18 +2088,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Sale ID",
-                        "name": "saleId",
+                        "description": "Location ID",
+                        "name": "locationId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Sale update details",
+                        "description": "Location update details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Domain.CreateSaleRequest"
+                            "$ref": "#/definitions/Domain.UpdateLocationRequest"
                         }
                     }
                 ],
@@ -2286,7 +2107,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.Sale"
+                            "$ref": "#/definitions/Domain.Location"
                         }
                     },
                     "400": {
@@ -2302,25 +2123,18 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/sync/batch": {
-            "post": {
+        "/api/v1/businesses/{businessId}/inventory/locations/{locationId}/products/{productId}/min-stock": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Process batch sync of offline transactions (sales, expenses, products)",
+                "description": "Set the low stock threshold of a product at one location, overriding the product minimum there",
                 "consumes": [
                     "application/json"
                 ],
@@ -2328,9 +2142,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sync"
+                    "locations"
                 ],
-                "summary": "Sync multiple transactions",
+                "summary": "Set location minimum stock",
                 "parameters": [
                     {
                         "type": "string",
@@ -2340,12 +2154,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Batch sync data",
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Minimum stock",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Domain.SyncBatch"
+                            "$ref": "#/definitions/Domain.SetLocationMinStockRequest"
                         }
                     }
                 ],
@@ -2353,7 +2181,8 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.SyncResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -2369,32 +2198,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/sync/last-sync": {
+        "/api/v1/businesses/{businessId}/inventory/locations/{locationId}/stock": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get last synchronization timestamp for specific device",
+                "description": "Get the stock level of every product held at a location, with low stock items",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sync"
+                    "locations"
                 ],
-                "summary": "Get last sync time for device",
+                "summary": "Get stock at a location",
                 "parameters": [
                     {
                         "type": "string",
@@ -2405,9 +2227,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Device ID",
-                        "name": "device_id",
-                        "in": "query",
+                        "description": "Location ID",
+                        "name": "locationId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -2415,8 +2237,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/Domain.LocationStockReport"
                         }
                     },
                     "400": {
@@ -2432,25 +2253,32 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/api/v1/businesses/{businessId}/sync/status": {
+        "/api/v1/businesses/{businessId}/inventory/negative-stock": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get synchronization status including last sync time and pending items",
+                "description": "List the sales that took products below zero under a warn or allow negative stock policy, with the later receipts that covered each shortfall",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sync"
+                    "inventory"
                 ],
-                "summary": "Get sync status for business",
+                "summary": "Reconcile negative stock",
                 "parameters": [
                     {
                         "type": "string",
@@ -2458,13 +2286,37 @@ const docTemplate = `{
                         "name": "businessId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (open, covered)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by product",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Went negative on or after (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Went negative on or before (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.SyncStatus"
+                            "$ref": "#/definitions/Domain.NegativeStockReport"
                         }
                     },
                     "400": {
@@ -2484,26 +2336,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/me": {
+        "/api/v1/businesses/{businessId}/inventory/price-changes": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve authenticated user's profile information",
+                "description": "Get every price change made between two dates, newest first. Defaults to the last 30 days",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "pricing"
+                ],
+                "summary": "List price changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Domain.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.PriceChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
@@ -2514,14 +2397,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/price-changes/schedule": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current user's profile information",
+                "description": "Schedule new prices for the listed products or for every active product of a category. Give new cost/selling prices or a percentage change of the selling price (e.g. 10 or -5). The changes are applied automatically at effective_at",
                 "consumes": [
                     "application/json"
                 ],
@@ -2529,25 +2414,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "pricing"
                 ],
-                "summary": "Update user profile",
+                "summary": "Schedule price changes",
                 "parameters": [
                     {
-                        "description": "User update details",
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scheduled change",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Domain.UpdateUserRequest"
+                            "$ref": "#/definitions/Domain.SchedulePriceChangeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/Domain.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.ScheduledPriceChange"
+                            }
                         }
                     },
                     "400": {
@@ -2566,347 +2461,9695 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "Domain.AdjustStockRequest": {
-            "type": "object",
-            "required": [
-                "quantity",
-                "reason",
-                "type"
-            ],
-            "properties": {
-                "quantity": {
-                    "type": "number"
-                },
-                "reason": {
-                    "type": "string"
-                },
-                "type": {
-                    "$ref": "#/definitions/Domain.MovementType"
-                }
-            }
         },
-        "Domain.Business": {
-            "type": "object",
-            "required": [
-                "business_type",
+        "/api/v1/businesses/{businessId}/inventory/price-changes/scheduled": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the scheduled price changes of the business, soonest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "List scheduled price changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status: pending, applied, cancelled, failed",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.ScheduledPriceChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/price-changes/scheduled/{scheduleId}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a scheduled price change that has not been applied yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Cancel scheduled price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled price change ID",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/prices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the cost and selling price of every product in effect at the end of a given date, for reports that need historical prices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Get prices on a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.ProductPrice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get products with filtering and search",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "List all products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID or name; includes its subcategories",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter low stock items",
+                        "name": "low_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in name, SKU, barcode",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset results",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Product"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product with stock information. A bundle (type \"bundle\") lists its component products instead; its stock and cost are derived from them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Add new product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/by-barcode/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Find the product with a scanned barcode. A 12-digit UPC-A scan also matches its EAN-13 form",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "barcodes"
+                ],
+                "summary": "Look up product by barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Barcode",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/low-stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get products with stock below minimum threshold",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Get products below threshold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "format": "float64",
+                        "description": "Custom threshold (optional)",
+                        "name": "threshold",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Product"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Get product details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a product (mark as discontinued)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update product information and pricing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Update product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Manually adjust product stock with movement type and reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Manually adjust stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock adjustment details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.AdjustStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/barcode": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give a product without a barcode an internal EAN-13 (prefix 20) or Code 128 barcode",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "barcodes"
+                ],
+                "summary": "Generate product barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Barcode format",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.GenerateBarcodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/batches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the stock batches held for a product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "List product batches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include depleted and expired batches",
+                        "name": "include_depleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.StockBatch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Receive stock for a product as a lot with lot number, expiry and received dates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Receive a stock batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Batch details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReceiveBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.StockBatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/cost-layers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the open cost layers (quantities still held at each purchase cost) for a product, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "List product cost layers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.CostLayer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/costs/rebuild": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replay a product's stock history to rebuild its cost layers, average cost and the cost of goods of its sales",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Rebuild product costs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CostRebuildResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get history of stock changes for a product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Get stock movement history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit results (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.StockMovement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/locations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get how much of a product each location holds and how much is in transit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get product stock by location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ProductLocationStock"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/price-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the price changes of a product, newest first, with who made them and the old and new prices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Get product price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit results (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.PriceChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/recipe": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the recipe of a menu item with its current ingredient cost",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get product recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Menu item product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or replace the recipe of a menu item. Quantities are per unit sold, in each ingredient's own unit, with an optional waste factor (0.05 for 5%). Selling the item back-flushes the ingredients out of stock, and its cost price becomes the ingredient cost",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Set product recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Menu item product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ingredients",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SetRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the recipe of a menu item; it is then sold from its own stock again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Delete product recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Menu item product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/products/{productId}/serials": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the serial numbers of a product with the stock on hand counted from serials still in stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "List product serial numbers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (in_stock, sold, written_off)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ProductSerials"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Receive units of a serial-tracked product into stock, one per serial number. With existing set, the serials are registered against stock already on hand and no stock is added",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Receive serialised units",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Serial numbers",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReceiveSerialsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.SerialNumber"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/purchase-orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the purchase orders of a business, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchasing"
+                ],
+                "summary": "List purchase orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status: draft, ordered, received, cancelled",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.PurchaseOrder"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/purchase-orders/from-suggestions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn the current reorder suggestions into one draft purchase order per supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchasing"
+                ],
+                "summary": "Draft purchase orders from suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Which suggestions to order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateDraftOrdersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.PurchaseOrder"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/purchase-orders/{orderId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a purchase order by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchasing"
+                ],
+                "summary": "Get purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/purchase-orders/{orderId}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a draft or ordered purchase order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchasing"
+                ],
+                "summary": "Cancel purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/purchase-orders/{orderId}/order": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that a draft purchase order has been sent to the supplier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchasing"
+                ],
+                "summary": "Mark purchase order as ordered",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/purchase-orders/{orderId}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Book the goods of an open purchase order into stock at their order cost",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchasing"
+                ],
+                "summary": "Receive purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Receiving location",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReceivePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/recipes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every recipe of the business with its current ingredient cost",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Recipe"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/recipes/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare the ingredient usage back-flushed by menu item sales with the actual usage revealed by a stocktake. The period runs from the previous stocktake (or start_date) to when the stocktake was opened",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Theoretical vs actual ingredient usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stocktake the actual usage is taken from",
+                        "name": "stocktake_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the period (YYYY-MM-DD); defaults to the previous stocktake",
+                        "name": "start_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.UsageReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/reorder-suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suggest what to reorder from recent sales velocity, lead times and maximum stock, grouped by supplier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchasing"
+                ],
+                "summary": "Get reorder suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of sales history used for demand (default 30)",
+                        "name": "lookback_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Extra days of cover held against demand spikes (default 7)",
+                        "name": "safety_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of demand ordered beyond the reorder point when a product has no maximum stock (default 30)",
+                        "name": "cover_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products of this supplier",
+                        "name": "supplier_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReorderSuggestionsReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/serials/{serial}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a serialised unit by its serial number or IMEI with its full history: received, sold to whom, returned, and its warranty expiry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Look up a serial number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SerialNumber"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/serials/{serial}/write-off": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take a damaged or lost unit out of stock by its serial number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Write off a serialised unit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.WriteOffSerialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SerialNumber"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/stocktakes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the stocktake sessions of a business, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "List stocktakes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status: open, posted, cancelled",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Stocktake"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open a physical count session for all products or one category, snapshotting expected quantities",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Open a stocktake",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stocktake details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateStocktakeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Stocktake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a stocktake with expected and counted quantities and the variance of each item at cost",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Get a stocktake",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stocktake ID",
+                        "name": "stocktakeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Stocktake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an open stocktake without changing stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Cancel a stocktake",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stocktake ID",
+                        "name": "stocktakeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}/counts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enter counted quantities by product ID or barcode. Counts add to the existing count unless mode is \"set\"; a barcode scan without a quantity counts one unit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Record counted quantities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stocktake ID",
+                        "name": "stocktakeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counts",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.RecordStocktakeCountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Stocktake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the count report with expected, counted and variance values as CSV or JSON",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Export a stocktake",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stocktake ID",
+                        "name": "stocktakeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format: csv (default), json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stocktake file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/stocktakes/{stocktakeId}/post": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close the stocktake and post the variance of every counted item as an adjust stock movement",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Post a stocktake",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stocktake ID",
+                        "name": "stocktakeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.PostStocktakeResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all suppliers of a business by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "List suppliers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Supplier"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a supplier that products can be ordered from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Create supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/suppliers/{supplierId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a supplier by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplierId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a supplier's details, lead time or status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Update supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplierId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.UpdateSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the stock transfers of a business, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "List stock transfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status: in_transit, received, cancelled",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.StockTransfer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move goods from one location to another. Stock leaves the source immediately and is in transit until received",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Dispatch stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/transfers/{transferId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a stock transfer by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Get stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transferId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/transfers/{transferId}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an in-transit transfer and return its goods to the source location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Cancel stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transferId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/inventory/transfers/{transferId}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Book the goods of an in-transit transfer into the destination location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Receive stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transferId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/compare": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set sales, expenses, profit, transactions and average basket against an earlier period, with the categories and products behind the change in sales. Presets compare this week or month to date with the same days of the last one, or a period with the same dates a year earlier; custom compares any two periods, or the current one with the same number of days just before it when no previous dates are given. Dates are days in the business's timezone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Compare two periods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preset: week_over_week, month_over_month (default without dates), year_over_year, custom (default with dates)",
+                        "name": "preset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current period start (YYYY-MM-DD) for custom and year_over_year",
+                        "name": "current_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current period end (YYYY-MM-DD) for custom and year_over_year",
+                        "name": "current_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Previous period start (YYYY-MM-DD) for custom",
+                        "name": "previous_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Previous period end (YYYY-MM-DD) for custom",
+                        "name": "previous_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Products in the contribution analysis (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.PeriodComparison"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/dashboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get key metrics for dashboard display (today's data)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get dashboard overview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.DashboardData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the delivery history of your report subscriptions, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "List report deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by subscription",
+                        "name": "subscription_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, sent, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit results (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.ReportDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/deliveries/{deliveryId}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make one more attempt at a delivery that failed after all its retries",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Retry a failed report delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReportDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/expenses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate expense report with optional period and category filtering",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get expenses report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period: daily, weekly, monthly, yearly, custom",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD) for custom period",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD) for custom period",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ExpensesReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export report data as CSV, JSON, a printable PDF with the business letterhead, summary tables, charts and page numbers, or an XLSX workbook with typed numbers and dates in sheets for the summary, daily breakdown, top products and transactions",
+                "produces": [
+                    "text/csv",
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Export a report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Report type: sales, expenses, profit, inventory",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period: daily, weekly, monthly, yearly, custom",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD) for custom period",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD) for custom period",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format: csv (default), pdf, xlsx, json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/inventory": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate inventory report with low stock alerts, across all locations or for a single location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get inventory status report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Report only the stock held at this location",
+                        "name": "location_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.InventoryReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/inventory/aging": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bucket each product's on-hand stock by days since it was last sold and last received, with the capital tied up in each bucket, and flag dead stock with no sales in dead_days days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Inventory aging report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days without a sale before stock counts as dead (default 90)",
+                        "name": "dead_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated upper day limits of the buckets (default 30,90,180)",
+                        "name": "buckets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.AgingReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/inventory/aging/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the inventory aging report as CSV or JSON",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Export inventory aging report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days without a sale before stock counts as dead (default 90)",
+                        "name": "dead_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated upper day limits of the buckets (default 30,90,180)",
+                        "name": "buckets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format: csv (default), json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Aging report file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/inventory/valuation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Value the stock held at the end of a date by replaying the stock movement ledger up to it, pricing each product with the business costing method and the costs in effect at the time. Defaults to now",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Stock valuation on a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Valuation date (YYYY-MM-DD)",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.StockValuation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Completed sales per day by payment method and payment status, with the paid takings of cash, card, mobile and bank set against the settlements entered and each day and method's reconciliation status. Dates are days in the business's timezone; the range defaults to the last 7 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Payment reconciliation report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, default today)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.PaymentReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/payments/settlements": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enter the amount actually received for cash, card, mobile or bank takings on a day, from a bank deposit, card settlement or mobile money statement, for the business owner. The variance against the day's paid takings is recorded with it; entering the same day and method again replaces it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Record a payment settlement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Settlement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.RecordSettlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.PaymentSettlement"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/products/abc": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rank every product in the catalogue, including those that did not sell, by revenue, gross profit and quantity sold over a range, and put each into class A, B or C by its cumulative share of each total. Dates are days in the business's timezone; the range defaults to the last 90 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "ABC product analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, default today)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order the products by: revenue (default), gross_profit, quantity",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products in this category or below it, by ID or name",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percent of each total taken by class A (default 80)",
+                        "name": "class_a",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percent of each total taken by class B (default 15)",
+                        "name": "class_b",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ABCReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/products/abc/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the ABC product analysis as CSV or JSON",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Export ABC product analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, default today)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order the products by: revenue (default), gross_profit, quantity",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products in this category or below it, by ID or name",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percent of each total taken by class A (default 80)",
+                        "name": "class_a",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percent of each total taken by class B (default 15)",
+                        "name": "class_b",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format: csv (default), json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ABC analysis file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/profit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate profit/loss report with optional period filtering",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get profit report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period: daily, weekly, monthly, yearly, custom",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD) for custom period",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD) for custom period",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ProfitReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/profit/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get profit summary with custom date range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get profit summary for period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period: daily, weekly, monthly, yearly, custom",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD) for custom period",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD) for custom period",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ProfitReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/profit/trends": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get profit trends for multiple periods",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get profit trends over time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period: daily, weekly, monthly",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of weeks to analyze (default 12)",
+                        "name": "weeks",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.ProfitTrend"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate sales report with optional period filtering",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get sales report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period: daily, weekly, monthly, yearly, custom",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD) for custom period",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD) for custom period",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SalesReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/sales/heatmap": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count completed sales and revenue by day of the week and hour of the day in the business's timezone, against the range of the same number of days just before. Dates are days in the business's timezone; the range defaults to the last four weeks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Sales heatmap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, default today)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales of this product",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales of products in this category or below it, by ID or name",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way (cash, card, mobile, bank, credit, other)",
+                        "name": "payment_method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SalesHeatmap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/sales/heatmap/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the sales heatmap as CSV or JSON",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Export sales heatmap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, default today)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales of this product",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales of products in this category or below it, by ID or name",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sales paid this way (cash, card, mobile, bank, credit, other)",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format: csv (default), json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sales heatmap file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/staff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sales, revenue, average basket, discounts, voids, edits and refunds per user over a period, for the business owner. Voids and edits of another user's sales are counted apart. Dates are days in the business's timezone; the period defaults to the last 30 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Staff performance report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, default today)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.StaffPerformanceReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/staff/{staffId}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The sales a user recorded, voided or edited over a period, oldest first, for the business owner. Sales voided or edited by someone other than their seller are highlighted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Staff member's transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the staff member",
+                        "name": "staffId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, default today)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.StaffTransactions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get your report subscriptions in the business",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "List report subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.ReportSubscription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Have a report emailed to you on a daily, weekly or monthly schedule. Each report covers the last full period before it is sent, in the business's timezone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Subscribe to a report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report, format and schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateReportSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReportSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/subscriptions/{subscriptionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop a subscription; its delivery history is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Delete a report subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscriptionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change a subscription's report, format or schedule, or pause it with active set to false",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Update a report subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscriptionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.UpdateReportSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReportSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/reports/subscriptions/{subscriptionId}/send": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Email a subscription's report for the last full period straight away. A failed send is retried like a scheduled one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Send a subscribed report now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscriptionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.ReportDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get sales transactions with filtering and pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "List all sales",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sale status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment method",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment status",
+                        "name": "payment_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset results",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Domain.Sale"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a sales transaction with optional product association",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Record a new sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sale details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateSaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Sale"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/sales/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get sales statistics and analytics",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Get sales statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period: today, week, month",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SaleStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/sales/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get aggregated sales data for a period (daily/weekly/monthly)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Get sales summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period: today, week, month",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SaleSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/sales/{saleId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific sale",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Get sale details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sale ID",
+                        "name": "saleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Sale"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Void a completed sale transaction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Void/soft delete sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sale ID",
+                        "name": "saleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update sale details (before sync)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Update sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sale ID",
+                        "name": "saleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sale update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.CreateSaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.Sale"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/sync/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Process batch sync of offline transactions (sales, expenses, products)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Sync multiple transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Batch sync data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SyncBatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SyncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/sync/last-sync": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get last synchronization timestamp for specific device",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Get last sync time for device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "device_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/businesses/{businessId}/sync/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get synchronization status including last sync time and pending items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Get sync status for business",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "businessId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.SyncStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve authenticated user's profile information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get current user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "User update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Domain.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Domain.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "Domain.ABCClass": {
+            "type": "string",
+            "enum": [
+                "A",
+                "B",
+                "C"
+            ],
+            "x-enum-varnames": [
+                "ABCClassA",
+                "ABCClassB",
+                "ABCClassC"
+            ]
+        },
+        "Domain.ABCClassSummary": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "$ref": "#/definitions/Domain.ABCClass"
+                },
+                "product_share": {
+                    "description": "Percent of the products",
+                    "type": "number"
+                },
+                "products": {
+                    "type": "integer"
+                },
+                "share": {
+                    "description": "Percent of the total",
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ABCItem": {
+            "type": "object",
+            "properties": {
+                "by_gross_profit": {
+                    "$ref": "#/definitions/Domain.ABCRanking"
+                },
+                "by_quantity": {
+                    "$ref": "#/definitions/Domain.ABCRanking"
+                },
+                "by_revenue": {
+                    "$ref": "#/definitions/Domain.ABCRanking"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "cost_of_goods": {
+                    "type": "number"
+                },
+                "gross_margin": {
+                    "description": "Percent of revenue",
+                    "type": "number"
+                },
+                "gross_profit": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ABCMetric": {
+            "type": "string",
+            "enum": [
+                "revenue",
+                "gross_profit",
+                "quantity"
+            ],
+            "x-enum-comments": {
+                "ABCMetricGrossProfit": "Gross margin earned, revenue less cost of goods"
+            },
+            "x-enum-descriptions": [
+                "",
+                "Gross margin earned, revenue less cost of goods",
+                ""
+            ],
+            "x-enum-varnames": [
+                "ABCMetricRevenue",
+                "ABCMetricGrossProfit",
+                "ABCMetricQuantity"
+            ]
+        },
+        "Domain.ABCMetricSummary": {
+            "type": "object",
+            "properties": {
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.ABCClassSummary"
+                    }
+                },
+                "metric": {
+                    "$ref": "#/definitions/Domain.ABCMetric"
+                },
+                "total": {
+                    "description": "Of the products' positive figures, which the shares are of",
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ABCRanking": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "$ref": "#/definitions/Domain.ABCClass"
+                },
+                "cumulative_share": {
+                    "description": "Percent of the total up to and including this product",
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "share": {
+                    "description": "Percent of the total",
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ABCReport": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "cost_of_goods": {
+                    "type": "number"
+                },
+                "gross_profit": {
+                    "type": "number"
+                },
+                "items": {
+                    "description": "Ranked by the report's metric",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.ABCItem"
+                    }
+                },
+                "metric": {
+                    "$ref": "#/definitions/Domain.ABCMetric"
+                },
+                "period": {
+                    "$ref": "#/definitions/Domain.ComparisonPeriod"
+                },
+                "products_not_sold": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "summaries": {
+                    "description": "One per metric",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.ABCMetricSummary"
+                    }
+                },
+                "thresholds": {
+                    "$ref": "#/definitions/Domain.ABCThresholds"
+                },
+                "total_products": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.ABCThresholds": {
+            "type": "object",
+            "properties": {
+                "a": {
+                    "type": "number"
+                },
+                "b": {
+                    "type": "number"
+                },
+                "c": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.AdjustStockRequest": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "reason",
+                "type"
+            ],
+            "properties": {
+                "location_id": {
+                    "description": "Defaults to the default location",
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/Domain.MovementType"
+                },
+                "unit_cost": {
+                    "description": "Purchase cost per unit; defaults to the product cost price",
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.AgingBucket": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "max_days": {
+                    "description": "Empty for the open-ended bucket",
+                    "type": "integer"
+                },
+                "min_days": {
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.AgingItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "days_since_received": {
+                    "type": "integer"
+                },
+                "days_since_sold": {
+                    "type": "integer"
+                },
+                "dead_stock": {
+                    "type": "boolean"
+                },
+                "last_received_at": {
+                    "type": "string"
+                },
+                "last_sold_at": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "receipt_bucket": {
+                    "type": "string"
+                },
+                "sales_bucket": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "number"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.AgingReport": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string"
+                },
+                "dead_stock_count": {
+                    "type": "integer"
+                },
+                "dead_stock_days": {
+                    "type": "integer"
+                },
+                "dead_stock_value": {
+                    "type": "number"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.AgingItem"
+                    }
+                },
+                "receipt_aging": {
+                    "description": "By days since last received",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.AgingBucket"
+                    }
+                },
+                "sales_aging": {
+                    "description": "By days since last sold",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.AgingBucket"
+                    }
+                },
+                "total_products": {
+                    "type": "integer"
+                },
+                "total_stock": {
+                    "type": "number"
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.BarcodeFormat": {
+            "type": "string",
+            "enum": [
+                "ean13",
+                "code128"
+            ],
+            "x-enum-varnames": [
+                "BarcodeFormatEAN13",
+                "BarcodeFormatCode128"
+            ]
+        },
+        "Domain.BatchStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "depleted",
+                "expired"
+            ],
+            "x-enum-varnames": [
+                "BatchStatusActive",
+                "BatchStatusDepleted",
+                "BatchStatusExpired"
+            ]
+        },
+        "Domain.BundleComponent": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.BundleComponentRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.Business": {
+            "type": "object",
+            "required": [
+                "business_type",
+                "currency",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "business_type": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "costing_method": {
+                    "description": "fifo or average",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.CostingMethod"
+                        }
+                    ]
+                },
+                "country": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "negative_stock": {
+                    "description": "block (default), warn or allow",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.NegativeStockPolicy"
+                        }
+                    ]
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.BusinessStatus"
+                },
+                "timezone": {
+                    "description": "IANA name; report days run midnight to midnight here",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.BusinessStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive",
+                "closed"
+            ],
+            "x-enum-varnames": [
+                "BusinessStatusActive",
+                "BusinessStatusInactive",
+                "BusinessStatusClosed"
+            ]
+        },
+        "Domain.CatalogField": {
+            "type": "string",
+            "enum": [
+                "name",
+                "sku",
+                "barcode",
+                "description",
+                "category",
+                "unit",
+                "cost_price",
+                "selling_price",
+                "stock",
+                "min_stock",
+                "max_stock",
+                "supplier",
+                "lead_time_days",
+                "track_batches"
+            ],
+            "x-enum-varnames": [
+                "CatalogFieldName",
+                "CatalogFieldSKU",
+                "CatalogFieldBarcode",
+                "CatalogFieldDescription",
+                "CatalogFieldCategory",
+                "CatalogFieldUnit",
+                "CatalogFieldCostPrice",
+                "CatalogFieldSellingPrice",
+                "CatalogFieldStock",
+                "CatalogFieldMinStock",
+                "CatalogFieldMaxStock",
+                "CatalogFieldSupplier",
+                "CatalogFieldLeadTimeDays",
+                "CatalogFieldTrackBatches"
+            ]
+        },
+        "Domain.CatalogFormat": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "xlsx"
+            ],
+            "x-enum-varnames": [
+                "CatalogFormatCSV",
+                "CatalogFormatXLSX"
+            ]
+        },
+        "Domain.CatalogImportJob": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "Why the whole job failed",
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.ImportRowError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "format": {
+                    "$ref": "#/definitions/Domain.CatalogFormat"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mapping": {
+                    "description": "File header to product field",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/Domain.CatalogField"
+                    }
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "description": "Percentage of rows processed",
+                    "type": "number"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.ImportJobStatus"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.Category": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "ancestors": {
+                    "description": "From the top-level category down to the parent",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.Category"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "Empty for top-level categories",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "Position among its siblings",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.CategoryContribution": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "Empty for products without a category",
+                    "type": "string"
+                },
+                "change": {
+                    "type": "number"
+                },
+                "change_percent": {
+                    "type": "number"
+                },
+                "contribution": {
+                    "description": "Percent of the total change in sales; empty when sales did not change",
+                    "type": "number"
+                },
+                "current": {
+                    "type": "number"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "previous": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.CategoryExpense": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/Domain.ExpenseCategory"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "total_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.CategoryMigrationResult": {
+            "type": "object",
+            "properties": {
+                "categories_created": {
+                    "type": "integer"
+                },
+                "products_updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.CategorySales": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "Empty for products without a category",
+                    "type": "string"
+                },
+                "cost_of_goods": {
+                    "type": "number"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "gross_margin": {
+                    "type": "number"
+                },
+                "gross_profit": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.CategoryStock": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "Empty for products without a category",
+                    "type": "string"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "type": "number"
+                },
+                "stock_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ComparisonPeriod": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ComparisonPreset": {
+            "type": "string",
+            "enum": [
+                "week_over_week",
+                "month_over_month",
+                "year_over_year",
+                "custom"
+            ],
+            "x-enum-comments": {
+                "ComparisonCustom": "Any two periods",
+                "ComparisonMonthOverMonth": "This month to date against the same days last month",
+                "ComparisonWeekOverWeek": "This week to date against the same days last week",
+                "ComparisonYearOverYear": "A period against the same dates a year earlier"
+            },
+            "x-enum-descriptions": [
+                "This week to date against the same days last week",
+                "This month to date against the same days last month",
+                "A period against the same dates a year earlier",
+                "Any two periods"
+            ],
+            "x-enum-varnames": [
+                "ComparisonWeekOverWeek",
+                "ComparisonMonthOverMonth",
+                "ComparisonYearOverYear",
+                "ComparisonCustom"
+            ]
+        },
+        "Domain.CostLayer": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movement_id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "number"
+                },
+                "unit_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.CostRebuildResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "movements_processed": {
+                    "type": "integer"
+                },
+                "products_processed": {
+                    "type": "integer"
+                },
+                "sales_updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.CostingMethod": {
+            "type": "string",
+            "enum": [
+                "fifo",
+                "average"
+            ],
+            "x-enum-varnames": [
+                "CostingMethodFIFO",
+                "CostingMethodAverage"
+            ]
+        },
+        "Domain.CreateBusinessRequest": {
+            "type": "object",
+            "required": [
+                "business_type",
                 "currency",
                 "name"
             ],
             "properties": {
-                "address": {
+                "address": {
+                    "type": "string"
+                },
+                "business_type": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "costing_method": {
+                    "$ref": "#/definitions/Domain.CostingMethod"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "negative_stock": {
+                    "$ref": "#/definitions/Domain.NegativeStockPolicy"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.CreateDraftOrdersRequest": {
+            "type": "object",
+            "properties": {
+                "cover_days": {
+                    "description": "Defaults to 30",
+                    "type": "integer"
+                },
+                "lookback_days": {
+                    "description": "Defaults to 30",
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "product_ids": {
+                    "description": "Only include these products; all suggestions when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "safety_days": {
+                    "description": "Defaults to 7",
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "description": "Only draft the order of this supplier",
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.CreateExpenseRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "category"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "$ref": "#/definitions/Domain.ExpenseCategory"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "local_id": {
+                    "description": "For offline sync",
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.CreateLocationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/Domain.LocationType"
+                }
+            }
+        },
+        "Domain.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "cost_price",
+                "name",
+                "selling_price"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category": {
+                    "description": "Category name, used when no ID is given; created when it does not exist",
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "components": {
+                    "description": "Required for bundles; replaces the existing lines on update",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.BundleComponentRequest"
+                    }
+                },
+                "cost_price": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "location_id": {
+                    "description": "Where the opening stock is held; defaults to the default location",
+                    "type": "string"
+                },
+                "max_stock": {
+                    "type": "number"
+                },
+                "min_stock": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "negative_stock": {
+                    "description": "NegativeStock is block, warn or allow; empty keeps the current policy\nand inherit falls back to the business policy.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.NegativeStockPolicy"
+                        }
+                    ]
+                },
+                "selling_price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "track_batches": {
+                    "type": "boolean"
+                },
+                "track_serials": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "standard (default) or bundle",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.ProductType"
+                        }
+                    ]
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "warranty_months": {
+                    "description": "WarrantyMonths applies to serialised products; zero leaves sold units\nwithout a warranty expiry.",
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.CreateReportSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "report_type",
+                "schedule"
+            ],
+            "properties": {
+                "day_of_month": {
+                    "description": "Defaults to 1",
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "Defaults to the user's email",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Defaults to pdf",
+                    "type": "string"
+                },
+                "hour": {
+                    "description": "Defaults to 7",
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "Defaults to the schedule's period",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.PeriodType"
+                        }
+                    ]
+                },
+                "report_type": {
+                    "$ref": "#/definitions/Domain.ReportType"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/Domain.ReportSchedule"
+                },
+                "weekday": {
+                    "description": "Defaults to Monday",
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.CreateSaleRequest": {
+            "type": "object",
+            "required": [
+                "payment_method",
+                "quantity",
+                "unit_price"
+            ],
+            "properties": {
+                "batch_id": {
+                    "description": "Overrides FEFO batch selection",
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "local_id": {
+                    "description": "For offline sync",
+                    "type": "string"
+                },
+                "location_id": {
+                    "description": "Location the goods leave from; defaults to the default location",
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "$ref": "#/definitions/Domain.PaymentMethod"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "serials": {
+                    "description": "Required for serialised products, one per unit",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "unit_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.CreateStocktakeRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "description": "Count only this category (ID or name) and its subcategories; all products when empty",
+                    "type": "string"
+                },
+                "location_id": {
+                    "description": "Count only the stock held at this location",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.CreateSupplierRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "contact_name": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.CreateTransferItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.CreateTransferRequest": {
+            "type": "object",
+            "required": [
+                "from_location_id",
+                "items",
+                "to_location_id"
+            ],
+            "properties": {
+                "from_location_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.CreateTransferItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "to_location_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.DailyExpense": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.DailySales": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "sales": {
+                    "type": "number"
+                },
+                "transactions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.DashboardData": {
+            "type": "object",
+            "properties": {
+                "low_stock_count": {
+                    "type": "integer"
+                },
+                "month_expenses": {
+                    "type": "number"
+                },
+                "month_profit": {
+                    "type": "number"
+                },
+                "month_sales": {
+                    "type": "number"
+                },
+                "negative_stock": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.NegativeStockItem"
+                    }
+                },
+                "negative_stock_count": {
+                    "description": "NegativeStock lists the products currently below zero, sold before\ntheir receipts were entered.",
+                    "type": "integer"
+                },
+                "pending_payments": {
+                    "type": "number"
+                },
+                "today_expenses": {
+                    "type": "number"
+                },
+                "today_profit": {
+                    "type": "number"
+                },
+                "today_sales": {
+                    "type": "number"
+                },
+                "week_expenses": {
+                    "type": "number"
+                },
+                "week_profit": {
+                    "type": "number"
+                },
+                "week_sales": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.Expense": {
+            "type": "object",
+            "required": [
+                "amount",
+                "category"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/Domain.ExpenseCategory"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "local_id": {
+                    "description": "For offline sync",
+                    "type": "string"
+                },
+                "receipt_url": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.ExpenseStatus"
+                },
+                "synced": {
+                    "type": "boolean"
+                },
+                "synced_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ExpenseCategory": {
+            "type": "string",
+            "enum": [
+                "rent",
+                "utilities",
+                "stock_purchase",
+                "transport",
+                "salaries",
+                "marketing",
+                "maintenance",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "ExpenseCategoryRent",
+                "ExpenseCategoryUtilities",
+                "ExpenseCategoryStockPurchase",
+                "ExpenseCategoryTransport",
+                "ExpenseCategorySalaries",
+                "ExpenseCategoryMarketing",
+                "ExpenseCategoryMaintenance",
+                "ExpenseCategoryOther"
+            ]
+        },
+        "Domain.ExpenseStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "voided",
+                "deleted"
+            ],
+            "x-enum-varnames": [
+                "ExpenseStatusActive",
+                "ExpenseStatusVoided",
+                "ExpenseStatusDeleted"
+            ]
+        },
+        "Domain.ExpenseSummary": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/Domain.ExpenseCategory"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "total_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ExpensesReport": {
+            "type": "object",
+            "properties": {
+                "category_breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.CategoryExpense"
+                    }
+                },
+                "daily_expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.DailyExpense"
+                    }
+                },
+                "period": {
+                    "type": "string"
+                },
+                "total_expenses": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ExpiryWriteOffResult": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.StockBatch"
+                    }
+                },
+                "batches_written_off": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_quantity": {
+                    "type": "number"
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.GenerateBarcodeRequest": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "Defaults to ean13",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.BarcodeFormat"
+                        }
+                    ]
+                }
+            }
+        },
+        "Domain.GenerateBarcodesResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "generated": {
+                    "type": "integer"
+                },
+                "products": {
+                    "description": "IDs of the products that got a barcode",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Domain.HeatmapCell": {
+            "type": "object",
+            "properties": {
+                "hour": {
+                    "type": "integer"
+                },
+                "previous_revenue": {
+                    "type": "number"
+                },
+                "previous_transactions": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "revenue_change_percent": {
+                    "description": "Empty when there was no previous revenue",
+                    "type": "number"
+                },
+                "transactions": {
+                    "type": "integer"
+                },
+                "weekday": {
+                    "description": "0 is Sunday",
+                    "type": "integer"
+                },
+                "weekday_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.HeatmapTotal": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "description": "Weekday name or hour as 15:00",
+                    "type": "string"
+                },
+                "previous_revenue": {
+                    "type": "number"
+                },
+                "previous_transactions": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "revenue_change_percent": {
+                    "type": "number"
+                },
+                "transactions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.ImportJobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportJobStatusPending",
+                "ImportJobStatusRunning",
+                "ImportJobStatusCompleted",
+                "ImportJobStatusFailed"
+            ]
+        },
+        "Domain.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.IngredientUsage": {
+            "type": "object",
+            "properties": {
+                "actual_usage": {
+                    "description": "Theoretical usage plus the stocktake shortfall",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "theoretical_usage": {
+                    "description": "Back-flushed by sales of menu items",
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "variance": {
+                    "description": "Actual minus theoretical",
+                    "type": "number"
+                },
+                "variance_percent": {
+                    "description": "Variance as a share of theoretical usage",
+                    "type": "number"
+                },
+                "variance_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.InventoryReport": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Every level of the category tree, including subcategories",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.CategoryStock"
+                    }
+                },
+                "location_id": {
+                    "description": "Set when the report covers a single location",
+                    "type": "string"
+                },
+                "locations": {
+                    "description": "Stock per location in the aggregate report",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.LocationStockSummary"
+                    }
+                },
+                "low_stock_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.LowStockItem"
+                    }
+                },
+                "stock_movement": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.StockMovement"
+                    }
+                },
+                "total_products": {
+                    "type": "integer"
+                },
+                "total_stock": {
+                    "type": "number"
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.LabelFormat": {
+            "type": "string",
+            "enum": [
+                "pdf",
+                "png"
+            ],
+            "x-enum-varnames": [
+                "LabelFormatPDF",
+                "LabelFormatPNG"
+            ]
+        },
+        "Domain.LabelSheetRequest": {
+            "type": "object",
+            "required": [
+                "product_ids"
+            ],
+            "properties": {
+                "copies": {
+                    "description": "Labels per product; defaults to 1",
+                    "type": "integer"
+                },
+                "format": {
+                    "description": "Defaults to pdf",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.LabelFormat"
+                        }
+                    ]
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Domain.LedgerDiscrepancy": {
+            "type": "object",
+            "properties": {
+                "broken_links": {
+                    "description": "BrokenLinks counts movements whose previous quantity is not the new\nquantity of the movement before them, or whose change does not match\ntheir quantity.",
+                    "type": "integer"
+                },
+                "current_stock": {
+                    "type": "number"
+                },
+                "difference": {
+                    "description": "Current stock less ledger stock",
+                    "type": "number"
+                },
+                "first_break_at": {
+                    "type": "string"
+                },
+                "ledger_stock": {
+                    "description": "New quantity of the last movement",
+                    "type": "number"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.LedgerIntegrityReport": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "discrepancies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.LedgerDiscrepancy"
+                    }
+                },
+                "movements_checked": {
+                    "type": "integer"
+                },
+                "products_checked": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.Location": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.LocationStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/Domain.LocationType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.LocationStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "LocationStatusActive",
+                "LocationStatusInactive"
+            ]
+        },
+        "Domain.LocationStockBalance": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "type": "string"
+                },
+                "location_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.LocationStockItem": {
+            "type": "object",
+            "properties": {
+                "low_stock": {
+                    "type": "boolean"
+                },
+                "min_stock": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.LocationStockReport": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.LocationStockItem"
+                    }
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "location_name": {
+                    "type": "string"
+                },
+                "low_stock_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.LowStockItem"
+                    }
+                },
+                "total_products": {
+                    "type": "integer"
+                },
+                "total_stock": {
+                    "type": "number"
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.LocationStockSummary": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "type": "string"
+                },
+                "location_name": {
+                    "type": "string"
+                },
+                "total_stock": {
+                    "type": "number"
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.LocationType": {
+            "type": "string",
+            "enum": [
+                "store",
+                "outlet",
+                "warehouse"
+            ],
+            "x-enum-varnames": [
+                "LocationTypeStore",
+                "LocationTypeOutlet",
+                "LocationTypeWarehouse"
+            ]
+        },
+        "Domain.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "phone"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/Domain.User"
+                }
+            }
+        },
+        "Domain.LowStockItem": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "number"
+                },
+                "difference": {
+                    "type": "number"
+                },
+                "minimum": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.MergeCategoryRequest": {
+            "type": "object",
+            "required": [
+                "target_id"
+            ],
+            "properties": {
+                "target_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.MetricChange": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "number"
+                },
+                "change_percent": {
+                    "description": "Empty when the previous figure is zero",
+                    "type": "number"
+                },
+                "current": {
+                    "type": "number"
+                },
+                "previous": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.MovementType": {
+            "type": "string",
+            "enum": [
+                "purchase",
+                "sale",
+                "adjust",
+                "damage",
+                "theft",
+                "return"
+            ],
+            "x-enum-varnames": [
+                "MovementTypePurchase",
+                "MovementTypeSale",
+                "MovementTypeAdjust",
+                "MovementTypeDamage",
+                "MovementTypeTheft",
+                "MovementTypeReturn"
+            ]
+        },
+        "Domain.NearExpiryItem": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "days_to_expiry": {
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "lot_number": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.NearExpiryReport": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.NearExpiryItem"
+                    }
+                },
+                "total_batches": {
+                    "type": "integer"
+                },
+                "total_quantity": {
+                    "type": "number"
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.NegativeStockCover": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "movement_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "reference_type": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/Domain.MovementType"
+                }
+            }
+        },
+        "Domain.NegativeStockEvent": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "covered": {
+                    "type": "number"
+                },
+                "covered_at": {
+                    "type": "string"
+                },
+                "coverings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.NegativeStockCover"
+                    }
+                },
+                "created_at": {
+                    "description": "When the stock went negative",
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "movement_id": {
+                    "type": "string"
+                },
+                "policy": {
+                    "description": "Set when a sale went below zero under the policy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.NegativeStockPolicy"
+                        }
+                    ]
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "reference_type": {
+                    "type": "string"
+                },
+                "shortfall": {
+                    "description": "Quantity sold beyond the stock on hand",
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.NegativeStockStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.NegativeStockItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.NegativeStockPolicy": {
+            "type": "string",
+            "enum": [
+                "block",
+                "warn",
+                "allow",
+                "inherit"
+            ],
+            "x-enum-comments": {
+                "NegativeStockAllow": "Make the sale silently",
+                "NegativeStockBlock": "Refuse the sale (default)",
+                "NegativeStockWarn": "Make the sale and return a warning"
+            },
+            "x-enum-descriptions": [
+                "Refuse the sale (default)",
+                "Make the sale and return a warning",
+                "Make the sale silently",
+                ""
+            ],
+            "x-enum-varnames": [
+                "NegativeStockBlock",
+                "NegativeStockWarn",
+                "NegativeStockAllow",
+                "NegativeStockInherit"
+            ]
+        },
+        "Domain.NegativeStockReport": {
+            "type": "object",
+            "properties": {
+                "covered_events": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.NegativeStockEvent"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "open_events": {
+                    "type": "integer"
+                },
+                "total_outstanding": {
+                    "type": "number"
+                },
+                "total_shortfall": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.NegativeStockStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "covered"
+            ],
+            "x-enum-varnames": [
+                "NegativeStockStatusOpen",
+                "NegativeStockStatusCovered"
+            ]
+        },
+        "Domain.PaymentMethod": {
+            "type": "string",
+            "enum": [
+                "cash",
+                "card",
+                "mobile",
+                "bank",
+                "credit",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "PaymentMethodCash",
+                "PaymentMethodCard",
+                "PaymentMethodMobile",
+                "PaymentMethodBank",
+                "PaymentMethodCredit",
+                "PaymentMethodOther"
+            ]
+        },
+        "Domain.PaymentMethodSummary": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "number"
+                },
+                "amount": {
+                    "description": "Every payment status",
+                    "type": "number"
+                },
+                "expected": {
+                    "description": "Paid sales only",
+                    "type": "number"
+                },
+                "payment_method": {
+                    "$ref": "#/definitions/Domain.PaymentMethod"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.PaymentStatusTotal"
+                    }
+                },
+                "transactions": {
+                    "type": "integer"
+                },
+                "variance": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.PaymentReconciliationDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "methods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.PaymentReconciliationLine"
+                    }
+                }
+            }
+        },
+        "Domain.PaymentReconciliationLine": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "description": "Empty until a settlement is entered",
+                    "type": "number"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "expected": {
+                    "type": "number"
+                },
+                "payment_method": {
+                    "$ref": "#/definitions/Domain.PaymentMethod"
+                },
+                "settlement": {
+                    "$ref": "#/definitions/Domain.PaymentSettlement"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.ReconciliationStatus"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.PaymentStatusTotal"
+                    }
+                },
+                "transactions": {
+                    "type": "integer"
+                },
+                "variance": {
+                    "description": "Actual less expected",
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.PaymentReconciliationReport": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "description": "Settlements entered",
+                    "type": "number"
+                },
+                "days": {
+                    "description": "Days with sales or settlements, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.PaymentReconciliationDay"
+                    }
+                },
+                "discrepancies": {
+                    "type": "integer"
+                },
+                "expected": {
+                    "description": "Paid takings of the settled methods",
+                    "type": "number"
+                },
+                "methods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.PaymentMethodSummary"
+                    }
+                },
+                "period": {
+                    "$ref": "#/definitions/Domain.ComparisonPeriod"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "unreconciled": {
+                    "type": "integer"
+                },
+                "variance": {
+                    "description": "Over the days and methods with a settlement",
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.PaymentSettlement": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "number"
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD in the business's timezone",
+                    "type": "string"
+                },
+                "expected": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "$ref": "#/definitions/Domain.PaymentMethod"
+                },
+                "recorded_by": {
+                    "type": "string"
+                },
+                "reference": {
+                    "description": "Deposit slip or statement reference",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.ReconciliationStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variance": {
+                    "description": "Actual less expected",
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.PaymentStatus": {
+            "type": "string",
+            "enum": [
+                "paid",
+                "pending",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "PaymentStatusPaid",
+                "PaymentStatusPending",
+                "PaymentStatusFailed"
+            ]
+        },
+        "Domain.PaymentStatusTotal": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "payment_status": {
+                    "$ref": "#/definitions/Domain.PaymentStatus"
+                },
+                "transactions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.PeriodComparison": {
+            "type": "object",
+            "properties": {
+                "average_basket": {
+                    "$ref": "#/definitions/Domain.MetricChange"
+                },
+                "categories": {
+                    "description": "Every level of the category tree",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.CategoryContribution"
+                    }
+                },
+                "current": {
+                    "$ref": "#/definitions/Domain.ComparisonPeriod"
+                },
+                "expenses": {
+                    "description": "Cost of goods sold plus operating expenses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.MetricChange"
+                        }
+                    ]
+                },
+                "preset": {
+                    "$ref": "#/definitions/Domain.ComparisonPreset"
+                },
+                "previous": {
+                    "$ref": "#/definitions/Domain.ComparisonPeriod"
+                },
+                "products": {
+                    "description": "The products whose sales moved most",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.ProductContribution"
+                    }
+                },
+                "profit": {
+                    "$ref": "#/definitions/Domain.MetricChange"
+                },
+                "sales": {
+                    "$ref": "#/definitions/Domain.MetricChange"
+                },
+                "transactions": {
+                    "$ref": "#/definitions/Domain.MetricChange"
+                }
+            }
+        },
+        "Domain.PeriodType": {
+            "type": "string",
+            "enum": [
+                "daily",
+                "weekly",
+                "monthly",
+                "yearly",
+                "custom"
+            ],
+            "x-enum-varnames": [
+                "PeriodTypeDaily",
+                "PeriodTypeWeekly",
+                "PeriodTypeMonthly",
+                "PeriodTypeYearly",
+                "PeriodTypeCustom"
+            ]
+        },
+        "Domain.PostStocktakeResult": {
+            "type": "object",
+            "properties": {
+                "adjustments_made": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "net_variance_value": {
+                    "type": "number"
+                },
+                "stocktake": {
+                    "$ref": "#/definitions/Domain.Stocktake"
+                }
+            }
+        },
+        "Domain.PriceChange": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "new_cost_price": {
+                    "type": "number"
+                },
+                "new_selling_price": {
+                    "type": "number"
+                },
+                "old_cost_price": {
+                    "type": "number"
+                },
+                "old_selling_price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "description": "Set for scheduled changes",
+                    "type": "string"
+                },
+                "source": {
+                    "$ref": "#/definitions/Domain.PriceChangeSource"
+                }
+            }
+        },
+        "Domain.PriceChangeSource": {
+            "type": "string",
+            "enum": [
+                "manual",
+                "scheduled",
+                "recipe"
+            ],
+            "x-enum-varnames": [
+                "PriceChangeSourceManual",
+                "PriceChangeSourceScheduled",
+                "PriceChangeSourceRecipe"
+            ]
+        },
+        "Domain.Product": {
+            "type": "object",
+            "required": [
+                "cost_price",
+                "name",
+                "selling_price"
+            ],
+            "properties": {
+                "average_cost": {
+                    "description": "Moving average of received stock",
+                    "type": "number"
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "category": {
+                    "description": "Name of the category, kept for display and exports",
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "components": {
+                    "description": "Components make up a bundle. A bundle holds no stock of its own: its\nstock is how many can be built from the components, and its cost is\nthe sum of their costs.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.BundleComponent"
+                    }
+                },
+                "cost_price": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "lead_time_days": {
+                    "description": "Overrides the supplier lead time",
+                    "type": "integer"
+                },
+                "max_stock": {
+                    "type": "number"
+                },
+                "min_stock": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "negative_stock": {
+                    "description": "NegativeStock overrides the business policy for selling below zero.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.NegativeStockPolicy"
+                        }
+                    ]
+                },
+                "selling_price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.ProductStatus"
+                },
+                "stock": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "track_batches": {
+                    "description": "Stock held per lot with expiry dates",
+                    "type": "boolean"
+                },
+                "track_serials": {
+                    "description": "Each unit carries a serial number, such as an IMEI",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "Empty for standard products",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.ProductType"
+                        }
+                    ]
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warranty_months": {
+                    "description": "WarrantyMonths is how long the warranty of a serialised unit runs from\nits sale date.",
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.ProductContribution": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "number"
+                },
+                "change_percent": {
+                    "type": "number"
+                },
+                "contribution": {
+                    "type": "number"
+                },
+                "current": {
+                    "type": "number"
+                },
+                "current_quantity": {
+                    "type": "number"
+                },
+                "previous": {
+                    "type": "number"
+                },
+                "previous_quantity": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ProductLocationStock": {
+            "type": "object",
+            "properties": {
+                "in_transit": {
+                    "type": "number"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.LocationStockBalance"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "total_stock": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ProductPrice": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "cost_price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "selling_price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ProductSerials": {
+            "type": "object",
+            "properties": {
+                "in_stock": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "serials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.SerialNumber"
+                    }
+                },
+                "stock": {
+                    "type": "number"
+                },
+                "untracked": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ProductStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive",
+                "discontinued"
+            ],
+            "x-enum-varnames": [
+                "ProductStatusActive",
+                "ProductStatusInactive",
+                "ProductStatusDiscontinued"
+            ]
+        },
+        "Domain.ProductType": {
+            "type": "string",
+            "enum": [
+                "standard",
+                "bundle"
+            ],
+            "x-enum-varnames": [
+                "ProductTypeStandard",
+                "ProductTypeBundle"
+            ]
+        },
+        "Domain.ProfitReport": {
+            "type": "object",
+            "properties": {
+                "cost_of_goods_sold": {
+                    "type": "number"
+                },
+                "gross_margin": {
+                    "type": "number"
+                },
+                "gross_profit": {
+                    "type": "number"
+                },
+                "net_profit": {
+                    "type": "number"
+                },
+                "operating_expenses": {
+                    "description": "Expenses other than stock purchases",
+                    "type": "number"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "profit_margin": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "stock_purchases": {
+                    "description": "Already counted through cost of goods sold",
+                    "type": "number"
+                },
+                "total_expenses": {
+                    "type": "number"
+                },
+                "total_sales": {
+                    "type": "number"
+                },
+                "trends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.ProfitTrend"
+                    }
+                }
+            }
+        },
+        "Domain.ProfitTrend": {
+            "type": "object",
+            "properties": {
+                "expenses": {
+                    "type": "number"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "profit": {
+                    "type": "number"
+                },
+                "sales": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.PurchaseOrderItem"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "ordered_at": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "received_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.PurchaseOrderStatus"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "supplier_name": {
+                    "type": "string"
+                },
+                "total_cost": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.PurchaseOrderItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "total_cost": {
+                    "type": "number"
+                },
+                "unit_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.PurchaseOrderStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "ordered",
+                "received",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "PurchaseOrderStatusDraft",
+                "PurchaseOrderStatusOrdered",
+                "PurchaseOrderStatusReceived",
+                "PurchaseOrderStatusCancelled"
+            ]
+        },
+        "Domain.ReceiveBatchRequest": {
+            "type": "object",
+            "required": [
+                "lot_number",
+                "quantity"
+            ],
+            "properties": {
+                "cost_price": {
+                    "type": "number"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "lot_number": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "received_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ReceivePurchaseOrderRequest": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "description": "Defaults to the default location",
+                    "type": "string"
+                },
+                "serials": {
+                    "description": "Serials holds the serial numbers received for each serialised product,\nkeyed by product ID, one per unit ordered.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "Domain.ReceiveSerialsRequest": {
+            "type": "object",
+            "required": [
+                "serials"
+            ],
+            "properties": {
+                "cost_price": {
+                    "description": "Defaults to the product cost price",
+                    "type": "number"
+                },
+                "existing": {
+                    "description": "Existing registers serials for units already counted in stock, such as\nwhen serial tracking is switched on for a stocked product. No stock\nmovement is recorded for them.",
+                    "type": "boolean"
+                },
+                "location_id": {
+                    "description": "Defaults to the default location",
+                    "type": "string"
+                },
+                "serials": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Domain.Recipe": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "cost": {
+                    "description": "Ingredient cost of one unit, waste included",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.RecipeIngredient"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "description": "The menu item",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.RecipeIngredient": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "waste_factor": {
+                    "description": "Fraction lost in preparation, e.g. 0.05 for 5%",
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.RecipeIngredientRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "waste_factor": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ReconciliationStatus": {
+            "type": "string",
+            "enum": [
+                "unreconciled",
+                "reconciled",
+                "discrepancy",
+                "not_required"
+            ],
+            "x-enum-comments": {
+                "ReconciliationDiscrepancy": "The settlement differs from the takings",
+                "ReconciliationNotRequired": "Credit and other methods are not settled",
+                "ReconciliationReconciled": "The settlement matches the takings",
+                "ReconciliationUnreconciled": "No settlement entered yet"
+            },
+            "x-enum-descriptions": [
+                "No settlement entered yet",
+                "The settlement matches the takings",
+                "The settlement differs from the takings",
+                "Credit and other methods are not settled"
+            ],
+            "x-enum-varnames": [
+                "ReconciliationUnreconciled",
+                "ReconciliationReconciled",
+                "ReconciliationDiscrepancy",
+                "ReconciliationNotRequired"
+            ]
+        },
+        "Domain.RecordSettlementRequest": {
+            "type": "object",
+            "required": [
+                "actual",
+                "date",
+                "payment_method"
+            ],
+            "properties": {
+                "actual": {
+                    "type": "number"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "$ref": "#/definitions/Domain.PaymentMethod"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.RecordStocktakeCountsRequest": {
+            "type": "object",
+            "required": [
+                "counts"
+            ],
+            "properties": {
+                "counts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.StocktakeCount"
+                    }
+                }
+            }
+        },
+        "Domain.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "password",
+                "phone"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ReorderCategoriesRequest": {
+            "type": "object",
+            "required": [
+                "category_ids"
+            ],
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ReorderSuggestion": {
+            "type": "object",
+            "properties": {
+                "average_daily_demand": {
+                    "type": "number"
+                },
+                "current_stock": {
+                    "type": "number"
+                },
+                "days_of_cover": {
+                    "description": "Nil when the product has not sold",
+                    "type": "number"
+                },
+                "estimated_cost": {
+                    "type": "number"
+                },
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "max_stock": {
+                    "type": "number"
+                },
+                "min_stock": {
+                    "type": "number"
+                },
+                "on_order": {
+                    "description": "Quantity on open purchase orders",
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "reorder_point": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "sold_in_period": {
+                    "type": "number"
+                },
+                "suggested_quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.ReorderSuggestionsReport": {
+            "type": "object",
+            "properties": {
+                "estimated_cost": {
+                    "type": "number"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "lookback_days": {
+                    "type": "integer"
+                },
+                "safety_days": {
+                    "type": "integer"
+                },
+                "suppliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.SupplierReorderGroup"
+                    }
+                },
+                "total_items": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.ReportDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "report_type": {
+                    "$ref": "#/definitions/Domain.ReportType"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.ReportDeliveryStatus"
+                },
+                "subscription_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.ReportDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "ReportDeliveryFailed": "Gave up after MaxReportDeliveryAttempts",
+                "ReportDeliveryPending": "Waiting for its first or next attempt"
+            },
+            "x-enum-descriptions": [
+                "Waiting for its first or next attempt",
+                "",
+                "Gave up after MaxReportDeliveryAttempts"
+            ],
+            "x-enum-varnames": [
+                "ReportDeliveryPending",
+                "ReportDeliverySent",
+                "ReportDeliveryFailed"
+            ]
+        },
+        "Domain.ReportSchedule": {
+            "type": "string",
+            "enum": [
+                "daily",
+                "weekly",
+                "monthly"
+            ],
+            "x-enum-comments": {
+                "ReportScheduleMonthly": "On DayOfMonth",
+                "ReportScheduleWeekly": "On Weekday"
+            },
+            "x-enum-descriptions": [
+                "",
+                "On Weekday",
+                "On DayOfMonth"
+            ],
+            "x-enum-varnames": [
+                "ReportScheduleDaily",
+                "ReportScheduleWeekly",
+                "ReportScheduleMonthly"
+            ]
+        },
+        "Domain.ReportSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "business_id": {
                     "type": "string"
                 },
-                "business_type": {
+                "created_at": {
                     "type": "string"
                 },
-                "city": {
+                "day_of_month": {
+                    "description": "1 to 28, for monthly reports",
+                    "type": "integer"
+                },
+                "email": {
                     "type": "string"
                 },
-                "country": {
+                "format": {
+                    "description": "pdf, xlsx or csv",
+                    "type": "string"
+                },
+                "hour": {
+                    "description": "Hour of the day in the business's timezone",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "period": {
+                    "$ref": "#/definitions/Domain.PeriodType"
+                },
+                "report_type": {
+                    "$ref": "#/definitions/Domain.ReportType"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/Domain.ReportSchedule"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 },
+                "weekday": {
+                    "description": "0 (Sunday) to 6, for weekly reports",
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.ReportType": {
+            "type": "string",
+            "enum": [
+                "sales",
+                "expenses",
+                "profit",
+                "inventory",
+                "stocktake",
+                "catalog",
+                "aging",
+                "heatmap",
+                "abc"
+            ],
+            "x-enum-varnames": [
+                "ReportTypeSales",
+                "ReportTypeExpenses",
+                "ReportTypeProfit",
+                "ReportTypeInventory",
+                "ReportTypeStocktake",
+                "ReportTypeCatalog",
+                "ReportTypeAging",
+                "ReportTypeHeatmap",
+                "ReportTypeABC"
+            ]
+        },
+        "Domain.Sale": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "unit_price"
+            ],
+            "properties": {
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.SaleBatchAllocation"
+                    }
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "components": {
+                    "description": "Stock taken for a bundle or recipe",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.SaleComponent"
+                    }
+                },
+                "cost_of_goods": {
+                    "type": "number"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "currency": {
+                "created_by": {
                     "type": "string"
                 },
-                "description": {
+                "customer_name": {
                     "type": "string"
                 },
-                "email": {
+                "customer_phone": {
                     "type": "string"
                 },
+                "discount": {
+                    "type": "number"
+                },
+                "edits": {
+                    "description": "Changes made after the sale was recorded, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.SaleEdit"
+                    }
+                },
+                "final_amount": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "local_id": {
+                    "description": "For offline sync",
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "$ref": "#/definitions/Domain.PaymentMethod"
+                },
+                "payment_status": {
+                    "$ref": "#/definitions/Domain.PaymentStatus"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "serials": {
+                    "description": "Serial numbers of the units sold",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.SaleStatus"
+                },
+                "synced": {
+                    "type": "boolean"
+                },
+                "synced_at": {
+                    "type": "string"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "unit_price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "voided_at": {
+                    "type": "string"
+                },
+                "voided_by": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Set when the sale took stock below zero under the warn policy",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Domain.SaleBatchAllocation": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "lot_number": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.SaleComponent": {
+            "type": "object",
+            "properties": {
+                "cost_of_goods": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.SaleEdit": {
+            "type": "object",
+            "properties": {
+                "edited_at": {
+                    "type": "string"
+                },
+                "edited_by": {
                     "type": "string"
-                },
-                "phone": {
+                }
+            }
+        },
+        "Domain.SaleStats": {
+            "type": "object",
+            "properties": {
+                "best_selling_day": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/Domain.BusinessStatus"
+                "daily_average": {
+                    "type": "number"
                 },
-                "timezone": {
-                    "type": "string"
+                "monthly_total": {
+                    "type": "number"
                 },
-                "updated_at": {
+                "top_product": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "weekly_total": {
+                    "type": "number"
                 }
             }
         },
-        "Domain.BusinessStatus": {
+        "Domain.SaleStatus": {
             "type": "string",
             "enum": [
-                "active",
-                "inactive",
-                "closed"
+                "completed",
+                "voided",
+                "refunded"
             ],
             "x-enum-varnames": [
-                "BusinessStatusActive",
-                "BusinessStatusInactive",
-                "BusinessStatusClosed"
+                "SaleStatusCompleted",
+                "SaleStatusVoided",
+                "SaleStatusRefunded"
             ]
         },
-        "Domain.CategoryExpense": {
+        "Domain.SaleSummary": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/Domain.ExpenseCategory"
+                "date": {
+                    "type": "string"
                 },
-                "count": {
-                    "type": "integer"
+                "total_amount": {
+                    "type": "number"
                 },
-                "percentage": {
+                "total_discount": {
                     "type": "number"
                 },
-                "total_amount": {
+                "total_sales": {
+                    "type": "number"
+                },
+                "total_tax": {
                     "type": "number"
+                },
+                "transaction_count": {
+                    "type": "integer"
                 }
             }
         },
-        "Domain.CreateBusinessRequest": {
+        "Domain.SalesHeatmap": {
             "type": "object",
-            "required": [
-                "business_type",
-                "currency",
-                "name"
-            ],
             "properties": {
-                "address": {
+                "category": {
                     "type": "string"
                 },
-                "business_type": {
-                    "type": "string"
+                "cells": {
+                    "description": "Monday 00:00 first, then hour by hour through Sunday",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.HeatmapCell"
+                    }
                 },
-                "city": {
-                    "type": "string"
+                "current": {
+                    "$ref": "#/definitions/Domain.ComparisonPeriod"
                 },
-                "country": {
-                    "type": "string"
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.HeatmapTotal"
+                    }
                 },
-                "currency": {
-                    "type": "string"
+                "payment_method": {
+                    "$ref": "#/definitions/Domain.PaymentMethod"
                 },
-                "description": {
-                    "type": "string"
+                "peak": {
+                    "description": "The hour with the most revenue; empty without sales",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.HeatmapCell"
+                        }
+                    ]
                 },
-                "email": {
-                    "type": "string"
+                "previous": {
+                    "$ref": "#/definitions/Domain.ComparisonPeriod"
                 },
-                "name": {
+                "product_id": {
                     "type": "string"
                 },
-                "phone": {
-                    "type": "string"
+                "revenue": {
+                    "$ref": "#/definitions/Domain.MetricChange"
                 },
                 "timezone": {
                     "type": "string"
+                },
+                "transactions": {
+                    "$ref": "#/definitions/Domain.MetricChange"
+                },
+                "weekdays": {
+                    "description": "Monday first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.HeatmapTotal"
+                    }
                 }
             }
         },
-        "Domain.CreateExpenseRequest": {
+        "Domain.SalesReport": {
             "type": "object",
-            "required": [
-                "amount",
-                "category"
-            ],
             "properties": {
-                "amount": {
+                "average_sale": {
                     "type": "number"
                 },
-                "category": {
-                    "$ref": "#/definitions/Domain.ExpenseCategory"
+                "categories": {
+                    "description": "Categories covers every level of the category tree; the figures of a\ncategory include those of its subcategories.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.CategorySales"
+                    }
                 },
-                "date": {
-                    "type": "string"
+                "daily_breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.DailySales"
+                    }
                 },
-                "description": {
+                "period": {
                     "type": "string"
                 },
-                "local_id": {
-                    "description": "For offline sync",
-                    "type": "string"
+                "top_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.TopProduct"
+                    }
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "total_sales": {
+                    "type": "number"
+                },
+                "total_transactions": {
+                    "type": "integer"
                 }
             }
         },
-        "Domain.CreateProductRequest": {
+        "Domain.SchedulePriceChangeRequest": {
             "type": "object",
             "required": [
-                "cost_price",
-                "name",
-                "selling_price"
+                "effective_at"
             ],
             "properties": {
-                "barcode": {
-                    "type": "string"
-                },
                 "category": {
+                    "description": "Category ID or name; includes its subcategories",
                     "type": "string"
                 },
                 "cost_price": {
                     "type": "number"
                 },
-                "description": {
+                "effective_at": {
                     "type": "string"
                 },
-                "max_stock": {
-                    "type": "number"
+                "notes": {
+                    "type": "string"
                 },
-                "min_stock": {
+                "percent_change": {
                     "type": "number"
                 },
-                "name": {
-                    "type": "string"
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "selling_price": {
                     "type": "number"
-                },
-                "sku": {
-                    "type": "string"
-                },
-                "stock": {
-                    "type": "number",
-                    "minimum": 0
-                },
-                "unit": {
-                    "type": "string"
                 }
             }
         },
-        "Domain.CreateSaleRequest": {
+        "Domain.ScheduledPriceChange": {
             "type": "object",
-            "required": [
-                "payment_method",
-                "quantity",
-                "unit_price"
-            ],
             "properties": {
-                "customer_name": {
+                "applied_at": {
                     "type": "string"
                 },
-                "customer_phone": {
+                "batch_id": {
+                    "description": "Shared by the changes scheduled together",
                     "type": "string"
                 },
-                "discount": {
+                "business_id": {
+                    "type": "string"
+                },
+                "cost_price": {
                     "type": "number"
                 },
-                "local_id": {
-                    "description": "For offline sync",
+                "created_at": {
                     "type": "string"
                 },
-                "notes": {
+                "created_by": {
                     "type": "string"
                 },
-                "payment_method": {
-                    "$ref": "#/definitions/Domain.PaymentMethod"
+                "effective_at": {
+                    "type": "string"
                 },
-                "product_id": {
+                "error": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "tax": {
-                    "type": "number"
+                "notes": {
+                    "type": "string"
                 },
-                "unit_price": {
-                    "type": "number"
-                }
-            }
-        },
-        "Domain.DailyExpense": {
-            "type": "object",
-            "properties": {
-                "amount": {
+                "percent_change": {
+                    "description": "Applied to the selling price, e.g. 10 or -5",
                     "type": "number"
                 },
-                "count": {
-                    "type": "integer"
-                },
-                "date": {
+                "product_id": {
                     "type": "string"
-                }
-            }
-        },
-        "Domain.DailySales": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "number"
                 },
-                "date": {
+                "product_name": {
                     "type": "string"
                 },
-                "sales": {
+                "selling_price": {
                     "type": "number"
                 },
-                "transactions": {
-                    "type": "integer"
+                "status": {
+                    "$ref": "#/definitions/Domain.ScheduledPriceStatus"
                 }
             }
         },
-        "Domain.DashboardData": {
+        "Domain.ScheduledPriceStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "applied",
+                "cancelled",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ScheduledPriceStatusPending",
+                "ScheduledPriceStatusApplied",
+                "ScheduledPriceStatusCancelled",
+                "ScheduledPriceStatusFailed"
+            ]
+        },
+        "Domain.SerialEvent": {
             "type": "object",
             "properties": {
-                "low_stock_count": {
-                    "type": "integer"
-                },
-                "month_expenses": {
-                    "type": "number"
-                },
-                "month_profit": {
-                    "type": "number"
-                },
-                "month_sales": {
-                    "type": "number"
+                "at": {
+                    "type": "string"
                 },
-                "pending_payments": {
-                    "type": "number"
+                "customer_name": {
+                    "type": "string"
                 },
-                "today_expenses": {
-                    "type": "number"
+                "customer_phone": {
+                    "type": "string"
                 },
-                "today_profit": {
-                    "type": "number"
+                "note": {
+                    "type": "string"
                 },
-                "today_sales": {
-                    "type": "number"
+                "reference_id": {
+                    "type": "string"
                 },
-                "week_expenses": {
-                    "type": "number"
+                "reference_type": {
+                    "description": "purchase_order, sale or empty",
+                    "type": "string"
                 },
-                "week_profit": {
-                    "type": "number"
+                "type": {
+                    "$ref": "#/definitions/Domain.SerialEventType"
                 },
-                "week_sales": {
-                    "type": "number"
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "Domain.Expense": {
-            "type": "object",
-            "required": [
-                "amount",
-                "category"
+        "Domain.SerialEventType": {
+            "type": "string",
+            "enum": [
+                "received",
+                "sold",
+                "returned",
+                "written_off"
             ],
+            "x-enum-varnames": [
+                "SerialEventReceived",
+                "SerialEventSold",
+                "SerialEventReturned",
+                "SerialEventWrittenOff"
+            ]
+        },
+        "Domain.SerialNumber": {
+            "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
                 "business_id": {
                     "type": "string"
                 },
-                "category": {
-                    "$ref": "#/definitions/Domain.ExpenseCategory"
+                "cost_price": {
+                    "type": "number"
                 },
                 "created_at": {
                     "type": "string"
@@ -2914,252 +12157,243 @@ const docTemplate = `{
                 "created_by": {
                     "type": "string"
                 },
-                "date": {
+                "customer_name": {
                     "type": "string"
                 },
-                "description": {
+                "customer_phone": {
                     "type": "string"
                 },
+                "events": {
+                    "description": "Full lifecycle, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.SerialEvent"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
-                "local_id": {
-                    "description": "For offline sync",
+                "product_id": {
                     "type": "string"
                 },
-                "receipt_url": {
+                "product_name": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/Domain.ExpenseStatus"
+                "received_at": {
+                    "type": "string"
                 },
-                "synced": {
-                    "type": "boolean"
+                "sale_id": {
+                    "type": "string"
                 },
-                "synced_at": {
+                "serial": {
+                    "type": "string"
+                },
+                "sold_at": {
                     "type": "string"
                 },
+                "status": {
+                    "$ref": "#/definitions/Domain.SerialStatus"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "warranty_expires_at": {
+                    "type": "string"
                 }
             }
         },
-        "Domain.ExpenseCategory": {
-            "type": "string",
-            "enum": [
-                "rent",
-                "utilities",
-                "stock_purchase",
-                "transport",
-                "salaries",
-                "marketing",
-                "maintenance",
-                "other"
-            ],
-            "x-enum-varnames": [
-                "ExpenseCategoryRent",
-                "ExpenseCategoryUtilities",
-                "ExpenseCategoryStockPurchase",
-                "ExpenseCategoryTransport",
-                "ExpenseCategorySalaries",
-                "ExpenseCategoryMarketing",
-                "ExpenseCategoryMaintenance",
-                "ExpenseCategoryOther"
-            ]
-        },
-        "Domain.ExpenseStatus": {
+        "Domain.SerialStatus": {
             "type": "string",
             "enum": [
-                "active",
-                "voided",
-                "deleted"
+                "in_stock",
+                "sold",
+                "written_off"
             ],
             "x-enum-varnames": [
-                "ExpenseStatusActive",
-                "ExpenseStatusVoided",
-                "ExpenseStatusDeleted"
+                "SerialStatusInStock",
+                "SerialStatusSold",
+                "SerialStatusWrittenOff"
             ]
         },
-        "Domain.ExpenseSummary": {
+        "Domain.SetLocationMinStockRequest": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/Domain.ExpenseCategory"
-                },
-                "count": {
-                    "type": "integer"
-                },
-                "percentage": {
-                    "type": "number"
-                },
-                "total_amount": {
-                    "type": "number"
+                "min_stock": {
+                    "type": "number",
+                    "minimum": 0
                 }
             }
         },
-        "Domain.ExpensesReport": {
+        "Domain.SetRecipeRequest": {
             "type": "object",
+            "required": [
+                "ingredients"
+            ],
             "properties": {
-                "category_breakdown": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/Domain.CategoryExpense"
-                    }
-                },
-                "daily_expenses": {
+                "ingredients": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/Domain.DailyExpense"
+                        "$ref": "#/definitions/Domain.RecipeIngredientRequest"
                     }
                 },
-                "period": {
+                "notes": {
                     "type": "string"
-                },
-                "total_expenses": {
-                    "type": "number"
                 }
             }
         },
-        "Domain.InventoryReport": {
+        "Domain.StaffAction": {
+            "type": "string",
+            "enum": [
+                "sold",
+                "voided",
+                "edited"
+            ],
+            "x-enum-varnames": [
+                "StaffActionSold",
+                "StaffActionVoided",
+                "StaffActionEdited"
+            ]
+        },
+        "Domain.StaffPerformance": {
             "type": "object",
             "properties": {
-                "low_stock_items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/Domain.LowStockItem"
-                    }
+                "average_basket": {
+                    "type": "number"
                 },
-                "stock_movement": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/Domain.StockMovement"
-                    }
+                "discounts": {
+                    "type": "number"
                 },
-                "total_products": {
+                "edits": {
                     "type": "integer"
                 },
-                "total_stock": {
-                    "type": "number"
+                "edits_of_others": {
+                    "description": "Edits of sales another user recorded",
+                    "type": "integer"
                 },
-                "total_value": {
+                "items_sold": {
                     "type": "number"
-                }
-            }
-        },
-        "Domain.LoginRequest": {
-            "type": "object",
-            "required": [
-                "password",
-                "phone"
-            ],
-            "properties": {
-                "password": {
+                },
+                "name": {
                     "type": "string"
                 },
-                "phone": {
+                "refunded_amount": {
+                    "type": "number"
+                },
+                "refunds": {
+                    "description": "Refunds counts the user's own sales that were later refunded.",
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "role": {
+                    "$ref": "#/definitions/Domain.UserRole"
+                },
+                "sales": {
+                    "type": "integer"
+                },
+                "sales_edited_by_others": {
+                    "type": "integer"
+                },
+                "sales_voided_by_others": {
+                    "description": "SalesVoidedByOthers and SalesEditedByOthers count the user's own sales\nthat another user voided or changed.",
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "string"
+                },
+                "voided_amount": {
+                    "type": "number"
+                },
+                "voids": {
+                    "type": "integer"
+                },
+                "voids_of_others": {
+                    "description": "Voids of sales another user recorded",
+                    "type": "integer"
                 }
             }
         },
-        "Domain.LoginResponse": {
+        "Domain.StaffPerformanceReport": {
             "type": "object",
             "properties": {
-                "token": {
-                    "type": "string"
+                "period": {
+                    "$ref": "#/definitions/Domain.ComparisonPeriod"
                 },
-                "user": {
-                    "$ref": "#/definitions/Domain.User"
+                "staff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.StaffPerformance"
+                    }
                 }
             }
         },
-        "Domain.LowStockItem": {
+        "Domain.StaffTransaction": {
             "type": "object",
             "properties": {
-                "current": {
-                    "type": "number"
-                },
-                "difference": {
-                    "type": "number"
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.StaffAction"
+                    }
                 },
-                "minimum": {
-                    "type": "number"
+                "editor_names": {
+                    "description": "Users other than the seller who edited the sale",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "product_id": {
-                    "type": "string"
+                "highlighted": {
+                    "type": "boolean"
                 },
-                "product_name": {
-                    "type": "string"
-                }
-            }
-        },
-        "Domain.MovementType": {
-            "type": "string",
-            "enum": [
-                "purchase",
-                "sale",
-                "adjust",
-                "damage",
-                "theft",
-                "return"
-            ],
-            "x-enum-varnames": [
-                "MovementTypePurchase",
-                "MovementTypeSale",
-                "MovementTypeAdjust",
-                "MovementTypeDamage",
-                "MovementTypeTheft",
-                "MovementTypeReturn"
-            ]
-        },
-        "Domain.PaymentMethod": {
-            "type": "string",
-            "enum": [
-                "cash",
-                "card",
-                "mobile",
-                "bank",
-                "credit",
-                "other"
-            ],
-            "x-enum-varnames": [
-                "PaymentMethodCash",
-                "PaymentMethodCard",
-                "PaymentMethodMobile",
-                "PaymentMethodBank",
-                "PaymentMethodCredit",
-                "PaymentMethodOther"
-            ]
+                "notes": {
+                    "description": "Why the sale is highlighted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sale": {
+                    "$ref": "#/definitions/Domain.Sale"
+                },
+                "seller_name": {
+                    "type": "string"
+                },
+                "voided_by_name": {
+                    "type": "string"
+                }
+            }
         },
-        "Domain.PaymentStatus": {
-            "type": "string",
-            "enum": [
-                "paid",
-                "pending",
-                "failed"
-            ],
-            "x-enum-varnames": [
-                "PaymentStatusPaid",
-                "PaymentStatusPending",
-                "PaymentStatusFailed"
-            ]
+        "Domain.StaffTransactions": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "period": {
+                    "$ref": "#/definitions/Domain.ComparisonPeriod"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.StaffTransaction"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
         },
-        "Domain.Product": {
+        "Domain.StockBatch": {
             "type": "object",
             "required": [
-                "cost_price",
-                "name",
-                "selling_price"
+                "lot_number"
             ],
             "properties": {
-                "barcode": {
-                    "type": "string"
-                },
                 "business_id": {
                     "type": "string"
                 },
-                "category": {
-                    "type": "string"
-                },
                 "cost_price": {
                     "type": "number"
                 },
@@ -3169,329 +12403,444 @@ const docTemplate = `{
                 "created_by": {
                     "type": "string"
                 },
-                "description": {
+                "expiry_date": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "image_url": {
+                "location_id": {
+                    "description": "Where the lot was received",
                     "type": "string"
                 },
-                "max_stock": {
-                    "type": "number"
-                },
-                "min_stock": {
-                    "type": "number"
+                "lot_number": {
+                    "type": "string"
                 },
-                "name": {
+                "product_id": {
                     "type": "string"
                 },
-                "selling_price": {
+                "quantity": {
+                    "description": "Quantity originally received",
                     "type": "number"
                 },
-                "sku": {
+                "received_date": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/Domain.ProductStatus"
-                },
-                "stock": {
-                    "type": "number",
-                    "minimum": 0
+                "remaining": {
+                    "description": "Quantity still on hand",
+                    "type": "number"
                 },
-                "unit": {
-                    "type": "string"
+                "status": {
+                    "$ref": "#/definitions/Domain.BatchStatus"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "Domain.ProductStatus": {
-            "type": "string",
-            "enum": [
-                "active",
-                "inactive",
-                "discontinued"
-            ],
-            "x-enum-varnames": [
-                "ProductStatusActive",
-                "ProductStatusInactive",
-                "ProductStatusDiscontinued"
-            ]
-        },
-        "Domain.ProfitReport": {
+        "Domain.StockMovement": {
             "type": "object",
             "properties": {
-                "gross_profit": {
+                "business_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "new": {
                     "type": "number"
                 },
-                "net_profit": {
+                "previous": {
                     "type": "number"
                 },
-                "period": {
+                "product_id": {
                     "type": "string"
                 },
-                "profit_margin": {
+                "quantity": {
                     "type": "number"
                 },
-                "total_expenses": {
+                "reason": {
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "reference_type": {
+                    "type": "string"
+                },
+                "total_cost": {
                     "type": "number"
                 },
-                "total_sales": {
+                "type": {
+                    "$ref": "#/definitions/Domain.MovementType"
+                },
+                "unit_cost": {
                     "type": "number"
+                }
+            }
+        },
+        "Domain.StockTransfer": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
                 },
-                "trends": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "dispatched_at": {
+                    "type": "string"
+                },
+                "from_location_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/Domain.ProfitTrend"
+                        "$ref": "#/definitions/Domain.TransferItem"
                     }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "received_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.TransferStatus"
+                },
+                "to_location_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "Domain.ProfitTrend": {
+        "Domain.StockValuation": {
             "type": "object",
             "properties": {
-                "expenses": {
-                    "type": "number"
-                },
-                "period": {
+                "as_of": {
                     "type": "string"
                 },
-                "profit": {
+                "costing_method": {
+                    "$ref": "#/definitions/Domain.CostingMethod"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.StockValuationItem"
+                    }
+                },
+                "total_products": {
+                    "type": "integer"
+                },
+                "total_quantity": {
                     "type": "number"
                 },
-                "sales": {
+                "total_value": {
                     "type": "number"
                 }
             }
         },
-        "Domain.RegisterRequest": {
+        "Domain.StockValuationItem": {
             "type": "object",
-            "required": [
-                "name",
-                "password",
-                "phone"
-            ],
             "properties": {
-                "email": {
+                "category": {
                     "type": "string"
                 },
-                "name": {
+                "last_movement_at": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "product_id": {
+                    "type": "string"
                 },
-                "phone": {
+                "product_name": {
                     "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "description": "Value divided by quantity",
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "Domain.Sale": {
+        "Domain.Stocktake": {
             "type": "object",
-            "required": [
-                "quantity",
-                "unit_price"
-            ],
             "properties": {
                 "business_id": {
                     "type": "string"
                 },
-                "created_at": {
+                "category": {
+                    "description": "Empty when counting all products",
                     "type": "string"
                 },
-                "created_by": {
+                "created_at": {
                     "type": "string"
                 },
-                "customer_name": {
+                "created_by": {
                     "type": "string"
                 },
-                "customer_phone": {
+                "id": {
                     "type": "string"
                 },
-                "discount": {
-                    "type": "number"
-                },
-                "final_amount": {
-                    "type": "number"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.StocktakeItem"
+                    }
                 },
-                "id": {
+                "location_id": {
+                    "description": "Counted location; the whole business when empty",
                     "type": "string"
                 },
-                "local_id": {
-                    "description": "For offline sync",
+                "name": {
                     "type": "string"
                 },
                 "notes": {
                     "type": "string"
                 },
-                "payment_method": {
-                    "$ref": "#/definitions/Domain.PaymentMethod"
-                },
-                "payment_status": {
-                    "$ref": "#/definitions/Domain.PaymentStatus"
-                },
-                "product_id": {
+                "posted_at": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
-                },
-                "status": {
-                    "$ref": "#/definitions/Domain.SaleStatus"
-                },
-                "synced": {
-                    "type": "boolean"
-                },
-                "synced_at": {
+                "posted_by": {
                     "type": "string"
                 },
-                "tax": {
-                    "type": "number"
-                },
-                "total_amount": {
-                    "type": "number"
+                "status": {
+                    "$ref": "#/definitions/Domain.StocktakeStatus"
                 },
-                "unit_price": {
-                    "type": "number"
+                "summary": {
+                    "$ref": "#/definitions/Domain.StocktakeSummary"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "Domain.SaleStats": {
+        "Domain.StocktakeCount": {
             "type": "object",
             "properties": {
-                "best_selling_day": {
+                "barcode": {
                     "type": "string"
                 },
-                "daily_average": {
-                    "type": "number"
-                },
-                "monthly_total": {
-                    "type": "number"
+                "mode": {
+                    "description": "Defaults to add",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Domain.StocktakeCountMode"
+                        }
+                    ]
                 },
-                "top_product": {
+                "product_id": {
                     "type": "string"
                 },
-                "weekly_total": {
+                "quantity": {
                     "type": "number"
                 }
             }
         },
-        "Domain.SaleStatus": {
+        "Domain.StocktakeCountMode": {
             "type": "string",
             "enum": [
-                "completed",
-                "voided",
-                "refunded"
+                "set",
+                "add"
+            ],
+            "x-enum-comments": {
+                "StocktakeCountModeAdd": "Add to the counted quantity, e.g. a second shelf or a scan",
+                "StocktakeCountModeSet": "Replace the counted quantity"
+            },
+            "x-enum-descriptions": [
+                "Replace the counted quantity",
+                "Add to the counted quantity, e.g. a second shelf or a scan"
             ],
             "x-enum-varnames": [
-                "SaleStatusCompleted",
-                "SaleStatusVoided",
-                "SaleStatusRefunded"
+                "StocktakeCountModeSet",
+                "StocktakeCountModeAdd"
             ]
         },
-        "Domain.SaleSummary": {
+        "Domain.StocktakeItem": {
             "type": "object",
             "properties": {
-                "date": {
+                "barcode": {
                     "type": "string"
                 },
-                "total_amount": {
+                "counted_at": {
+                    "type": "string"
+                },
+                "counted_by": {
+                    "type": "string"
+                },
+                "counted_quantity": {
+                    "description": "Nil until counted",
                     "type": "number"
                 },
-                "total_discount": {
+                "expected_quantity": {
+                    "description": "Stock held when last counted, or when the session was opened",
                     "type": "number"
                 },
-                "total_sales": {
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "unit_cost": {
                     "type": "number"
                 },
-                "total_tax": {
+                "variance": {
+                    "description": "Counted minus expected",
                     "type": "number"
                 },
-                "transaction_count": {
-                    "type": "integer"
+                "variance_value": {
+                    "description": "Variance at unit cost",
+                    "type": "number"
                 }
             }
         },
-        "Domain.SalesReport": {
+        "Domain.StocktakeStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "posted",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "StocktakeStatusOpen",
+                "StocktakeStatusPosted",
+                "StocktakeStatusCancelled"
+            ]
+        },
+        "Domain.StocktakeSummary": {
             "type": "object",
             "properties": {
-                "average_sale": {
-                    "type": "number"
-                },
-                "daily_breakdown": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/Domain.DailySales"
-                    }
+                "counted_items": {
+                    "type": "integer"
                 },
-                "period": {
-                    "type": "string"
+                "items_with_variance": {
+                    "type": "integer"
                 },
-                "top_products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/Domain.TopProduct"
-                    }
+                "net_variance_value": {
+                    "type": "number"
                 },
-                "total_amount": {
+                "shortage_value": {
                     "type": "number"
                 },
-                "total_sales": {
+                "surplus_value": {
                     "type": "number"
                 },
-                "total_transactions": {
+                "total_items": {
+                    "type": "integer"
+                },
+                "uncounted_items": {
                     "type": "integer"
                 }
             }
         },
-        "Domain.StockMovement": {
+        "Domain.Supplier": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
                 "business_id": {
                     "type": "string"
                 },
+                "contact_name": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "created_by": {
+                "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "new": {
-                    "type": "number"
-                },
-                "previous": {
-                    "type": "number"
+                "lead_time_days": {
+                    "description": "Days from ordering to delivery",
+                    "type": "integer"
                 },
-                "product_id": {
+                "name": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
+                "notes": {
+                    "type": "string"
                 },
-                "reason": {
+                "phone": {
                     "type": "string"
                 },
-                "reference_id": {
+                "status": {
+                    "$ref": "#/definitions/Domain.SupplierStatus"
+                },
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "Domain.SupplierReorderGroup": {
+            "type": "object",
+            "properties": {
+                "estimated_cost": {
+                    "type": "number"
                 },
-                "reference_type": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.ReorderSuggestion"
+                    }
+                },
+                "supplier_id": {
                     "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/Domain.MovementType"
+                "supplier_name": {
+                    "type": "string"
                 }
             }
         },
+        "Domain.SupplierStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "SupplierStatusActive",
+                "SupplierStatusInactive"
+            ]
+        },
         "Domain.SyncBatch": {
             "type": "object",
             "required": [
@@ -3616,6 +12965,10 @@ const docTemplate = `{
         "Domain.TopProduct": {
             "type": "object",
             "properties": {
+                "cost_of_goods": {
+                    "description": "Set by GetProductSales",
+                    "type": "number"
+                },
                 "product_id": {
                     "type": "string"
                 },
@@ -3630,6 +12983,33 @@ const docTemplate = `{
                 }
             }
         },
+        "Domain.TransferItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "Domain.TransferStatus": {
+            "type": "string",
+            "enum": [
+                "in_transit",
+                "received",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "TransferStatusInTransit",
+                "TransferStatusReceived",
+                "TransferStatusCancelled"
+            ]
+        },
         "Domain.UpdateBusinessRequest": {
             "type": "object",
             "properties": {
@@ -3642,6 +13022,9 @@ const docTemplate = `{
                 "city": {
                     "type": "string"
                 },
+                "costing_method": {
+                    "$ref": "#/definitions/Domain.CostingMethod"
+                },
                 "country": {
                     "type": "string"
                 },
@@ -3657,6 +13040,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "negative_stock": {
+                    "$ref": "#/definitions/Domain.NegativeStockPolicy"
+                },
                 "phone": {
                     "type": "string"
                 },
@@ -3665,6 +13051,98 @@ const docTemplate = `{
                 }
             }
         },
+        "Domain.UpdateCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "Domain.UpdateLocationRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.LocationStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/Domain.LocationType"
+                }
+            }
+        },
+        "Domain.UpdateReportSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "day_of_month": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "hour": {
+                    "type": "integer"
+                },
+                "period": {
+                    "$ref": "#/definitions/Domain.PeriodType"
+                },
+                "report_type": {
+                    "$ref": "#/definitions/Domain.ReportType"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/Domain.ReportSchedule"
+                },
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Domain.UpdateSupplierRequest": {
+            "type": "object",
+            "properties": {
+                "contact_name": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/Domain.SupplierStatus"
+                }
+            }
+        },
         "Domain.UpdateUserRequest": {
             "type": "object",
             "properties": {
@@ -3679,6 +13157,48 @@ const docTemplate = `{
                 }
             }
         },
+        "Domain.UsageReport": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Domain.IngredientUsage"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "stocktake_id": {
+                    "type": "string"
+                },
+                "stocktake_name": {
+                    "type": "string"
+                },
+                "total_actual_value": {
+                    "type": "number"
+                },
+                "total_theoretical_value": {
+                    "type": "number"
+                },
+                "total_variance_value": {
+                    "type": "number"
+                },
+                "uncounted": {
+                    "description": "Ingredients the stocktake did not count",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "Domain.User": {
             "type": "object",
             "required": [
@@ -3737,6 +13257,21 @@ const docTemplate = `{
                 "UserStatusInactive",
                 "UserStatusSuspended"
             ]
+        },
+        "Domain.WriteOffSerialRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "location_id": {
+                    "description": "Defaults to the default location",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -11,6 +11,7 @@ require (
 	github.com/swaggo/swag v1.16.6
 	go.mongodb.org/mongo-driver v1.17.6
 	golang.org/x/crypto v0.43.0
+	golang.org/x/image v0.25.0
 )
 
 require (